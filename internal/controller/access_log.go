@@ -0,0 +1,230 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/snowmerak/ticketing/internal/service"
+	"github.com/snowmerak/ticketing/lib/adapter"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// AuditedEndpoints are the routes, as "METHOD template", whose requests the access log records
+var AuditedEndpoints = []string{
+	"GET /events/{id}",
+	"GET /events/{id}/seats/available",
+	"POST /tickets/purchase",
+}
+
+// maxAuditedBodySize bounds how much of a request body is read to find the caller's user and session
+const maxAuditedBodySize = 64 << 10
+
+// AccessLogController records who called the audited endpoints and serves the log to admins.
+// Recording is opt-in: install Middleware on the router to enable it.
+type AccessLogController struct {
+	accessLogService *service.AccessLogService
+	logger           adapter.Logger
+}
+
+// NewAccessLogController creates a new AccessLogController
+func NewAccessLogController(accessLogService *service.AccessLogService, logger adapter.Logger) *AccessLogController {
+	return &AccessLogController{
+		accessLogService: accessLogService,
+		logger:           logger,
+	}
+}
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status before passing it on
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+// Middleware records an access log entry for each request to an audited endpoint; add it to a MiddlewareStack or use it with router.Use
+func (c *AccessLogController) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		endpoint, ok := auditedEndpoint(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		userID, sessionID := requestIdentity(r)
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		entry := &domain.AccessLogEntry{
+			Endpoint:   endpoint,
+			Method:     r.Method,
+			UserID:     userID,
+			SessionID:  sessionID,
+			Status:     recorder.status,
+			RemoteAddr: r.RemoteAddr,
+			At:         time.Now(),
+		}
+
+		// The request context may already be cancelled once the handler returns
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), 2*time.Second)
+		defer cancel()
+		if err := c.accessLogService.RecordAccess(ctx, entry); err != nil {
+			c.logger.Warn(ctx, "Access log entry dropped", "endpoint", endpoint, "error", err)
+		}
+	})
+}
+
+// auditedEndpoint returns the route template of the request if it is audited
+func auditedEndpoint(r *http.Request) (string, bool) {
+	for _, audited := range AuditedEndpoints {
+		if matchesEndpoint(r, audited) {
+			_, path, _ := strings.Cut(audited, " ")
+			return path, true
+		}
+	}
+
+	return "", false
+}
+
+// requestIdentity finds the caller's user and session in the query, the X-User-ID and X-Session-ID
+// headers or a JSON body, restoring the body for the handler
+func requestIdentity(r *http.Request) (*uuid.UUID, string) {
+	query := r.URL.Query()
+	rawUserID := firstNonEmpty(query.Get("user_id"), r.Header.Get("X-User-ID"))
+	sessionID := firstNonEmpty(query.Get("session_id"), r.Header.Get("X-Session-ID"))
+
+	if r.Body != nil && strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxAuditedBodySize))
+		if err == nil {
+			var identity struct {
+				UserID    string `json:"user_id"`
+				SessionID string `json:"session_id"`
+			}
+			if json.Unmarshal(body, &identity) == nil {
+				rawUserID = firstNonEmpty(rawUserID, identity.UserID)
+				sessionID = firstNonEmpty(sessionID, identity.SessionID)
+			}
+		}
+		r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+	}
+
+	var userID *uuid.UUID
+	if parsed, err := uuid.Parse(rawUserID); err == nil {
+		userID = &parsed
+	}
+
+	return userID, sessionID
+}
+
+// firstNonEmpty returns the first of the values that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// ListAccessLog handles GET /admin/access-log?user_id={user_id}&limit={limit}
+func (c *AccessLogController) ListAccessLog(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	var userID *uuid.UUID
+	if raw := query.Get("user_id"); raw != "" {
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			http.Error(w, "Invalid user ID", http.StatusBadRequest)
+			return
+		}
+		userID = &parsed
+	}
+
+	limit := DefaultPageLimit
+	if raw := query.Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 || parsed > service.MaxAccessLogListSize {
+			http.Error(w, fmt.Sprintf("limit must be between 1 and %d", service.MaxAccessLogListSize), http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	entries, err := c.accessLogService.ListAccess(ctx, userID, limit)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to list access log", "error", err)
+		http.Error(w, "Failed to list access log", http.StatusInternalServerError)
+		return
+	}
+
+	if entries == nil {
+		entries = []*domain.AccessLogEntry{}
+	}
+
+	response := map[string]interface{}{
+		"entries": entries,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// ListPurchaseSignals handles GET /admin/events/{id}/purchase-signals?limit={limit}
+func (c *AccessLogController) ListPurchaseSignals(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	eventID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	limit := DefaultPageLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 || parsed > service.MaxPurchaseSignalListSize {
+			http.Error(w, fmt.Sprintf("limit must be between 1 and %d", service.MaxPurchaseSignalListSize), http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	signals, err := c.accessLogService.ListPurchaseSignals(ctx, eventID, limit)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to list purchase signals", "event_id", eventID, "error", err)
+		http.Error(w, "Failed to list purchase signals", http.StatusInternalServerError)
+		return
+	}
+
+	if signals == nil {
+		signals = []*domain.PurchaseSignal{}
+	}
+
+	response := map[string]interface{}{
+		"event_id": eventID,
+		"signals":  signals,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// RegisterRoutes registers the access log and purchase signal admin routes
+func (c *AccessLogController) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/admin/access-log", c.ListAccessLog).Methods("GET")
+	router.HandleFunc("/admin/events/{id}/purchase-signals", c.ListPurchaseSignals).Methods("GET")
+}
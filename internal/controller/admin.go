@@ -0,0 +1,268 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/snowmerak/ticketing/internal/service"
+	"github.com/snowmerak/ticketing/lib/adapter"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// AdminController handles HTTP requests for operator and compliance tasks
+type AdminController struct {
+	ticketingService *service.TicketingService
+	eventService     *service.EventService
+	logger           adapter.Logger
+}
+
+// NewAdminController creates a new AdminController
+func NewAdminController(ticketingService *service.TicketingService, eventService *service.EventService, logger adapter.Logger) *AdminController {
+	return &AdminController{
+		ticketingService: ticketingService,
+		eventService:     eventService,
+		logger:           logger,
+	}
+}
+
+// ExportUserData handles GET /admin/users/{user_id}/export
+func (c *AdminController) ExportUserData(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	userID, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid user ID", "id", vars["user_id"], "error", err)
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	export, err := c.ticketingService.ExportUserData(ctx, userID)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to export user data", "user_id", userID, "error", err)
+		http.Error(w, "Failed to export user data", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", "attachment; filename=\"user-"+userID.String()+".json\"")
+	json.NewEncoder(w).Encode(export)
+}
+
+// DeleteUserData handles DELETE /admin/users/{user_id}
+func (c *AdminController) DeleteUserData(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	userID, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid user ID", "id", vars["user_id"], "error", err)
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	deletion, err := c.ticketingService.DeleteUserData(ctx, userID)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to delete user data", "user_id", userID, "error", err)
+		http.Error(w, "Failed to delete user data: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deletion)
+}
+
+// ListFailedActions handles GET /admin/dead-letters
+func (c *AdminController) ListFailedActions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	offset, limit, err := parsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	actions, total, err := c.ticketingService.ListFailedActions(ctx, limit, offset)
+	if err != nil {
+		if errors.Is(err, service.ErrDeadLetterDisabled) {
+			http.Error(w, err.Error(), http.StatusNotImplemented)
+			return
+		}
+		c.logger.Error(ctx, "Failed to list failed actions", "error", err)
+		http.Error(w, "Failed to list failed actions", http.StatusInternalServerError)
+		return
+	}
+
+	writePage(w, NewPage(actions, total, offset, limit))
+}
+
+// RetryFailedAction handles POST /admin/dead-letters/{id}/retry
+func (c *AdminController) RetryFailedAction(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	actionID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid failed action ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid failed action ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := c.ticketingService.RetryFailedAction(ctx, actionID); err != nil {
+		switch {
+		case errors.Is(err, service.ErrDeadLetterDisabled):
+			http.Error(w, err.Error(), http.StatusNotImplemented)
+		case errors.Is(err, repository.ErrFailedActionNotFound):
+			http.Error(w, "Failed action not found", http.StatusNotFound)
+		default:
+			c.logger.Error(ctx, "Failed to retry failed action", "action_id", actionID, "error", err)
+			http.Error(w, "Failed to retry action: "+err.Error(), http.StatusBadGateway)
+		}
+		return
+	}
+
+	response := map[string]interface{}{
+		"message": "Action replayed successfully",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// RetryFailedActions handles POST /admin/dead-letters/retry
+func (c *AdminController) RetryFailedActions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, limit, err := parsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	succeeded, failed, err := c.ticketingService.RetryFailedActions(ctx, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrDeadLetterDisabled) {
+			http.Error(w, err.Error(), http.StatusNotImplemented)
+			return
+		}
+		c.logger.Error(ctx, "Failed to drain dead-letter", "error", err)
+		http.Error(w, "Failed to retry actions", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"succeeded": succeeded,
+		"failed":    failed,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// ClearReservations handles POST /admin/events/{id}/clear-reservations
+func (c *AdminController) ClearReservations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid event ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	clearance, err := c.ticketingService.ClearReservations(ctx, eventID)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to clear reservations", "event_id", eventID, "error", err)
+		http.Error(w, "Failed to clear reservations: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(clearance)
+}
+
+// GetSeatTicketConsistency handles GET /admin/events/{id}/consistency
+func (c *AdminController) GetSeatTicketConsistency(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid event ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	report, err := c.eventService.AuditSeatTicketConsistency(ctx, eventID)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to audit seat and ticket consistency", "event_id", eventID, "error", err)
+		http.Error(w, "Failed to audit seat and ticket consistency: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// operatorHeader names the operator performing a destructive admin action, for the audit log
+const operatorHeader = "X-Operator"
+
+// InspectLock handles GET /admin/locks/{key}
+func (c *AdminController) InspectLock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	key := mux.Vars(r)["key"]
+
+	status, err := c.ticketingService.InspectLock(ctx, key)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to inspect lock", "key", key, "error", err)
+		http.Error(w, "Failed to inspect lock", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// ForceReleaseLock handles DELETE /admin/locks/{key}; the X-Operator header names who is clearing it
+func (c *AdminController) ForceReleaseLock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	key := mux.Vars(r)["key"]
+
+	operator := r.Header.Get(operatorHeader)
+	if operator == "" {
+		http.Error(w, operatorHeader+" header is required", http.StatusBadRequest)
+		return
+	}
+
+	released, err := c.ticketingService.ForceReleaseLock(ctx, key, operator)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to force release lock", "key", key, "operator", operator, "remote_addr", r.RemoteAddr, "error", err)
+		http.Error(w, "Failed to release lock", http.StatusInternalServerError)
+		return
+	}
+
+	if !released {
+		http.Error(w, "Lock is not held", http.StatusNotFound)
+		return
+	}
+
+	c.logger.Info(ctx, "Lock cleared via admin API", "key", key, "operator", operator, "remote_addr", r.RemoteAddr)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegisterRoutes registers all admin routes
+func (c *AdminController) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/admin/users/{user_id}/export", c.ExportUserData).Methods("GET")
+	router.HandleFunc("/admin/users/{user_id}", c.DeleteUserData).Methods("DELETE")
+	router.HandleFunc("/admin/dead-letters", c.ListFailedActions).Methods("GET")
+	router.HandleFunc("/admin/dead-letters/retry", c.RetryFailedActions).Methods("POST")
+	router.HandleFunc("/admin/dead-letters/{id}/retry", c.RetryFailedAction).Methods("POST")
+	router.HandleFunc("/admin/events/{id}/clear-reservations", c.ClearReservations).Methods("POST")
+	router.HandleFunc("/admin/events/{id}/consistency", c.GetSeatTicketConsistency).Methods("GET")
+	router.HandleFunc("/admin/locks/{key}", c.InspectLock).Methods("GET")
+	router.HandleFunc("/admin/locks/{key}", c.ForceReleaseLock).Methods("DELETE")
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/snowmerak/ticketing/internal/service"
+)
+
+// inspectLock answers GET /admin/locks/{key}
+func (ts *testServer) inspectLock(t *testing.T, key string) service.LockStatus {
+	t.Helper()
+
+	rec := ts.do(http.MethodGet, "/admin/locks/"+key, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("inspect lock status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	var status service.LockStatus
+	if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+		t.Fatalf("decode lock status: %v", err)
+	}
+	return status
+}
+
+func TestInspectLock(t *testing.T) {
+	ts := newTestServer(t)
+	const key = "lock:stuck"
+
+	if status := ts.inspectLock(t, key); status.Key != key || status.Locked || status.TTLMillis != 0 {
+		t.Fatalf("free lock status = %+v, want unlocked", status)
+	}
+
+	if _, acquired, err := ts.lock.Acquire(context.Background(), key, time.Minute); err != nil || !acquired {
+		t.Fatalf("acquire lock: acquired %v, err %v", acquired, err)
+	}
+	status := ts.inspectLock(t, key)
+	if !status.Locked || status.TTLMillis <= 0 || status.TTLMillis > time.Minute.Milliseconds() {
+		t.Fatalf("held lock status = %+v, want locked with up to a minute left", status)
+	}
+}
+
+func TestForceReleaseLock(t *testing.T) {
+	ts := newTestServer(t)
+	const key = "lock:stuck"
+
+	if _, acquired, err := ts.lock.Acquire(context.Background(), key, time.Minute); err != nil || !acquired {
+		t.Fatalf("acquire lock: acquired %v, err %v", acquired, err)
+	}
+
+	// Without an operator the lock is left alone
+	if rec := ts.do(http.MethodDelete, "/admin/locks/"+key, ""); rec.Code != http.StatusBadRequest {
+		t.Fatalf("release without %s status = %d, want %d", operatorHeader, rec.Code, http.StatusBadRequest)
+	}
+	if !ts.inspectLock(t, key).Locked {
+		t.Fatal("lock released without an operator")
+	}
+
+	if rec := ts.do(http.MethodDelete, "/admin/locks/"+key, "", operatorHeader, "oncall"); rec.Code != http.StatusNoContent {
+		t.Fatalf("release status = %d, want %d: %s", rec.Code, http.StatusNoContent, rec.Body)
+	}
+	if ts.inspectLock(t, key).Locked {
+		t.Fatal("lock still held after a forced release")
+	}
+
+	// A lock that is no longer held can't be released again
+	if rec := ts.do(http.MethodDelete, "/admin/locks/"+key, "", operatorHeader, "oncall"); rec.Code != http.StatusNotFound {
+		t.Fatalf("release of a free lock status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,254 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/snowmerak/ticketing/internal/service"
+	"github.com/snowmerak/ticketing/lib/adapter"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/pkg/logger"
+	"github.com/snowmerak/ticketing/pkg/repository/memory"
+)
+
+// errCacheMiss is returned by testCache for every read
+var errCacheMiss = errors.New("cache miss")
+
+// testCache is a Cache that never holds anything, so services always read through to the repositories
+type testCache struct{}
+
+func (testCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return nil
+}
+
+func (testCache) Get(ctx context.Context, key string) (interface{}, error) {
+	return nil, errCacheMiss
+}
+
+func (testCache) Delete(ctx context.Context, key string) error {
+	return nil
+}
+
+func (testCache) Exists(ctx context.Context, key string) (bool, error) {
+	return false, nil
+}
+
+func (testCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return nil
+}
+
+func (testCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return 0, nil
+}
+
+// testLock is an in-process Lock
+type testLock struct {
+	mu    sync.Mutex
+	locks map[string]testLockHold
+}
+
+// testLockHold is the holder of a testLock key and when the hold lapses
+type testLockHold struct {
+	token     string
+	expiresAt time.Time
+}
+
+func newTestLock() *testLock {
+	return &testLock{locks: make(map[string]testLockHold)}
+}
+
+// held returns the live hold on key; the caller holds l.mu
+func (l *testLock) held(key string) (testLockHold, bool) {
+	hold, ok := l.locks[key]
+	if ok && !time.Now().Before(hold.expiresAt) {
+		delete(l.locks, key)
+		return testLockHold{}, false
+	}
+	return hold, ok
+}
+
+func (l *testLock) Acquire(ctx context.Context, key string, expiration time.Duration) (string, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.held(key); ok {
+		return "", false, nil
+	}
+	token := uuid.NewString()
+	l.locks[key] = testLockHold{token: token, expiresAt: time.Now().Add(expiration)}
+	return token, true, nil
+}
+
+func (l *testLock) Release(ctx context.Context, key, token string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if hold, ok := l.held(key); ok && hold.token == token {
+		delete(l.locks, key)
+	}
+	return nil
+}
+
+func (l *testLock) Extend(ctx context.Context, key, token string, expiration time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	hold, ok := l.held(key)
+	if !ok || hold.token != token {
+		return adapter.ErrLockNotHeld
+	}
+	l.locks[key] = testLockHold{token: token, expiresAt: time.Now().Add(expiration)}
+	return nil
+}
+
+func (l *testLock) IsLocked(ctx context.Context, key string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, ok := l.held(key)
+	return ok, nil
+}
+
+func (l *testLock) TTL(ctx context.Context, key string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	hold, ok := l.held(key)
+	if !ok {
+		return 0, nil
+	}
+	return time.Until(hold.expiresAt), nil
+}
+
+func (l *testLock) ForceRelease(ctx context.Context, key string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, ok := l.held(key)
+	delete(l.locks, key)
+	return ok, nil
+}
+
+// testServer routes requests to the event, ticketing and admin controllers over in-memory repositories, with
+// the repositories at hand for setup and assertions
+type testServer struct {
+	router  *mux.Router
+	lock    *testLock
+	events  *memory.EventRepository
+	seats   *memory.SeatRepository
+	tickets *memory.TicketRepository
+	queue   *memory.QueueRepository
+}
+
+// newTestServer builds the controllers over fresh in-memory repositories
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	ts := &testServer{
+		router:  mux.NewRouter(),
+		lock:    newTestLock(),
+		events:  memory.NewEventRepository(),
+		seats:   memory.NewSeatRepository(0),
+		tickets: memory.NewTicketRepository(),
+		queue:   memory.NewQueueRepository(),
+	}
+	log := logger.NewLoggerWithLevel(zerolog.Disabled)
+	ticketing := service.NewTicketingService(ts.tickets, ts.events, ts.seats, ts.queue, testCache{}, ts.lock, log)
+	events := service.NewEventService(ts.events, ts.seats, testCache{}, newTestLock(), log)
+	events.SetTicketRepository(ts.tickets)
+
+	NewEventController(events, log).RegisterRoutes(ts.router)
+	NewTicketingController(ticketing, log).RegisterRoutes(ts.router)
+	NewAdminController(ticketing, events, log).RegisterRoutes(ts.router)
+	return ts
+}
+
+// do serves a request with an optional JSON body and headers given as name, value pairs
+func (ts *testServer) do(method, path, body string, headers ...string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+
+	rec := httptest.NewRecorder()
+	ts.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// createEvent stores an active seated event with available tickets left
+func (ts *testServer) createEvent(t *testing.T, available int) *domain.Event {
+	t.Helper()
+
+	now := time.Now()
+	event := &domain.Event{
+		ID:               uuid.New(),
+		Name:             "Controller Test Event",
+		StartTime:        now.Add(24 * time.Hour),
+		EndTime:          now.Add(27 * time.Hour),
+		Venue:            "Test Hall",
+		Status:           string(domain.EventStatusActive),
+		TotalTickets:     available,
+		AvailableTickets: available,
+		IsSeatedEvent:    true,
+		Currency:         "USD",
+	}
+	if err := ts.events.Create(context.Background(), event); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+	return event
+}
+
+// createSeat stores an available seat of an event
+func (ts *testServer) createSeat(t *testing.T, event *domain.Event, number string) *domain.Seat {
+	t.Helper()
+
+	seat := &domain.Seat{
+		ID:      uuid.New(),
+		EventID: event.ID,
+		Section: "A",
+		Row:     "1",
+		Number:  number,
+		Price:   10000,
+		Status:  string(domain.SeatStatusAvailable),
+	}
+	if err := ts.seats.Create(context.Background(), seat); err != nil {
+		t.Fatalf("create seat: %v", err)
+	}
+	return seat
+}
+
+// activateSession joins a user to an event's queue and activates their session from now on, returning the
+// session ID
+func (ts *testServer) activateSession(t *testing.T, eventID, userID uuid.UUID) string {
+	t.Helper()
+	ctx := context.Background()
+
+	sessionID := uuid.NewString()
+	entry, err := ts.queue.Join(ctx, eventID, userID, sessionID)
+	if err != nil {
+		t.Fatalf("join queue: %v", err)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(15 * time.Minute)
+	entry.Status = string(domain.QueueStatusActive)
+	entry.ActivatedAt = &now
+	entry.ExpiresAt = &expiresAt
+	if err := ts.queue.Update(ctx, entry); err != nil {
+		t.Fatalf("activate session: %v", err)
+	}
+	return sessionID
+}
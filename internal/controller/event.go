@@ -2,7 +2,10 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,6 +13,7 @@ import (
 	"github.com/snowmerak/ticketing/internal/service"
 	"github.com/snowmerak/ticketing/lib/adapter"
 	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
 )
 
 // EventController handles HTTP requests for event operations
@@ -28,13 +32,27 @@ func NewEventController(eventService *service.EventService, logger adapter.Logge
 
 // CreateEventRequest represents the request body for creating an event
 type CreateEventRequest struct {
-	Name          string    `json:"name"`
-	Description   string    `json:"description"`
-	StartTime     time.Time `json:"start_time"`
-	EndTime       time.Time `json:"end_time"`
-	Venue         string    `json:"venue"`
-	TotalTickets  int       `json:"total_tickets"`
-	IsSeatedEvent bool      `json:"is_seated_event"`
+	Name                   string                `json:"name"`
+	Description            string                `json:"description"`
+	StartTime              time.Time             `json:"start_time"`
+	EndTime                time.Time             `json:"end_time"`
+	Venue                  string                `json:"venue"`
+	TotalTickets           int                   `json:"total_tickets"`
+	IsSeatedEvent          bool                  `json:"is_seated_event"`
+	NumberedStanding       bool                  `json:"numbered_standing"`
+	StandingPrice          int64                 `json:"standing_price"` // Face value of a standing ticket in cents
+	MaxConcurrentPurchases int                   `json:"max_concurrent_purchases"`
+	MaxTicketsPerUser      int                   `json:"max_tickets_per_user,omitempty"`   // 0 uses the server default
+	QueueHighWaterMark     int                   `json:"queue_high_water_mark,omitempty"`  // Waiting users at which joins are shed
+	ActiveHighWaterMark    int                   `json:"active_high_water_mark,omitempty"` // Active sessions at which joins are shed
+	Currency               string                `json:"currency,omitempty"`               // ISO 4217 code; the server default when empty
+	ImageURL               string                `json:"image_url,omitempty"`
+	ThumbnailURL           string                `json:"thumbnail_url,omitempty"`
+	SeatRanking            string                `json:"seat_ranking,omitempty"`     // front_to_back, center_out or price_ascending
+	LockGranularity        string                `json:"lock_granularity,omitempty"` // seat, section or event
+	AllowGuestCheckout     bool                  `json:"allow_guest_checkout,omitempty"`
+	RefundPolicy           *domain.RefundPolicy  `json:"refund_policy,omitempty"`  // The server default when omitted
+	StandingZones          []domain.StandingZone `json:"standing_zones,omitempty"` // Capacities must add up to total_tickets
 }
 
 // CreateEvent handles POST /events
@@ -50,48 +68,99 @@ func (c *EventController) CreateEvent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate request
+	fields := ValidationErrors{}
 	if req.Name == "" {
-		http.Error(w, "Event name is required", http.StatusBadRequest)
-		return
+		fields.Add("name", "is required")
 	}
-
-	if req.StartTime.IsZero() || req.EndTime.IsZero() {
-		http.Error(w, "Start time and end time are required", http.StatusBadRequest)
-		return
+	if req.StartTime.IsZero() {
+		fields.Add("start_time", "is required")
 	}
-
-	if req.StartTime.After(req.EndTime) {
-		http.Error(w, "Start time must be before end time", http.StatusBadRequest)
-		return
+	if req.EndTime.IsZero() {
+		fields.Add("end_time", "is required")
+	}
+	if !req.StartTime.IsZero() && !req.EndTime.IsZero() && req.StartTime.After(req.EndTime) {
+		fields.Add("start_time", "must be before end_time")
 	}
-
 	if req.TotalTickets <= 0 {
-		http.Error(w, "Total tickets must be positive", http.StatusBadRequest)
+		fields.Add("total_tickets", "must be positive")
+	}
+	if req.MaxConcurrentPurchases < 0 {
+		fields.Add("max_concurrent_purchases", "must not be negative")
+	}
+	if req.StandingPrice < 0 {
+		fields.Add("standing_price", "must not be negative")
+	}
+	if req.MaxTicketsPerUser < 0 {
+		fields.Add("max_tickets_per_user", "must not be negative")
+	}
+	if req.QueueHighWaterMark < 0 {
+		fields.Add("queue_high_water_mark", "must not be negative")
+	}
+	if req.ActiveHighWaterMark < 0 {
+		fields.Add("active_high_water_mark", "must not be negative")
+	}
+	if req.Currency != "" && !domain.IsValidCurrency(req.Currency) {
+		fields.Add("currency", "must be an ISO 4217 currency code")
+	}
+	if req.ImageURL != "" && !domain.IsValidMediaURL(req.ImageURL) {
+		fields.Add("image_url", "must be an http or https URL")
+	}
+	if req.ThumbnailURL != "" && !domain.IsValidMediaURL(req.ThumbnailURL) {
+		fields.Add("thumbnail_url", "must be an http or https URL")
+	}
+	if req.SeatRanking != "" && !domain.SeatRanking(req.SeatRanking).IsValid() {
+		fields.Add("seat_ranking", "must be front_to_back, center_out or price_ascending")
+	}
+	if req.LockGranularity != "" && !domain.LockGranularity(req.LockGranularity).IsValid() {
+		fields.Add("lock_granularity", "must be seat, section or event")
+	}
+	if req.RefundPolicy != nil && !req.RefundPolicy.IsValid() {
+		fields.Add("refund_policy", "tiers must have non-negative, distinct min_notice_hours and refund_basis_points between 0 and 10000")
+	}
+	if fields.HasErrors() {
+		writeValidationErrors(w, fields)
 		return
 	}
 
 	// Create event
 	event := &domain.Event{
-		ID:               uuid.New(),
-		Name:             req.Name,
-		Description:      req.Description,
-		StartTime:        req.StartTime,
-		EndTime:          req.EndTime,
-		Venue:            req.Venue,
-		Status:           string(domain.EventStatusActive),
-		TotalTickets:     req.TotalTickets,
-		AvailableTickets: req.TotalTickets,
-		IsSeatedEvent:    req.IsSeatedEvent,
+		Name:                   req.Name,
+		Description:            req.Description,
+		StartTime:              req.StartTime,
+		EndTime:                req.EndTime,
+		Venue:                  req.Venue,
+		Status:                 string(domain.EventStatusActive),
+		TotalTickets:           req.TotalTickets,
+		AvailableTickets:       req.TotalTickets,
+		IsSeatedEvent:          req.IsSeatedEvent,
+		NumberedStanding:       req.NumberedStanding,
+		StandingPrice:          req.StandingPrice,
+		MaxConcurrentPurchases: req.MaxConcurrentPurchases,
+		MaxTicketsPerUser:      req.MaxTicketsPerUser,
+		QueueHighWaterMark:     req.QueueHighWaterMark,
+		ActiveHighWaterMark:    req.ActiveHighWaterMark,
+		Currency:               req.Currency,
+		ImageURL:               req.ImageURL,
+		ThumbnailURL:           req.ThumbnailURL,
+		SeatRanking:            req.SeatRanking,
+		LockGranularity:        req.LockGranularity,
+		AllowGuestCheckout:     req.AllowGuestCheckout,
+		RefundPolicy:           req.RefundPolicy,
+		StandingZones:          req.StandingZones,
 	}
 
 	if err := c.eventService.CreateEvent(ctx, event); err != nil {
+		if errors.Is(err, service.ErrDuplicateEvent) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
 		c.logger.Error(ctx, "Failed to create event", "error", err)
 		http.Error(w, "Failed to create event", http.StatusInternalServerError)
 		return
 	}
 
 	response := map[string]interface{}{
-		"event": event,
+		"event": newEventResponse(event),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -119,7 +188,95 @@ func (c *EventController) GetEvent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(event)
+	json.NewEncoder(w).Encode(newEventResponse(event))
+}
+
+// BatchGetEventsRequest represents the request body for fetching several events at once
+type BatchGetEventsRequest struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+// BatchGetEvents handles POST /events/batch-get
+func (c *EventController) BatchGetEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req BatchGetEventsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.logger.Error(ctx, "Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if len(req.IDs) > service.MaxEventBatchSize {
+		http.Error(w, fmt.Sprintf("At most %d IDs may be requested at once", service.MaxEventBatchSize), http.StatusBadRequest)
+		return
+	}
+
+	events, err := c.eventService.GetEventsByIDs(ctx, req.IDs)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to batch get events", "error", err)
+		http.Error(w, "Failed to get events", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"events": newEventResponses(events),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetLiveStats handles GET /events/{id}/live
+func (c *EventController) GetLiveStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid event ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	stats, err := c.eventService.GetLiveStats(ctx, eventID)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to get live stats", "event_id", eventID, "error", err)
+		http.Error(w, "Failed to get live stats", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(stats)
+}
+
+// GetStandingZones handles GET /events/{id}/zones
+func (c *EventController) GetStandingZones(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid event ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	zones, err := c.eventService.GetStandingZones(ctx, eventID)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to get standing zones", "event_id", eventID, "error", err)
+		http.Error(w, "Failed to get standing zones", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"zones": zones,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(response)
 }
 
 // GetActiveEvents handles GET /events/active
@@ -134,7 +291,7 @@ func (c *EventController) GetActiveEvents(w http.ResponseWriter, r *http.Request
 	}
 
 	response := map[string]interface{}{
-		"events": events,
+		"events": newEventResponses(events),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -145,6 +302,11 @@ func (c *EventController) GetActiveEvents(w http.ResponseWriter, r *http.Request
 func (c *EventController) GetAllEvents(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	if status := r.URL.Query().Get("status"); status != "" {
+		c.getEventsByStatus(w, r, status)
+		return
+	}
+
 	events, err := c.eventService.GetAllEvents(ctx)
 	if err != nil {
 		c.logger.Error(ctx, "Failed to get all events", "error", err)
@@ -153,23 +315,62 @@ func (c *EventController) GetAllEvents(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := map[string]interface{}{
-		"events": events,
+		"events": newEventResponses(events),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
+// getEventsByStatus handles GET /events?status={status} with offset/limit pagination
+func (c *EventController) getEventsByStatus(w http.ResponseWriter, r *http.Request, status string) {
+	ctx := r.Context()
+
+	if !domain.EventStatus(status).IsValid() {
+		http.Error(w, "Invalid event status", http.StatusBadRequest)
+		return
+	}
+
+	offset, limit, err := parsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	events, total, err := c.eventService.GetEventsByStatus(ctx, status, limit, offset)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to get events by status", "status", status, "error", err)
+		http.Error(w, "Failed to get events", http.StatusInternalServerError)
+		return
+	}
+
+	writePage(w, NewPage(newEventResponses(events), total, offset, limit))
+}
+
 // UpdateEventRequest represents the request body for updating an event
 type UpdateEventRequest struct {
-	Name          *string    `json:"name,omitempty"`
-	Description   *string    `json:"description,omitempty"`
-	StartTime     *time.Time `json:"start_time,omitempty"`
-	EndTime       *time.Time `json:"end_time,omitempty"`
-	Venue         *string    `json:"venue,omitempty"`
-	Status        *string    `json:"status,omitempty"`
-	TotalTickets  *int       `json:"total_tickets,omitempty"`
-	IsSeatedEvent *bool      `json:"is_seated_event,omitempty"`
+	Name                   *string                `json:"name,omitempty"`
+	Description            *string                `json:"description,omitempty"`
+	StartTime              *time.Time             `json:"start_time,omitempty"`
+	EndTime                *time.Time             `json:"end_time,omitempty"`
+	Venue                  *string                `json:"venue,omitempty"`
+	Status                 *string                `json:"status,omitempty"`
+	TotalTickets           *int                   `json:"total_tickets,omitempty"`
+	IsSeatedEvent          *bool                  `json:"is_seated_event,omitempty"`
+	NumberedStanding       *bool                  `json:"numbered_standing,omitempty"`
+	StandingPrice          *int64                 `json:"standing_price,omitempty"`
+	MaxConcurrentPurchases *int                   `json:"max_concurrent_purchases,omitempty"`
+	MaxTicketsPerUser      *int                   `json:"max_tickets_per_user,omitempty"`   // 0 restores the server default
+	QueueHighWaterMark     *int                   `json:"queue_high_water_mark,omitempty"`  // 0 stops shedding on queue length
+	ActiveHighWaterMark    *int                   `json:"active_high_water_mark,omitempty"` // 0 stops shedding on active sessions
+	Currency               *string                `json:"currency,omitempty"`
+	ImageURL               *string                `json:"image_url,omitempty"`        // An empty string clears the image
+	ThumbnailURL           *string                `json:"thumbnail_url,omitempty"`    // An empty string clears the thumbnail
+	SeatRanking            *string                `json:"seat_ranking,omitempty"`     // An empty string restores the server default
+	LockGranularity        *string                `json:"lock_granularity,omitempty"` // An empty string restores per-seat locks
+	AllowGuestCheckout     *bool                  `json:"allow_guest_checkout,omitempty"`
+	RefundPolicy           *domain.RefundPolicy   `json:"refund_policy,omitempty"`  // Replaces the event's refund schedule
+	StandingZones          *[]domain.StandingZone `json:"standing_zones,omitempty"` // Replaces the event's zones; new zones start full
 }
 
 // UpdateEvent handles PUT /events/{id}
@@ -224,6 +425,96 @@ func (c *EventController) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 	if req.IsSeatedEvent != nil {
 		event.IsSeatedEvent = *req.IsSeatedEvent
 	}
+	if req.NumberedStanding != nil {
+		event.NumberedStanding = *req.NumberedStanding
+	}
+	if req.StandingPrice != nil {
+		if *req.StandingPrice < 0 {
+			http.Error(w, "Standing price must not be negative", http.StatusBadRequest)
+			return
+		}
+		event.StandingPrice = *req.StandingPrice
+	}
+	if req.AllowGuestCheckout != nil {
+		event.AllowGuestCheckout = *req.AllowGuestCheckout
+	}
+	if req.RefundPolicy != nil {
+		if !req.RefundPolicy.IsValid() {
+			http.Error(w, "Refund policy tiers must have non-negative, distinct notices and refund between 0 and 100 percent", http.StatusBadRequest)
+			return
+		}
+		event.RefundPolicy = req.RefundPolicy
+	}
+	if req.StandingZones != nil {
+		event.StandingZones = *req.StandingZones
+	}
+	if req.MaxTicketsPerUser != nil {
+		if *req.MaxTicketsPerUser < 0 {
+			http.Error(w, "Max tickets per user must not be negative", http.StatusBadRequest)
+			return
+		}
+		event.MaxTicketsPerUser = *req.MaxTicketsPerUser
+	}
+	if req.QueueHighWaterMark != nil {
+		if *req.QueueHighWaterMark < 0 {
+			http.Error(w, "Queue high-water mark must not be negative", http.StatusBadRequest)
+			return
+		}
+		event.QueueHighWaterMark = *req.QueueHighWaterMark
+	}
+	if req.ActiveHighWaterMark != nil {
+		if *req.ActiveHighWaterMark < 0 {
+			http.Error(w, "Active high-water mark must not be negative", http.StatusBadRequest)
+			return
+		}
+		event.ActiveHighWaterMark = *req.ActiveHighWaterMark
+	}
+	if req.MaxConcurrentPurchases != nil {
+		if *req.MaxConcurrentPurchases < 0 {
+			http.Error(w, "Max concurrent purchases must not be negative", http.StatusBadRequest)
+			return
+		}
+		event.MaxConcurrentPurchases = *req.MaxConcurrentPurchases
+	}
+	if req.Currency != nil {
+		if !domain.IsValidCurrency(*req.Currency) {
+			fields := ValidationErrors{}
+			fields.Add("currency", "must be an ISO 4217 currency code")
+			writeValidationErrors(w, fields)
+			return
+		}
+		event.Currency = *req.Currency
+	}
+
+	fields := ValidationErrors{}
+	if req.ImageURL != nil && *req.ImageURL != "" && !domain.IsValidMediaURL(*req.ImageURL) {
+		fields.Add("image_url", "must be an http or https URL")
+	}
+	if req.ThumbnailURL != nil && *req.ThumbnailURL != "" && !domain.IsValidMediaURL(*req.ThumbnailURL) {
+		fields.Add("thumbnail_url", "must be an http or https URL")
+	}
+	if req.SeatRanking != nil && *req.SeatRanking != "" && !domain.SeatRanking(*req.SeatRanking).IsValid() {
+		fields.Add("seat_ranking", "must be front_to_back, center_out or price_ascending")
+	}
+	if req.LockGranularity != nil && *req.LockGranularity != "" && !domain.LockGranularity(*req.LockGranularity).IsValid() {
+		fields.Add("lock_granularity", "must be seat, section or event")
+	}
+	if fields.HasErrors() {
+		writeValidationErrors(w, fields)
+		return
+	}
+	if req.ImageURL != nil {
+		event.ImageURL = *req.ImageURL
+	}
+	if req.ThumbnailURL != nil {
+		event.ThumbnailURL = *req.ThumbnailURL
+	}
+	if req.SeatRanking != nil {
+		event.SeatRanking = *req.SeatRanking
+	}
+	if req.LockGranularity != nil {
+		event.LockGranularity = *req.LockGranularity
+	}
 
 	if err := c.eventService.UpdateEvent(ctx, event); err != nil {
 		c.logger.Error(ctx, "Failed to update event", "error", err)
@@ -232,7 +523,7 @@ func (c *EventController) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(event)
+	json.NewEncoder(w).Encode(newEventResponse(event))
 }
 
 // DeleteEvent handles DELETE /events/{id}
@@ -248,6 +539,10 @@ func (c *EventController) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := c.eventService.DeleteEvent(ctx, eventID); err != nil {
+		if errors.Is(err, service.ErrEventHasConfirmedTickets) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
 		c.logger.Error(ctx, "Failed to delete event", "event_id", eventID, "error", err)
 		http.Error(w, "Failed to delete event", http.StatusInternalServerError)
 		return
@@ -267,6 +562,12 @@ type SeatRequest struct {
 	Row     string `json:"row"`
 	Number  string `json:"number"`
 	Price   int64  `json:"price"`
+	X       int    `json:"x,omitempty"`
+	Y       int    `json:"y,omitempty"`
+
+	IsAccessible bool `json:"is_accessible,omitempty"`
+	// CompanionIndex points an accessible seat at its companion seat by position in the same request
+	CompanionIndex *int `json:"companion_index,omitempty"`
 }
 
 // CreateSeats handles POST /events/{id}/seats
@@ -288,8 +589,24 @@ func (c *EventController) CreateSeats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	fields := ValidationErrors{}
 	if len(req.Seats) == 0 {
-		http.Error(w, "At least one seat is required", http.StatusBadRequest)
+		fields.Add("seats", "at least one seat is required")
+	}
+	for i, seatReq := range req.Seats {
+		if seatReq.Section == "" {
+			fields.Add(fmt.Sprintf("seats[%d].section", i), "is required")
+		}
+		if seatReq.Number == "" {
+			fields.Add(fmt.Sprintf("seats[%d].number", i), "is required")
+		}
+		if seatReq.Price < 0 {
+			fields.Add(fmt.Sprintf("seats[%d].price", i), "must not be negative")
+		}
+	}
+	validateCompanionSeats(req.Seats, fields)
+	if fields.HasErrors() {
+		writeValidationErrors(w, fields)
 		return
 	}
 
@@ -297,14 +614,28 @@ func (c *EventController) CreateSeats(w http.ResponseWriter, r *http.Request) {
 	seats := make([]*domain.Seat, len(req.Seats))
 	for i, seatReq := range req.Seats {
 		seats[i] = &domain.Seat{
-			ID:      uuid.New(),
+			ID:      c.eventService.NewID(),
 			EventID: eventID,
 			Section: seatReq.Section,
 			Row:     seatReq.Row,
 			Number:  seatReq.Number,
 			Price:   seatReq.Price,
+			X:       seatReq.X,
+			Y:       seatReq.Y,
 			Status:  string(domain.SeatStatusAvailable),
+
+			IsAccessible: seatReq.IsAccessible,
+		}
+	}
+
+	// Link each accessible seat and its companion in both directions
+	for i, seatReq := range req.Seats {
+		if seatReq.CompanionIndex == nil {
+			continue
 		}
+		companion := seats[*seatReq.CompanionIndex]
+		seats[i].CompanionSeatID = &companion.ID
+		companion.CompanionSeatID = &seats[i].ID
 	}
 
 	if err := c.eventService.CreateSeatsForEvent(ctx, eventID, seats); err != nil {
@@ -321,6 +652,32 @@ func (c *EventController) CreateSeats(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// validateCompanionSeats checks that every companion_index pairs an accessible seat with exactly one
+// other, non-accessible seat of the same request
+func validateCompanionSeats(seats []SeatRequest, fields ValidationErrors) {
+	paired := make(map[int]bool)
+	for i, seatReq := range seats {
+		if seatReq.CompanionIndex == nil {
+			continue
+		}
+
+		field := fmt.Sprintf("seats[%d].companion_index", i)
+		index := *seatReq.CompanionIndex
+		switch {
+		case !seatReq.IsAccessible:
+			fields.Add(field, "is only allowed on accessible seats")
+		case index < 0 || index >= len(seats) || index == i:
+			fields.Add(field, "must reference another seat in the request")
+		case seats[index].IsAccessible:
+			fields.Add(field, "must reference a non-accessible seat")
+		case paired[index]:
+			fields.Add(field, "references a seat that is already a companion")
+		default:
+			paired[index] = true
+		}
+	}
+}
+
 // GetAvailableSeats handles GET /events/{id}/seats/available
 func (c *EventController) GetAvailableSeats(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -333,6 +690,28 @@ func (c *EventController) GetAvailableSeats(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	includeHeld := false
+	if raw := r.URL.Query().Get("include_held"); raw != "" {
+		includeHeld, err = strconv.ParseBool(raw)
+		if err != nil {
+			http.Error(w, "include_held must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if includeHeld {
+		seats, err := c.eventService.GetSeatAvailability(ctx, eventID, true)
+		if err != nil {
+			c.logger.Error(ctx, "Failed to get seat availability", "error", err)
+			http.Error(w, "Failed to get available seats", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(seats)
+		return
+	}
+
 	seats, err := c.eventService.GetAvailableSeats(ctx, eventID)
 	if err != nil {
 		c.logger.Error(ctx, "Failed to get available seats", "error", err)
@@ -344,14 +723,125 @@ func (c *EventController) GetAvailableSeats(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode(seats)
 }
 
+// GetAvailableSeatCount handles GET /events/{id}/seats/available/count
+func (c *EventController) GetAvailableSeatCount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid event ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	count, err := c.eventService.CountAvailableSeats(ctx, eventID)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to count available seats", "error", err)
+		http.Error(w, "Failed to count available seats", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"event_id":  eventID,
+		"available": count,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// UpdateSeatPricesRequest represents the request body for repricing seats in bulk
+type UpdateSeatPricesRequest struct {
+	Section   string `json:"section,omitempty"`
+	TierPrice *int64 `json:"tier_price,omitempty"`
+	Price     int64  `json:"price"`
+}
+
+// GetSeat handles GET /seats/{id}
+func (c *EventController) GetSeat(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	seatID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid seat ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid seat ID", http.StatusBadRequest)
+		return
+	}
+
+	seat, err := c.eventService.GetSeat(ctx, seatID)
+	if err != nil {
+		if errors.Is(err, repository.ErrSeatNotFound) {
+			http.Error(w, "Seat not found", http.StatusNotFound)
+			return
+		}
+		c.logger.Error(ctx, "Failed to get seat", "seat_id", seatID, "error", err)
+		http.Error(w, "Failed to get seat", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(seat)
+}
+
+// UpdateSeatPrices handles PUT /events/{id}/seats/price
+func (c *EventController) UpdateSeatPrices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid event ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	var req UpdateSeatPricesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.logger.Error(ctx, "Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Price < 0 {
+		http.Error(w, "Price must be non-negative", http.StatusBadRequest)
+		return
+	}
+
+	filter := repository.SeatPriceFilter{
+		Section: req.Section,
+		Price:   req.TierPrice,
+	}
+
+	updated, err := c.eventService.UpdateSeatPrices(ctx, eventID, filter, req.Price)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to update seat prices", "error", err)
+		http.Error(w, "Failed to update seat prices: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Seat prices updated successfully",
+		"updated": updated,
+	})
+}
+
 // RegisterRoutes registers all event routes
 func (c *EventController) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/events", c.CreateEvent).Methods("POST")
 	router.HandleFunc("/events", c.GetAllEvents).Methods("GET")
 	router.HandleFunc("/events/active", c.GetActiveEvents).Methods("GET")
+	router.HandleFunc("/events/batch-get", c.BatchGetEvents).Methods("POST")
 	router.HandleFunc("/events/{id}", c.GetEvent).Methods("GET")
 	router.HandleFunc("/events/{id}", c.UpdateEvent).Methods("PUT")
 	router.HandleFunc("/events/{id}", c.DeleteEvent).Methods("DELETE")
+	router.HandleFunc("/events/{id}/live", c.GetLiveStats).Methods("GET")
+	router.HandleFunc("/events/{id}/zones", c.GetStandingZones).Methods("GET")
 	router.HandleFunc("/events/{id}/seats", c.CreateSeats).Methods("POST")
 	router.HandleFunc("/events/{id}/seats/available", c.GetAvailableSeats).Methods("GET")
+	router.HandleFunc("/events/{id}/seats/available/count", c.GetAvailableSeatCount).Methods("GET")
+	router.HandleFunc("/events/{id}/seats/price", c.UpdateSeatPrices).Methods("PUT")
+	router.HandleFunc("/seats/{id}", c.GetSeat).Methods("GET")
 }
@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+func TestGetSeat(t *testing.T) {
+	ts := newTestServer(t)
+	seat := ts.createSeat(t, ts.createEvent(t, 10), "7")
+
+	rec := ts.do(http.MethodGet, "/seats/"+seat.ID.String(), "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	var got domain.Seat
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if got.ID != seat.ID || got.EventID != seat.EventID || got.Number != "7" || got.Status != string(domain.SeatStatusAvailable) {
+		t.Fatalf("seat = %+v, want %+v", got, *seat)
+	}
+
+	tests := []struct {
+		name string
+		id   string
+		want int
+	}{
+		{name: "unknown seat", id: uuid.NewString(), want: http.StatusNotFound},
+		{name: "invalid id", id: "not-a-uuid", want: http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if rec := ts.do(http.MethodGet, "/seats/"+tc.id, ""); rec.Code != tc.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tc.want, rec.Body)
+			}
+		})
+	}
+}
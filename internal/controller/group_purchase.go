@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/internal/service"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// PurchaseSeatsRequest represents the request body for purchasing several seats in one transaction
+type PurchaseSeatsRequest struct {
+	EventID       uuid.UUID   `json:"event_id"`
+	UserID        uuid.UUID   `json:"user_id"`
+	SeatIDs       []uuid.UUID `json:"seat_ids"`
+	SessionID     string      `json:"session_id"`
+	WaitroomToken string      `json:"waitroom_token,omitempty"`
+	// ContiguousCount asks for that many adjacent seats instead of listing seat_ids
+	ContiguousCount int `json:"contiguous_count,omitempty"`
+	// Section limits a contiguous search to one section
+	Section string `json:"section,omitempty"`
+	// GuestToken buys as a guest with a verified email; user_id must be the guest's pseudo user ID
+	GuestToken string `json:"guest_token,omitempty"`
+}
+
+// PurchaseSeatsResponse lists the reservations made by a multi-seat purchase
+type PurchaseSeatsResponse struct {
+	Reservations []*service.ReservationHandle `json:"reservations"`
+}
+
+// PurchaseSeats handles POST /tickets/purchase-seats
+func (c *TicketingController) PurchaseSeats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	c.logger.Info(ctx, "Purchase seats request", "method", r.Method, "path", r.URL.Path)
+
+	var req PurchaseSeatsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.logger.Error(ctx, "Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	fields := ValidationErrors{}
+	if req.EventID == uuid.Nil {
+		fields.Add("event_id", "is required")
+	}
+	if req.UserID == uuid.Nil {
+		fields.Add("user_id", "is required")
+	}
+	if req.SessionID == "" {
+		fields.Add("session_id", "is required")
+	}
+	switch {
+	case len(req.SeatIDs) > 0 && req.ContiguousCount != 0:
+		fields.Add("contiguous_count", "cannot be combined with seat_ids")
+	case req.ContiguousCount < 0:
+		fields.Add("contiguous_count", "must be positive")
+	case len(req.SeatIDs) == 0 && req.ContiguousCount == 0:
+		fields.Add("seat_ids", "must list at least one seat unless contiguous_count is set")
+	}
+	if fields.HasErrors() {
+		writeValidationErrors(w, fields)
+		return
+	}
+
+	opts := service.PurchaseOptions{
+		WaitroomToken: req.WaitroomToken,
+		GuestToken:    req.GuestToken,
+		RemoteAddr:    r.RemoteAddr,
+		UserAgent:     r.UserAgent(),
+	}
+
+	var tickets []*domain.Ticket
+	var err error
+	if req.ContiguousCount > 0 {
+		tickets, err = c.ticketingService.PurchaseContiguousSeats(ctx, req.EventID, req.UserID, req.Section, req.ContiguousCount, req.SessionID, opts)
+	} else {
+		tickets, err = c.ticketingService.PurchaseSeats(ctx, req.EventID, req.UserID, req.SeatIDs, req.SessionID, opts)
+	}
+	if err != nil {
+		if writePurchaseError(w, err) {
+			return
+		}
+		c.logger.Error(ctx, "Failed to purchase seats", "error", err)
+		http.Error(w, "Failed to purchase seats: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	c.writeSeatReservations(ctx, w, tickets)
+}
+
+// writeSeatReservations answers a multi-seat purchase with a reservation handle per ticket
+func (c *TicketingController) writeSeatReservations(ctx context.Context, w http.ResponseWriter, tickets []*domain.Ticket) {
+	// The group lapses with its earliest reservation
+	var earliest *time.Time
+	for _, ticket := range tickets {
+		if ticket.ExpiresAt != nil && (earliest == nil || ticket.ExpiresAt.Before(*earliest)) {
+			earliest = ticket.ExpiresAt
+		}
+	}
+	setReservationDeadlineHeaders(w, earliest)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	response := PurchaseSeatsResponse{Reservations: make([]*service.ReservationHandle, 0, len(tickets))}
+	for _, ticket := range tickets {
+		handle, err := c.ticketingService.NewReservationHandle(ctx, ticket)
+		if err != nil {
+			// The reservations stand; the client can still ask for tokens via /tickets/{id}/confirmation-link
+			c.logger.Warn(ctx, "Failed to issue reservation handle", "ticket_id", ticket.ID, "error", err)
+			json.NewEncoder(w).Encode(map[string]interface{}{"tickets": newTicketResponses(tickets)})
+			return
+		}
+		response.Reservations = append(response.Reservations, handle)
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
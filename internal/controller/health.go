@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+// HealthController handles liveness and readiness probes
+type HealthController struct {
+	checkers []adapter.HealthChecker
+	logger   adapter.Logger
+}
+
+// NewHealthController creates a new HealthController
+func NewHealthController(logger adapter.Logger, checkers ...adapter.HealthChecker) *HealthController {
+	return &HealthController{
+		checkers: checkers,
+		logger:   logger,
+	}
+}
+
+// Health handles GET /health
+func (c *HealthController) Health(w http.ResponseWriter, r *http.Request) {
+	response := map[string]interface{}{
+		"status": "ok",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// Ready handles GET /health/ready
+func (c *HealthController) Ready(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	status := http.StatusOK
+	checks := make(map[string]string, len(c.checkers))
+	for _, checker := range c.checkers {
+		if err := checker.Check(ctx); err != nil {
+			c.logger.Error(ctx, "Readiness check failed", "checker", checker.Name(), "error", err)
+			checks[checker.Name()] = err.Error()
+			status = http.StatusServiceUnavailable
+			continue
+		}
+		checks[checker.Name()] = "ok"
+	}
+
+	response := map[string]interface{}{
+		"status": "ready",
+		"checks": checks,
+	}
+	if status != http.StatusOK {
+		response["status"] = "not_ready"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
+
+// RegisterRoutes registers the health routes; they belong on the root router, outside /api/v1
+func (c *HealthController) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/health", c.Health).Methods("GET")
+	router.HandleFunc("/health/ready", c.Ready).Methods("GET")
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/snowmerak/ticketing/lib/adapter"
+	"github.com/snowmerak/ticketing/pkg/logger"
+)
+
+// testChecker is a HealthChecker reporting a fixed result
+type testChecker struct {
+	name string
+	err  error
+}
+
+func (c testChecker) Name() string {
+	return c.name
+}
+
+func (c testChecker) Check(ctx context.Context) error {
+	return c.err
+}
+
+func TestReady(t *testing.T) {
+	errScript := errors.New("failed to load script reserve_seat: ERR Error compiling script")
+
+	tests := []struct {
+		name       string
+		checkers   []adapter.HealthChecker
+		wantCode   int
+		wantStatus string
+		wantChecks map[string]string
+	}{
+		{
+			name:       "every dependency ready",
+			checkers:   []adapter.HealthChecker{testChecker{name: "redis"}, testChecker{name: "payments"}},
+			wantCode:   http.StatusOK,
+			wantStatus: "ready",
+			wantChecks: map[string]string{"redis": "ok", "payments": "ok"},
+		},
+		{
+			name:       "malformed script",
+			checkers:   []adapter.HealthChecker{testChecker{name: "redis", err: errScript}, testChecker{name: "payments"}},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: "not_ready",
+			wantChecks: map[string]string{"redis": errScript.Error(), "payments": "ok"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewHealthController(logger.NewLoggerWithLevel(zerolog.Disabled), tc.checkers...)
+
+			rec := httptest.NewRecorder()
+			c.Ready(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+			if rec.Code != tc.wantCode {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tc.wantCode, rec.Body)
+			}
+			var body struct {
+				Status string            `json:"status"`
+				Checks map[string]string `json:"checks"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body.Status != tc.wantStatus || !maps.Equal(body.Checks, tc.wantChecks) {
+				t.Errorf("body = %+v, want status %q and checks %v", body, tc.wantStatus, tc.wantChecks)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// Middleware wraps a handler with behavior that runs around it; it has the shape router.Use expects
+type Middleware func(http.Handler) http.Handler
+
+// Chain composes middleware into one, the first listed being the outermost: Chain(a, b)(h) runs a, then b, then h.
+// Any of them may answer the request itself and skip the rest of the chain.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next http.Handler) http.Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+// MiddlewareStack is the one place the server's middleware is assembled, so its order is explicit.
+// Global middleware wraps every route in the order it was added (e.g. request ID before logging,
+// auth before RBAC); route middleware then runs, also in order, for requests to its endpoint only.
+type MiddlewareStack struct {
+	global []Middleware
+	routes []routeMiddleware
+}
+
+// routeMiddleware is middleware added for one endpoint
+type routeMiddleware struct {
+	endpoint   string
+	middleware Middleware
+}
+
+// NewMiddlewareStack creates a stack with the given global middleware
+func NewMiddlewareStack(global ...Middleware) *MiddlewareStack {
+	return &MiddlewareStack{global: append([]Middleware(nil), global...)}
+}
+
+// Use appends global middleware, which runs inside the middleware added before it
+func (s *MiddlewareStack) Use(middlewares ...Middleware) {
+	s.global = append(s.global, middlewares...)
+}
+
+// UseFor appends middleware for one endpoint, written like AuditedEndpoints as "METHOD template",
+// e.g. "POST /tickets/purchase". It runs inside all global middleware.
+func (s *MiddlewareStack) UseFor(endpoint string, middlewares ...Middleware) {
+	for _, middleware := range middlewares {
+		s.routes = append(s.routes, routeMiddleware{endpoint: endpoint, middleware: middleware})
+	}
+}
+
+// Apply installs the stack on a router; call it once, after registering the routes
+func (s *MiddlewareStack) Apply(router *mux.Router) {
+	router.Use(mux.MiddlewareFunc(s.Middleware))
+}
+
+// Middleware runs the global middleware and then the middleware of the matched route around next
+func (s *MiddlewareStack) Middleware(next http.Handler) http.Handler {
+	routed := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var matched []Middleware
+		for _, route := range s.routes {
+			if matchesEndpoint(r, route.endpoint) {
+				matched = append(matched, route.middleware)
+			}
+		}
+
+		if len(matched) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		Chain(matched...)(next).ServeHTTP(w, r)
+	})
+
+	return Chain(s.global...)(routed)
+}
+
+// matchesEndpoint reports whether the request's route is the "METHOD template" endpoint
+func matchesEndpoint(r *http.Request, endpoint string) bool {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return false
+	}
+
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return false
+	}
+
+	method, path, _ := strings.Cut(endpoint, " ")
+	// Routes may be mounted under a prefix such as /api/v1
+	return r.Method == method && strings.HasSuffix(template, path)
+}
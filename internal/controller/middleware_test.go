@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// trace records the order middleware and handlers run in
+type trace struct {
+	calls []string
+}
+
+// middleware returns middleware that records name and passes the request on
+func (tr *trace) middleware(name string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tr.calls = append(tr.calls, name)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// handler returns a handler that records name
+func (tr *trace) handler(name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tr.calls = append(tr.calls, name)
+	})
+}
+
+// reject is middleware that answers every request itself
+func reject(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	})
+}
+
+func TestChain(t *testing.T) {
+	tests := []struct {
+		name       string
+		middleware func(tr *trace) []Middleware
+		want       []string
+		wantStatus int
+	}{
+		{
+			name:       "none",
+			middleware: func(tr *trace) []Middleware { return nil },
+			want:       []string{"handler"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "first listed is outermost",
+			middleware: func(tr *trace) []Middleware {
+				return []Middleware{tr.middleware("a"), tr.middleware("b"), tr.middleware("c")}
+			},
+			want:       []string{"a", "b", "c", "handler"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "answering skips the rest",
+			middleware: func(tr *trace) []Middleware { return []Middleware{tr.middleware("a"), reject, tr.middleware("c")} },
+			want:       []string{"a"},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := &trace{}
+			rec := httptest.NewRecorder()
+			Chain(tc.middleware(tr)...)(tr.handler("handler")).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if !slices.Equal(tr.calls, tc.want) {
+				t.Errorf("calls = %v, want %v", tr.calls, tc.want)
+			}
+			if rec.Code != tc.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+		})
+	}
+}
+
+func TestMiddlewareStack(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		want       []string
+		wantStatus int
+	}{
+		{name: "endpoint with route middleware", method: http.MethodPost, path: "/api/v1/tickets/purchase", want: []string{"request_id", "logging", "audit", "rate_limit", "purchase"}, wantStatus: http.StatusOK},
+		{name: "endpoint without route middleware", method: http.MethodGet, path: "/api/v1/events/abc", want: []string{"request_id", "logging", "event"}, wantStatus: http.StatusOK},
+		{name: "route middleware answering", method: http.MethodDelete, path: "/api/v1/events/abc", want: []string{"request_id", "logging"}, wantStatus: http.StatusForbidden},
+		{name: "same path, other method", method: http.MethodGet, path: "/api/v1/tickets/purchase", want: []string{"request_id", "logging", "purchase status"}, wantStatus: http.StatusOK},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := &trace{}
+
+			router := mux.NewRouter()
+			api := router.PathPrefix("/api/v1").Subrouter()
+			api.Handle("/tickets/purchase", tr.handler("purchase")).Methods(http.MethodPost)
+			api.Handle("/tickets/purchase", tr.handler("purchase status")).Methods(http.MethodGet)
+			api.Handle("/events/{id}", tr.handler("event")).Methods(http.MethodGet)
+			api.Handle("/events/{id}", tr.handler("delete event")).Methods(http.MethodDelete)
+
+			stack := NewMiddlewareStack(tr.middleware("request_id"))
+			stack.Use(tr.middleware("logging"))
+			stack.UseFor("POST /tickets/purchase", tr.middleware("audit"), tr.middleware("rate_limit"))
+			stack.UseFor("DELETE /events/{id}", reject)
+			stack.Apply(api)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+
+			if !slices.Equal(tr.calls, tc.want) {
+				t.Errorf("calls = %v, want %v", tr.calls, tc.want)
+			}
+			if rec.Code != tc.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+		})
+	}
+}
+
+func TestNewMiddlewareStackCopiesGlobal(t *testing.T) {
+	tr := &trace{}
+	global := make([]Middleware, 1, 2)
+	global[0] = tr.middleware("a")
+
+	stack := NewMiddlewareStack(global...)
+	stack.Use(tr.middleware("b"))
+	_ = append(global, tr.middleware("c"))
+
+	stack.Middleware(tr.handler("handler")).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if want := []string{"a", "b", "handler"}; !slices.Equal(tr.calls, want) {
+		t.Errorf("calls = %v, want %v", tr.calls, want)
+	}
+}
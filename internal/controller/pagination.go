@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// DefaultPageLimit is the page size used when a request does not specify a limit
+	DefaultPageLimit = 20
+
+	// MaxPageLimit is the largest page size a client may request
+	MaxPageLimit = 100
+)
+
+// Page represents a paginated list response shared by all list endpoints
+type Page[T any] struct {
+	Items      []T  `json:"items"`
+	Total      int  `json:"total"`
+	Offset     int  `json:"offset"`
+	Limit      int  `json:"limit"`
+	HasMore    bool `json:"has_more"`
+	NextOffset *int `json:"next_offset,omitempty"`
+	PrevOffset *int `json:"prev_offset,omitempty"`
+}
+
+// NewPage builds a page from a slice of items and the total number of matching items. The next page starts
+// limit past this one rather than after the items returned, since a repository may skip items it could not read,
+// and following the offsets must not revisit the ones it did.
+func NewPage[T any](items []T, total, offset, limit int) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	page := Page[T]{
+		Items:   items,
+		Total:   total,
+		Offset:  offset,
+		Limit:   limit,
+		HasMore: offset+limit < total,
+	}
+
+	if page.HasMore {
+		next := offset + limit
+		page.NextOffset = &next
+	}
+
+	if offset > 0 {
+		prev := offset - limit
+		if prev < 0 {
+			prev = 0
+		}
+		page.PrevOffset = &prev
+	}
+
+	return page
+}
+
+// writePage encodes a page as a JSON response
+func writePage[T any](w http.ResponseWriter, page Page[T]) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+// parsePagination reads the offset and limit query parameters from a request
+func parsePagination(r *http.Request) (int, int, error) {
+	offset := 0
+	limit := DefaultPageLimit
+
+	if value := r.URL.Query().Get("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			return 0, 0, fmt.Errorf("offset must be a non-negative integer")
+		}
+		offset = parsed
+	}
+
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			return 0, 0, fmt.Errorf("limit must be a positive integer")
+		}
+		limit = parsed
+	}
+
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+
+	return offset, limit, nil
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewPage(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name     string
+		items    []int
+		total    int
+		offset   int
+		limit    int
+		wantMore bool
+		wantNext *int
+		wantPrev *int
+	}{
+		{name: "first of several pages", items: []int{1, 2}, total: 5, offset: 0, limit: 2, wantMore: true, wantNext: intPtr(2)},
+		{name: "middle page", items: []int{3, 4}, total: 5, offset: 2, limit: 2, wantMore: true, wantNext: intPtr(4), wantPrev: intPtr(0)},
+		{name: "last partial page", items: []int{5}, total: 5, offset: 4, limit: 2, wantPrev: intPtr(2)},
+		{name: "exact last page", items: []int{3, 4}, total: 4, offset: 2, limit: 2, wantPrev: intPtr(0)},
+		{name: "short page with more behind it", items: []int{1}, total: 5, offset: 0, limit: 2, wantMore: true, wantNext: intPtr(2)},
+		{name: "empty page past the end", items: nil, total: 5, offset: 10, limit: 2, wantPrev: intPtr(8)},
+		{name: "previous offset stops at zero", items: []int{2, 3}, total: 5, offset: 1, limit: 2, wantMore: true, wantNext: intPtr(3), wantPrev: intPtr(0)},
+		{name: "no items at all", items: nil, total: 0, offset: 0, limit: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := NewPage(tt.items, tt.total, tt.offset, tt.limit)
+
+			if page.Items == nil {
+				t.Fatal("items is nil, want an empty slice")
+			}
+			if page.Total != tt.total || page.Offset != tt.offset || page.Limit != tt.limit {
+				t.Fatalf("page = total %d offset %d limit %d, want %d %d %d", page.Total, page.Offset, page.Limit, tt.total, tt.offset, tt.limit)
+			}
+			if page.HasMore != tt.wantMore {
+				t.Fatalf("has more = %v, want %v", page.HasMore, tt.wantMore)
+			}
+			assertOffset(t, "next", page.NextOffset, tt.wantNext)
+			assertOffset(t, "prev", page.PrevOffset, tt.wantPrev)
+		})
+	}
+}
+
+func assertOffset(t *testing.T, name string, got, want *int) {
+	t.Helper()
+
+	switch {
+	case got == nil && want == nil:
+	case got == nil || want == nil:
+		t.Fatalf("%s offset = %v, want %v", name, got, want)
+	case *got != *want:
+		t.Fatalf("%s offset = %d, want %d", name, *got, *want)
+	}
+}
+
+func TestParsePagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantOffset int
+		wantLimit  int
+		wantErr    bool
+	}{
+		{name: "defaults", query: "", wantOffset: 0, wantLimit: DefaultPageLimit},
+		{name: "explicit values", query: "?offset=40&limit=10", wantOffset: 40, wantLimit: 10},
+		{name: "limit is capped", query: "?limit=1000", wantOffset: 0, wantLimit: MaxPageLimit},
+		{name: "negative offset", query: "?offset=-1", wantErr: true},
+		{name: "zero limit", query: "?limit=0", wantErr: true},
+		{name: "non-numeric limit", query: "?limit=ten", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, limit, err := parsePagination(httptest.NewRequest("GET", "/items"+tt.query, nil))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse pagination: %v", err)
+			}
+			if offset != tt.wantOffset || limit != tt.wantLimit {
+				t.Fatalf("offset, limit = %d, %d, want %d, %d", offset, limit, tt.wantOffset, tt.wantLimit)
+			}
+		})
+	}
+}
@@ -2,12 +2,17 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/snowmerak/ticketing/internal/service"
 	"github.com/snowmerak/ticketing/lib/adapter"
+	"github.com/snowmerak/ticketing/lib/repository"
 )
 
 // QueueController handles HTTP requests for queue operations
@@ -62,6 +67,11 @@ func (c *QueueController) JoinQueue(w http.ResponseWriter, r *http.Request) {
 	// Join queue
 	entry, err := c.queueService.JoinQueue(ctx, req.EventID, req.UserID, req.SessionID)
 	if err != nil {
+		if errors.Is(err, service.ErrQueueOverloaded) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(service.QueueShedRetryAfter.Seconds())))
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
 		c.logger.Error(ctx, "Failed to join queue", "error", err)
 		http.Error(w, "Failed to join queue: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -72,6 +82,106 @@ func (c *QueueController) JoinQueue(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(entry)
 }
 
+// JoinQueueBatchRequest represents the request body for enqueuing a group
+type JoinQueueBatchRequest struct {
+	EventID   uuid.UUID   `json:"event_id"`
+	UserIDs   []uuid.UUID `json:"user_ids"`
+	SessionID string      `json:"session_id"`
+}
+
+// JoinQueueBatch handles POST /queue/join-batch
+func (c *QueueController) JoinQueueBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	c.logger.Info(ctx, "Join queue batch request", "method", r.Method, "path", r.URL.Path)
+
+	var req JoinQueueBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.logger.Error(ctx, "Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	fields := ValidationErrors{}
+	if req.EventID == uuid.Nil {
+		fields.Add("event_id", "is required")
+	}
+	if len(req.UserIDs) == 0 {
+		fields.Add("user_ids", "at least one user is required")
+	}
+	if len(req.UserIDs) > service.MaxQueueBatchSize {
+		fields.Add("user_ids", fmt.Sprintf("at most %d users are allowed", service.MaxQueueBatchSize))
+	}
+	if req.SessionID == "" {
+		fields.Add("session_id", "is required")
+	}
+	if fields.HasErrors() {
+		writeValidationErrors(w, fields)
+		return
+	}
+
+	results, err := c.queueService.JoinQueueBatch(ctx, req.EventID, req.UserIDs, req.SessionID)
+	if err != nil {
+		if errors.Is(err, service.ErrQueueOverloaded) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(service.QueueShedRetryAfter.Seconds())))
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		c.logger.Error(ctx, "Failed to join queue batch", "error", err)
+		http.Error(w, "Failed to join queue: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"results": results,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// LeaveQueueRequest represents the request body for leaving a queue
+type LeaveQueueRequest struct {
+	EventID uuid.UUID `json:"event_id"`
+	UserID  uuid.UUID `json:"user_id"`
+}
+
+// LeaveQueue handles POST /queue/leave
+func (c *QueueController) LeaveQueue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req LeaveQueueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.logger.Error(ctx, "Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.EventID == uuid.Nil {
+		http.Error(w, "Event ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if req.UserID == uuid.Nil {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := c.queueService.LeaveQueue(ctx, req.EventID, req.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrQueueEntryNotFound) {
+			http.Error(w, "User is not in the queue", http.StatusNotFound)
+			return
+		}
+		c.logger.Error(ctx, "Failed to leave queue", "error", err)
+		http.Error(w, "Failed to leave queue: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entry)
+}
+
 // GetQueuePosition handles GET /queue/position/{event_id}/{user_id}
 func (c *QueueController) GetQueuePosition(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -164,6 +274,35 @@ func (c *QueueController) GetQueueLength(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(response)
 }
 
+// GetActiveEntries handles GET /queue/active/{event_id}
+func (c *QueueController) GetActiveEntries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	eventID, err := uuid.Parse(vars["event_id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid event ID", "id", vars["event_id"], "error", err)
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	entries, err := c.queueService.GetActiveEntries(ctx, eventID)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to get active queue entries", "event_id", eventID, "error", err)
+		http.Error(w, "Failed to get active queue entries", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"event_id": eventID,
+		"active":   entries,
+		"count":    len(entries),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
 // ProcessQueue handles POST /queue/process/{event_id}
 func (c *QueueController) ProcessQueue(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -178,6 +317,13 @@ func (c *QueueController) ProcessQueue(w http.ResponseWriter, r *http.Request) {
 
 	entry, err := c.queueService.ProcessQueue(ctx, eventID)
 	if err != nil {
+		if writeActivationPaced(w, err) {
+			return
+		}
+		if errors.Is(err, service.ErrNoInventoryToActivate) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
 		c.logger.Error(ctx, "Failed to process queue", "error", err)
 		http.Error(w, "Failed to process queue: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -187,6 +333,112 @@ func (c *QueueController) ProcessQueue(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(entry)
 }
 
+// writeActivationPaced answers a paced activation with 429 and a Retry-After of the remaining wait
+func writeActivationPaced(w http.ResponseWriter, err error) bool {
+	var paced *service.ActivationPacedError
+	if !errors.As(err, &paced) {
+		return false
+	}
+
+	retryAfter := int(math.Ceil(paced.RetryAfter.Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+	http.Error(w, err.Error(), http.StatusTooManyRequests)
+	return true
+}
+
+// ProcessQueueBatchRequest represents the request body for activating several users at once
+type ProcessQueueBatchRequest struct {
+	Count int `json:"count"`
+}
+
+// ProcessQueueBatch handles POST /queue/process/{event_id}/batch
+func (c *QueueController) ProcessQueueBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	eventID, err := uuid.Parse(vars["event_id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid event ID", "id", vars["event_id"], "error", err)
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	var req ProcessQueueBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.logger.Error(ctx, "Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Count <= 0 || req.Count > service.MaxQueueProcessBatchSize {
+		http.Error(w, fmt.Sprintf("Count must be between 1 and %d", service.MaxQueueProcessBatchSize), http.StatusBadRequest)
+		return
+	}
+
+	entries, err := c.queueService.ProcessQueueBatch(ctx, eventID, req.Count)
+	if err != nil {
+		if writeActivationPaced(w, err) {
+			return
+		}
+		if errors.Is(err, service.ErrNoInventoryToActivate) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		c.logger.Error(ctx, "Failed to process queue batch", "error", err)
+		http.Error(w, "Failed to process queue: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"activated": entries,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// ActivateAll handles POST /admin/queue/{event_id}/activate-all. Pass bypass_cap=true to activate everyone even
+// when activation is capped at the remaining inventory.
+func (c *QueueController) ActivateAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	eventID, err := uuid.Parse(vars["event_id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid event ID", "id", vars["event_id"], "error", err)
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	bypassCap := false
+	if raw := r.URL.Query().Get("bypass_cap"); raw != "" {
+		bypassCap, err = strconv.ParseBool(raw)
+		if err != nil {
+			http.Error(w, "bypass_cap must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	entries, err := c.queueService.ActivateAll(ctx, eventID, bypassCap)
+	if err != nil {
+		if errors.Is(err, service.ErrNoInventoryToActivate) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		c.logger.Error(ctx, "Failed to activate queue", "event_id", eventID, "error", err)
+		http.Error(w, "Failed to activate queue: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"activated": entries,
+		"count":     len(entries),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
 // RefreshSessionRequest represents the request body for refreshing a session
 type RefreshSessionRequest struct {
 	SessionID string `json:"session_id"`
@@ -209,6 +461,14 @@ func (c *QueueController) RefreshSession(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := c.queueService.RefreshSession(ctx, req.SessionID); err != nil {
+		if errors.Is(err, service.ErrSessionRefreshLimit) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, service.ErrSessionExpired) {
+			http.Error(w, err.Error(), http.StatusGone)
+			return
+		}
 		c.logger.Error(ctx, "Failed to refresh session", "error", err)
 		http.Error(w, "Failed to refresh session: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -225,9 +485,14 @@ func (c *QueueController) RefreshSession(w http.ResponseWriter, r *http.Request)
 // RegisterRoutes registers all queue routes
 func (c *QueueController) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/queue/join", c.JoinQueue).Methods("POST")
+	router.HandleFunc("/queue/join-batch", c.JoinQueueBatch).Methods("POST")
+	router.HandleFunc("/queue/leave", c.LeaveQueue).Methods("POST")
 	router.HandleFunc("/queue/position/{event_id}/{user_id}", c.GetQueuePosition).Methods("GET")
 	router.HandleFunc("/queue/status/{session_id}", c.GetQueueStatus).Methods("GET")
 	router.HandleFunc("/queue/length/{event_id}", c.GetQueueLength).Methods("GET")
+	router.HandleFunc("/queue/active/{event_id}", c.GetActiveEntries).Methods("GET")
 	router.HandleFunc("/queue/process/{event_id}", c.ProcessQueue).Methods("POST")
+	router.HandleFunc("/queue/process/{event_id}/batch", c.ProcessQueueBatch).Methods("POST")
 	router.HandleFunc("/queue/refresh", c.RefreshSession).Methods("POST")
+	router.HandleFunc("/admin/queue/{event_id}/activate-all", c.ActivateAll).Methods("POST")
 }
@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/snowmerak/ticketing/internal/service"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// ListForResaleRequest represents the request body for listing a ticket for resale
+type ListForResaleRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+	Price  int64     `json:"price"`
+}
+
+// ResaleParticipantRequest represents the request body naming the user buying or withdrawing a listing
+type ResaleParticipantRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// writeResaleError maps resale errors to HTTP responses
+func writeResaleError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrResaleDisabled):
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+	case errors.Is(err, repository.ErrResaleListingNotFound):
+		http.Error(w, "Resale listing not found", http.StatusNotFound)
+	case errors.Is(err, service.ErrResalePriceAboveCap):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, service.ErrTicketNotTransferable), errors.Is(err, service.ErrResaleListingUnavailable):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, fallback+": "+err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// ListForResale handles POST /tickets/{id}/resale
+func (c *TicketingController) ListForResale(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	ticketID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid ticket ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid ticket ID", http.StatusBadRequest)
+		return
+	}
+
+	var req ListForResaleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.logger.Error(ctx, "Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.UserID == uuid.Nil {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if req.Price <= 0 {
+		http.Error(w, "Price must be positive", http.StatusBadRequest)
+		return
+	}
+
+	listing, err := c.ticketingService.ListForResale(ctx, ticketID, req.UserID, req.Price)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to list ticket for resale", "ticket_id", ticketID, "error", err)
+		writeResaleError(w, err, "Failed to list ticket for resale")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(listing)
+}
+
+// GetResaleListings handles GET /events/{id}/resale
+func (c *TicketingController) GetResaleListings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid event ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	listings, err := c.ticketingService.GetResaleListings(ctx, eventID)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to get resale listings", "event_id", eventID, "error", err)
+		writeResaleError(w, err, "Failed to get resale listings")
+		return
+	}
+
+	if listings == nil {
+		listings = []*domain.ResaleListing{}
+	}
+
+	response := map[string]interface{}{
+		"listings": listings,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// BuyResaleListing handles POST /resale/{id}/buy
+func (c *TicketingController) BuyResaleListing(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	listingID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid listing ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid listing ID", http.StatusBadRequest)
+		return
+	}
+
+	var req ResaleParticipantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.logger.Error(ctx, "Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.UserID == uuid.Nil {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	ticket, err := c.ticketingService.BuyResaleListing(ctx, listingID, req.UserID)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to buy resale listing", "listing_id", listingID, "error", err)
+		writeResaleError(w, err, "Failed to buy resale listing")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newTicketResponse(ticket))
+}
+
+// CancelResaleListing handles POST /resale/{id}/cancel
+func (c *TicketingController) CancelResaleListing(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	listingID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid listing ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid listing ID", http.StatusBadRequest)
+		return
+	}
+
+	var req ResaleParticipantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.logger.Error(ctx, "Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.UserID == uuid.Nil {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := c.ticketingService.CancelResaleListing(ctx, listingID, req.UserID); err != nil {
+		c.logger.Error(ctx, "Failed to cancel resale listing", "listing_id", listingID, "error", err)
+		writeResaleError(w, err, "Failed to cancel resale listing")
+		return
+	}
+
+	response := map[string]interface{}{
+		"message": "Resale listing cancelled successfully",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
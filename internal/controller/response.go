@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/internal/service"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// EventResponse is the v1 wire format of an event. It is mapped field by field from domain.Event, so a field
+// added to the domain type stays off the API until it is added here.
+type EventResponse struct {
+	ID                     uuid.UUID             `json:"id"`
+	Name                   string                `json:"name"`
+	Description            string                `json:"description"`
+	StartTime              time.Time             `json:"start_time"`
+	EndTime                time.Time             `json:"end_time"`
+	Venue                  string                `json:"venue"`
+	Status                 string                `json:"status"`
+	TotalTickets           int                   `json:"total_tickets"`
+	AvailableTickets       int                   `json:"available_tickets"`
+	IsSeatedEvent          bool                  `json:"is_seated_event"`
+	NumberedStanding       bool                  `json:"numbered_standing"`
+	StandingPrice          int64                 `json:"standing_price"`
+	StandingZones          []domain.StandingZone `json:"standing_zones,omitempty"`
+	MaxConcurrentPurchases int                   `json:"max_concurrent_purchases,omitempty"`
+	MaxTicketsPerUser      int                   `json:"max_tickets_per_user,omitempty"`
+	QueueHighWaterMark     int                   `json:"queue_high_water_mark,omitempty"`
+	ActiveHighWaterMark    int                   `json:"active_high_water_mark,omitempty"`
+	Currency               string                `json:"currency"`
+	ImageURL               string                `json:"image_url,omitempty"`
+	ThumbnailURL           string                `json:"thumbnail_url,omitempty"`
+	SeatRanking            string                `json:"seat_ranking,omitempty"`
+	LockGranularity        string                `json:"lock_granularity,omitempty"`
+	AllowGuestCheckout     bool                  `json:"allow_guest_checkout,omitempty"`
+	RefundPolicy           *domain.RefundPolicy  `json:"refund_policy,omitempty"`
+	CreatedAt              time.Time             `json:"created_at"`
+	UpdatedAt              time.Time             `json:"updated_at"`
+}
+
+// newEventResponse maps an event to its v1 wire format
+func newEventResponse(event *domain.Event) EventResponse {
+	return EventResponse{
+		ID:                     event.ID,
+		Name:                   event.Name,
+		Description:            event.Description,
+		StartTime:              event.StartTime,
+		EndTime:                event.EndTime,
+		Venue:                  event.Venue,
+		Status:                 event.Status,
+		TotalTickets:           event.TotalTickets,
+		AvailableTickets:       event.AvailableTickets,
+		IsSeatedEvent:          event.IsSeatedEvent,
+		NumberedStanding:       event.NumberedStanding,
+		StandingPrice:          event.StandingPrice,
+		StandingZones:          event.StandingZones,
+		MaxConcurrentPurchases: event.MaxConcurrentPurchases,
+		MaxTicketsPerUser:      event.MaxTicketsPerUser,
+		QueueHighWaterMark:     event.QueueHighWaterMark,
+		ActiveHighWaterMark:    event.ActiveHighWaterMark,
+		Currency:               event.Currency,
+		ImageURL:               event.ImageURL,
+		ThumbnailURL:           event.ThumbnailURL,
+		SeatRanking:            event.SeatRanking,
+		LockGranularity:        event.LockGranularity,
+		AllowGuestCheckout:     event.AllowGuestCheckout,
+		RefundPolicy:           event.RefundPolicy,
+		CreatedAt:              event.CreatedAt,
+		UpdatedAt:              event.UpdatedAt,
+	}
+}
+
+// newEventResponses maps a list of events to their v1 wire format
+func newEventResponses(events []*domain.Event) []EventResponse {
+	responses := make([]EventResponse, 0, len(events))
+	for _, event := range events {
+		responses = append(responses, newEventResponse(event))
+	}
+	return responses
+}
+
+// TicketResponse is the v1 wire format of a ticket. It is mapped field by field from domain.Ticket, so a field
+// added to the domain type stays off the API until it is added here.
+type TicketResponse struct {
+	ID                uuid.UUID              `json:"id"`
+	EventID           uuid.UUID              `json:"event_id"`
+	SeatID            *uuid.UUID             `json:"seat_id,omitempty"`
+	UserID            uuid.UUID              `json:"user_id"`
+	GuestEmail        string                 `json:"guest_email,omitempty"`
+	CallbackURL       string                 `json:"callback_url,omitempty"`
+	Price             int64                  `json:"price"`
+	Breakdown         *domain.PriceBreakdown `json:"breakdown,omitempty"`
+	Currency          string                 `json:"currency,omitempty"`
+	Status            string                 `json:"status"`
+	GANumber          *int64                 `json:"ga_number,omitempty"`
+	Zone              string                 `json:"zone,omitempty"`
+	CompanionTicketID *uuid.UUID             `json:"companion_ticket_id,omitempty"`
+	AccessToken       string                 `json:"access_token,omitempty"`
+	CheckedInAt       *time.Time             `json:"checked_in_at,omitempty"`
+	ConfirmedAt       *time.Time             `json:"confirmed_at,omitempty"`
+	CancelledAt       *time.Time             `json:"cancelled_at,omitempty"`
+	RefundAmount      int64                  `json:"refund_amount,omitempty"`
+	IssuedAt          time.Time              `json:"issued_at"`
+	ExpiresAt         *time.Time             `json:"expires_at,omitempty"`
+	CreatedAt         time.Time              `json:"created_at"`
+	UpdatedAt         time.Time              `json:"updated_at"`
+}
+
+// newTicketResponse maps a ticket to its v1 wire format
+func newTicketResponse(ticket *domain.Ticket) TicketResponse {
+	return TicketResponse{
+		ID:                ticket.ID,
+		EventID:           ticket.EventID,
+		SeatID:            ticket.SeatID,
+		UserID:            ticket.UserID,
+		GuestEmail:        ticket.GuestEmail,
+		CallbackURL:       ticket.CallbackURL,
+		Price:             ticket.Price,
+		Breakdown:         ticket.Breakdown,
+		Currency:          ticket.Currency,
+		Status:            ticket.Status,
+		GANumber:          ticket.GANumber,
+		Zone:              ticket.Zone,
+		CompanionTicketID: ticket.CompanionTicketID,
+		AccessToken:       ticket.AccessToken,
+		CheckedInAt:       ticket.CheckedInAt,
+		ConfirmedAt:       ticket.ConfirmedAt,
+		CancelledAt:       ticket.CancelledAt,
+		RefundAmount:      ticket.RefundAmount,
+		IssuedAt:          ticket.IssuedAt,
+		ExpiresAt:         ticket.ExpiresAt,
+		CreatedAt:         ticket.CreatedAt,
+		UpdatedAt:         ticket.UpdatedAt,
+	}
+}
+
+// newTicketResponses maps a list of tickets to their v1 wire format
+func newTicketResponses(tickets []*domain.Ticket) []TicketResponse {
+	responses := make([]TicketResponse, 0, len(tickets))
+	for _, ticket := range tickets {
+		responses = append(responses, newTicketResponse(ticket))
+	}
+	return responses
+}
+
+// TicketLookupResponse is the v1 wire format of one answer to a batch ticket lookup
+type TicketLookupResponse struct {
+	ID     uuid.UUID       `json:"id"`
+	Found  bool            `json:"found"`
+	Ticket *TicketResponse `json:"ticket,omitempty"`
+}
+
+// newTicketLookupResponses maps batch lookup answers to their v1 wire format
+func newTicketLookupResponses(lookups []service.TicketLookup) []TicketLookupResponse {
+	responses := make([]TicketLookupResponse, 0, len(lookups))
+	for _, lookup := range lookups {
+		response := TicketLookupResponse{ID: lookup.ID, Found: lookup.Found}
+		if lookup.Ticket != nil {
+			ticket := newTicketResponse(lookup.Ticket)
+			response.Ticket = &ticket
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/internal/service"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// jsonKeys returns the sorted top-level keys v marshals to
+func jsonKeys(t *testing.T, v any) []string {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func TestEventResponseShape(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		event *domain.Event
+		want  []string
+	}{
+		{
+			name:  "minimal event",
+			event: &domain.Event{ID: uuid.New(), Name: "Concert", StartTime: now, EndTime: now.Add(time.Hour)},
+			want: []string{
+				"available_tickets", "created_at", "currency", "description", "end_time", "id", "is_seated_event",
+				"name", "numbered_standing", "standing_price", "start_time", "status", "total_tickets", "updated_at",
+				"venue",
+			},
+		},
+		{
+			name: "every field set",
+			event: &domain.Event{
+				ID:                     uuid.New(),
+				Name:                   "Concert",
+				StandingZones:          []domain.StandingZone{{Name: "floor", Capacity: 100}},
+				MaxConcurrentPurchases: 10,
+				MaxTicketsPerUser:      4,
+				QueueHighWaterMark:     1000,
+				ActiveHighWaterMark:    100,
+				ImageURL:               "https://example.com/image.png",
+				ThumbnailURL:           "https://example.com/thumb.png",
+				SeatRanking:            "best_available",
+				LockGranularity:        "seat",
+				AllowGuestCheckout:     true,
+				RefundPolicy:           &domain.RefundPolicy{},
+			},
+			want: []string{
+				"active_high_water_mark", "allow_guest_checkout", "available_tickets", "created_at", "currency",
+				"description", "end_time", "id", "image_url", "is_seated_event", "lock_granularity",
+				"max_concurrent_purchases", "max_tickets_per_user", "name", "numbered_standing",
+				"queue_high_water_mark", "refund_policy", "seat_ranking", "standing_price", "standing_zones",
+				"start_time", "status", "thumbnail_url", "total_tickets", "updated_at", "venue",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := jsonKeys(t, newEventResponse(tc.event)); !slices.Equal(got, tc.want) {
+				t.Errorf("event response keys = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTicketResponseShape(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	seatID := uuid.New()
+	companionID := uuid.New()
+	gaNumber := int64(7)
+
+	tests := []struct {
+		name   string
+		ticket *domain.Ticket
+		want   []string
+	}{
+		{
+			name:   "minimal ticket",
+			ticket: &domain.Ticket{ID: uuid.New(), EventID: uuid.New(), UserID: uuid.New(), Status: string(domain.TicketStatusReserved)},
+			want:   []string{"created_at", "event_id", "id", "issued_at", "price", "status", "updated_at", "user_id"},
+		},
+		{
+			name: "every field set",
+			ticket: &domain.Ticket{
+				ID:                uuid.New(),
+				EventID:           uuid.New(),
+				SeatID:            &seatID,
+				UserID:            uuid.New(),
+				GuestEmail:        "guest@example.com",
+				CallbackURL:       "https://example.com/callback",
+				Price:             11000,
+				Breakdown:         &domain.PriceBreakdown{},
+				Currency:          "USD",
+				Status:            string(domain.TicketStatusCancelled),
+				GANumber:          &gaNumber,
+				Zone:              "floor",
+				CompanionTicketID: &companionID,
+				AccessToken:       "token",
+				CheckedInAt:       &now,
+				ConfirmedAt:       &now,
+				CancelledAt:       &now,
+				RefundAmount:      5500,
+				ExpiresAt:         &now,
+			},
+			want: []string{
+				"access_token", "breakdown", "callback_url", "cancelled_at", "checked_in_at", "companion_ticket_id",
+				"confirmed_at", "created_at", "currency", "event_id", "expires_at", "ga_number", "guest_email", "id",
+				"issued_at", "price", "refund_amount", "seat_id", "status", "updated_at", "user_id", "zone",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := jsonKeys(t, newTicketResponse(tc.ticket)); !slices.Equal(got, tc.want) {
+				t.Errorf("ticket response keys = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTicketLookupResponseShape(t *testing.T) {
+	ticket := &domain.Ticket{ID: uuid.New(), EventID: uuid.New(), UserID: uuid.New()}
+	responses := newTicketLookupResponses([]service.TicketLookup{
+		{ID: ticket.ID, Found: true, Ticket: ticket},
+		{ID: uuid.New()},
+	})
+
+	if got, want := jsonKeys(t, responses[0]), []string{"found", "id", "ticket"}; !slices.Equal(got, want) {
+		t.Errorf("found lookup keys = %v, want %v", got, want)
+	}
+	if got, want := jsonKeys(t, responses[1]), []string{"found", "id"}; !slices.Equal(got, want) {
+		t.Errorf("missing lookup keys = %v, want %v", got, want)
+	}
+	if responses[0].Ticket.ID != ticket.ID {
+		t.Errorf("lookup ticket ID = %s, want %s", responses[0].Ticket.ID, ticket.ID)
+	}
+}
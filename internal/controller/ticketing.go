@@ -1,13 +1,21 @@
 package controller
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/snowmerak/ticketing/internal/service"
 	"github.com/snowmerak/ticketing/lib/adapter"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
 )
 
 // TicketingController handles HTTP requests for ticketing operations
@@ -30,6 +38,20 @@ type PurchaseTicketRequest struct {
 	UserID    uuid.UUID  `json:"user_id"`
 	SeatID    *uuid.UUID `json:"seat_id,omitempty"`
 	SessionID string     `json:"session_id"`
+	// SeatIDs buys several seats at once, all or none, instead of seat_id
+	SeatIDs []uuid.UUID `json:"seat_ids,omitempty"`
+	// Accessible requests an accessible seat, booking its companion seat alongside it
+	Accessible bool `json:"accessible,omitempty"`
+	// WaitroomToken is the signed token from the user's queue status once they became active
+	WaitroomToken string `json:"waitroom_token,omitempty"`
+	// AllowSeatFallback accepts the nearest comparable seat if the requested one is taken
+	AllowSeatFallback bool `json:"allow_seat_fallback,omitempty"`
+	// GuestToken buys as a guest with a verified email; user_id must be the guest's pseudo user ID
+	GuestToken string `json:"guest_token,omitempty"`
+	// StandingZone names the zone a standing ticket admits to; required for events sold per zone
+	StandingZone string `json:"standing_zone,omitempty"`
+	// CallbackURL is POSTed a signed notice when the ticket is confirmed; its host must be allowlisted
+	CallbackURL string `json:"callback_url,omitempty"`
 }
 
 // PurchaseTicket handles POST /tickets/purchase
@@ -60,17 +82,151 @@ func (c *TicketingController) PurchaseTicket(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	idempotencyKey := r.Header.Get("Idempotency-Key")
+	if len(idempotencyKey) > service.MaxIdempotencyKeyLength {
+		http.Error(w, fmt.Sprintf("Idempotency-Key must be at most %d characters", service.MaxIdempotencyKeyLength), http.StatusBadRequest)
+		return
+	}
+
+	if len(req.SeatIDs) > 0 {
+		c.purchaseTicketSeats(w, r, req, idempotencyKey)
+		return
+	}
+
 	// Purchase ticket
-	ticket, err := c.ticketingService.PurchaseTicket(ctx, req.EventID, req.UserID, req.SeatID, req.SessionID)
+	ticket, err := c.ticketingService.PurchaseTicket(ctx, req.EventID, req.UserID, req.SeatID, req.SessionID, service.PurchaseOptions{
+		Accessible:        req.Accessible,
+		WaitroomToken:     req.WaitroomToken,
+		AllowSeatFallback: req.AllowSeatFallback,
+		GuestToken:        req.GuestToken,
+		RemoteAddr:        r.RemoteAddr,
+		UserAgent:         r.UserAgent(),
+		IdempotencyKey:    idempotencyKey,
+		StandingZone:      req.StandingZone,
+		CallbackURL:       req.CallbackURL,
+	})
 	if err != nil {
+		if writePurchaseError(w, err) {
+			return
+		}
 		c.logger.Error(ctx, "Failed to purchase ticket", "error", err)
 		http.Error(w, "Failed to purchase ticket: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+
+	// A reservation comes back as a handle carrying its confirm token, so checkout needs no extra round trip
+	if ticket.IsReserved() {
+		setReservationDeadlineHeaders(w, ticket.ExpiresAt)
+
+		handle, err := c.ticketingService.NewReservationHandle(ctx, ticket)
+		if err == nil {
+			if req.SeatID != nil && ticket.SeatID != nil && *ticket.SeatID != *req.SeatID {
+				handle.RequestedSeatID = req.SeatID
+				handle.SeatFallback = true
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(handle)
+			return
+		}
+		// The reservation stands; the client can still ask for a token via /tickets/{id}/confirmation-link
+		c.logger.Warn(ctx, "Failed to issue reservation handle", "ticket_id", ticket.ID, "error", err)
+	}
+
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(ticket)
+	json.NewEncoder(w).Encode(newTicketResponse(ticket))
+}
+
+// purchaseTicketSeats answers a purchase request listing seat_ids, reserving every seat or none
+func (c *TicketingController) purchaseTicketSeats(w http.ResponseWriter, r *http.Request, req PurchaseTicketRequest, idempotencyKey string) {
+	ctx := r.Context()
+
+	switch {
+	case req.SeatID != nil:
+		http.Error(w, "seat_id cannot be combined with seat_ids", http.StatusBadRequest)
+		return
+	case req.Accessible || req.AllowSeatFallback || req.StandingZone != "" || req.CallbackURL != "":
+		http.Error(w, "accessible, allow_seat_fallback, standing_zone and callback_url apply to single-seat purchases only", http.StatusBadRequest)
+		return
+	case idempotencyKey != "":
+		http.Error(w, "Idempotency-Key is not supported for multi-seat purchases", http.StatusBadRequest)
+		return
+	}
+
+	tickets, err := c.ticketingService.PurchaseSeats(ctx, req.EventID, req.UserID, req.SeatIDs, req.SessionID, service.PurchaseOptions{
+		WaitroomToken: req.WaitroomToken,
+		GuestToken:    req.GuestToken,
+		RemoteAddr:    r.RemoteAddr,
+		UserAgent:     r.UserAgent(),
+	})
+	if err != nil {
+		if writePurchaseError(w, err) {
+			return
+		}
+		c.logger.Error(ctx, "Failed to purchase seats", "error", err)
+		http.Error(w, "Failed to purchase seats: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	c.writeSeatReservations(ctx, w, tickets)
+}
+
+// setReservationDeadlineHeaders states when a reservation lapses unless confirmed, for clients and middleboxes
+// that don't read the body. The TTL is rounded down so acting on it never misses the deadline.
+func setReservationDeadlineHeaders(w http.ResponseWriter, expiresAt *time.Time) {
+	if expiresAt == nil {
+		return
+	}
+
+	ttl := max(int64(time.Until(*expiresAt)/time.Second), 0)
+	w.Header().Set("X-Reservation-Expires-At", expiresAt.UTC().Format(time.RFC3339))
+	w.Header().Set("X-Reservation-TTL-Seconds", strconv.FormatInt(ttl, 10))
+}
+
+// writePurchaseError answers a failed purchase whose error maps to a specific status; it reports false for
+// errors the caller should answer as internal failures
+func writePurchaseError(w http.ResponseWriter, err error) bool {
+	var attemptErr *service.PurchaseAttemptError
+	if errors.As(err, &attemptErr) {
+		w.Header().Set("X-Purchase-Attempts-Remaining", strconv.Itoa(attemptErr.AttemptsRemaining))
+	}
+	if errors.Is(err, service.ErrPurchaseBudgetExhausted) {
+		w.Header().Set("X-Purchase-Attempts-Remaining", "0")
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return true
+	}
+	if errors.Is(err, service.ErrPurchaseSaturated) {
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return true
+	}
+	if errors.Is(err, service.ErrInvalidWaitroomToken) || errors.Is(err, service.ErrInvalidGuestToken) {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return true
+	}
+	if errors.Is(err, service.ErrSeatHoldExpired) || errors.Is(err, service.ErrNoFallbackSeat) || errors.Is(err, service.ErrNoContiguousSeats) ||
+		errors.Is(err, service.ErrIdempotentPurchaseInProgress) || errors.Is(err, service.ErrStandingZoneSoldOut) ||
+		errors.Is(err, service.ErrCarriedReservationHeld) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return true
+	}
+	if errors.Is(err, service.ErrAccessibleSeatRestricted) || errors.Is(err, service.ErrGuestCheckoutDisabled) || errors.Is(err, service.ErrPurchaseLimitExceeded) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return true
+	}
+	if errors.Is(err, service.ErrPurchaseNotStarted) {
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, err.Error(), http.StatusTooEarly)
+		return true
+	}
+	if errors.Is(err, service.ErrSeatLimitExceeded) || errors.Is(err, service.ErrIdempotencyKeyReused) ||
+		errors.Is(err, service.ErrStandingZoneRequired) || errors.Is(err, service.ErrUnknownStandingZone) ||
+		errors.Is(err, service.ErrCallbackURLNotAllowed) {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return true
+	}
+	return false
 }
 
 // ConfirmTicket handles POST /tickets/{id}/confirm
@@ -99,6 +255,60 @@ func (c *TicketingController) ConfirmTicket(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode(response)
 }
 
+// IssueConfirmationLink handles POST /tickets/{id}/confirmation-link
+func (c *TicketingController) IssueConfirmationLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	ticketID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid ticket ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid ticket ID", http.StatusBadRequest)
+		return
+	}
+
+	link, err := c.ticketingService.IssueConfirmationLink(ctx, ticketID)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to issue confirmation link", "ticket_id", ticketID, "error", err)
+		http.Error(w, "Failed to issue confirmation link: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(link)
+}
+
+// ConfirmTicketByToken handles GET /tickets/confirm?token={token}
+func (c *TicketingController) ConfirmTicketByToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		http.Error(w, "Token is required", http.StatusBadRequest)
+		return
+	}
+
+	ticketID, err := c.ticketingService.ConfirmTicketByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, service.ErrConfirmationTokenInvalid) {
+			http.Error(w, err.Error(), http.StatusGone)
+			return
+		}
+		c.logger.Error(ctx, "Failed to confirm ticket by token", "ticket_id", ticketID, "error", err)
+		http.Error(w, "Failed to confirm ticket: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message":   "Ticket confirmed successfully",
+		"ticket_id": ticketID,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
 // CancelTicket handles POST /tickets/{id}/cancel
 func (c *TicketingController) CancelTicket(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -112,6 +322,10 @@ func (c *TicketingController) CancelTicket(w http.ResponseWriter, r *http.Reques
 	}
 
 	if err := c.ticketingService.CancelTicket(ctx, ticketID); err != nil {
+		if errors.Is(err, repository.ErrTicketAlreadyCancelled) || errors.Is(err, repository.ErrTicketNotReserved) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
 		c.logger.Error(ctx, "Failed to cancel ticket", "ticket_id", ticketID, "error", err)
 		http.Error(w, "Failed to cancel ticket: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -125,6 +339,266 @@ func (c *TicketingController) CancelTicket(w http.ResponseWriter, r *http.Reques
 	json.NewEncoder(w).Encode(response)
 }
 
+// TransferTicketRequest represents the request body for handing a ticket to another user
+type TransferTicketRequest struct {
+	FromUserID uuid.UUID `json:"from_user_id"`
+	ToUserID   uuid.UUID `json:"to_user_id"`
+}
+
+// TransferTicket handles POST /tickets/{id}/transfer
+func (c *TicketingController) TransferTicket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	ticketID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid ticket ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid ticket ID", http.StatusBadRequest)
+		return
+	}
+
+	var req TransferTicketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.logger.Error(ctx, "Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.FromUserID == uuid.Nil || req.ToUserID == uuid.Nil {
+		http.Error(w, "From and to user IDs are required", http.StatusBadRequest)
+		return
+	}
+
+	if req.FromUserID == req.ToUserID {
+		http.Error(w, "A ticket can't be transferred to its own holder", http.StatusBadRequest)
+		return
+	}
+
+	ticket, err := c.ticketingService.TransferTicket(ctx, ticketID, req.FromUserID, req.ToUserID)
+	if err != nil {
+		if errors.Is(err, service.ErrTicketNotTransferable) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		c.logger.Error(ctx, "Failed to transfer ticket", "ticket_id", ticketID, "error", err)
+		http.Error(w, "Failed to transfer ticket: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newTicketResponse(ticket))
+}
+
+// AbandonReservationRequest is the beacon body sent when a user leaves checkout
+type AbandonReservationRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// AbandonReservation handles POST /tickets/{id}/abandon. It is meant for navigator.sendBeacon, so the body is
+// read as JSON whatever its content type, and repeated or late calls succeed without doing anything.
+func (c *TicketingController) AbandonReservation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	ticketID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid ticket ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid ticket ID", http.StatusBadRequest)
+		return
+	}
+
+	var req AbandonReservationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.logger.Error(ctx, "Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.UserID == uuid.Nil {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := c.ticketingService.AbandonReservation(ctx, ticketID, req.UserID); err != nil {
+		if errors.Is(err, repository.ErrTicketOwnerMismatch) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		c.logger.Error(ctx, "Failed to abandon reservation", "ticket_id", ticketID, "error", err)
+		http.Error(w, "Failed to abandon reservation: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CheckInTicket handles POST /tickets/{id}/check-in
+func (c *TicketingController) CheckInTicket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	ticketID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid ticket ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid ticket ID", http.StatusBadRequest)
+		return
+	}
+
+	ticket, err := c.ticketingService.CheckInTicket(ctx, ticketID)
+	if err != nil {
+		if errors.Is(err, service.ErrTicketAlreadyCheckedIn) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		c.logger.Error(ctx, "Failed to check in ticket", "ticket_id", ticketID, "error", err)
+		http.Error(w, "Failed to check in ticket: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newTicketResponse(ticket))
+}
+
+// GetAccessList handles GET /events/{id}/access-list?updated_since={cursor}
+func (c *TicketingController) GetAccessList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid event ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	var since *time.Time
+	if value := r.URL.Query().Get("updated_since"); value != "" {
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			http.Error(w, "updated_since must be an RFC 3339 timestamp", http.StatusBadRequest)
+			return
+		}
+		since = &parsed
+	}
+
+	list, err := c.ticketingService.GetAccessList(ctx, eventID, since)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to get access list", "event_id", eventID, "error", err)
+		http.Error(w, "Failed to get access list", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// seatSelectionIdleTimeout is how long a seat selection connection may stay silent before it is treated as dropped
+const seatSelectionIdleTimeout = 2 * time.Minute
+
+// SeatSelectionMessage is a client command on the seat selection WebSocket
+type SeatSelectionMessage struct {
+	Action string    `json:"action"` // "hold" or "release"
+	SeatID uuid.UUID `json:"seat_id"`
+}
+
+// SeatSelectionReply is a server reply on the seat selection WebSocket
+type SeatSelectionReply struct {
+	Type   string       `json:"type"` // "held", "released" or "error"
+	SeatID uuid.UUID    `json:"seat_id,omitempty"`
+	Seat   *domain.Seat `json:"seat,omitempty"`
+	Held   []uuid.UUID  `json:"held"`
+	Error  string       `json:"error,omitempty"`
+}
+
+// SeatSelection handles GET /events/{id}/seat-selection?user_id={user_id} as a WebSocket.
+// Seats held over the connection are released as soon as it closes or goes silent.
+func (c *TicketingController) SeatSelection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := uuid.Parse(r.URL.Query().Get("user_id"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	selection, err := c.ticketingService.OpenSeatSelection(ctx, eventID, userID)
+	if err != nil {
+		c.logger.Warn(ctx, "Failed to open seat selection", "event_id", eventID, "user_id", userID, "error", err)
+		http.Error(w, "Failed to open seat selection: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	conn, err := upgradeWebSocket(w, r)
+	if err != nil {
+		selection.Close(ctx)
+		c.logger.Warn(ctx, "Failed to upgrade seat selection connection", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer conn.Close()
+
+	// The request context is cancelled once the handler returns, so release with a fresh one
+	defer func() {
+		releaseCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := selection.Close(releaseCtx); err != nil {
+			c.logger.Error(releaseCtx, "Failed to release seats after disconnect", "event_id", eventID, "user_id", userID, "error", err)
+		}
+	}()
+
+	for {
+		data, err := conn.ReadMessage(seatSelectionIdleTimeout)
+		if err != nil {
+			if !errors.Is(err, errWebSocketClosed) {
+				c.logger.Info(ctx, "Seat selection connection dropped", "event_id", eventID, "user_id", userID, "error", err)
+			}
+			return
+		}
+
+		reply := c.handleSeatSelectionMessage(ctx, selection, data)
+		payload, err := json.Marshal(reply)
+		if err != nil {
+			c.logger.Error(ctx, "Failed to encode seat selection reply", "error", err)
+			return
+		}
+
+		if err := conn.WriteMessage(payload); err != nil {
+			return
+		}
+	}
+}
+
+// handleSeatSelectionMessage applies one client command to the selection
+func (c *TicketingController) handleSeatSelectionMessage(ctx context.Context, selection *service.SeatSelection, data []byte) SeatSelectionReply {
+	var msg SeatSelectionMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return SeatSelectionReply{Type: "error", Error: "invalid message", Held: selection.Held()}
+	}
+
+	switch msg.Action {
+	case "hold":
+		seat, err := selection.Hold(ctx, msg.SeatID)
+		if err != nil {
+			return SeatSelectionReply{Type: "error", SeatID: msg.SeatID, Error: err.Error(), Held: selection.Held()}
+		}
+		return SeatSelectionReply{Type: "held", SeatID: msg.SeatID, Seat: seat, Held: selection.Held()}
+	case "release":
+		if err := selection.Release(ctx, msg.SeatID); err != nil {
+			return SeatSelectionReply{Type: "error", SeatID: msg.SeatID, Error: err.Error(), Held: selection.Held()}
+		}
+		return SeatSelectionReply{Type: "released", SeatID: msg.SeatID, Held: selection.Held()}
+	default:
+		return SeatSelectionReply{Type: "error", Error: "unknown action", Held: selection.Held()}
+	}
+}
+
 // GetTicket handles GET /tickets/{id}
 func (c *TicketingController) GetTicket(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -145,7 +619,98 @@ func (c *TicketingController) GetTicket(w http.ResponseWriter, r *http.Request)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(ticket)
+	json.NewEncoder(w).Encode(newTicketResponse(ticket))
+}
+
+// GetSessionReservation handles GET /queue/status/{session_id}/reservation
+func (c *TicketingController) GetSessionReservation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	sessionID := vars["session_id"]
+	if sessionID == "" {
+		http.Error(w, "Session ID is required", http.StatusBadRequest)
+		return
+	}
+
+	reservation, err := c.ticketingService.GetSessionReservation(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, service.ErrNoActiveReservation) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		c.logger.Error(ctx, "Failed to get session reservation", "session_id", sessionID, "error", err)
+		http.Error(w, "Failed to get reservation: "+err.Error(), http.StatusNotFound)
+		return
+	}
+
+	setReservationDeadlineHeaders(w, &reservation.ExpiresAt)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reservation)
+}
+
+// GetTickets handles GET /tickets?ids={id},{id},...
+func (c *TicketingController) GetTickets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	raw := r.URL.Query().Get("ids")
+	if raw == "" {
+		http.Error(w, "ids query parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	parts := strings.Split(raw, ",")
+	if len(parts) > service.MaxTicketBatchSize {
+		http.Error(w, fmt.Sprintf("At most %d IDs may be requested at once", service.MaxTicketBatchSize), http.StatusBadRequest)
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(parts))
+	for _, part := range parts {
+		id, err := uuid.Parse(strings.TrimSpace(part))
+		if err != nil {
+			http.Error(w, "Invalid ticket ID: "+part, http.StatusBadRequest)
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	lookups, err := c.ticketingService.GetTickets(ctx, ids)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to batch get tickets", "error", err)
+		http.Error(w, "Failed to get tickets", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"tickets": newTicketLookupResponses(lookups),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetReceipt handles GET /tickets/{id}/receipt
+func (c *TicketingController) GetReceipt(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	ticketID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid ticket ID", "id", vars["id"], "error", err)
+		http.Error(w, "Invalid ticket ID", http.StatusBadRequest)
+		return
+	}
+
+	receipt, err := c.ticketingService.GetReceipt(ctx, ticketID)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to get receipt", "ticket_id", ticketID, "error", err)
+		http.Error(w, "Ticket not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(receipt)
 }
 
 // GetUserTickets handles GET /tickets/user/{user_id}
@@ -168,14 +733,143 @@ func (c *TicketingController) GetUserTickets(w http.ResponseWriter, r *http.Requ
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tickets)
+	json.NewEncoder(w).Encode(newTicketResponses(tickets))
+}
+
+// GetUserEventTickets handles GET /tickets/user/{user_id}/event/{event_id}
+func (c *TicketingController) GetUserEventTickets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	userID, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid user ID", "id", vars["user_id"], "error", err)
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	eventID, err := uuid.Parse(vars["event_id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid event ID", "id", vars["event_id"], "error", err)
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	tickets, err := c.ticketingService.GetUserEventTickets(ctx, userID, eventID)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to get user event tickets", "user_id", userID, "event_id", eventID, "error", err)
+		http.Error(w, "Failed to get user event tickets", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newTicketResponses(tickets))
+}
+
+// GetEventTickets handles GET /tickets/event/{event_id}?offset={offset}&limit={limit}&status={status}
+func (c *TicketingController) GetEventTickets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	eventID, err := uuid.Parse(vars["event_id"])
+	if err != nil {
+		c.logger.Error(ctx, "Invalid event ID", "id", vars["event_id"], "error", err)
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	offset, limit, err := parsePagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	if status != "" && !domain.TicketStatus(status).IsValid() {
+		http.Error(w, "status must be reserved, confirmed or cancelled", http.StatusBadRequest)
+		return
+	}
+
+	var tickets []*domain.Ticket
+	var total int
+	if status != "" {
+		tickets, total, err = c.ticketingService.GetEventTicketsByStatus(ctx, eventID, status, limit, offset)
+	} else {
+		tickets, total, err = c.ticketingService.GetEventTickets(ctx, eventID, limit, offset)
+	}
+	if err != nil {
+		c.logger.Error(ctx, "Failed to get event tickets", "event_id", eventID, "error", err)
+		http.Error(w, "Failed to get event tickets", http.StatusInternalServerError)
+		return
+	}
+
+	writePage(w, NewPage(tickets, total, offset, limit))
+}
+
+// ResolveGuestRequest represents the request body for resolving a guest token
+type ResolveGuestRequest struct {
+	GuestToken string `json:"guest_token"`
+}
+
+// ResolveGuest handles POST /guests/resolve, returning the pseudo user ID a guest joins queues and buys under
+func (c *TicketingController) ResolveGuest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req ResolveGuestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.logger.Error(ctx, "Failed to decode request", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.GuestToken == "" {
+		http.Error(w, "Guest token is required", http.StatusBadRequest)
+		return
+	}
+
+	claims, err := c.ticketingService.ResolveGuest(req.GuestToken)
+	if err != nil {
+		if writePurchaseError(w, err) {
+			return
+		}
+		c.logger.Error(ctx, "Failed to resolve guest", "error", err)
+		http.Error(w, "Failed to resolve guest", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"user_id":    claims.UserID(),
+		"email":      claims.Email,
+		"expires_at": time.Unix(claims.ExpiresAt, 0).UTC(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
 }
 
 // RegisterRoutes registers all ticketing routes
 func (c *TicketingController) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/tickets", c.GetTickets).Methods("GET")
 	router.HandleFunc("/tickets/purchase", c.PurchaseTicket).Methods("POST")
+	router.HandleFunc("/tickets/purchase-seats", c.PurchaseSeats).Methods("POST")
+	router.HandleFunc("/tickets/confirm", c.ConfirmTicketByToken).Methods("GET")
 	router.HandleFunc("/tickets/{id}/confirm", c.ConfirmTicket).Methods("POST")
+	router.HandleFunc("/tickets/{id}/confirmation-link", c.IssueConfirmationLink).Methods("POST")
 	router.HandleFunc("/tickets/{id}/cancel", c.CancelTicket).Methods("POST")
+	router.HandleFunc("/tickets/{id}/abandon", c.AbandonReservation).Methods("POST")
+	router.HandleFunc("/tickets/{id}/transfer", c.TransferTicket).Methods("POST")
+	router.HandleFunc("/tickets/{id}/check-in", c.CheckInTicket).Methods("POST")
+	router.HandleFunc("/tickets/{id}/receipt", c.GetReceipt).Methods("GET")
+	router.HandleFunc("/tickets/{id}/resale", c.ListForResale).Methods("POST")
 	router.HandleFunc("/tickets/{id}", c.GetTicket).Methods("GET")
 	router.HandleFunc("/tickets/user/{user_id}", c.GetUserTickets).Methods("GET")
+	router.HandleFunc("/tickets/user/{user_id}/event/{event_id}", c.GetUserEventTickets).Methods("GET")
+	router.HandleFunc("/tickets/event/{event_id}", c.GetEventTickets).Methods("GET")
+	router.HandleFunc("/guests/resolve", c.ResolveGuest).Methods("POST")
+	router.HandleFunc("/queue/status/{session_id}/reservation", c.GetSessionReservation).Methods("GET")
+	router.HandleFunc("/events/{id}/access-list", c.GetAccessList).Methods("GET")
+	router.HandleFunc("/events/{id}/seat-selection", c.SeatSelection).Methods("GET")
+	router.HandleFunc("/events/{id}/resale", c.GetResaleListings).Methods("GET")
+	router.HandleFunc("/resale/{id}/buy", c.BuyResaleListing).Methods("POST")
+	router.HandleFunc("/resale/{id}/cancel", c.CancelResaleListing).Methods("POST")
 }
@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// expectReservationDeadline fails the test unless the response's deadline headers name the earliest expiry of the
+// user's reservations
+func (ts *testServer) expectReservationDeadline(t *testing.T, rec *httptest.ResponseRecorder, userID uuid.UUID) {
+	t.Helper()
+
+	tickets, err := ts.tickets.GetByUserID(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("get user tickets: %v", err)
+	}
+	var earliest time.Time
+	for _, ticket := range tickets {
+		if ticket.ExpiresAt == nil {
+			t.Fatalf("reservation %s has no expiry", ticket.ID)
+		}
+		if earliest.IsZero() || ticket.ExpiresAt.Before(earliest) {
+			earliest = *ticket.ExpiresAt
+		}
+	}
+	if len(tickets) == 0 {
+		t.Fatal("no reservation was made")
+	}
+
+	if got, want := rec.Header().Get("X-Reservation-Expires-At"), earliest.UTC().Format(time.RFC3339); got != want {
+		t.Errorf("X-Reservation-Expires-At = %q, want %q", got, want)
+	}
+	ttl, err := strconv.ParseInt(rec.Header().Get("X-Reservation-TTL-Seconds"), 10, 64)
+	if err != nil {
+		t.Fatalf("X-Reservation-TTL-Seconds: %v", err)
+	}
+	// The TTL is rounded down from whatever is left when the response was written
+	if remaining := int64(time.Until(earliest) / time.Second); ttl < remaining-1 || ttl > remaining {
+		t.Errorf("X-Reservation-TTL-Seconds = %d, want about %d", ttl, remaining)
+	}
+}
+
+func TestPurchaseReservationDeadlineHeaders(t *testing.T) {
+	ts := newTestServer(t)
+	event := ts.createEvent(t, 10)
+	userID := uuid.New()
+	sessionID := ts.activateSession(t, event.ID, userID)
+	seat := ts.createSeat(t, event, "1")
+
+	rec := ts.do(http.MethodPost, "/tickets/purchase", fmt.Sprintf(`{"event_id":%q,"user_id":%q,"seat_id":%q,"session_id":%q}`,
+		event.ID, userID, seat.ID, sessionID))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body)
+	}
+	ts.expectReservationDeadline(t, rec, userID)
+}
+
+func TestPurchaseSeatsReservationDeadlineHeaders(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "seat_ids on purchase", path: "/tickets/purchase"},
+		{name: "group purchase", path: "/tickets/purchase-seats"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := newTestServer(t)
+			event := ts.createEvent(t, 10)
+			userID := uuid.New()
+			sessionID := ts.activateSession(t, event.ID, userID)
+			first, second := ts.createSeat(t, event, "1"), ts.createSeat(t, event, "2")
+
+			rec := ts.do(http.MethodPost, tc.path, fmt.Sprintf(`{"event_id":%q,"user_id":%q,"session_id":%q,"seat_ids":[%q,%q]}`,
+				event.ID, userID, sessionID, first.ID, second.ID))
+			if rec.Code != http.StatusCreated {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body)
+			}
+			ts.expectReservationDeadline(t, rec, userID)
+		})
+	}
+}
+
+func TestPurchaseFailureHasNoReservationDeadline(t *testing.T) {
+	ts := newTestServer(t)
+	event := ts.createEvent(t, 10)
+
+	// The session was never activated, so nothing is reserved
+	rec := ts.do(http.MethodPost, "/tickets/purchase", fmt.Sprintf(`{"event_id":%q,"user_id":%q,"seat_id":%q,"session_id":%q}`,
+		event.ID, uuid.New(), ts.createSeat(t, event, "1").ID, uuid.NewString()))
+	if rec.Code == http.StatusCreated {
+		t.Fatalf("purchased without an active session: %s", rec.Body)
+	}
+	for _, header := range []string{"X-Reservation-Expires-At", "X-Reservation-TTL-Seconds"} {
+		if value := rec.Header().Get(header); value != "" {
+			t.Errorf("failed purchase sets %s to %q", header, value)
+		}
+	}
+}
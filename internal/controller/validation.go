@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ValidationErrors collects every invalid field of a request, keyed by its JSON field name
+type ValidationErrors map[string]string
+
+// Add records a message for a field; the first message for a field wins
+func (v ValidationErrors) Add(field, message string) {
+	if _, exists := v[field]; !exists {
+		v[field] = message
+	}
+}
+
+// HasErrors checks if any field failed validation
+func (v ValidationErrors) HasErrors() bool {
+	return len(v) > 0
+}
+
+// writeValidationErrors responds with 422 and the collected field errors
+func writeValidationErrors(w http.ResponseWriter, fields ValidationErrors) {
+	response := map[string]interface{}{
+		"error":  "validation failed",
+		"fields": fields,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/snowmerak/ticketing/pkg/logger"
+)
+
+func TestValidationErrorsAdd(t *testing.T) {
+	fields := ValidationErrors{}
+	if fields.HasErrors() {
+		t.Fatal("empty ValidationErrors has errors")
+	}
+
+	fields.Add("name", "is required")
+	fields.Add("name", "must be shorter")
+	fields.Add("total_tickets", "must be positive")
+
+	if !fields.HasErrors() {
+		t.Fatal("HasErrors() = false after adding errors")
+	}
+	want := ValidationErrors{"name": "is required", "total_tickets": "must be positive"}
+	if !maps.Equal(fields, want) {
+		t.Errorf("fields = %v, want %v with the first message of each field", fields, want)
+	}
+}
+
+func TestWriteValidationErrors(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeValidationErrors(rec, ValidationErrors{"currency": "must be an ISO 4217 currency code"})
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("content type = %q, want application/json", got)
+	}
+
+	var body struct {
+		Error  string            `json:"error"`
+		Fields map[string]string `json:"fields"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.Error != "validation failed" || body.Fields["currency"] != "must be an ISO 4217 currency code" {
+		t.Errorf("body = %+v", body)
+	}
+}
+
+func TestCreateEventValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want ValidationErrors
+	}{
+		{
+			name: "empty request",
+			body: `{}`,
+			want: ValidationErrors{
+				"name":          "is required",
+				"start_time":    "is required",
+				"end_time":      "is required",
+				"total_tickets": "must be positive",
+			},
+		},
+		{
+			name: "ends before it starts",
+			body: `{"name":"Concert","start_time":"2026-06-01T22:00:00Z","end_time":"2026-06-01T20:00:00Z","total_tickets":10}`,
+			want: ValidationErrors{"start_time": "must be before end_time"},
+		},
+		{
+			name: "every optional field invalid",
+			body: `{"name":"Concert","start_time":"2026-06-01T20:00:00Z","end_time":"2026-06-01T22:00:00Z","total_tickets":10,
+				"max_concurrent_purchases":-1,"standing_price":-1,"max_tickets_per_user":-1,"queue_high_water_mark":-1,
+				"active_high_water_mark":-1,"currency":"dollars","image_url":"ftp://example.com/a.png",
+				"thumbnail_url":"not a url","seat_ranking":"random","lock_granularity":"row",
+				"refund_policy":{"tiers":[{"min_notice_hours":-1,"refund_basis_points":5000}]}}`,
+			want: ValidationErrors{
+				"max_concurrent_purchases": "must not be negative",
+				"standing_price":           "must not be negative",
+				"max_tickets_per_user":     "must not be negative",
+				"queue_high_water_mark":    "must not be negative",
+				"active_high_water_mark":   "must not be negative",
+				"currency":                 "must be an ISO 4217 currency code",
+				"image_url":                "must be an http or https URL",
+				"thumbnail_url":            "must be an http or https URL",
+				"seat_ranking":             "must be front_to_back, center_out or price_ascending",
+				"lock_granularity":         "must be seat, section or event",
+				"refund_policy":            "tiers must have non-negative, distinct min_notice_hours and refund_basis_points between 0 and 10000",
+			},
+		},
+	}
+
+	// Invalid requests are answered before the service is reached
+	c := NewEventController(nil, logger.NewLoggerWithLevel(zerolog.Disabled))
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c.CreateEvent(rec, httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(tc.body)))
+
+			if rec.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusUnprocessableEntity, rec.Body)
+			}
+			var body struct {
+				Fields ValidationErrors `json:"fields"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if !maps.Equal(body.Fields, tc.want) {
+				t.Errorf("fields = %v, want %v", body.Fields, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateCompanionSeats(t *testing.T) {
+	index := func(i int) *int { return &i }
+
+	tests := []struct {
+		name  string
+		seats []SeatRequest
+		want  ValidationErrors
+	}{
+		{
+			name:  "no companions",
+			seats: []SeatRequest{{Number: "1"}, {Number: "2"}},
+			want:  ValidationErrors{},
+		},
+		{
+			name:  "accessible seat with companion",
+			seats: []SeatRequest{{IsAccessible: true, CompanionIndex: index(1)}, {}},
+			want:  ValidationErrors{},
+		},
+		{
+			name:  "companion on a regular seat",
+			seats: []SeatRequest{{CompanionIndex: index(1)}, {}},
+			want:  ValidationErrors{"seats[0].companion_index": "is only allowed on accessible seats"},
+		},
+		{
+			name:  "companion out of range",
+			seats: []SeatRequest{{IsAccessible: true, CompanionIndex: index(2)}, {}},
+			want:  ValidationErrors{"seats[0].companion_index": "must reference another seat in the request"},
+		},
+		{
+			name:  "negative companion",
+			seats: []SeatRequest{{}, {IsAccessible: true, CompanionIndex: index(-1)}},
+			want:  ValidationErrors{"seats[1].companion_index": "must reference another seat in the request"},
+		},
+		{
+			name:  "own companion",
+			seats: []SeatRequest{{IsAccessible: true, CompanionIndex: index(0)}},
+			want:  ValidationErrors{"seats[0].companion_index": "must reference another seat in the request"},
+		},
+		{
+			name:  "accessible companion",
+			seats: []SeatRequest{{IsAccessible: true, CompanionIndex: index(1)}, {IsAccessible: true}},
+			want:  ValidationErrors{"seats[0].companion_index": "must reference a non-accessible seat"},
+		},
+		{
+			name:  "shared companion",
+			seats: []SeatRequest{{IsAccessible: true, CompanionIndex: index(2)}, {IsAccessible: true, CompanionIndex: index(2)}, {}},
+			want:  ValidationErrors{"seats[1].companion_index": "references a seat that is already a companion"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fields := ValidationErrors{}
+			validateCompanionSeats(tc.seats, fields)
+
+			if !maps.Equal(fields, tc.want) {
+				t.Errorf("fields = %v, want %v", fields, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// testWSClient is the client end of a WebSocket to a test server, speaking unfragmented frames
+type testWSClient struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// dialWebSocket opens a WebSocket to path on server, failing the test unless the handshake succeeds
+func dialWebSocket(t *testing.T, server *httptest.Server, path string) *testWSClient {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	var nonce [16]byte
+	rand.Read(nonce[:])
+	fmt.Fprintf(conn, "GET %s HTTP/1.1\r\nHost: %s\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n"+
+		"Sec-WebSocket-Version: 13\r\nSec-WebSocket-Key: %s\r\n\r\n",
+		path, conn.RemoteAddr(), base64.StdEncoding.EncodeToString(nonce[:]))
+
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatalf("read handshake: %v", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("handshake status = %d, want %d: %s", resp.StatusCode, http.StatusSwitchingProtocols, body)
+	}
+	return &testWSClient{conn: conn, r: r}
+}
+
+// writeFrame sends a masked client frame
+func (c *testWSClient) writeFrame(t *testing.T, opcode byte, payload []byte) {
+	t.Helper()
+
+	var mask [4]byte
+	rand.Read(mask[:])
+	frame := append([]byte{0x80 | opcode, 0x80 | byte(len(payload))}, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	if _, err := c.conn.Write(frame); err != nil {
+		t.Fatalf("write frame: %v", err)
+	}
+}
+
+// send issues a seat selection command and returns the server's reply
+func (c *testWSClient) send(t *testing.T, msg SeatSelectionMessage) SeatSelectionReply {
+	t.Helper()
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("encode message: %v", err)
+	}
+	c.writeFrame(t, wsOpText, payload)
+
+	var header [2]byte
+	if _, err := io.ReadFull(c.r, header[:]); err != nil {
+		t.Fatalf("read reply: %v", err)
+	}
+	length := int(header[1] & 0x7F)
+	if length == 126 {
+		var ext [2]byte
+		if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+			t.Fatalf("read reply length: %v", err)
+		}
+		length = int(ext[0])<<8 | int(ext[1])
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.r, body); err != nil {
+		t.Fatalf("read reply: %v", err)
+	}
+
+	var reply SeatSelectionReply
+	if err := json.Unmarshal(body, &reply); err != nil {
+		t.Fatalf("decode reply %q: %v", body, err)
+	}
+	return reply
+}
+
+// waitSeatStatus fails the test unless the seat reaches status shortly; disconnects are handled asynchronously
+func (ts *testServer) waitSeatStatus(t *testing.T, seatID uuid.UUID, status domain.SeatStatus) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		seat, err := ts.seats.GetByID(context.Background(), seatID)
+		if err != nil {
+			t.Fatalf("get seat: %v", err)
+		}
+		if seat.Status == string(status) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("seat %s is %s, want %s", seatID, seat.Status, status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSeatSelectionReleasesHoldsOnDisconnect(t *testing.T) {
+	tests := []struct {
+		name       string
+		disconnect func(t *testing.T, c *testWSClient)
+	}{
+		{
+			name:       "close frame",
+			disconnect: func(t *testing.T, c *testWSClient) { c.writeFrame(t, wsOpClose, nil) },
+		},
+		{
+			name:       "dropped connection",
+			disconnect: func(t *testing.T, c *testWSClient) { c.conn.Close() },
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := newTestServer(t)
+			server := httptest.NewServer(ts.router)
+			defer server.Close()
+
+			event := ts.createEvent(t, 10)
+			kept, released := ts.createSeat(t, event, "1"), ts.createSeat(t, event, "2")
+			client := dialWebSocket(t, server, fmt.Sprintf("/events/%s/seat-selection?user_id=%s", event.ID, uuid.New()))
+
+			for _, seat := range []*domain.Seat{kept, released} {
+				if reply := client.send(t, SeatSelectionMessage{Action: "hold", SeatID: seat.ID}); reply.Type != "held" {
+					t.Fatalf("hold seat %s: %+v", seat.Number, reply)
+				}
+			}
+			if reply := client.send(t, SeatSelectionMessage{Action: "release", SeatID: released.ID}); reply.Type != "released" || len(reply.Held) != 1 {
+				t.Fatalf("release seat %s: %+v", released.Number, reply)
+			}
+			ts.waitSeatStatus(t, kept.ID, domain.SeatStatusReserved)
+			ts.waitSeatStatus(t, released.ID, domain.SeatStatusAvailable)
+
+			tc.disconnect(t, client)
+			ts.waitSeatStatus(t, kept.ID, domain.SeatStatusAvailable)
+		})
+	}
+}
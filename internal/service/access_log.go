@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/adapter"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// MaxAccessLogListSize is the most access log entries returned by one query
+const MaxAccessLogListSize = 500
+
+// AccessLogService records and queries the request-level access log of sensitive endpoints
+type AccessLogService struct {
+	auditRepo repository.AuditRepository
+	logger    adapter.Logger
+}
+
+// NewAccessLogService creates a new AccessLogService
+func NewAccessLogService(auditRepo repository.AuditRepository, logger adapter.Logger) *AccessLogService {
+	return &AccessLogService{
+		auditRepo: auditRepo,
+		logger:    logger,
+	}
+}
+
+// RecordAccess appends an entry to the access log
+func (s *AccessLogService) RecordAccess(ctx context.Context, entry *domain.AccessLogEntry) error {
+	if err := s.auditRepo.AppendAccess(ctx, entry); err != nil {
+		s.logger.Error(ctx, "Failed to record access", "endpoint", entry.Endpoint, "error", err)
+		return fmt.Errorf("failed to record access: %w", err)
+	}
+
+	return nil
+}
+
+// ListAccess retrieves up to limit access log entries newest first, optionally only for one user
+func (s *AccessLogService) ListAccess(ctx context.Context, userID *uuid.UUID, limit int) ([]*domain.AccessLogEntry, error) {
+	if limit <= 0 || limit > MaxAccessLogListSize {
+		return nil, fmt.Errorf("limit must be between 1 and %d", MaxAccessLogListSize)
+	}
+
+	entries, err := s.auditRepo.ListAccess(ctx, repository.AccessLogFilter{UserID: userID, Limit: limit})
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list access log", "error", err)
+		return nil, fmt.Errorf("failed to list access log: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// createAccessiblePair stores an accessible seat linked to a companion seat in the given status
+func (tt *testTicketing) createAccessiblePair(t *testing.T, event *domain.Event, companionStatus domain.SeatStatus) (*domain.Seat, *domain.Seat) {
+	t.Helper()
+
+	accessible := tt.createSeat(t, event, domain.SeatStatusAvailable)
+	companion := tt.createSeat(t, event, companionStatus)
+	accessible.IsAccessible = true
+	accessible.CompanionSeatID = &companion.ID
+	companion.CompanionSeatID = &accessible.ID
+	for _, seat := range []*domain.Seat{accessible, companion} {
+		if err := tt.seats.Update(context.Background(), seat); err != nil {
+			t.Fatalf("link companion seats: %v", err)
+		}
+	}
+	return accessible, companion
+}
+
+// expectSeatStatus fails the test unless the stored seat has the given status
+func (tt *testTicketing) expectSeatStatus(t *testing.T, seatID uuid.UUID, status domain.SeatStatus) {
+	t.Helper()
+
+	seat, err := tt.seats.GetByID(context.Background(), seatID)
+	if err != nil {
+		t.Fatalf("get seat: %v", err)
+	}
+	if seat.Status != string(status) {
+		t.Fatalf("seat %s status = %s, want %s", seatID, seat.Status, status)
+	}
+}
+
+func TestAccessibleSeatReservesCompanion(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	event := tt.createEvent(t, 10, 10)
+	accessible, companion := tt.createAccessiblePair(t, event, domain.SeatStatusAvailable)
+	userID := uuid.New()
+	sessionID := tt.activateSession(t, event.ID, userID)
+
+	ticket, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &accessible.ID, sessionID, PurchaseOptions{Accessible: true})
+	if err != nil {
+		t.Fatalf("purchase accessible seat: %v", err)
+	}
+	if ticket.CompanionTicketID == nil {
+		t.Fatal("accessible ticket carries no companion ticket")
+	}
+
+	companionTicket, err := tt.tickets.GetByID(ctx, *ticket.CompanionTicketID)
+	if err != nil {
+		t.Fatalf("get companion ticket: %v", err)
+	}
+	if companionTicket.SeatID == nil || *companionTicket.SeatID != companion.ID || companionTicket.UserID != userID {
+		t.Fatalf("companion ticket holds seat %v for user %s, want seat %s for user %s", companionTicket.SeatID, companionTicket.UserID, companion.ID, userID)
+	}
+	tt.expectSeatStatus(t, accessible.ID, domain.SeatStatusReserved)
+	tt.expectSeatStatus(t, companion.ID, domain.SeatStatusReserved)
+	if got := tt.availableTickets(t, event.ID); got != 8 {
+		t.Fatalf("available tickets = %d, want 8 after booking the pair", got)
+	}
+
+	// The pair is released together too
+	if err := tt.service.CancelTicket(ctx, ticket.ID); err != nil {
+		t.Fatalf("cancel accessible ticket: %v", err)
+	}
+	tt.expectSeatStatus(t, accessible.ID, domain.SeatStatusAvailable)
+	tt.expectSeatStatus(t, companion.ID, domain.SeatStatusAvailable)
+	if got := tt.availableTickets(t, event.ID); got != 10 {
+		t.Fatalf("available tickets = %d after cancelling the pair, want 10", got)
+	}
+}
+
+func TestAccessibleSeatRestrictions(t *testing.T) {
+	tests := []struct {
+		name string
+		// companionStatus is the status the companion seat starts in
+		companionStatus domain.SeatStatus
+		// companion buys the companion seat instead of the accessible one
+		companion  bool
+		accessible bool
+		wantErr    error
+	}{
+		{name: "general purchase of an accessible seat", companionStatus: domain.SeatStatusAvailable, wantErr: ErrAccessibleSeatRestricted},
+		{name: "companion seat on its own", companionStatus: domain.SeatStatusAvailable, companion: true, accessible: true, wantErr: ErrAccessibleSeatRestricted},
+		{name: "companion seat already taken", companionStatus: domain.SeatStatusSold, accessible: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tt := newTestTicketing(t)
+			event := tt.createEvent(t, 10, 10)
+			accessible, companion := tt.createAccessiblePair(t, event, tc.companionStatus)
+			userID := uuid.New()
+			sessionID := tt.activateSession(t, event.ID, userID)
+
+			seatID := accessible.ID
+			if tc.companion {
+				seatID = companion.ID
+			}
+			_, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &seatID, sessionID, PurchaseOptions{Accessible: tc.accessible})
+			if err == nil {
+				t.Fatal("purchase succeeded")
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+
+			tt.expectSeatStatus(t, accessible.ID, domain.SeatStatusAvailable)
+			tt.expectSeatStatus(t, companion.ID, tc.companionStatus)
+			if got := tt.availableTickets(t, event.ID); got != 10 {
+				t.Fatalf("available tickets = %d after a refused purchase, want 10", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrActivationTooSoon is returned when a queue is processed again before its MinActivationInterval has passed
+var ErrActivationTooSoon = errors.New("queue was activated too recently")
+
+// ActivationPacedError reports a paced activation along with how long the caller should wait before retrying
+type ActivationPacedError struct {
+	RetryAfter time.Duration
+}
+
+// Error describes the pacing and the remaining wait
+func (e *ActivationPacedError) Error() string {
+	return fmt.Sprintf("%v, retry in %s", ErrActivationTooSoon, e.RetryAfter)
+}
+
+// Unwrap returns ErrActivationTooSoon so callers can match it with errors.Is
+func (e *ActivationPacedError) Unwrap() error {
+	return ErrActivationTooSoon
+}
+
+// paceActivation rejects an activation that comes within MinActivationInterval of the event's last one.
+// It must be called under the queue_process lock. A last activation that can't be read lets the activation through.
+func (s *QueueService) paceActivation(ctx context.Context, eventID uuid.UUID, now time.Time) error {
+	if s.config.MinActivationInterval <= 0 {
+		return nil
+	}
+
+	last, err := s.queueRepo.GetLastActivation(ctx, eventID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get last activation for pacing", "event_id", eventID, "error", err)
+		return nil
+	}
+
+	if wait := last.Add(s.config.MinActivationInterval).Sub(now); !last.IsZero() && wait > 0 {
+		s.logger.Info(ctx, "Pacing queue activation", "event_id", eventID, "retry_after", wait)
+		return &ActivationPacedError{RetryAfter: wait}
+	}
+
+	return nil
+}
+
+// recordActivation stamps the event's last activation so the next one is paced from it
+func (s *QueueService) recordActivation(ctx context.Context, eventID uuid.UUID, at time.Time) {
+	if s.config.MinActivationInterval <= 0 {
+		return
+	}
+
+	if err := s.queueRepo.SetLastActivation(ctx, eventID, at); err != nil {
+		s.logger.Warn(ctx, "Failed to record last activation", "event_id", eventID, "error", err)
+	}
+}
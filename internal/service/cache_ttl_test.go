@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/snowmerak/ticketing/lib/adapter"
+	"github.com/snowmerak/ticketing/pkg/logger"
+)
+
+// recordingCache is a Cache that never hits and records the TTL of every value put in it
+type recordingCache struct {
+	testCache
+
+	mu   sync.Mutex
+	ttls map[string]time.Duration
+}
+
+func newRecordingCache() *recordingCache {
+	return &recordingCache{ttls: make(map[string]time.Duration)}
+}
+
+func (c *recordingCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ttls[key] = expiration
+	return nil
+}
+
+// ttl returns the TTL the value under key was last set with
+func (c *recordingCache) ttl(t *testing.T, key string) time.Duration {
+	t.Helper()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ttl, ok := c.ttls[key]
+	if !ok {
+		t.Fatalf("nothing was cached under %s", key)
+	}
+	return ttl
+}
+
+func TestCustomCacheTTLsAreApplied(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	cache := newRecordingCache()
+	log := logger.NewLoggerWithLevel(zerolog.Disabled)
+
+	// Unset TTLs keep their defaults
+	config := adapter.CacheConfig{
+		EventTTL:          7 * time.Minute,
+		ActiveEventsTTL:   11 * time.Second,
+		AvailableSeatsTTL: 3 * time.Second,
+		QueueLengthTTL:    2 * time.Second,
+	}
+	events := NewEventService(tt.events, tt.seats, cache, newTestLock(), log)
+	events.SetCacheConfig(config)
+	queue := NewQueueService(tt.queue, tt.events, cache, newTestLock(), log)
+	queue.SetCacheConfig(config)
+
+	event := newEventRequest("USD")
+	if err := events.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+	if _, err := events.GetActiveEvents(ctx); err != nil {
+		t.Fatalf("get active events: %v", err)
+	}
+	if _, err := events.GetAllEvents(ctx); err != nil {
+		t.Fatalf("get all events: %v", err)
+	}
+	if _, err := events.GetAvailableSeats(ctx, event.ID); err != nil {
+		t.Fatalf("get available seats: %v", err)
+	}
+	if _, err := queue.GetQueueLength(ctx, event.ID); err != nil {
+		t.Fatalf("get queue length: %v", err)
+	}
+
+	tests := []struct {
+		key  string
+		want time.Duration
+	}{
+		{key: "event:" + event.ID.String(), want: 7 * time.Minute},
+		{key: "events:active", want: 11 * time.Second},
+		{key: "events:all", want: adapter.DefaultCacheConfig().EventListTTL},
+		{key: "seats:available:" + event.ID.String(), want: 3 * time.Second},
+		{key: "queue_length:" + event.ID.String(), want: 2 * time.Second},
+	}
+	for _, tc := range tests {
+		if got := cache.ttl(t, tc.key); got != tc.want {
+			t.Errorf("%s cached for %v, want %v", tc.key, got, tc.want)
+		}
+	}
+}
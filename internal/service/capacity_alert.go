@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/adapter"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// SetCapacityAlerter sets the optional alerter that capacity alerts are published to; they are logged either way
+func (s *TicketingService) SetCapacityAlerter(alerter adapter.CapacityAlerter) {
+	s.alerter = alerter
+}
+
+// capacityAlertCounterKey names the rolling counter of capacity alerts raised for an event
+func capacityAlertCounterKey(eventID uuid.UUID) string {
+	return fmt.Sprintf("capacity_alerts:%s", eventID.String())
+}
+
+// decrementAvailableTickets takes tickets off an event's inventory and raises any capacity alert the sale crossed
+func (s *TicketingService) decrementAvailableTickets(ctx context.Context, eventID uuid.UUID, count int) error {
+	if err := s.eventRepo.DecrementAvailableTickets(ctx, eventID, count); err != nil {
+		return err
+	}
+
+	s.checkCapacityAlerts(ctx, eventID)
+	return nil
+}
+
+// checkCapacityAlerts raises an alert for every configured threshold the event's sold share has reached.
+// The repository marks each threshold as it fires, so concurrent purchases crossing it together alert once
+// and a threshold stays spent even if refunds bring the share back under it.
+func (s *TicketingService) checkCapacityAlerts(ctx context.Context, eventID uuid.UUID) {
+	if len(s.config.CapacityAlertThresholds) == 0 {
+		return
+	}
+
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to get event for capacity alerts", "event_id", eventID, "error", err)
+		return
+	}
+
+	percentSold := event.PercentSold()
+
+	thresholds := append([]int(nil), s.config.CapacityAlertThresholds...)
+	sort.Ints(thresholds)
+
+	for _, threshold := range thresholds {
+		if percentSold < threshold {
+			break
+		}
+
+		first, err := s.eventRepo.MarkCapacityAlert(ctx, eventID, threshold)
+		if err != nil {
+			s.logger.Warn(ctx, "Failed to mark capacity alert", "event_id", eventID, "threshold", threshold, "error", err)
+			continue
+		}
+		if !first {
+			continue
+		}
+
+		alert := domain.CapacityAlert{
+			EventID:          eventID,
+			Threshold:        threshold,
+			PercentSold:      percentSold,
+			TotalTickets:     event.TotalTickets,
+			AvailableTickets: event.AvailableTickets,
+			CrossedAt:        time.Now(),
+		}
+		s.raiseCapacityAlert(ctx, alert)
+	}
+}
+
+// raiseCapacityAlert logs, counts and publishes one capacity alert; publishing failures are only logged
+func (s *TicketingService) raiseCapacityAlert(ctx context.Context, alert domain.CapacityAlert) {
+	s.logger.Warn(ctx, "Event capacity threshold crossed",
+		"event_id", alert.EventID,
+		"threshold", alert.Threshold,
+		"percent_sold", alert.PercentSold,
+		"available_tickets", alert.AvailableTickets,
+		"total_tickets", alert.TotalTickets)
+
+	s.recordRate(ctx, capacityAlertCounterKey(alert.EventID))
+
+	if s.alerter == nil {
+		return
+	}
+
+	if err := s.alerter.CapacityThresholdCrossed(ctx, alert); err != nil {
+		s.logger.Warn(ctx, "Failed to publish capacity alert", "event_id", alert.EventID, "threshold", alert.Threshold, "error", err)
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// ErrCarriedReservationHeld is returned when a purchase names seats other than the ones the user still holds
+// from an earlier queue session; the error names the held seats
+var ErrCarriedReservationHeld = errors.New("a reservation from an earlier queue session is still held")
+
+// carriedReservations returns the live reservations a user made for the event before their current queue
+// session was activated, i.e. in an earlier session that expired before they re-queued, oldest first. Purchases
+// return these instead of reserving again, so re-queueing can't double the inventory a user holds. Lookup
+// failures are logged and yield none, leaving the purchase to go ahead as usual.
+func (s *TicketingService) carriedReservations(ctx context.Context, eventID, userID uuid.UUID, sessionID string) []*domain.Ticket {
+	entry, err := s.queueRepo.GetBySessionID(ctx, sessionID)
+	if err != nil || entry.EventID != eventID || entry.UserID != userID || entry.ActivatedAt == nil {
+		// authorizePurchase turns a missing or mismatched session into the purchase's error
+		return nil
+	}
+
+	now := s.now()
+	if !entry.IsActive() || entry.IsExpiredAt(now) {
+		return nil
+	}
+
+	tickets, err := s.ticketRepo.GetByUserAndEvent(ctx, userID, eventID)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to look up existing reservations", "event_id", eventID, "user_id", userID, "error", err)
+		return nil
+	}
+
+	var carried []*domain.Ticket
+	for _, ticket := range tickets {
+		if ticket.CanBeConfirmedAt(now) && ticket.CreatedAt.Before(*entry.ActivatedAt) {
+			carried = append(carried, ticket)
+		}
+	}
+	if len(carried) == 0 {
+		return nil
+	}
+
+	sort.Slice(carried, func(i, j int) bool { return carried[i].CreatedAt.Before(carried[j].CreatedAt) })
+
+	s.logger.Info(ctx, "Returning reservations from an earlier queue session",
+		"event_id", eventID,
+		"user_id", userID,
+		"session_id", sessionID,
+		"tickets", len(carried))
+
+	return carried
+}
+
+// carriedForSeats picks the carried reservations a purchase of seatIDs gets back. A purchase naming no seats
+// gets every carried reservation; one naming seats gets the carried reservations for them, and fails with
+// ErrCarriedReservationHeld, naming the held seats, when it asks for any seat the user does not already hold.
+func carriedForSeats(carried []*domain.Ticket, seatIDs []uuid.UUID) ([]*domain.Ticket, error) {
+	if len(seatIDs) == 0 {
+		return carried, nil
+	}
+
+	bySeat := make(map[uuid.UUID]*domain.Ticket, len(carried))
+	for _, ticket := range carried {
+		if ticket.SeatID != nil {
+			bySeat[*ticket.SeatID] = ticket
+		}
+	}
+
+	matched := make([]*domain.Ticket, 0, len(seatIDs))
+	for _, seatID := range seatIDs {
+		ticket, ok := bySeat[seatID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrCarriedReservationHeld, describeHeld(carried))
+		}
+		matched = append(matched, ticket)
+	}
+
+	return matched, nil
+}
+
+// describeHeld names what carried reservations hold, by seat, or by ticket for general admission
+func describeHeld(carried []*domain.Ticket) string {
+	held := make([]string, len(carried))
+	for i, ticket := range carried {
+		if ticket.SeatID != nil {
+			held[i] = "seat " + ticket.SeatID.String()
+		} else {
+			held[i] = "ticket " + ticket.ID.String()
+		}
+	}
+	return "holding " + strings.Join(held, ", ")
+}
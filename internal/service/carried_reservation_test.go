@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestPurchaseReturnsCarriedReservation(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	event := tt.createEvent(t, 10, 9)
+	userID := uuid.New()
+	seat, held := tt.createReservation(t, event, userID, time.Minute)
+
+	// The user's first session lapsed and they re-queued
+	sessionID := tt.activateSession(t, event.ID, userID)
+
+	tests := []struct {
+		name   string
+		seatID *uuid.UUID
+	}{
+		{name: "no seat named", seatID: nil},
+		{name: "held seat named", seatID: &seat.ID},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ticket, err := tt.service.PurchaseTicket(ctx, event.ID, userID, tc.seatID, sessionID, PurchaseOptions{})
+			if err != nil {
+				t.Fatalf("purchase: %v", err)
+			}
+			if ticket.ID != held.ID {
+				t.Fatalf("purchase returned ticket %s, want the held reservation %s", ticket.ID, held.ID)
+			}
+		})
+	}
+
+	tickets, err := tt.tickets.GetByUserAndEvent(ctx, userID, event.ID)
+	if err != nil {
+		t.Fatalf("get user tickets: %v", err)
+	}
+	if len(tickets) != 1 {
+		t.Fatalf("user holds %d tickets, want the one reservation", len(tickets))
+	}
+	if got := tt.availableTickets(t, event.ID); got != 9 {
+		t.Fatalf("available tickets = %d, want 9", got)
+	}
+}
+
+func TestPurchaseOfAnotherSeatWhileHoldingCarriedReservation(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	event := tt.createEvent(t, 10, 8)
+	userID := uuid.New()
+	seat, _ := tt.createReservation(t, event, userID, time.Minute)
+	other, _ := tt.createReservation(t, event, uuid.New(), time.Minute)
+	sessionID := tt.activateSession(t, event.ID, userID)
+
+	_, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &other.ID, sessionID, PurchaseOptions{})
+	if !errors.Is(err, ErrCarriedReservationHeld) {
+		t.Fatalf("purchase error = %v, want %v", err, ErrCarriedReservationHeld)
+	}
+	if !strings.Contains(err.Error(), seat.ID.String()) {
+		t.Fatalf("purchase error %q does not name the held seat %s", err, seat.ID)
+	}
+
+	_, err = tt.service.PurchaseSeats(ctx, event.ID, userID, []uuid.UUID{seat.ID, other.ID}, sessionID, PurchaseOptions{})
+	if !errors.Is(err, ErrCarriedReservationHeld) {
+		t.Fatalf("multi-seat purchase error = %v, want %v", err, ErrCarriedReservationHeld)
+	}
+
+	tickets, err := tt.tickets.GetByUserAndEvent(ctx, userID, event.ID)
+	if err != nil {
+		t.Fatalf("get user tickets: %v", err)
+	}
+	if len(tickets) != 1 {
+		t.Fatalf("user holds %d tickets, want only the carried reservation", len(tickets))
+	}
+}
+
+func TestPurchaseIgnoresReservationsOfTheCurrentSession(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	event := tt.createEvent(t, 10, 9)
+	userID := uuid.New()
+	sessionID := tt.activateSession(t, event.ID, userID)
+
+	// Made after the session was activated, so it is not carried over
+	time.Sleep(time.Millisecond)
+	tt.createReservation(t, event, userID, time.Minute)
+
+	if carried := tt.service.carriedReservations(ctx, event.ID, userID, sessionID); len(carried) != 0 {
+		t.Fatalf("%d reservations carried, want none from the current session", len(carried))
+	}
+}
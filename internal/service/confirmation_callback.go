@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/adapter"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// ErrCallbackURLNotAllowed is returned when a purchase names a callback URL that is malformed or whose host
+// isn't in TicketingConfig.CallbackHosts, or while no callback sender is set
+var ErrCallbackURLNotAllowed = errors.New("callback URL is not allowed")
+
+// ConfirmationCallbackType is the type of the callback sent when a ticket is confirmed
+const ConfirmationCallbackType = "ticket.confirmed"
+
+// ConfirmationCallback is the body POSTed to a ticket's callback URL once it is confirmed
+type ConfirmationCallback struct {
+	Type        string     `json:"type"`
+	TicketID    uuid.UUID  `json:"ticket_id"`
+	EventID     uuid.UUID  `json:"event_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	SeatID      *uuid.UUID `json:"seat_id,omitempty"`
+	Zone        string     `json:"zone,omitempty"`
+	Price       int64      `json:"price"`
+	Currency    string     `json:"currency,omitempty"`
+	ConfirmedAt *time.Time `json:"confirmed_at"`
+}
+
+// SetCallbackSender enables confirmation callbacks; purchases may then name a callback URL on an allowed host
+func (s *TicketingService) SetCallbackSender(callbacks adapter.CallbackSender) {
+	s.callbacks = callbacks
+}
+
+// validateCallbackURL checks a purchase's callback URL; an empty one asks for no callback
+func (s *TicketingService) validateCallbackURL(callbackURL string) error {
+	if callbackURL == "" {
+		return nil
+	}
+
+	if s.callbacks == nil {
+		return fmt.Errorf("%w: callbacks are not enabled", ErrCallbackURLNotAllowed)
+	}
+
+	u, err := url.ParseRequestURI(callbackURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil {
+		return fmt.Errorf("%w: must be an http or https URL without credentials", ErrCallbackURLNotAllowed)
+	}
+
+	for _, host := range s.config.CallbackHosts {
+		if strings.EqualFold(u.Hostname(), host) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: host %s is not allowed", ErrCallbackURLNotAllowed, u.Hostname())
+}
+
+// sendConfirmationCallback tells the buyer's callback URL that the ticket was confirmed. Delivery is tried once
+// and a failure is only logged; the confirmation stands either way. The URL is checked against the allowlist
+// again, as hosts may have been removed since the purchase.
+func (s *TicketingService) sendConfirmationCallback(ctx context.Context, ticket *domain.Ticket) {
+	if ticket.CallbackURL == "" || s.callbacks == nil {
+		return
+	}
+
+	if err := s.validateCallbackURL(ticket.CallbackURL); err != nil {
+		s.logger.Warn(ctx, "Skipped confirmation callback", "ticket_id", ticket.ID, "error", err)
+		return
+	}
+
+	payload, err := json.Marshal(ConfirmationCallback{
+		Type:        ConfirmationCallbackType,
+		TicketID:    ticket.ID,
+		EventID:     ticket.EventID,
+		UserID:      ticket.UserID,
+		SeatID:      ticket.SeatID,
+		Zone:        ticket.Zone,
+		Price:       ticket.Price,
+		Currency:    ticket.Currency,
+		ConfirmedAt: ticket.ConfirmedAt,
+	})
+	if err != nil {
+		s.logger.Error(ctx, "Failed to marshal confirmation callback", "ticket_id", ticket.ID, "error", err)
+		return
+	}
+
+	if err := s.callbacks.Send(ctx, ticket.CallbackURL, payload); err != nil {
+		s.logger.Warn(ctx, "Failed to send confirmation callback", "ticket_id", ticket.ID, "error", err)
+		return
+	}
+
+	s.logger.Info(ctx, "Confirmation callback sent", "ticket_id", ticket.ID)
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// ErrConfirmationTokenInvalid is returned when a confirmation link token is unknown, already used or expired
+var ErrConfirmationTokenInvalid = errors.New("confirmation link is invalid or has expired")
+
+// ConfirmationLink is a single-use token that confirms a reservation without signing in
+type ConfirmationLink struct {
+	TicketID  uuid.UUID `json:"ticket_id"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// IssueConfirmationLink creates a single-use confirmation token for a reserved ticket.
+// The token lives exactly as long as the reservation, and only its hash is stored.
+func (s *TicketingService) IssueConfirmationLink(ctx context.Context, ticketID uuid.UUID) (*ConfirmationLink, error) {
+	ticket, err := s.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get ticket", "ticket_id", ticketID, "error", err)
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	token, err := s.issueConfirmationToken(ctx, ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Confirmation link issued", "ticket_id", ticketID, "expires_at", *ticket.ExpiresAt)
+
+	return &ConfirmationLink{
+		TicketID:  ticket.ID,
+		Token:     token,
+		ExpiresAt: *ticket.ExpiresAt,
+	}, nil
+}
+
+// ReservationHandle is everything a client needs to finish checkout of a reservation it just made
+type ReservationHandle struct {
+	TicketID     uuid.UUID             `json:"ticket_id"`
+	EventID      uuid.UUID             `json:"event_id"`
+	SeatID       *uuid.UUID            `json:"seat_id,omitempty"`
+	Price        int64                 `json:"price"` // Locked in at reservation time, in cents
+	Breakdown    domain.PriceBreakdown `json:"breakdown"`
+	Currency     string                `json:"currency"`
+	ExpiresAt    time.Time             `json:"expires_at"`
+	ConfirmToken string                `json:"confirm_token"` // Single-use; accepted by GET /tickets/confirm
+
+	// RequestedSeatID and SeatFallback report a purchase that got a fallback seat instead of the one requested
+	RequestedSeatID *uuid.UUID `json:"requested_seat_id,omitempty"`
+	SeatFallback    bool       `json:"seat_fallback,omitempty"`
+}
+
+// NewReservationHandle issues a confirmation token for a fresh reservation and bundles it with the
+// reservation's price and expiry, so the purchase response is enough to complete checkout
+func (s *TicketingService) NewReservationHandle(ctx context.Context, ticket *domain.Ticket) (*ReservationHandle, error) {
+	token, err := s.issueConfirmationToken(ctx, ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReservationHandle{
+		TicketID:     ticket.ID,
+		EventID:      ticket.EventID,
+		SeatID:       ticket.SeatID,
+		Price:        ticket.Price,
+		Breakdown:    ticket.PriceBreakdown(),
+		Currency:     ticket.Currency,
+		ExpiresAt:    *ticket.ExpiresAt,
+		ConfirmToken: token,
+	}, nil
+}
+
+// issueConfirmationToken stores the hash of a new token that confirms a reserved ticket until its reservation expires
+func (s *TicketingService) issueConfirmationToken(ctx context.Context, ticket *domain.Ticket) (string, error) {
+	if !ticket.IsReserved() {
+		return "", fmt.Errorf("ticket is not reserved")
+	}
+
+	// The token lives as long as the reservation, so one without an expiry can't have a link
+	now := s.now()
+	if ticket.ExpiresAt == nil || !ticket.CanBeConfirmedAt(now) {
+		return "", fmt.Errorf("ticket reservation has expired")
+	}
+
+	token, err := newConfirmationToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+
+	ttl := ticket.ExpiresAt.Sub(now)
+	if err := s.ticketRepo.SaveConfirmationToken(ctx, hashConfirmationToken(token), ticket.ID, ttl); err != nil {
+		s.logger.Error(ctx, "Failed to save confirmation token", "ticket_id", ticket.ID, "error", err)
+		return "", fmt.Errorf("failed to save confirmation token: %w", err)
+	}
+
+	return token, nil
+}
+
+// ConfirmTicketByToken confirms the ticket a confirmation link was issued for.
+// The token is consumed before confirming, so a link works at most once even if confirmation fails.
+func (s *TicketingService) ConfirmTicketByToken(ctx context.Context, token string) (uuid.UUID, error) {
+	ticketID, err := s.ticketRepo.ConsumeConfirmationToken(ctx, hashConfirmationToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrConfirmationTokenNotFound) {
+			s.logger.Warn(ctx, "Invalid confirmation token presented")
+			return uuid.Nil, ErrConfirmationTokenInvalid
+		}
+		return uuid.Nil, fmt.Errorf("failed to consume confirmation token: %w", err)
+	}
+
+	return ticketID, s.ConfirmTicket(ctx, ticketID)
+}
+
+// newConfirmationToken generates a URL-safe random token for a confirmation link
+func newConfirmationToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// hashConfirmationToken derives the stored form of a token so a leaked store can't confirm tickets
+func hashConfirmationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
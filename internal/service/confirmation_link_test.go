@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+func TestReservationHandleConfirmsTicket(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+
+	event := tt.createEvent(t, 10, 10)
+	seat := tt.createSeat(t, event, domain.SeatStatusAvailable)
+	userID := uuid.New()
+	sessionID := tt.activateSession(t, event.ID, userID)
+
+	ticket, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &seat.ID, sessionID, PurchaseOptions{})
+	if err != nil {
+		t.Fatalf("purchase ticket: %v", err)
+	}
+
+	handle, err := tt.service.NewReservationHandle(ctx, ticket)
+	if err != nil {
+		t.Fatalf("new reservation handle: %v", err)
+	}
+	if handle.TicketID != ticket.ID || handle.EventID != event.ID || handle.SeatID == nil || *handle.SeatID != seat.ID {
+		t.Fatalf("handle describes ticket %s of event %s, want ticket %s of event %s", handle.TicketID, handle.EventID, ticket.ID, event.ID)
+	}
+	if handle.Price != ticket.Price || handle.Currency != event.Currency || !handle.ExpiresAt.Equal(*ticket.ExpiresAt) {
+		t.Fatalf("handle price %d %s expiring %v, want %d %s expiring %v", handle.Price, handle.Currency, handle.ExpiresAt, ticket.Price, event.Currency, *ticket.ExpiresAt)
+	}
+	if handle.ConfirmToken == "" {
+		t.Fatal("handle carries no confirm token")
+	}
+
+	confirmedID, err := tt.service.ConfirmTicketByToken(ctx, handle.ConfirmToken)
+	if err != nil {
+		t.Fatalf("confirm by the handle's token: %v", err)
+	}
+	if confirmedID != ticket.ID {
+		t.Fatalf("token confirmed ticket %s, want %s", confirmedID, ticket.ID)
+	}
+	confirmed, err := tt.tickets.GetByID(ctx, ticket.ID)
+	if err != nil {
+		t.Fatalf("get ticket: %v", err)
+	}
+	if !confirmed.IsConfirmed() || confirmed.AccessToken == "" {
+		t.Fatalf("ticket status = %s with access token %q, want confirmed with one", confirmed.Status, confirmed.AccessToken)
+	}
+
+	if _, err := tt.service.ConfirmTicketByToken(ctx, handle.ConfirmToken); !errors.Is(err, ErrConfirmationTokenInvalid) {
+		t.Fatalf("expected ErrConfirmationTokenInvalid reusing the token, got %v", err)
+	}
+	if _, err := tt.service.ConfirmTicketByToken(ctx, "unknown-token"); !errors.Is(err, ErrConfirmationTokenInvalid) {
+		t.Fatalf("expected ErrConfirmationTokenInvalid for an unknown token, got %v", err)
+	}
+}
+
+func TestReservationHandleRefusesSettledReservation(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	event := tt.createEvent(t, 10, 9)
+	_, ticket := tt.createReservation(t, event, uuid.New(), -time.Second)
+
+	if _, err := tt.service.NewReservationHandle(ctx, ticket); err == nil {
+		t.Fatal("issued a handle for a reservation that already lapsed")
+	}
+
+	_, ticket = tt.createReservation(t, event, uuid.New(), 10*time.Minute)
+	if err := tt.service.ConfirmTicket(ctx, ticket.ID); err != nil {
+		t.Fatalf("confirm ticket: %v", err)
+	}
+	confirmed, err := tt.tickets.GetByID(ctx, ticket.ID)
+	if err != nil {
+		t.Fatalf("get ticket: %v", err)
+	}
+	if _, err := tt.service.NewReservationHandle(ctx, confirmed); err == nil {
+		t.Fatal("issued a handle for a confirmed ticket")
+	}
+}
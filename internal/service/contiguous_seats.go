@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// ErrNoContiguousSeats is returned when a group purchase finds no block of adjacent seats it can reserve
+var ErrNoContiguousSeats = errors.New("no block of adjacent seats is available")
+
+// SuggestAdjacentSeats lists every block of count available general seats that sit together: the same section and
+// row with consecutive seat numbers. Blocks come best first under the event's seat ranking; overlapping blocks
+// are all listed. An empty section searches the whole event. Seat numbers may be numeric ("7") or letters ("G");
+// seats whose number is neither are never adjacent.
+func (s *TicketingService) SuggestAdjacentSeats(ctx context.Context, eventID uuid.UUID, section string, count int) ([][]*domain.Seat, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	return s.adjacentSeatBlocks(ctx, event, section, count)
+}
+
+// adjacentSeatBlocks finds the blocks SuggestAdjacentSeats lists for an event
+func (s *TicketingService) adjacentSeatBlocks(ctx context.Context, event *domain.Event, section string, count int) ([][]*domain.Seat, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("seat count must be positive")
+	}
+
+	eventID := event.ID
+
+	var seats []*domain.Seat
+	var err error
+	if section != "" {
+		seats, err = s.seatRepo.GetBySection(ctx, eventID, section)
+	} else {
+		seats, err = s.seatRepo.GetByEventID(ctx, eventID)
+	}
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get seats", "event_id", eventID, "section", section, "error", err)
+		return nil, fmt.Errorf("failed to get seats: %w", err)
+	}
+
+	type rowKey struct{ section, row string }
+	type numberedSeat struct {
+		number int
+		seat   *domain.Seat
+	}
+
+	rows := make(map[rowKey][]numberedSeat)
+	for _, seat := range seats {
+		if !seat.IsAvailable() || seat.IsRestricted() {
+			continue
+		}
+
+		number, ok := seatLabelOrdinal(seat.Number)
+		if !ok {
+			continue
+		}
+
+		key := rowKey{section: seat.Section, row: seat.Row}
+		rows[key] = append(rows[key], numberedSeat{number: number, seat: seat})
+	}
+
+	keys := make([]rowKey, 0, len(rows))
+	for key := range rows {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].section != keys[j].section {
+			return keys[i].section < keys[j].section
+		}
+		return compareSeatLabels(keys[i].row, keys[j].row) < 0
+	})
+
+	var blocks [][]*domain.Seat
+	for _, key := range keys {
+		row := rows[key]
+		sort.Slice(row, func(i, j int) bool { return row[i].number < row[j].number })
+
+		// run is how many seats up to and including i have consecutive numbers
+		run := 0
+		for i := range row {
+			if i > 0 && row[i].number == row[i-1].number+1 {
+				run++
+			} else {
+				run = 1
+			}
+
+			if run < count {
+				continue
+			}
+
+			block := make([]*domain.Seat, 0, count)
+			for _, numbered := range row[i-count+1 : i+1] {
+				block = append(block, numbered.seat)
+			}
+			blocks = append(blocks, block)
+		}
+	}
+
+	rankBlocks(s.seatRankerFor(event), blocks)
+
+	return blocks, nil
+}
+
+// rankBlocks orders seat blocks by the summed rank of their seats, best first; equally ranked blocks keep
+// their order
+func rankBlocks(ranker SeatRanker, blocks [][]*domain.Seat) {
+	var seats []*domain.Seat
+	seen := make(map[*domain.Seat]struct{})
+	for _, block := range blocks {
+		for _, seat := range block {
+			if _, ok := seen[seat]; !ok {
+				seen[seat] = struct{}{}
+				seats = append(seats, seat)
+			}
+		}
+	}
+
+	ranks := seatRanks(ranker, seats)
+	score := func(block []*domain.Seat) int {
+		total := 0
+		for _, seat := range block {
+			total += ranks[seat]
+		}
+		return total
+	}
+
+	sort.SliceStable(blocks, func(i, j int) bool { return score(blocks[i]) < score(blocks[j]) })
+}
+
+// PurchaseContiguousSeats reserves count adjacent seats for one user in a single transaction, or nothing at all.
+// It takes the first block from SuggestAdjacentSeats it can reserve atomically, moving on to the next block
+// when another buyer takes a seat first, and fails with ErrNoContiguousSeats when no block is left.
+// The count is capped by TicketingConfig.MaxSeatsPerTransaction like any multi-seat purchase.
+func (s *TicketingService) PurchaseContiguousSeats(ctx context.Context, eventID, userID uuid.UUID, section string, count int, sessionID string, opts PurchaseOptions) ([]*domain.Ticket, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("seat count must be positive")
+	}
+
+	if limit := s.config.MaxSeatsPerTransaction; count > limit {
+		s.logger.Warn(ctx, "Purchase exceeds seat limit", "event_id", eventID, "user_id", userID, "seats", count, "limit", limit)
+		return nil, fmt.Errorf("%w: %d seats requested, at most %d allowed", ErrSeatLimitExceeded, count, limit)
+	}
+
+	// Reservations kept from an earlier queue session are returned rather than reserving more seats
+	if carried := s.carriedReservations(ctx, eventID, userID, sessionID); len(carried) > 0 {
+		return carried, nil
+	}
+
+	if err := s.checkPurchaseBudget(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	tickets, err := s.purchaseContiguousSeats(ctx, eventID, userID, section, count, sessionID, opts)
+	if err := s.recordPurchaseOutcome(ctx, sessionID, err); err != nil {
+		return nil, err
+	}
+
+	s.recordPurchaseSignal(ctx, eventID, userID, sessionID, opts, tickets)
+	s.publishReserved(ctx, tickets)
+
+	return tickets, nil
+}
+
+// purchaseContiguousSeats runs a single contiguous group purchase attempt
+func (s *TicketingService) purchaseContiguousSeats(ctx context.Context, eventID, userID uuid.UUID, section string, count int, sessionID string, opts PurchaseOptions) ([]*domain.Ticket, error) {
+	s.logger.Info(ctx, "Starting contiguous seat purchase",
+		"event_id", eventID,
+		"user_id", userID,
+		"section", section,
+		"seats", count,
+		"session_id", sessionID)
+
+	event, err := s.authorizePurchase(ctx, eventID, userID, sessionID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if !event.IsSeatedEvent {
+		return nil, fmt.Errorf("multi-seat purchases are only available for seated events")
+	}
+
+	guestEmail, err := s.authorizeGuest(event, userID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := s.acquirePurchaseSlot(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	claim, err := s.claimUserTickets(ctx, event, userID, count)
+	if err != nil {
+		return nil, err
+	}
+	defer claim.release(ctx)
+
+	blocks, err := s.adjacentSeatBlocks(ctx, event, section, count)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(blocks) > MaxSeatFallbackAttempts {
+		blocks = blocks[:MaxSeatFallbackAttempts]
+	}
+
+	for _, block := range blocks {
+		tickets, err := s.reserveSeatBlock(ctx, event, userID, guestEmail, block)
+		if errors.Is(err, repository.ErrSeatNotAvailable) {
+			// Someone else took a seat of this block first; try the next one
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		claim.keep(ctx, len(tickets))
+
+		s.logger.Info(ctx, "Contiguous seats purchased successfully", "event_id", eventID, "user_id", userID, "tickets", len(tickets))
+		s.recordRate(ctx, purchaseCounterKey(eventID))
+
+		return tickets, nil
+	}
+
+	s.logger.Warn(ctx, "No contiguous seats available", "event_id", eventID, "section", section, "seats", count)
+	return nil, ErrNoContiguousSeats
+}
+
+// reserveSeatBlock locks and atomically reserves one block of seats and tickets it.
+// It returns an error wrapping ErrSeatNotAvailable, with nothing reserved, if any seat of the block is taken.
+func (s *TicketingService) reserveSeatBlock(ctx context.Context, event *domain.Event, userID uuid.UUID, guestEmail string, block []*domain.Seat) ([]*domain.Ticket, error) {
+	seatIDs := seatIDsOf(block)
+
+	unlock, err := s.lockSeats(ctx, event, seatIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err := s.seatRepo.ReserveSeats(ctx, seatIDs); err != nil {
+		if !errors.Is(err, repository.ErrSeatNotAvailable) {
+			s.logger.Error(ctx, "Failed to reserve seats", "seat_ids", seatIDs, "error", err)
+		}
+		return nil, fmt.Errorf("failed to reserve seats: %w", err)
+	}
+
+	return s.ticketReservedSeats(ctx, event, userID, guestEmail, block)
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/pkg/logger"
+)
+
+// createRow stores seats numbered 1 to len(statuses) in row 1 of section A, in the given statuses
+func (tt *testTicketing) createRow(t *testing.T, event *domain.Event, statuses ...domain.SeatStatus) []*domain.Seat {
+	t.Helper()
+
+	seats := make([]*domain.Seat, len(statuses))
+	for i, status := range statuses {
+		seat := tt.createSeat(t, event, status)
+		seat.Number = strconv.Itoa(i + 1)
+		if err := tt.seats.Update(context.Background(), seat); err != nil {
+			t.Fatalf("number seat: %v", err)
+		}
+		seats[i] = seat
+	}
+	return seats
+}
+
+func TestPurchaseContiguousSeats(t *testing.T) {
+	const (
+		available = domain.SeatStatusAvailable
+		sold      = domain.SeatStatusSold
+	)
+
+	tests := []struct {
+		name  string
+		row   []domain.SeatStatus
+		count int
+		// race sells the seat at this index just before the first block is reserved; -1 for no race
+		race int
+		// want lists the seat numbers the purchase should get; nil when it should fail
+		want []string
+	}{
+		{name: "block found", row: []domain.SeatStatus{available, available, sold, available, available}, count: 2, race: -1, want: []string{"1", "2"}},
+		{name: "block taken during the purchase", row: []domain.SeatStatus{available, available, sold, available, available}, count: 2, race: 0, want: []string{"4", "5"}},
+		{name: "no block long enough", row: []domain.SeatStatus{available, available, sold, available, available}, count: 3, race: -1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tt := newTestTicketing(t)
+			event := tt.createEvent(t, len(tc.row), len(tc.row))
+			seats := tt.createRow(t, event, tc.row...)
+			if tc.race >= 0 {
+				racing := &racingSeatRepository{SeatRepository: tt.seats, sell: seats[tc.race].ID}
+				tt.service = NewTicketingService(tt.tickets, tt.events, racing, tt.queue, testCache{}, newTestLock(), logger.NewLoggerWithLevel(zerolog.Disabled))
+			}
+
+			userID := uuid.New()
+			sessionID := tt.activateSession(t, event.ID, userID)
+			tickets, err := tt.service.PurchaseContiguousSeats(ctx, event.ID, userID, "A", tc.count, sessionID, PurchaseOptions{})
+
+			if tc.want == nil {
+				if !errors.Is(err, ErrNoContiguousSeats) {
+					t.Fatalf("expected ErrNoContiguousSeats, got %v", err)
+				}
+				for i, seat := range seats {
+					got, err := tt.seats.GetByID(ctx, seat.ID)
+					if err != nil {
+						t.Fatalf("get seat %d: %v", i+1, err)
+					}
+					if got.Status != string(tc.row[i]) {
+						t.Errorf("seat %d status = %s after a failed purchase, want %s", i+1, got.Status, tc.row[i])
+					}
+				}
+				held, err := tt.tickets.GetByUserAndEvent(ctx, userID, event.ID)
+				if err != nil {
+					t.Fatalf("get user tickets: %v", err)
+				}
+				if len(held) != 0 {
+					t.Fatalf("user holds %d tickets after a failed purchase, want none", len(held))
+				}
+				if got := tt.availableTickets(t, event.ID); got != len(tc.row) {
+					t.Fatalf("available tickets = %d after a failed purchase, want %d", got, len(tc.row))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("purchase contiguous seats: %v", err)
+			}
+			if len(tickets) != len(tc.want) {
+				t.Fatalf("got %d tickets, want %d", len(tickets), len(tc.want))
+			}
+			for i, ticket := range tickets {
+				seat, err := tt.seats.GetByID(ctx, *ticket.SeatID)
+				if err != nil {
+					t.Fatalf("get ticketed seat: %v", err)
+				}
+				if seat.Number != tc.want[i] || seat.Status != string(domain.SeatStatusReserved) {
+					t.Errorf("ticket %d holds seat %s (%s), want reserved seat %s", i, seat.Number, seat.Status, tc.want[i])
+				}
+			}
+			if got := tt.availableTickets(t, event.ID); got != len(tc.row)-len(tc.want) {
+				t.Fatalf("available tickets = %d, want %d", got, len(tc.row)-len(tc.want))
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,13 +12,61 @@ import (
 	"github.com/snowmerak/ticketing/lib/repository"
 )
 
+// ErrEventHasConfirmedTickets is returned when deleting an event whose confirmed tickets haven't been cancelled
+var ErrEventHasConfirmedTickets = errors.New("event has confirmed tickets")
+
+// ErrDuplicateEvent is returned when creating an event that looks like an existing one while duplicates are rejected
+var ErrDuplicateEvent = errors.New("an event with the same name, venue and start date already exists")
+
+// DuplicateEventPolicy is what CreateEvent does about an event with the same name, venue and start date as an
+// existing one, compared by domain.Event.Fingerprint
+type DuplicateEventPolicy string
+
+const (
+	// DuplicateEventsAllow creates the event without checking; it is the zero policy
+	DuplicateEventsAllow DuplicateEventPolicy = ""
+	// DuplicateEventsWarn creates the event and logs a warning naming the event it duplicates
+	DuplicateEventsWarn DuplicateEventPolicy = "warn"
+	// DuplicateEventsReject refuses the event with ErrDuplicateEvent
+	DuplicateEventsReject DuplicateEventPolicy = "reject"
+)
+
+// IsValid checks if the policy is a known duplicate event policy
+func (p DuplicateEventPolicy) IsValid() bool {
+	return p == DuplicateEventsAllow || p == DuplicateEventsWarn || p == DuplicateEventsReject
+}
+
+// EventConfig holds tunable event behavior
+type EventConfig struct {
+	// DefaultCurrency is the ISO 4217 code applied to events created without one
+	DefaultCurrency string
+	// DuplicateEvents is what creating an event that looks like an existing one does; duplicates are allowed
+	// by default
+	DuplicateEvents DuplicateEventPolicy
+}
+
+// DefaultEventConfig returns the default event configuration
+func DefaultEventConfig() EventConfig {
+	return EventConfig{
+		DefaultCurrency: "USD",
+	}
+}
+
 // EventService handles event-related business logic
 type EventService struct {
-	eventRepo repository.EventRepository
-	seatRepo  repository.SeatRepository
-	cache     adapter.Cache
-	lock      adapter.Lock
-	logger    adapter.Logger
+	eventRepo    repository.EventRepository
+	seatRepo     repository.SeatRepository
+	cache        adapter.Cache
+	lock         adapter.Lock
+	logger       adapter.Logger
+	queueRepo    repository.QueueRepository
+	ticketRepo   repository.TicketRepository
+	seatHoldRepo repository.SeatHoldRepository
+	counter      adapter.RateCounter
+	ids          adapter.IDGenerator
+	clock        adapter.Clock
+	config       EventConfig
+	cacheTTL     adapter.CacheConfig
 }
 
 // NewEventService creates a new EventService
@@ -34,28 +83,103 @@ func NewEventService(
 		cache:     cache,
 		lock:      lock,
 		logger:    logger,
+		config:    DefaultEventConfig(),
+		cacheTTL:  adapter.DefaultCacheConfig(),
+	}
+}
+
+// SetConfig replaces the event configuration
+func (s *EventService) SetConfig(config EventConfig) error {
+	if !domain.IsValidCurrency(config.DefaultCurrency) {
+		return fmt.Errorf("default currency %q is not an ISO 4217 code", config.DefaultCurrency)
 	}
+
+	if !config.DuplicateEvents.IsValid() {
+		return fmt.Errorf("duplicate event policy %q must be empty, warn or reject", config.DuplicateEvents)
+	}
+
+	config.DefaultCurrency = domain.NormalizeCurrency(config.DefaultCurrency)
+	s.config = config
+	return nil
 }
 
-// CreateEvent creates a new event
+// SetTicketRepository sets the ticket repository used to delete an event's tickets and audit its seats
+func (s *EventService) SetTicketRepository(ticketRepo repository.TicketRepository) {
+	s.ticketRepo = ticketRepo
+}
+
+// SetCacheConfig sets how long events and seat lists stay cached; unset TTLs keep their defaults
+func (s *EventService) SetCacheConfig(config adapter.CacheConfig) {
+	s.cacheTTL = config.WithDefaults()
+}
+
+// SetIDGenerator sets the generator used for the IDs of new events and seats; random UUIDs are used when none is set
+func (s *EventService) SetIDGenerator(ids adapter.IDGenerator) {
+	s.ids = ids
+}
+
+// NewID returns a new event or seat ID from the configured generator
+func (s *EventService) NewID() uuid.UUID {
+	if s.ids == nil {
+		return uuid.New()
+	}
+	return s.ids.NewID()
+}
+
+// SetClock sets the clock reservations are checked for expiry against; the wall clock is used when none is set
+func (s *EventService) SetClock(clock adapter.Clock) {
+	s.clock = clock
+}
+
+// now returns the current time from the configured clock
+func (s *EventService) now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock.Now()
+}
+
+// applyDefaults fills in event fields the request left empty
+func (s *EventService) applyDefaults(event *domain.Event) {
+	if event.Currency == "" {
+		event.Currency = s.config.DefaultCurrency
+	}
+	event.Currency = domain.NormalizeCurrency(event.Currency)
+}
+
+// CreateEvent creates a new event, giving it an ID if it has none
 func (s *EventService) CreateEvent(ctx context.Context, event *domain.Event) error {
+	if event.ID == uuid.Nil {
+		event.ID = s.NewID()
+	}
+
 	s.logger.Info(ctx, "Creating new event", "event_id", event.ID, "name", event.Name)
 
+	s.applyDefaults(event)
+
 	// Validate event
 	if err := s.validateEvent(event); err != nil {
 		s.logger.Error(ctx, "Event validation failed", "error", err)
 		return fmt.Errorf("event validation failed: %w", err)
 	}
 
+	if err := s.checkDuplicateEvent(ctx, event); err != nil {
+		return err
+	}
+
 	// Create event
 	if err := s.eventRepo.Create(ctx, event); err != nil {
 		s.logger.Error(ctx, "Failed to create event", "error", err)
 		return fmt.Errorf("failed to create event: %w", err)
 	}
 
+	if err := s.initStandingZones(ctx, event); err != nil {
+		return err
+	}
+
 	// Cache event
 	cacheKey := fmt.Sprintf("event:%s", event.ID.String())
-	if err := s.cache.Set(ctx, cacheKey, event, 1*time.Hour); err != nil {
+	if err := s.cache.Set(ctx, cacheKey, event, s.cacheTTL.EventTTL); err != nil {
 		s.logger.Warn(ctx, "Failed to cache event", "error", err)
 	}
 
@@ -63,6 +187,40 @@ func (s *EventService) CreateEvent(ctx context.Context, event *domain.Event) err
 	return nil
 }
 
+// checkDuplicateEvent applies the duplicate event policy to an event about to be created. The check is best
+// effort: two identical events created at the same moment can both pass it.
+func (s *EventService) checkDuplicateEvent(ctx context.Context, event *domain.Event) error {
+	if s.config.DuplicateEvents == DuplicateEventsAllow {
+		return nil
+	}
+
+	ids, err := s.eventRepo.GetIDsByFingerprint(ctx, event.Fingerprint())
+	if err != nil {
+		if s.config.DuplicateEvents == DuplicateEventsReject {
+			s.logger.Error(ctx, "Failed to check for duplicate events", "event_id", event.ID, "error", err)
+			return fmt.Errorf("failed to check for duplicate events: %w", err)
+		}
+		// A warning is only advice, so don't hold the event up for it
+		s.logger.Warn(ctx, "Failed to check for duplicate events", "event_id", event.ID, "error", err)
+		return nil
+	}
+
+	for _, id := range ids {
+		if id == event.ID {
+			continue
+		}
+
+		if s.config.DuplicateEvents == DuplicateEventsReject {
+			s.logger.Warn(ctx, "Rejected duplicate event", "event_id", event.ID, "duplicate_of", id, "name", event.Name)
+			return fmt.Errorf("%w: event %s", ErrDuplicateEvent, id)
+		}
+		s.logger.Warn(ctx, "Event looks like a duplicate", "event_id", event.ID, "duplicate_of", id, "name", event.Name)
+		return nil
+	}
+
+	return nil
+}
+
 // GetEvent retrieves an event by ID
 func (s *EventService) GetEvent(ctx context.Context, id uuid.UUID) (*domain.Event, error) {
 	// Try cache first
@@ -81,13 +239,31 @@ func (s *EventService) GetEvent(ctx context.Context, id uuid.UUID) (*domain.Even
 	}
 
 	// Cache for future use
-	if err := s.cache.Set(ctx, cacheKey, event, 1*time.Hour); err != nil {
+	if err := s.cache.Set(ctx, cacheKey, event, s.cacheTTL.EventTTL); err != nil {
 		s.logger.Warn(ctx, "Failed to cache event", "error", err)
 	}
 
 	return event, nil
 }
 
+// MaxEventBatchSize bounds how many events GetEventsByIDs fetches in one call
+const MaxEventBatchSize = 100
+
+// GetEventsByIDs retrieves several events in one round trip; IDs that don't exist are skipped
+func (s *EventService) GetEventsByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Event, error) {
+	if len(ids) > MaxEventBatchSize {
+		return nil, fmt.Errorf("cannot fetch more than %d events at once", MaxEventBatchSize)
+	}
+
+	events, err := s.eventRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get events by IDs", "count", len(ids), "error", err)
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+
+	return events, nil
+}
+
 // GetActiveEvents retrieves all active events
 func (s *EventService) GetActiveEvents(ctx context.Context) ([]*domain.Event, error) {
 	// Try cache first
@@ -104,14 +280,28 @@ func (s *EventService) GetActiveEvents(ctx context.Context) ([]*domain.Event, er
 		return nil, fmt.Errorf("failed to get active events: %w", err)
 	}
 
-	// Cache for 5 minutes
-	if err := s.cache.Set(ctx, cacheKey, events, 5*time.Minute); err != nil {
+	if err := s.cache.Set(ctx, cacheKey, events, s.cacheTTL.ActiveEventsTTL); err != nil {
 		s.logger.Warn(ctx, "Failed to cache active events", "error", err)
 	}
 
 	return events, nil
 }
 
+// GetEventsByStatus retrieves a page of events with the given status along with the total number of matches
+func (s *EventService) GetEventsByStatus(ctx context.Context, status string, limit, offset int) ([]*domain.Event, int, error) {
+	if !domain.EventStatus(status).IsValid() {
+		return nil, 0, fmt.Errorf("invalid event status: %s", status)
+	}
+
+	events, total, err := s.eventRepo.GetByStatus(ctx, status, offset, limit)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get events by status", "status", status, "error", err)
+		return nil, 0, fmt.Errorf("failed to get events by status: %w", err)
+	}
+
+	return events, total, nil
+}
+
 // GetAllEvents retrieves all events with pagination
 func (s *EventService) GetAllEvents(ctx context.Context) ([]*domain.Event, error) {
 	// Try cache first
@@ -128,8 +318,7 @@ func (s *EventService) GetAllEvents(ctx context.Context) ([]*domain.Event, error
 		return nil, fmt.Errorf("failed to get all events: %w", err)
 	}
 
-	// Cache for 2 minutes
-	if err := s.cache.Set(ctx, cacheKey, events, 2*time.Minute); err != nil {
+	if err := s.cache.Set(ctx, cacheKey, events, s.cacheTTL.EventListTTL); err != nil {
 		s.logger.Warn(ctx, "Failed to cache all events", "error", err)
 	}
 
@@ -140,6 +329,8 @@ func (s *EventService) GetAllEvents(ctx context.Context) ([]*domain.Event, error
 func (s *EventService) UpdateEvent(ctx context.Context, event *domain.Event) error {
 	s.logger.Info(ctx, "Updating event", "event_id", event.ID)
 
+	s.applyDefaults(event)
+
 	// Validate event
 	if err := s.validateEvent(event); err != nil {
 		s.logger.Error(ctx, "Event validation failed", "error", err)
@@ -152,6 +343,11 @@ func (s *EventService) UpdateEvent(ctx context.Context, event *domain.Event) err
 		return fmt.Errorf("failed to update event: %w", err)
 	}
 
+	// Zones added by the update start full; zones that already had a counter keep what is left
+	if err := s.initStandingZones(ctx, event); err != nil {
+		return err
+	}
+
 	// Invalidate cache
 	cacheKey := fmt.Sprintf("event:%s", event.ID.String())
 	if err := s.cache.Delete(ctx, cacheKey); err != nil {
@@ -167,10 +363,14 @@ func (s *EventService) UpdateEvent(ctx context.Context, event *domain.Event) err
 	return nil
 }
 
-// DeleteEvent deletes an event
+// DeleteEvent deletes an event along with its seats and its reserved and cancelled tickets
 func (s *EventService) DeleteEvent(ctx context.Context, id uuid.UUID) error {
 	s.logger.Info(ctx, "Deleting event", "event_id", id)
 
+	if err := s.deleteEventTickets(ctx, id); err != nil {
+		return err
+	}
+
 	// Delete all seats for this event
 	if err := s.seatRepo.DeleteByEventID(ctx, id); err != nil {
 		s.logger.Error(ctx, "Failed to delete event seats", "error", err)
@@ -198,6 +398,45 @@ func (s *EventService) DeleteEvent(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// deleteEventTickets deletes the reserved and cancelled tickets of an event being deleted, dropping them from
+// their holders' ticket lists. Confirmed tickets were paid for and have to be cancelled and refunded first,
+// so an event that still has any is not deleted.
+func (s *EventService) deleteEventTickets(ctx context.Context, eventID uuid.UUID) error {
+	if s.ticketRepo == nil {
+		return nil
+	}
+
+	tickets, err := s.ticketRepo.GetByEventID(ctx, eventID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get event tickets", "event_id", eventID, "error", err)
+		return fmt.Errorf("failed to get event tickets: %w", err)
+	}
+
+	confirmed := 0
+	for _, ticket := range tickets {
+		if ticket.IsConfirmed() {
+			confirmed++
+		}
+	}
+	if confirmed > 0 {
+		s.logger.Warn(ctx, "Refusing to delete event with confirmed tickets", "event_id", eventID, "confirmed", confirmed)
+		return fmt.Errorf("%w: %d confirmed tickets", ErrEventHasConfirmedTickets, confirmed)
+	}
+
+	for _, ticket := range tickets {
+		if err := s.ticketRepo.Delete(ctx, ticket.ID); err != nil {
+			s.logger.Error(ctx, "Failed to delete event ticket", "ticket_id", ticket.ID, "error", err)
+			return fmt.Errorf("failed to delete ticket %s: %w", ticket.ID, err)
+		}
+	}
+
+	if len(tickets) > 0 {
+		s.logger.Info(ctx, "Deleted event tickets", "event_id", eventID, "tickets", len(tickets))
+	}
+
+	return nil
+}
+
 // CreateSeatsForEvent creates seats for an event
 func (s *EventService) CreateSeatsForEvent(ctx context.Context, eventID uuid.UUID, seats []*domain.Seat) error {
 	s.logger.Info(ctx, "Creating seats for event", "event_id", eventID, "seat_count", len(seats))
@@ -229,6 +468,27 @@ func (s *EventService) CreateSeatsForEvent(ctx context.Context, eventID uuid.UUI
 	return nil
 }
 
+// GetSeat retrieves a seat by its ID
+func (s *EventService) GetSeat(ctx context.Context, id uuid.UUID) (*domain.Seat, error) {
+	seat, err := s.seatRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seat: %w", err)
+	}
+
+	return seat, nil
+}
+
+// CountAvailableSeats counts an event's available seats without loading them
+func (s *EventService) CountAvailableSeats(ctx context.Context, eventID uuid.UUID) (int, error) {
+	count, err := s.seatRepo.CountAvailable(ctx, eventID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to count available seats", "event_id", eventID, "error", err)
+		return 0, fmt.Errorf("failed to count available seats: %w", err)
+	}
+
+	return count, nil
+}
+
 // GetAvailableSeats retrieves available seats for an event
 func (s *EventService) GetAvailableSeats(ctx context.Context, eventID uuid.UUID) ([]*domain.Seat, error) {
 	// Try cache first
@@ -245,14 +505,48 @@ func (s *EventService) GetAvailableSeats(ctx context.Context, eventID uuid.UUID)
 		return nil, fmt.Errorf("failed to get available seats: %w", err)
 	}
 
-	// Cache for 1 minute (frequently changing data)
-	if err := s.cache.Set(ctx, cacheKey, seats, 1*time.Minute); err != nil {
+	// Kept briefly; availability changes with every purchase
+	if err := s.cache.Set(ctx, cacheKey, seats, s.cacheTTL.AvailableSeatsTTL); err != nil {
 		s.logger.Warn(ctx, "Failed to cache available seats", "error", err)
 	}
 
 	return seats, nil
 }
 
+// UpdateSeatPrices reprices the unsold seats of an event matching the filter.
+// Tickets already issued keep the price that was locked in at purchase time.
+func (s *EventService) UpdateSeatPrices(ctx context.Context, eventID uuid.UUID, filter repository.SeatPriceFilter, price int64) (int, error) {
+	s.logger.Info(ctx, "Updating seat prices", "event_id", eventID, "section", filter.Section, "price", price)
+
+	if price < 0 {
+		return 0, fmt.Errorf("price must be non-negative")
+	}
+
+	event, err := s.GetEvent(ctx, eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if !event.IsSeatedEvent {
+		return 0, fmt.Errorf("event is not a seated event")
+	}
+
+	updated, err := s.seatRepo.UpdatePrices(ctx, eventID, filter, price)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to update seat prices", "event_id", eventID, "error", err)
+		return 0, fmt.Errorf("failed to update seat prices: %w", err)
+	}
+
+	// Invalidate available seats cache
+	cacheKey := fmt.Sprintf("seats:available:%s", eventID.String())
+	if err := s.cache.Delete(ctx, cacheKey); err != nil {
+		s.logger.Warn(ctx, "Failed to invalidate available seats cache", "error", err)
+	}
+
+	s.logger.Info(ctx, "Seat prices updated successfully", "event_id", eventID, "updated", updated)
+	return updated, nil
+}
+
 // validateEvent validates an event
 func (s *EventService) validateEvent(event *domain.Event) error {
 	if event.Name == "" {
@@ -283,5 +577,50 @@ func (s *EventService) validateEvent(event *domain.Event) error {
 		return fmt.Errorf("available tickets cannot exceed total tickets")
 	}
 
+	if event.StandingPrice < 0 {
+		return fmt.Errorf("standing price must be non-negative")
+	}
+
+	if event.HasStandingZones() {
+		if event.IsSeatedEvent {
+			return fmt.Errorf("seated events cannot have standing zones")
+		}
+		if !event.StandingZonesValid() {
+			return fmt.Errorf("standing zones must have distinct names of up to %d characters and positive capacities adding up to total tickets", domain.MaxStandingZoneNameLength)
+		}
+	}
+
+	if event.MaxTicketsPerUser < 0 {
+		return fmt.Errorf("max tickets per user must be non-negative")
+	}
+
+	if event.QueueHighWaterMark < 0 || event.ActiveHighWaterMark < 0 {
+		return fmt.Errorf("queue high-water marks must be non-negative")
+	}
+
+	if event.RefundPolicy != nil && !event.RefundPolicy.IsValid() {
+		return fmt.Errorf("refund policy tiers must have non-negative, distinct notices and refund between 0 and 100 percent")
+	}
+
+	if !domain.IsValidCurrency(event.Currency) {
+		return fmt.Errorf("currency %q is not an ISO 4217 code", event.Currency)
+	}
+
+	if event.ImageURL != "" && !domain.IsValidMediaURL(event.ImageURL) {
+		return fmt.Errorf("image URL %q is not an http or https URL", event.ImageURL)
+	}
+
+	if event.ThumbnailURL != "" && !domain.IsValidMediaURL(event.ThumbnailURL) {
+		return fmt.Errorf("thumbnail URL %q is not an http or https URL", event.ThumbnailURL)
+	}
+
+	if event.SeatRanking != "" && !domain.SeatRanking(event.SeatRanking).IsValid() {
+		return fmt.Errorf("seat ranking %q is not a known ranking", event.SeatRanking)
+	}
+
+	if event.LockGranularity != "" && !domain.LockGranularity(event.LockGranularity).IsValid() {
+		return fmt.Errorf("lock granularity %q is not a known granularity", event.LockGranularity)
+	}
+
 	return nil
 }
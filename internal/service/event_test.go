@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/pkg/logger"
+)
+
+// newTestEvents returns an EventService over the repositories of tt
+func newTestEvents(tt *testTicketing) *EventService {
+	events := NewEventService(tt.events, tt.seats, testCache{}, newTestLock(), logger.NewLoggerWithLevel(zerolog.Disabled))
+	events.SetTicketRepository(tt.tickets)
+	return events
+}
+
+// newEventRequest returns an active seated event as a create request would carry it, in the given currency
+func newEventRequest(currency string) *domain.Event {
+	now := time.Now()
+	return &domain.Event{
+		Name:             "Currency Test Event",
+		StartTime:        now.Add(24 * time.Hour),
+		EndTime:          now.Add(27 * time.Hour),
+		Venue:            "Test Hall",
+		Status:           string(domain.EventStatusActive),
+		TotalTickets:     10,
+		AvailableTickets: 10,
+		IsSeatedEvent:    true,
+		Currency:         currency,
+	}
+}
+
+func TestCreateEventCurrency(t *testing.T) {
+	tests := []struct {
+		name string
+		// defaultCurrency is the server default, empty to keep DefaultEventConfig's
+		defaultCurrency string
+		currency        string
+		want            string
+		wantErr         bool
+	}{
+		{name: "built-in default", want: "USD"},
+		{name: "configured default", defaultCurrency: "eur", want: "EUR"},
+		{name: "explicit override", defaultCurrency: "EUR", currency: " jpy ", want: "JPY"},
+		{name: "invalid code", currency: "XYZ", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tt := newTestTicketing(t)
+			events := newTestEvents(tt)
+			if tc.defaultCurrency != "" {
+				config := DefaultEventConfig()
+				config.DefaultCurrency = tc.defaultCurrency
+				if err := events.SetConfig(config); err != nil {
+					t.Fatalf("set config: %v", err)
+				}
+			}
+
+			event := newEventRequest(tc.currency)
+			err := events.CreateEvent(ctx, event)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("created an event in currency %q", tc.currency)
+				}
+				if _, err := tt.events.GetByID(ctx, event.ID); err == nil {
+					t.Fatal("event with an invalid currency was stored")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("create event: %v", err)
+			}
+
+			stored, err := tt.events.GetByID(ctx, event.ID)
+			if err != nil {
+				t.Fatalf("get event: %v", err)
+			}
+			if stored.Currency != tc.want {
+				t.Fatalf("event currency = %q, want %q", stored.Currency, tc.want)
+			}
+
+			// Tickets are priced in the currency of their event
+			seat := tt.createSeat(t, stored, domain.SeatStatusAvailable)
+			userID := uuid.New()
+			sessionID := tt.activateSession(t, stored.ID, userID)
+			ticket, err := tt.service.PurchaseTicket(ctx, stored.ID, userID, &seat.ID, sessionID, PurchaseOptions{})
+			if err != nil {
+				t.Fatalf("purchase ticket: %v", err)
+			}
+			if ticket.Currency != tc.want {
+				t.Fatalf("ticket currency = %q, want the event's %q", ticket.Currency, tc.want)
+			}
+		})
+	}
+}
+
+func TestUpdateEventCurrency(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	events := newTestEvents(tt)
+
+	event := newEventRequest("")
+	if err := events.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+
+	event.Currency = "gbp"
+	if err := events.UpdateEvent(ctx, event); err != nil {
+		t.Fatalf("update currency: %v", err)
+	}
+	event.Currency = "NOPE"
+	if err := events.UpdateEvent(ctx, event); err == nil {
+		t.Fatal("updated an event to an invalid currency")
+	}
+
+	stored, err := tt.events.GetByID(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("get event: %v", err)
+	}
+	if stored.Currency != "GBP" {
+		t.Fatalf("event currency = %q, want GBP", stored.Currency)
+	}
+}
+
+func TestEventConfigRejectsInvalidDefaultCurrency(t *testing.T) {
+	events := newTestEvents(newTestTicketing(t))
+
+	config := DefaultEventConfig()
+	config.DefaultCurrency = "DOLLARS"
+	if err := events.SetConfig(config); err == nil {
+		t.Fatal("accepted a default currency that is not an ISO 4217 code")
+	}
+}
+
+func TestDeleteEventCleansUpTickets(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	events := newTestEvents(tt)
+
+	event := tt.createEvent(t, 10, 8)
+	other := tt.createEvent(t, 10, 9)
+	holder, canceller := uuid.New(), uuid.New()
+
+	_, reserved := tt.createReservation(t, event, holder, 10*time.Minute)
+	_, cancelled := tt.createReservation(t, event, canceller, 10*time.Minute)
+	if err := tt.service.CancelTicket(ctx, cancelled.ID); err != nil {
+		t.Fatalf("cancel ticket: %v", err)
+	}
+	_, kept := tt.createReservation(t, other, holder, 10*time.Minute)
+
+	if err := events.DeleteEvent(ctx, event.ID); err != nil {
+		t.Fatalf("delete event: %v", err)
+	}
+
+	for _, ticket := range []*domain.Ticket{reserved, cancelled} {
+		if _, err := tt.tickets.GetByID(ctx, ticket.ID); err == nil {
+			t.Fatalf("ticket %s of the deleted event still exists", ticket.ID)
+		}
+	}
+	held, err := tt.tickets.GetByUserID(ctx, holder)
+	if err != nil {
+		t.Fatalf("get holder tickets: %v", err)
+	}
+	if len(held) != 1 || held[0].ID != kept.ID {
+		t.Fatalf("holder lists %d tickets after the event was deleted, want only ticket %s of the other event", len(held), kept.ID)
+	}
+	if held, err := tt.tickets.GetByUserID(ctx, canceller); err != nil || len(held) != 0 {
+		t.Fatalf("canceller lists %d tickets (err %v) after the event was deleted, want none", len(held), err)
+	}
+	if seats, err := tt.seats.GetByEventID(ctx, event.ID); err != nil || len(seats) != 0 {
+		t.Fatalf("deleted event has %d seats left (err %v)", len(seats), err)
+	}
+	if _, err := tt.events.GetByID(ctx, event.ID); err == nil {
+		t.Fatal("event still exists after deletion")
+	}
+}
+
+func TestDeleteEventRefusesConfirmedTickets(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	events := newTestEvents(tt)
+
+	event := tt.createEvent(t, 10, 8)
+	userID := uuid.New()
+	_, reserved := tt.createReservation(t, event, userID, 10*time.Minute)
+	_, confirmed := tt.createReservation(t, event, userID, 10*time.Minute)
+	if err := tt.service.ConfirmTicket(ctx, confirmed.ID); err != nil {
+		t.Fatalf("confirm ticket: %v", err)
+	}
+
+	if err := events.DeleteEvent(ctx, event.ID); !errors.Is(err, ErrEventHasConfirmedTickets) {
+		t.Fatalf("expected ErrEventHasConfirmedTickets, got %v", err)
+	}
+
+	held, err := tt.tickets.GetByUserID(ctx, userID)
+	if err != nil {
+		t.Fatalf("get user tickets: %v", err)
+	}
+	if len(held) != 2 {
+		t.Fatalf("user lists %d tickets after a refused deletion, want 2", len(held))
+	}
+	if _, err := tt.tickets.GetByID(ctx, reserved.ID); err != nil {
+		t.Fatalf("reservation removed by a refused deletion: %v", err)
+	}
+	if _, err := tt.events.GetByID(ctx, event.ID); err != nil {
+		t.Fatalf("event removed by a refused deletion: %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+// DefaultExpiryInterval is how often the expiry worker looks for lapsed reservations when no interval is given
+const DefaultExpiryInterval = 30 * time.Second
+
+// expiryLockKey is held for the length of one expiry run, so only one instance processes lapsed reservations at a time
+const expiryLockKey = "reservation_expiry"
+
+// ReleaseExpiredReservations cancels every reserved ticket whose confirmation window has lapsed, releasing its seat
+// and returning its inventory. Expired reservations are coalesced per event, so each event's seats are released
+// in one ReleaseReservedSeats call and its inventory returned in one update however many reservations lapsed together.
+// Each ticket is cancelled only if it is still reserved, so one confirmed or released since the listing is left
+// alone. It reports how many reservations it released.
+func (s *TicketingService) ReleaseExpiredReservations(ctx context.Context) (int, error) {
+	expired, err := s.ticketRepo.GetExpiredReservations(ctx, s.now())
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get expired reservations", "error", err)
+		return 0, fmt.Errorf("failed to get expired reservations: %w", err)
+	}
+
+	var eventIDs []uuid.UUID
+	byEvent := make(map[uuid.UUID][]uuid.UUID)
+	for _, ticket := range expired {
+		if _, ok := byEvent[ticket.EventID]; !ok {
+			eventIDs = append(eventIDs, ticket.EventID)
+		}
+		byEvent[ticket.EventID] = append(byEvent[ticket.EventID], ticket.ID)
+	}
+
+	released := 0
+	for _, eventID := range eventIDs {
+		if err := ctx.Err(); err != nil {
+			return released, fmt.Errorf("releasing expired reservations interrupted: %w", err)
+		}
+
+		cancelled, _ := s.releaseReservations(ctx, eventID, byEvent[eventID], "reservation expiry")
+		released += len(cancelled)
+	}
+
+	if released > 0 {
+		s.logger.Info(ctx, "Released expired reservations", "released", released, "events", len(eventIDs))
+	}
+
+	return released, nil
+}
+
+// NotifyExpiringReservations warns the holders of reservations lapsing within the configured expiry notice
+// window that they are about to expire. The repository marks each reservation as it is warned, so a holder is
+// warned once however many runs, or instances, see the reservation. It does nothing without a notifier or with
+// the window disabled, and reports how many holders it warned.
+func (s *TicketingService) NotifyExpiringReservations(ctx context.Context) (int, error) {
+	if s.notifier == nil || s.config.ExpiryNoticeWindow <= 0 {
+		return 0, nil
+	}
+
+	now := s.now()
+	expiring, err := s.ticketRepo.GetReservationsExpiringBy(ctx, now.Add(s.config.ExpiryNoticeWindow))
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get expiring reservations", "error", err)
+		return 0, fmt.Errorf("failed to get expiring reservations: %w", err)
+	}
+
+	notified := 0
+	for _, ticket := range expiring {
+		// Lapsed ones are released rather than warned
+		if ticket.IsExpiredAt(now) {
+			continue
+		}
+
+		// Keep the mark until the reservation is certainly out of the window
+		first, err := s.ticketRepo.MarkExpiryNotice(ctx, ticket.ID, ticket.ExpiresAt.Sub(now)+s.config.ExpiryNoticeWindow)
+		if err != nil {
+			s.logger.Warn(ctx, "Failed to mark reservation expiry notice", "ticket_id", ticket.ID, "error", err)
+			continue
+		}
+		if !first {
+			continue
+		}
+
+		s.notifyReservationExpiring(ctx, ticket)
+		notified++
+	}
+
+	return notified, nil
+}
+
+// ExpiryWorker periodically releases reservations whose confirmation window lapsed, so seats and inventory
+// abandoned at checkout go back on sale. Runs take a distributed lock, so with several instances only one
+// processes a given run. Run it under a worker manager, or on its own with Start and Stop.
+type ExpiryWorker struct {
+	service  *TicketingService
+	interval time.Duration
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewExpiryWorker creates a new ExpiryWorker that runs every interval, or every DefaultExpiryInterval when
+// interval is not positive
+func NewExpiryWorker(service *TicketingService, interval time.Duration) *ExpiryWorker {
+	if interval <= 0 {
+		interval = DefaultExpiryInterval
+	}
+
+	return &ExpiryWorker{
+		service:  service,
+		interval: interval,
+	}
+}
+
+// Compile-time check to ensure ExpiryWorker implements adapter.Worker
+var _ adapter.Worker = (*ExpiryWorker)(nil)
+
+// Name returns the worker's name
+func (w *ExpiryWorker) Name() string {
+	return "reservation-expiry"
+}
+
+// Run releases expired reservations every interval until ctx is cancelled. A run in progress when ctx is
+// cancelled finishes first, detached from the cancellation.
+func (w *ExpiryWorker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		// A tick may be waiting alongside the cancellation; don't start another run
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if _, err := w.RunOnce(context.WithoutCancel(ctx)); err != nil {
+			w.service.logger.Warn(ctx, "Reservation expiry run failed", "error", err)
+		}
+	}
+}
+
+// RunOnce performs a single expiry run under the distributed lock and reports how many reservations it released.
+// The run also reclaims seats orphaned by reservations that never got a ticket and warns holders whose
+// reservations are about to expire. It releases nothing when another instance holds the lock.
+func (w *ExpiryWorker) RunOnce(ctx context.Context) (int, error) {
+	token, acquired, err := w.service.lock.Acquire(ctx, expiryLockKey, w.interval)
+	if err != nil {
+		return 0, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	if !acquired {
+		w.service.logger.Debug(ctx, "Reservation expiry run skipped; another instance holds the lock")
+		return 0, nil
+	}
+	defer func() {
+		if err := w.service.lock.Release(ctx, expiryLockKey, token); err != nil {
+			w.service.logger.Warn(ctx, "Failed to release lock", "lock_key", expiryLockKey, "error", err)
+		}
+	}()
+
+	released, err := w.service.ReleaseExpiredReservations(ctx)
+	if err != nil {
+		return released, err
+	}
+
+	if reclaimed, err := w.service.ReclaimOrphanedSeats(ctx); err != nil {
+		w.service.logger.Warn(ctx, "Orphaned seat reclaim failed", "error", err)
+	} else if reclaimed > 0 {
+		w.service.logger.Info(ctx, "Reclaimed orphaned seats", "seats", reclaimed)
+	}
+
+	if notified, err := w.service.NotifyExpiringReservations(ctx); err != nil {
+		w.service.logger.Warn(ctx, "Reservation expiry notices failed", "error", err)
+	} else if notified > 0 {
+		w.service.logger.Info(ctx, "Warned holders of expiring reservations", "reservations", notified)
+	}
+
+	return released, nil
+}
+
+// Start runs the worker in the background until Stop is called or ctx is cancelled; starting a running worker
+// does nothing
+func (w *ExpiryWorker) Start(ctx context.Context) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cancel != nil {
+		return
+	}
+
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.done = make(chan struct{})
+
+	go func(done chan struct{}) {
+		defer close(done)
+		w.Run(ctx)
+	}(w.done)
+}
+
+// Stop stops a worker started with Start and waits for its run in progress to finish
+func (w *ExpiryWorker) Stop() {
+	w.mu.Lock()
+	cancel, done := w.cancel, w.done
+	w.cancel, w.done = nil, nil
+	w.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	<-done
+}
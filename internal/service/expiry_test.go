@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+	"github.com/snowmerak/ticketing/pkg/clock"
+	"github.com/snowmerak/ticketing/pkg/logger"
+	"github.com/snowmerak/ticketing/pkg/repository/memory"
+)
+
+func TestReleaseExpiredReservationsRacingCancel(t *testing.T) {
+	ctx := context.Background()
+
+	for i := 0; i < 20; i++ {
+		tt := newTestTicketing(t)
+		event := tt.createEvent(t, 10, 9)
+		seat, ticket := tt.createReservation(t, event, uuid.New(), -time.Minute)
+
+		var wg sync.WaitGroup
+		for j := 0; j < 10; j++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, _ = tt.service.ReleaseExpiredReservations(ctx)
+			}()
+			go func() {
+				defer wg.Done()
+				_ = tt.service.CancelTicket(ctx, ticket.ID)
+			}()
+		}
+		wg.Wait()
+
+		if got := tt.availableTickets(t, event.ID); got != 10 {
+			t.Fatalf("available tickets = %d, want 10: the released reservation was returned more than once", got)
+		}
+		stored, err := tt.seats.GetByID(ctx, seat.ID)
+		if err != nil {
+			t.Fatalf("get seat: %v", err)
+		}
+		if stored.Status != string(domain.SeatStatusAvailable) {
+			t.Fatalf("seat status = %s, want %s", stored.Status, domain.SeatStatusAvailable)
+		}
+		cancelled, err := tt.tickets.GetByID(ctx, ticket.ID)
+		if err != nil {
+			t.Fatalf("get ticket: %v", err)
+		}
+		if !cancelled.IsCancelled() {
+			t.Fatalf("ticket status = %s, want cancelled", cancelled.Status)
+		}
+	}
+}
+
+func TestReleaseExpiredReservationsSkipsConfirmed(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	event := tt.createEvent(t, 10, 9)
+	_, ticket := tt.createReservation(t, event, uuid.New(), -time.Minute)
+
+	if err := tt.tickets.UpdateStatus(ctx, ticket.ID, string(domain.TicketStatusConfirmed)); err != nil {
+		t.Fatalf("confirm ticket: %v", err)
+	}
+
+	released, err := tt.service.ReleaseExpiredReservations(ctx)
+	if err != nil {
+		t.Fatalf("release expired reservations: %v", err)
+	}
+	if released != 0 {
+		t.Fatalf("released = %d, want 0", released)
+	}
+	if got := tt.availableTickets(t, event.ID); got != 9 {
+		t.Fatalf("available tickets = %d, want 9", got)
+	}
+}
+
+func TestReleaseExpiredReservationsWithSeatAlreadyReleased(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	deadLetters := memory.NewDeadLetterRepository()
+	tt.service.SetDeadLetterRepository(deadLetters)
+
+	event := tt.createEvent(t, 10, 7)
+	freedSeat, _ := tt.createReservation(t, event, uuid.New(), -time.Minute)
+	seat, _ := tt.createReservation(t, event, uuid.New(), -time.Minute)
+	otherSeat, _ := tt.createReservation(t, event, uuid.New(), -time.Minute)
+
+	// The orphaned-seat sweep got to one seat of the batch first
+	if err := tt.seats.ReleaseSeats(ctx, []uuid.UUID{freedSeat.ID}); err != nil {
+		t.Fatalf("release seat: %v", err)
+	}
+
+	released, err := tt.service.ReleaseExpiredReservations(ctx)
+	if err != nil {
+		t.Fatalf("release expired reservations: %v", err)
+	}
+	if released != 3 {
+		t.Fatalf("released = %d, want 3", released)
+	}
+	if got := tt.availableTickets(t, event.ID); got != 10 {
+		t.Fatalf("available tickets = %d, want 10", got)
+	}
+	for _, seatID := range []uuid.UUID{freedSeat.ID, seat.ID, otherSeat.ID} {
+		stored, err := tt.seats.GetByID(ctx, seatID)
+		if err != nil {
+			t.Fatalf("get seat: %v", err)
+		}
+		if stored.Status != string(domain.SeatStatusAvailable) {
+			t.Fatalf("seat %s status = %s, want %s", seatID, stored.Status, domain.SeatStatusAvailable)
+		}
+	}
+
+	_, failed, err := deadLetters.List(ctx, 0, 10)
+	if err != nil {
+		t.Fatalf("list failed actions: %v", err)
+	}
+	if failed != 0 {
+		t.Fatalf("%d failed actions recorded, want none", failed)
+	}
+}
+
+func TestExpiryWorkerNotifiesExpiringAndReleasedReservations(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	notifier := &testNotifier{}
+	tt.service.SetNotifier(notifier)
+
+	event := tt.createEvent(t, 10, 7)
+	expiringUser, releasedUser, laterUser := uuid.New(), uuid.New(), uuid.New()
+	_, expiring := tt.createReservation(t, event, expiringUser, time.Minute)
+	_, released := tt.createReservation(t, event, releasedUser, -time.Minute)
+	tt.createReservation(t, event, laterUser, time.Hour)
+
+	worker := NewExpiryWorker(tt.service, time.Minute)
+	for run := 0; run < 3; run++ {
+		if _, err := worker.RunOnce(ctx); err != nil {
+			t.Fatalf("expiry run %d: %v", run, err)
+		}
+	}
+
+	if emails := notifier.sent(expiringUser); len(emails) != 1 || !strings.Contains(emails[0].body, expiring.ID.String()) || !strings.Contains(emails[0].subject, "about to expire") {
+		t.Fatalf("expiring reservation holder got %+v, want one pre-expiry notice", emails)
+	}
+	if emails := notifier.sent(releasedUser); len(emails) != 1 || !strings.Contains(emails[0].body, released.ID.String()) || !strings.Contains(emails[0].subject, "released") {
+		t.Fatalf("lapsed reservation holder got %+v, want one release notice", emails)
+	}
+	if emails := notifier.sent(laterUser); len(emails) != 0 {
+		t.Fatalf("holder of a reservation outside the notice window got %+v", emails)
+	}
+}
+
+func TestNotifyExpiringReservationsDisabled(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	notifier := &testNotifier{}
+	tt.service.SetNotifier(notifier)
+
+	config := DefaultTicketingConfig()
+	config.ExpiryNoticeWindow = 0
+	if err := tt.service.SetConfig(config); err != nil {
+		t.Fatalf("set config: %v", err)
+	}
+
+	event := tt.createEvent(t, 10, 9)
+	userID := uuid.New()
+	tt.createReservation(t, event, userID, time.Minute)
+
+	notified, err := tt.service.NotifyExpiringReservations(ctx)
+	if err != nil {
+		t.Fatalf("notify expiring reservations: %v", err)
+	}
+	if notified != 0 || len(notifier.sent(userID)) != 0 {
+		t.Fatalf("notified %d with the window disabled, want 0", notified)
+	}
+}
+
+func TestReleaseExpiredReservationsFollowsClock(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	manual := clock.NewManual(time.Now())
+	tt.service.SetClock(manual)
+
+	event := tt.createEvent(t, 10, 9)
+	_, ticket := tt.createReservation(t, event, uuid.New(), 15*time.Minute)
+
+	released, err := tt.service.ReleaseExpiredReservations(ctx)
+	if err != nil {
+		t.Fatalf("release expired reservations: %v", err)
+	}
+	if released != 0 {
+		t.Fatalf("released = %d before the clock passed the expiry, want 0", released)
+	}
+
+	manual.Advance(16 * time.Minute)
+	released, err = tt.service.ReleaseExpiredReservations(ctx)
+	if err != nil {
+		t.Fatalf("release expired reservations: %v", err)
+	}
+	if released != 1 {
+		t.Fatalf("released = %d after the clock passed the expiry, want 1", released)
+	}
+	stored, err := tt.tickets.GetByID(ctx, ticket.ID)
+	if err != nil {
+		t.Fatalf("get ticket: %v", err)
+	}
+	if !stored.IsCancelled() {
+		t.Fatalf("ticket status = %s, want cancelled", stored.Status)
+	}
+}
+
+// sweptTicketRepository runs the expiry sweep just before a confirmation is written, so a confirmation that
+// passed its expiry check races a sweep that already sees the reservation as lapsed
+type sweptTicketRepository struct {
+	*memory.TicketRepository
+	sweep func()
+}
+
+func (r *sweptTicketRepository) ConfirmReservation(ctx context.Context, ticketID uuid.UUID, accessToken string, at time.Time) (*domain.Ticket, error) {
+	r.sweep()
+	return r.TicketRepository.ConfirmReservation(ctx, ticketID, accessToken, at)
+}
+
+func TestConfirmTicketLosingToExpirySweep(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	manual := clock.NewManual(time.Now())
+
+	swept := 0
+	tickets := &sweptTicketRepository{TicketRepository: tt.tickets}
+	tt.service = NewTicketingService(tickets, tt.events, tt.seats, tt.queue, testCache{}, newTestLock(), logger.NewLoggerWithLevel(zerolog.Disabled))
+	tt.service.SetClock(manual)
+	tickets.sweep = func() {
+		manual.Advance(2 * time.Minute)
+		released, err := tt.service.ReleaseExpiredReservations(ctx)
+		if err != nil {
+			t.Fatalf("release expired reservations: %v", err)
+		}
+		swept += released
+	}
+
+	event := tt.createEvent(t, 10, 9)
+	seat, ticket := tt.createReservation(t, event, uuid.New(), time.Minute)
+
+	if err := tt.service.ConfirmTicket(ctx, ticket.ID); !errors.Is(err, repository.ErrTicketNotReserved) {
+		t.Fatalf("expected ErrTicketNotReserved confirming a reservation the sweep released, got %v", err)
+	}
+	if swept != 1 {
+		t.Fatalf("sweep released %d reservations, want 1", swept)
+	}
+
+	stored, err := tt.tickets.GetByID(ctx, ticket.ID)
+	if err != nil {
+		t.Fatalf("get ticket: %v", err)
+	}
+	if !stored.IsCancelled() || stored.AccessToken != "" {
+		t.Fatalf("ticket status = %s with access token %q, want a cancelled ticket without one", stored.Status, stored.AccessToken)
+	}
+	storedSeat, err := tt.seats.GetByID(ctx, seat.ID)
+	if err != nil {
+		t.Fatalf("get seat: %v", err)
+	}
+	if storedSeat.Status != string(domain.SeatStatusAvailable) {
+		t.Fatalf("seat status = %s, want %s", storedSeat.Status, domain.SeatStatusAvailable)
+	}
+	if got := tt.availableTickets(t, event.ID); got != 10 {
+		t.Fatalf("available tickets = %d, want 10", got)
+	}
+}
+
+func TestReleaseExpiredReservationsAfterConfirmation(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	manual := clock.NewManual(time.Now())
+	tt.service.SetClock(manual)
+
+	event := tt.createEvent(t, 10, 9)
+	seat, ticket := tt.createReservation(t, event, uuid.New(), time.Minute)
+
+	if err := tt.service.ConfirmTicket(ctx, ticket.ID); err != nil {
+		t.Fatalf("confirm ticket: %v", err)
+	}
+
+	manual.Advance(2 * time.Minute)
+	released, err := tt.service.ReleaseExpiredReservations(ctx)
+	if err != nil {
+		t.Fatalf("release expired reservations: %v", err)
+	}
+	if released != 0 {
+		t.Fatalf("released = %d after the reservation was confirmed, want 0", released)
+	}
+
+	storedSeat, err := tt.seats.GetByID(ctx, seat.ID)
+	if err != nil {
+		t.Fatalf("get seat: %v", err)
+	}
+	if storedSeat.Status != string(domain.SeatStatusSold) {
+		t.Fatalf("seat status = %s, want %s", storedSeat.Status, domain.SeatStatusSold)
+	}
+	if got := tt.availableTickets(t, event.ID); got != 9 {
+		t.Fatalf("available tickets = %d, want 9", got)
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestConcurrentStandingPurchasesNumberTicketsInOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		buyers    int
+		available int
+		events    int
+	}{
+		{name: "one event", buyers: 40, available: 40, events: 1},
+		{name: "more buyers than tickets", buyers: 40, available: 25, events: 1},
+		{name: "events numbered separately", buyers: 20, available: 20, events: 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tt := newTestTicketing(t)
+
+			type buyer struct {
+				eventID   uuid.UUID
+				userID    uuid.UUID
+				sessionID string
+			}
+			var buyers []buyer
+			for range tc.events {
+				event := tt.createEvent(t, tc.available, tc.available)
+				event.IsSeatedEvent = false
+				event.NumberedStanding = true
+				event.StandingPrice = 5000
+				if err := tt.events.Update(ctx, event); err != nil {
+					t.Fatalf("make event numbered standing: %v", err)
+				}
+				for range tc.buyers {
+					userID := uuid.New()
+					buyers = append(buyers, buyer{eventID: event.ID, userID: userID, sessionID: tt.activateSession(t, event.ID, userID)})
+				}
+			}
+
+			var (
+				mu      sync.Mutex
+				numbers = make(map[uuid.UUID][]int64)
+				wg      sync.WaitGroup
+			)
+			for _, b := range buyers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					ticket, err := tt.service.PurchaseTicket(ctx, b.eventID, b.userID, nil, b.sessionID, PurchaseOptions{})
+					if err != nil {
+						return
+					}
+					if ticket.GANumber == nil {
+						t.Errorf("ticket %s has no GA number", ticket.ID)
+						return
+					}
+
+					mu.Lock()
+					defer mu.Unlock()
+					numbers[b.eventID] = append(numbers[b.eventID], *ticket.GANumber)
+				}()
+			}
+			wg.Wait()
+
+			want := min(tc.buyers, tc.available)
+			for eventID, issued := range numbers {
+				if len(issued) != want {
+					t.Errorf("event %s issued %d tickets, want %d", eventID, len(issued), want)
+				}
+
+				seen := make(map[int64]bool, len(issued))
+				for _, number := range issued {
+					if seen[number] {
+						t.Errorf("event %s issued GA number %d twice", eventID, number)
+					}
+					seen[number] = true
+				}
+				for number := int64(1); number <= int64(len(issued)); number++ {
+					if !seen[number] {
+						t.Errorf("event %s skipped GA number %d", eventID, number)
+					}
+				}
+
+				if got := tt.availableTickets(t, eventID); got != tc.available-len(issued) {
+					t.Errorf("event %s has %d tickets available, want %d", eventID, got, tc.available-len(issued))
+				}
+			}
+			if len(numbers) != tc.events {
+				t.Errorf("%d events issued tickets, want %d", len(numbers), tc.events)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// ErrSeatLimitExceeded is returned when a single purchase asks for more seats than the venue allows per transaction
+var ErrSeatLimitExceeded = errors.New("too many seats in one purchase")
+
+// PurchaseSeats reserves several seats of a seated event for one user in a single transaction.
+// Either every seat is reserved and ticketed or none is. The number of seats is capped by
+// TicketingConfig.MaxSeatsPerTransaction; a request over the cap is rejected before anything is locked
+// and is not charged to the session's purchase budget.
+func (s *TicketingService) PurchaseSeats(ctx context.Context, eventID, userID uuid.UUID, seatIDs []uuid.UUID, sessionID string, opts PurchaseOptions) ([]*domain.Ticket, error) {
+	seatIDs = uniqueSeatIDs(seatIDs)
+	if len(seatIDs) == 0 {
+		return nil, fmt.Errorf("at least one seat ID is required")
+	}
+
+	if limit := s.config.MaxSeatsPerTransaction; len(seatIDs) > limit {
+		s.logger.Warn(ctx, "Purchase exceeds seat limit", "event_id", eventID, "user_id", userID, "seats", len(seatIDs), "limit", limit)
+		return nil, fmt.Errorf("%w: %d seats requested, at most %d allowed", ErrSeatLimitExceeded, len(seatIDs), limit)
+	}
+
+	// Reservations kept from an earlier queue session are returned rather than reserving more seats
+	if carried := s.carriedReservations(ctx, eventID, userID, sessionID); len(carried) > 0 {
+		return carriedForSeats(carried, seatIDs)
+	}
+
+	if err := s.checkPurchaseBudget(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	tickets, err := s.purchaseSeats(ctx, eventID, userID, seatIDs, sessionID, opts)
+	if err := s.recordPurchaseOutcome(ctx, sessionID, err); err != nil {
+		return nil, err
+	}
+
+	s.recordPurchaseSignal(ctx, eventID, userID, sessionID, opts, tickets)
+	s.publishReserved(ctx, tickets)
+
+	return tickets, nil
+}
+
+// purchaseSeats runs a single multi-seat purchase attempt
+func (s *TicketingService) purchaseSeats(ctx context.Context, eventID, userID uuid.UUID, seatIDs []uuid.UUID, sessionID string, opts PurchaseOptions) ([]*domain.Ticket, error) {
+	s.logger.Info(ctx, "Starting multi-seat purchase",
+		"event_id", eventID,
+		"user_id", userID,
+		"seats", len(seatIDs),
+		"session_id", sessionID)
+
+	event, err := s.authorizePurchase(ctx, eventID, userID, sessionID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if !event.IsSeatedEvent {
+		return nil, fmt.Errorf("multi-seat purchases are only available for seated events")
+	}
+
+	guestEmail, err := s.authorizeGuest(event, userID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := s.acquirePurchaseSlot(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	unlock, err := s.lockSeats(ctx, event, seatIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	claim, err := s.claimUserTickets(ctx, event, userID, len(seatIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer claim.release(ctx)
+
+	seats := make([]*domain.Seat, 0, len(seatIDs))
+	for _, seatID := range seatIDs {
+		seat, err := s.seatRepo.GetByID(ctx, seatID)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get seat", "seat_id", seatID, "error", err)
+			return nil, fmt.Errorf("failed to get seat %s: %w", seatID, err)
+		}
+
+		if seat.EventID != eventID {
+			return nil, fmt.Errorf("seat %s does not belong to this event", seatID)
+		}
+
+		// Accessible seats and their companions are booked in pairs through PurchaseTicket only
+		if seat.IsRestricted() {
+			return nil, ErrAccessibleSeatRestricted
+		}
+
+		if !seat.CanBeReserved() {
+			s.logger.Warn(ctx, "Seat not available", "seat_id", seatID, "status", seat.Status)
+			return nil, fmt.Errorf("seat %s is not available", seatID)
+		}
+
+		seats = append(seats, seat)
+	}
+
+	// Reserve every seat in one all-or-nothing call
+	if err := s.seatRepo.ReserveSeats(ctx, seatIDs); err != nil {
+		s.logger.Error(ctx, "Failed to reserve seats", "seat_ids", seatIDs, "error", err)
+		return nil, fmt.Errorf("failed to reserve seats: %w", err)
+	}
+
+	tickets, err := s.ticketReservedSeats(ctx, event, userID, guestEmail, seats)
+	if err != nil {
+		return nil, err
+	}
+	claim.keep(ctx, len(tickets))
+
+	s.logger.Info(ctx, "Seats purchased successfully", "event_id", eventID, "user_id", userID, "tickets", len(tickets))
+	s.recordRate(ctx, purchaseCounterKey(eventID))
+
+	return tickets, nil
+}
+
+// ticketReservedSeats creates a reservation ticket for each of the seats a multi-seat purchase reserved and
+// takes them off the event's inventory. If a ticket can't be created the whole group is undone.
+func (s *TicketingService) ticketReservedSeats(ctx context.Context, event *domain.Event, userID uuid.UUID, guestEmail string, seats []*domain.Seat) ([]*domain.Ticket, error) {
+	tickets := make([]*domain.Ticket, 0, len(seats))
+	for i, seat := range seats {
+		ticket := s.newSeatReservation(event, userID, guestEmail, seat)
+		if err := s.ticketRepo.Create(ctx, ticket); err != nil {
+			s.logger.Error(ctx, "Failed to create ticket", "seat_id", seat.ID, "error", err)
+
+			// Undo the tickets already created; the seats are only sold together. Each is cancelled only while it
+			// is still reserved, and a ticket confirmed or released by someone else meanwhile keeps its seat
+			release := make([]uuid.UUID, 0, len(seats))
+			for j, created := range tickets {
+				if _, cancelErr := s.ticketRepo.CancelReservation(ctx, created.ID); cancelErr != nil {
+					s.logger.Error(ctx, "Failed to cancel ticket after multi-seat failure", "ticket_id", created.ID, "error", cancelErr)
+					if errors.Is(cancelErr, repository.ErrTicketNotReserved) {
+						continue
+					}
+				}
+				release = append(release, seats[j].ID)
+			}
+
+			// The failed seat is released too unless another ticket still holds it, as in purchaseSeatedTicket
+			if !errors.Is(err, repository.ErrSeatAlreadyTicketed) {
+				release = append(release, seat.ID)
+			}
+			release = append(release, seatIDsOf(seats[i+1:])...)
+			s.releaseSeatsAfterFailure(ctx, event.ID, release, "release seats after multi-seat ticket failure")
+
+			return nil, fmt.Errorf("failed to create ticket: %w", err)
+		}
+		tickets = append(tickets, ticket)
+	}
+
+	if err := s.decrementAvailableTickets(ctx, event.ID, len(tickets)); err != nil {
+		s.logger.Error(ctx, "Failed to decrement available tickets", "error", err)
+		// The tickets stand; replay the decrement later so the counter catches up
+		s.recordFailedAction(ctx, &domain.FailedAction{
+			Kind:     string(domain.FailedActionDecrementAvailable),
+			EventID:  event.ID,
+			Quantity: len(tickets),
+			Reason:   "decrement available tickets after multi-seat reservation",
+		}, err)
+	}
+
+	return tickets, nil
+}
+
+// seatIDsOf returns the IDs of seats
+func seatIDsOf(seats []*domain.Seat) []uuid.UUID {
+	seatIDs := make([]uuid.UUID, len(seats))
+	for i, seat := range seats {
+		seatIDs[i] = seat.ID
+	}
+	return seatIDs
+}
+
+// uniqueSeatIDs drops repeated seat IDs, keeping the first occurrence of each
+func uniqueSeatIDs(seatIDs []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{}, len(seatIDs))
+	unique := make([]uuid.UUID, 0, len(seatIDs))
+	for _, seatID := range seatIDs {
+		if _, ok := seen[seatID]; ok {
+			continue
+		}
+		seen[seatID] = struct{}{}
+		unique = append(unique, seatID)
+	}
+	return unique
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/pkg/logger"
+	"github.com/snowmerak/ticketing/pkg/repository/memory"
+)
+
+// racingSeatRepository sells a seat to someone else just before reserving, as a purchase that won the seat
+// between the availability check and the reservation would
+type racingSeatRepository struct {
+	*memory.SeatRepository
+	sell uuid.UUID
+}
+
+func (r *racingSeatRepository) ReserveSeats(ctx context.Context, seatIDs []uuid.UUID) error {
+	if err := r.SeatRepository.UpdateStatus(ctx, r.sell, string(domain.SeatStatusSold)); err != nil {
+		return err
+	}
+	return r.SeatRepository.ReserveSeats(ctx, seatIDs)
+}
+
+func TestPurchaseSeatsRollsBackWhenOneSeatIsSold(t *testing.T) {
+	tests := []struct {
+		name string
+		// race sells the third seat between the availability check and the reservation rather than before the
+		// purchase
+		race bool
+	}{
+		{name: "sold before the purchase"},
+		{name: "sold during the purchase", race: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tt := newTestTicketing(t)
+			event := tt.createEvent(t, 4, 4)
+
+			seats := make([]*domain.Seat, 4)
+			for i := range seats {
+				seats[i] = tt.createSeat(t, event, domain.SeatStatusAvailable)
+			}
+			if tc.race {
+				racing := &racingSeatRepository{SeatRepository: tt.seats, sell: seats[2].ID}
+				tt.service = NewTicketingService(tt.tickets, tt.events, racing, tt.queue, testCache{}, newTestLock(), logger.NewLoggerWithLevel(zerolog.Disabled))
+			} else if err := tt.seats.UpdateStatus(ctx, seats[2].ID, string(domain.SeatStatusSold)); err != nil {
+				t.Fatalf("sell third seat: %v", err)
+			}
+
+			userID := uuid.New()
+			sessionID := tt.activateSession(t, event.ID, userID)
+
+			tickets, err := tt.service.PurchaseSeats(ctx, event.ID, userID, seatIDsOf(seats), sessionID, PurchaseOptions{})
+			if err == nil {
+				t.Fatalf("purchase of four seats with the third sold succeeded with %d tickets", len(tickets))
+			}
+
+			for i, seat := range seats {
+				got, err := tt.seats.GetByID(ctx, seat.ID)
+				if err != nil {
+					t.Fatalf("get seat %d: %v", i+1, err)
+				}
+				want := domain.SeatStatusAvailable
+				if i == 2 {
+					want = domain.SeatStatusSold
+				}
+				if got.Status != string(want) {
+					t.Errorf("seat %d status = %q, want %q", i+1, got.Status, want)
+				}
+			}
+
+			held, err := tt.tickets.GetByUserAndEvent(ctx, userID, event.ID)
+			if err != nil {
+				t.Fatalf("get user tickets: %v", err)
+			}
+			if len(held) != 0 {
+				t.Errorf("user holds %d tickets after the failed purchase, want none", len(held))
+			}
+			if got := tt.availableTickets(t, event.ID); got != 4 {
+				t.Errorf("available tickets = %d, want 4", got)
+			}
+
+			// Nothing of the failed attempt is left holding the other seats or the user's ticket allowance
+			rest := []uuid.UUID{seats[0].ID, seats[1].ID, seats[3].ID}
+			tickets, err = tt.service.PurchaseSeats(ctx, event.ID, userID, rest, sessionID, PurchaseOptions{})
+			if err != nil {
+				t.Fatalf("purchase of the remaining seats: %v", err)
+			}
+			if len(tickets) != len(rest) {
+				t.Errorf("purchase of the remaining seats returned %d tickets, want %d", len(tickets), len(rest))
+			}
+		})
+	}
+}
+
+// failingTicketRepository fails the failOn-th ticket it is asked to create with err, running beforeFail first
+// when it is set
+type failingTicketRepository struct {
+	*memory.TicketRepository
+	failOn     int
+	err        error
+	beforeFail func()
+	created    int
+}
+
+func (r *failingTicketRepository) Create(ctx context.Context, ticket *domain.Ticket) error {
+	r.created++
+	if r.created == r.failOn {
+		if r.beforeFail != nil {
+			r.beforeFail()
+		}
+		return r.err
+	}
+	return r.TicketRepository.Create(ctx, ticket)
+}
+
+func TestPurchaseSeatsReleasesEverySeatWhenTicketCreationFails(t *testing.T) {
+	tests := []struct {
+		name   string
+		failOn int
+	}{
+		{name: "first ticket fails", failOn: 1},
+		{name: "middle ticket fails", failOn: 2},
+		{name: "last ticket fails", failOn: 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tt := newTestTicketing(t)
+			tickets := &failingTicketRepository{TicketRepository: tt.tickets, failOn: tc.failOn, err: errors.New("ticket store unavailable")}
+			tt.service = NewTicketingService(tickets, tt.events, tt.seats, tt.queue, testCache{}, newTestLock(), logger.NewLoggerWithLevel(zerolog.Disabled))
+
+			event := tt.createEvent(t, 3, 3)
+			seats := make([]*domain.Seat, 3)
+			for i := range seats {
+				seats[i] = tt.createSeat(t, event, domain.SeatStatusAvailable)
+			}
+			userID := uuid.New()
+			sessionID := tt.activateSession(t, event.ID, userID)
+
+			if _, err := tt.service.PurchaseSeats(ctx, event.ID, userID, seatIDsOf(seats), sessionID, PurchaseOptions{}); err == nil {
+				t.Fatal("purchase succeeded although a ticket could not be created")
+			}
+
+			for i, seat := range seats {
+				got, err := tt.seats.GetByID(ctx, seat.ID)
+				if err != nil {
+					t.Fatalf("get seat %d: %v", i+1, err)
+				}
+				if got.Status != string(domain.SeatStatusAvailable) {
+					t.Errorf("seat %d status = %q, want %q", i+1, got.Status, domain.SeatStatusAvailable)
+				}
+			}
+			if got := tt.availableTickets(t, event.ID); got != 3 {
+				t.Errorf("available tickets = %d, want 3", got)
+			}
+		})
+	}
+}
+
+func TestPurchaseSeatsRollbackKeepsTicketConfirmedMeanwhile(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	tickets := &failingTicketRepository{TicketRepository: tt.tickets, failOn: 3, err: errors.New("ticket store unavailable")}
+	tt.service = NewTicketingService(tickets, tt.events, tt.seats, tt.queue, testCache{}, newTestLock(), logger.NewLoggerWithLevel(zerolog.Disabled))
+
+	event := tt.createEvent(t, 3, 3)
+	seats := make([]*domain.Seat, 3)
+	for i := range seats {
+		seats[i] = tt.createSeat(t, event, domain.SeatStatusAvailable)
+	}
+
+	// The first ticket is confirmed between its creation and the failure, so the rollback must not take it back
+	var confirmed uuid.UUID
+	tickets.beforeFail = func() {
+		first, err := tt.tickets.GetBySeatID(ctx, seats[0].ID)
+		if err != nil {
+			t.Fatalf("get first ticket: %v", err)
+		}
+		if _, err := tt.tickets.ConfirmReservation(ctx, first.ID, "token", time.Now()); err != nil {
+			t.Fatalf("confirm first ticket: %v", err)
+		}
+		confirmed = first.ID
+	}
+
+	userID := uuid.New()
+	sessionID := tt.activateSession(t, event.ID, userID)
+	if _, err := tt.service.PurchaseSeats(ctx, event.ID, userID, seatIDsOf(seats), sessionID, PurchaseOptions{}); err == nil {
+		t.Fatal("purchase succeeded although a ticket could not be created")
+	}
+
+	first, err := tt.tickets.GetByID(ctx, confirmed)
+	if err != nil {
+		t.Fatalf("get first ticket: %v", err)
+	}
+	if !first.IsConfirmed() {
+		t.Errorf("confirmed ticket status = %s after the rollback, want confirmed", first.Status)
+	}
+
+	held, err := tt.tickets.GetByUserAndEvent(ctx, userID, event.ID)
+	if err != nil {
+		t.Fatalf("get user tickets: %v", err)
+	}
+	for _, ticket := range held {
+		if ticket.ID != confirmed && !ticket.IsCancelled() {
+			t.Errorf("ticket %s status = %s after the rollback, want cancelled", ticket.ID, ticket.Status)
+		}
+	}
+
+	for i, seat := range seats {
+		got, err := tt.seats.GetByID(ctx, seat.ID)
+		if err != nil {
+			t.Fatalf("get seat %d: %v", i+1, err)
+		}
+		want := domain.SeatStatusAvailable
+		if i == 0 {
+			want = domain.SeatStatusReserved
+		}
+		if got.Status != string(want) {
+			t.Errorf("seat %d status = %q, want %q", i+1, got.Status, want)
+		}
+	}
+}
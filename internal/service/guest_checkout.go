@@ -0,0 +1,146 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+var (
+	// ErrInvalidGuestToken is returned when a guest purchase presents a forged, mismatched or expired guest token
+	ErrInvalidGuestToken = errors.New("invalid or expired guest token")
+
+	// ErrGuestCheckoutDisabled is returned when a guest tries to buy for an event that requires an account
+	ErrGuestCheckoutDisabled = errors.New("guest checkout is not available for this event")
+)
+
+// GuestClaims is what a guest token vouches for: the holder verified this email and may buy as its guest
+// until ExpiresAt
+type GuestClaims struct {
+	Email     string `json:"email"`
+	ExpiresAt int64  `json:"exp"` // Unix seconds
+}
+
+// UserID returns the pseudo user ID the guest buys under
+func (c *GuestClaims) UserID() uuid.UUID {
+	return domain.GuestUserID(c.Email)
+}
+
+// GuestTokens signs and verifies guest tokens with HMAC-SHA256. Whatever verifies a buyer's email, such as
+// a link sent to it, issues the token; purchases then present it in place of an account.
+// A token is base64url(claims JSON) "." base64url(signature).
+type GuestTokens struct {
+	secret []byte
+}
+
+// NewGuestTokens creates a GuestTokens with a shared secret of at least 32 bytes
+func NewGuestTokens(secret []byte) (*GuestTokens, error) {
+	if len(secret) < 32 {
+		return nil, fmt.Errorf("guest token secret must be at least 32 bytes")
+	}
+
+	return &GuestTokens{secret: secret}, nil
+}
+
+// Issue signs a token for a verified email, valid for ttl
+func (g *GuestTokens) Issue(email string, ttl time.Duration) (string, error) {
+	email = domain.NormalizeGuestEmail(email)
+	if _, err := mail.ParseAddress(email); err != nil {
+		return "", fmt.Errorf("invalid guest email %q: %w", email, err)
+	}
+
+	payload, err := json.Marshal(GuestClaims{
+		Email:     email,
+		ExpiresAt: time.Now().Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal guest claims: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(g.sign(encoded)), nil
+}
+
+// Verify checks a token's signature and expiry and returns its claims
+func (g *GuestTokens) Verify(token string, now time.Time) (*GuestClaims, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidGuestToken
+	}
+
+	given, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(given, g.sign(encoded)) {
+		return nil, ErrInvalidGuestToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidGuestToken
+	}
+
+	var claims GuestClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Email == "" {
+		return nil, ErrInvalidGuestToken
+	}
+
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, ErrInvalidGuestToken
+	}
+
+	return &claims, nil
+}
+
+// sign computes the HMAC of the encoded claims
+func (g *GuestTokens) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, g.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// SetGuestTokens enables guest checkout: purchases presenting a guest token signed with the same secret buy
+// as the guest, for events that allow it
+func (s *TicketingService) SetGuestTokens(tokens *GuestTokens) {
+	s.guestTokens = tokens
+}
+
+// ResolveGuest verifies a guest token and returns its claims; the guest joins queues and purchases under
+// the claims' UserID
+func (s *TicketingService) ResolveGuest(token string) (*GuestClaims, error) {
+	if s.guestTokens == nil {
+		return nil, ErrGuestCheckoutDisabled
+	}
+
+	return s.guestTokens.Verify(token, s.now())
+}
+
+// authorizeGuest checks a guest purchase and returns the guest's email, or an empty email for an account
+// purchase. The token must be valid, the user must be the guest's pseudo user and the event must allow guests.
+func (s *TicketingService) authorizeGuest(event *domain.Event, userID uuid.UUID, opts PurchaseOptions) (string, error) {
+	if opts.GuestToken == "" {
+		return "", nil
+	}
+
+	claims, err := s.ResolveGuest(opts.GuestToken)
+	if err != nil {
+		return "", err
+	}
+
+	if claims.UserID() != userID {
+		return "", ErrInvalidGuestToken
+	}
+
+	if !event.AllowGuestCheckout {
+		return "", ErrGuestCheckoutDisabled
+	}
+
+	return claims.Email, nil
+}
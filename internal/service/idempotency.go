@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/adapter"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// ErrIdempotentPurchaseInProgress is returned when a purchase with the same idempotency key hasn't finished yet
+var ErrIdempotentPurchaseInProgress = errors.New("a purchase with this idempotency key is still in progress")
+
+// ErrIdempotencyKeyReused is returned when an idempotency key that bought a ticket is sent for another event
+var ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different purchase")
+
+// IdempotencyKeyTTL is how long a purchase's idempotency key is remembered
+const IdempotencyKeyTTL = time.Hour
+
+// MaxIdempotencyKeyLength bounds the idempotency keys clients may send
+const MaxIdempotencyKeyLength = 255
+
+// idempotencyPending marks a claimed key whose purchase is still running
+const idempotencyPending = "pending"
+
+// SetIdempotencyStore enables idempotency keys on PurchaseTicket; without a store the keys are ignored
+func (s *TicketingService) SetIdempotencyStore(store adapter.IdempotencyStore) {
+	s.idempotency = store
+}
+
+// purchaseIdempotencyKey scopes a client's idempotency key to the user so two users can't collide
+func purchaseIdempotencyKey(userID uuid.UUID, key string) string {
+	return fmt.Sprintf("%s:%s", userID.String(), key)
+}
+
+// claimPurchase claims an idempotency key before a purchase runs. When the key already bought a ticket that
+// ticket is returned for replay; otherwise the scoped key is returned so the outcome can be recorded on it.
+// The scoped key is empty when the purchase has no key or no store is set.
+func (s *TicketingService) claimPurchase(ctx context.Context, eventID, userID uuid.UUID, key string) (*domain.Ticket, string, error) {
+	if key == "" || s.idempotency == nil {
+		return nil, "", nil
+	}
+
+	scoped := purchaseIdempotencyKey(userID, key)
+	existing, claimed, err := s.idempotency.Claim(ctx, scoped, idempotencyPending, IdempotencyKeyTTL)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to claim idempotency key", "user_id", userID, "error", err)
+		return nil, "", fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+	if claimed {
+		return nil, scoped, nil
+	}
+
+	if existing == idempotencyPending {
+		return nil, "", ErrIdempotentPurchaseInProgress
+	}
+
+	ticketID, err := uuid.Parse(existing)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid ticket recorded for idempotency key: %w", err)
+	}
+
+	ticket, err := s.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get ticket for idempotency key: %w", err)
+	}
+
+	if ticket.EventID != eventID || ticket.UserID != userID {
+		return nil, "", ErrIdempotencyKeyReused
+	}
+
+	s.logger.Info(ctx, "Replaying idempotent purchase", "ticket_id", ticket.ID, "user_id", userID)
+	return ticket, "", nil
+}
+
+// settlePurchase records the ticket a claimed key bought, or releases the key after a failed purchase so
+// the client can retry with it
+func (s *TicketingService) settlePurchase(ctx context.Context, scoped string, ticket *domain.Ticket) {
+	if scoped == "" {
+		return
+	}
+
+	if ticket == nil {
+		if err := s.idempotency.Release(ctx, scoped); err != nil {
+			s.logger.Warn(ctx, "Failed to release idempotency key", "error", err)
+		}
+		return
+	}
+
+	if err := s.idempotency.Set(ctx, scoped, ticket.ID.String()); err != nil {
+		s.logger.Warn(ctx, "Failed to record idempotent purchase", "ticket_id", ticket.ID, "error", err)
+	}
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// testIdempotencyStore is an in-process IdempotencyStore whose keys never lapse
+type testIdempotencyStore struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func newTestIdempotencyStore() *testIdempotencyStore {
+	return &testIdempotencyStore{values: make(map[string]string)}
+}
+
+func (s *testIdempotencyStore) Claim(ctx context.Context, key, value string, ttl time.Duration) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.values[key]; ok {
+		return existing, false, nil
+	}
+	s.values[key] = value
+	return "", true, nil
+}
+
+func (s *testIdempotencyStore) Set(ctx context.Context, key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values[key] = value
+	return nil
+}
+
+func (s *testIdempotencyStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.values, key)
+	return nil
+}
+
+func TestIdempotentPurchaseReplays(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	tt.service.SetIdempotencyStore(newTestIdempotencyStore())
+
+	event := tt.createEvent(t, 10, 10)
+	seat := tt.createSeat(t, event, domain.SeatStatusAvailable)
+	userID := uuid.New()
+	sessionID := tt.activateSession(t, event.ID, userID)
+	opts := PurchaseOptions{IdempotencyKey: "retry-1"}
+
+	first, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &seat.ID, sessionID, opts)
+	if err != nil {
+		t.Fatalf("purchase ticket: %v", err)
+	}
+	second, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &seat.ID, sessionID, opts)
+	if err != nil {
+		t.Fatalf("retry purchase with the same key: %v", err)
+	}
+	if second.ID != first.ID {
+		t.Fatalf("retry returned ticket %s, want the first ticket %s", second.ID, first.ID)
+	}
+
+	if got := tt.availableTickets(t, event.ID); got != 9 {
+		t.Fatalf("available tickets = %d after a retried purchase, want 9", got)
+	}
+	held, err := tt.tickets.GetByUserAndEvent(ctx, userID, event.ID)
+	if err != nil {
+		t.Fatalf("get user tickets: %v", err)
+	}
+	if len(held) != 1 {
+		t.Fatalf("user holds %d tickets after a retried purchase, want 1", len(held))
+	}
+
+	// The key bought a ticket for this event only
+	other := tt.createEvent(t, 10, 10)
+	if _, err := tt.service.PurchaseTicket(ctx, other.ID, userID, nil, sessionID, opts); !errors.Is(err, ErrIdempotencyKeyReused) {
+		t.Fatalf("expected ErrIdempotencyKeyReused for another event, got %v", err)
+	}
+}
+
+func TestIdempotencyKeysAreScopedPerUser(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	tt.service.SetIdempotencyStore(newTestIdempotencyStore())
+
+	event := tt.createEvent(t, 10, 10)
+	opts := PurchaseOptions{IdempotencyKey: "shared-key"}
+
+	tickets := make(map[uuid.UUID]struct{})
+	for range 2 {
+		seat := tt.createSeat(t, event, domain.SeatStatusAvailable)
+		userID := uuid.New()
+		sessionID := tt.activateSession(t, event.ID, userID)
+
+		ticket, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &seat.ID, sessionID, opts)
+		if err != nil {
+			t.Fatalf("purchase ticket: %v", err)
+		}
+		if ticket.UserID != userID {
+			t.Fatalf("user %s got the ticket of user %s", userID, ticket.UserID)
+		}
+		tickets[ticket.ID] = struct{}{}
+	}
+
+	if len(tickets) != 2 {
+		t.Fatalf("two users sending the same key got %d distinct tickets, want 2", len(tickets))
+	}
+	if got := tt.availableTickets(t, event.ID); got != 8 {
+		t.Fatalf("available tickets = %d, want 8", got)
+	}
+}
+
+func TestIdempotencyKeyAfterFailedOrPendingPurchase(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	store := newTestIdempotencyStore()
+	tt.service.SetIdempotencyStore(store)
+
+	event := tt.createEvent(t, 10, 10)
+	sold := tt.createSeat(t, event, domain.SeatStatusSold)
+	open := tt.createSeat(t, event, domain.SeatStatusAvailable)
+	userID := uuid.New()
+	sessionID := tt.activateSession(t, event.ID, userID)
+	opts := PurchaseOptions{IdempotencyKey: "retry-after-failure"}
+
+	if _, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &sold.ID, sessionID, opts); err == nil {
+		t.Fatal("purchase of a sold seat succeeded")
+	}
+	ticket, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &open.ID, sessionID, opts)
+	if err != nil {
+		t.Fatalf("retry with the key of a failed purchase: %v", err)
+	}
+	if ticket.SeatID == nil || *ticket.SeatID != open.ID {
+		t.Fatalf("ticket seat = %v, want %s", ticket.SeatID, open.ID)
+	}
+
+	// A key whose purchase is still running is neither replayed nor purchased again
+	if err := store.Set(ctx, purchaseIdempotencyKey(userID, "in-flight"), idempotencyPending); err != nil {
+		t.Fatalf("mark key pending: %v", err)
+	}
+	opts.IdempotencyKey = "in-flight"
+	if _, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &open.ID, sessionID, opts); !errors.Is(err, ErrIdempotentPurchaseInProgress) {
+		t.Fatalf("expected ErrIdempotentPurchaseInProgress, got %v", err)
+	}
+	if got := tt.availableTickets(t, event.ID); got != 9 {
+		t.Fatalf("available tickets = %d, want 9", got)
+	}
+}
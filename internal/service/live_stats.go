@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/adapter"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// LiveStatsWindow is the trailing window the rolling counters in EventLiveStats cover
+const LiveStatsWindow = 1 * time.Minute
+
+// EventLiveStats is an operator's snapshot of load on an event during an on-sale
+type EventLiveStats struct {
+	EventID             uuid.UUID `json:"event_id"`
+	QueueLength         int       `json:"queue_length"`
+	ActiveUsers         int       `json:"active_users"`
+	PurchasesLastMin    int64     `json:"purchases_last_minute"`
+	LockFailuresLastMin int64     `json:"lock_failures_last_minute"`
+	GeneratedAt         time.Time `json:"generated_at"`
+}
+
+// purchaseCounterKey names the rolling counter of successful purchases for an event
+func purchaseCounterKey(eventID uuid.UUID) string {
+	return fmt.Sprintf("purchases:%s", eventID.String())
+}
+
+// lockFailureCounterKey names the rolling counter of purchases turned away by a busy lock or full semaphore
+func lockFailureCounterKey(eventID uuid.UUID) string {
+	return fmt.Sprintf("lock_failures:%s", eventID.String())
+}
+
+// SetQueueRepository sets the queue repository used for live queue figures
+func (s *EventService) SetQueueRepository(queueRepo repository.QueueRepository) {
+	s.queueRepo = queueRepo
+}
+
+// SetRateCounter sets the optional rolling counter the purchase path records into
+func (s *EventService) SetRateCounter(counter adapter.RateCounter) {
+	s.counter = counter
+}
+
+// GetLiveStats combines the queue length, active session count and rolling purchase and lock failure
+// counters for an event. Every figure is a counter read, so it is cheap to poll during an on-sale.
+func (s *EventService) GetLiveStats(ctx context.Context, eventID uuid.UUID) (*EventLiveStats, error) {
+	if _, err := s.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	stats := &EventLiveStats{
+		EventID:     eventID,
+		GeneratedAt: s.now(),
+	}
+
+	if s.queueRepo != nil {
+		length, err := s.queueRepo.GetQueueLength(ctx, eventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get queue length: %w", err)
+		}
+		stats.QueueLength = length
+
+		active, err := s.queueRepo.GetActiveCount(ctx, eventID, stats.GeneratedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get active users: %w", err)
+		}
+		stats.ActiveUsers = active
+	}
+
+	if s.counter != nil {
+		purchases, err := s.counter.Count(ctx, purchaseCounterKey(eventID), LiveStatsWindow)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count purchases: %w", err)
+		}
+		stats.PurchasesLastMin = purchases
+
+		failures, err := s.counter.Count(ctx, lockFailureCounterKey(eventID), LiveStatsWindow)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count lock failures: %w", err)
+		}
+		stats.LockFailuresLastMin = failures
+	}
+
+	return stats, nil
+}
+
+// SetRateCounter sets the optional rolling counter that feeds EventService.GetLiveStats
+func (s *TicketingService) SetRateCounter(counter adapter.RateCounter) {
+	s.counter = counter
+}
+
+// recordRate bumps a rolling counter; a failure only costs accuracy of the live stats
+func (s *TicketingService) recordRate(ctx context.Context, key string) {
+	if s.counter == nil {
+		return
+	}
+
+	if err := s.counter.Incr(ctx, key); err != nil {
+		s.logger.Warn(ctx, "Failed to record rate counter", "key", key, "error", err)
+	}
+}
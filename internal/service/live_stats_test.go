@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/pkg/clock"
+)
+
+// testRateCounter is an in-process RateCounter timing occurrences with a manual clock
+type testRateCounter struct {
+	mu     sync.Mutex
+	clock  *clock.Manual
+	events map[string][]time.Time
+}
+
+func newTestRateCounter(clock *clock.Manual) *testRateCounter {
+	return &testRateCounter{clock: clock, events: make(map[string][]time.Time)}
+}
+
+func (c *testRateCounter) Incr(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.events[key] = append(c.events[key], c.clock.Now())
+	return nil
+}
+
+func (c *testRateCounter) Count(ctx context.Context, key string, window time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	since := c.clock.Now().Add(-window)
+	var count int64
+	for _, at := range c.events[key] {
+		if at.After(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// expectLiveStats fails the test unless the event's live stats match want, ignoring GeneratedAt
+func expectLiveStats(t *testing.T, events *EventService, eventID uuid.UUID, want EventLiveStats) {
+	t.Helper()
+
+	stats, err := events.GetLiveStats(context.Background(), eventID)
+	if err != nil {
+		t.Fatalf("get live stats: %v", err)
+	}
+	want.EventID = eventID
+	want.GeneratedAt = stats.GeneratedAt
+	if *stats != want {
+		t.Fatalf("live stats = %+v, want %+v", *stats, want)
+	}
+}
+
+func TestLiveStatsFollowQueueAndPurchases(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	lock := newTestLock()
+	tt.withLock(lock)
+
+	now := clock.NewManual(time.Now())
+	counter := newTestRateCounter(now)
+	tt.service.SetRateCounter(counter)
+	events := newTestEvents(tt)
+	events.SetQueueRepository(tt.queue)
+	events.SetRateCounter(counter)
+	events.SetClock(now)
+
+	event := tt.createEvent(t, 10, 10)
+	expectLiveStats(t, events, event.ID, EventLiveStats{})
+
+	// Three buyers hold purchase sessions and two users wait behind them
+	buyers := make([]uuid.UUID, 3)
+	sessions := make([]string, len(buyers))
+	for i := range buyers {
+		buyers[i] = uuid.New()
+		sessions[i] = tt.activateSession(t, event.ID, buyers[i])
+	}
+	for range 2 {
+		if _, err := tt.queue.Join(ctx, event.ID, uuid.New(), uuid.NewString()); err != nil {
+			t.Fatalf("join queue: %v", err)
+		}
+	}
+	expectLiveStats(t, events, event.ID, EventLiveStats{QueueLength: 5, ActiveUsers: 3})
+
+	// Two buyers purchase and the third is turned away by a busy seat lock
+	for i := range 2 {
+		seat := tt.createSeat(t, event, domain.SeatStatusAvailable)
+		if _, err := tt.service.PurchaseTicket(ctx, event.ID, buyers[i], &seat.ID, sessions[i], PurchaseOptions{}); err != nil {
+			t.Fatalf("purchase ticket: %v", err)
+		}
+	}
+	contested := tt.createSeat(t, event, domain.SeatStatusAvailable)
+	if _, acquired, err := lock.Acquire(ctx, seatPurchaseLockKey(event.ID, contested.ID), time.Minute); err != nil || !acquired {
+		t.Fatalf("hold seat lock: acquired %v, err %v", acquired, err)
+	}
+	if _, err := tt.service.PurchaseTicket(ctx, event.ID, buyers[2], &contested.ID, sessions[2], PurchaseOptions{}); err == nil {
+		t.Fatal("purchased a seat whose lock was held")
+	}
+
+	stats, err := events.GetLiveStats(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("get live stats: %v", err)
+	}
+	if stats.PurchasesLastMin != 2 || stats.LockFailuresLastMin != 1 {
+		t.Fatalf("live stats count %d purchases and %d lock failures, want 2 and 1", stats.PurchasesLastMin, stats.LockFailuresLastMin)
+	}
+	if !stats.GeneratedAt.Equal(now.Now()) {
+		t.Fatalf("stats generated at %v, want the clock's %v", stats.GeneratedAt, now.Now())
+	}
+
+	// The rolling counters only cover the last minute
+	now.Advance(LiveStatsWindow + time.Second)
+	stats, err = events.GetLiveStats(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("get live stats: %v", err)
+	}
+	if stats.PurchasesLastMin != 0 || stats.LockFailuresLastMin != 0 {
+		t.Fatalf("live stats count %d purchases and %d lock failures a minute later, want none", stats.PurchasesLastMin, stats.LockFailuresLastMin)
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// ErrQueueOverloaded is returned when an event's queue is past one of its high-water marks and new joins are shed
+var ErrQueueOverloaded = errors.New("queue is overloaded, please try again later")
+
+// QueueShedRetryAfter is how long a shed client is told to wait before trying to join again
+const QueueShedRetryAfter = 30 * time.Second
+
+// shedJoin reports ErrQueueOverloaded when the event's waiting queue or its active sessions have reached the
+// event's high-water marks. A user who is already queued is never shed so a retried join still returns their entry;
+// pass uuid.Nil to check the event alone. Counts that can't be read let the join through.
+func (s *QueueService) shedJoin(ctx context.Context, event *domain.Event, userID uuid.UUID) error {
+	if event.QueueHighWaterMark <= 0 && event.ActiveHighWaterMark <= 0 {
+		return nil
+	}
+
+	if userID != uuid.Nil {
+		if existing, err := s.queueRepo.GetPosition(ctx, event.ID, userID); err == nil && existing != nil {
+			return nil
+		}
+	}
+
+	if event.QueueHighWaterMark > 0 {
+		length, err := s.queueRepo.GetQueueLength(ctx, event.ID)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get queue length for load shedding", "event_id", event.ID, "error", err)
+		} else if length >= event.QueueHighWaterMark {
+			s.logger.Warn(ctx, "Shedding queue join", "event_id", event.ID, "queue_length", length, "high_water_mark", event.QueueHighWaterMark)
+			return fmt.Errorf("%w: %d users waiting", ErrQueueOverloaded, length)
+		}
+	}
+
+	if event.ActiveHighWaterMark > 0 {
+		active, err := s.queueRepo.GetActiveCount(ctx, event.ID, s.now())
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get active count for load shedding", "event_id", event.ID, "error", err)
+		} else if active >= event.ActiveHighWaterMark {
+			s.logger.Warn(ctx, "Shedding queue join", "event_id", event.ID, "active_count", active, "high_water_mark", event.ActiveHighWaterMark)
+			return fmt.Errorf("%w: %d sessions active", ErrQueueOverloaded, active)
+		}
+	}
+
+	return nil
+}
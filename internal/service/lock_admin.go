@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// LockStatus is an operator's view of one distributed lock
+type LockStatus struct {
+	Key    string `json:"key"`
+	Locked bool   `json:"locked"`
+	// TTLMillis is the lock's remaining lifetime; -1 means it never expires, the signature of a stuck lock
+	TTLMillis int64 `json:"ttl_ms"`
+}
+
+// InspectLock reports whether a lock is held and how long it has left
+func (s *TicketingService) InspectLock(ctx context.Context, key string) (*LockStatus, error) {
+	locked, err := s.lock.IsLocked(ctx, key)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to check lock", "key", key, "error", err)
+		return nil, fmt.Errorf("failed to check lock: %w", err)
+	}
+
+	status := &LockStatus{Key: key, Locked: locked}
+	if !locked {
+		return status, nil
+	}
+
+	ttl, err := s.lock.TTL(ctx, key)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get lock TTL", "key", key, "error", err)
+		return nil, fmt.Errorf("failed to get lock TTL: %w", err)
+	}
+
+	status.TTLMillis = -1
+	if ttl >= 0 {
+		status.TTLMillis = ttl.Milliseconds()
+	}
+
+	return status, nil
+}
+
+// ForceReleaseLock deletes a lock regardless of its holder and records which operator did it.
+// It is an escape hatch for locks left behind by a crashed process; it reports whether a lock was held.
+func (s *TicketingService) ForceReleaseLock(ctx context.Context, key, operator string) (bool, error) {
+	status, err := s.InspectLock(ctx, key)
+	if err != nil {
+		return false, err
+	}
+
+	released, err := s.lock.ForceRelease(ctx, key)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to force release lock", "key", key, "operator", operator, "error", err)
+		return false, fmt.Errorf("failed to force release lock: %w", err)
+	}
+
+	s.logger.Warn(ctx, "Lock force released by operator",
+		"key", key,
+		"operator", operator,
+		"was_locked", released,
+		"ttl_ms", status.TTLMillis,
+		"released_at", time.Now())
+
+	return released, nil
+}
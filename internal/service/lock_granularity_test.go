@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/pkg/logger"
+)
+
+// withLock rebuilds the service around lock so a test can inspect and hold purchase locks
+func (tt *testTicketing) withLock(lock *testLock) {
+	tt.service = NewTicketingService(tt.tickets, tt.events, tt.seats, tt.queue, testCache{}, lock, logger.NewLoggerWithLevel(zerolog.Disabled))
+}
+
+// createLockedEvent stores an event locking purchases at granularity, with two seats in section A and one in B
+func (tt *testTicketing) createLockedEvent(t *testing.T, granularity domain.LockGranularity) (*domain.Event, []*domain.Seat) {
+	t.Helper()
+
+	event := tt.createEvent(t, 10, 10)
+	event.LockGranularity = string(granularity)
+	if err := tt.events.Update(context.Background(), event); err != nil {
+		t.Fatalf("update event: %v", err)
+	}
+
+	seats := make([]*domain.Seat, 3)
+	for i, section := range []string{"A", "A", "B"} {
+		seats[i] = tt.createSeat(t, event, domain.SeatStatusAvailable)
+		seats[i].Section = section
+		if err := tt.seats.Update(context.Background(), seats[i]); err != nil {
+			t.Fatalf("update seat: %v", err)
+		}
+	}
+	return event, seats
+}
+
+func TestPurchaseLockKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		granularity domain.LockGranularity
+		// want returns the expected keys for the purchase of seats b, a, a and c, where a and b share section A
+		want func(eventID uuid.UUID, seats []*domain.Seat) []string
+	}{
+		{
+			name: "unset locks seats",
+			want: func(eventID uuid.UUID, seats []*domain.Seat) []string {
+				return []string{seatPurchaseLockKey(eventID, seats[0].ID), seatPurchaseLockKey(eventID, seats[1].ID), seatPurchaseLockKey(eventID, seats[2].ID)}
+			},
+		},
+		{
+			name:        "seat",
+			granularity: domain.LockGranularitySeat,
+			want: func(eventID uuid.UUID, seats []*domain.Seat) []string {
+				return []string{seatPurchaseLockKey(eventID, seats[0].ID), seatPurchaseLockKey(eventID, seats[1].ID), seatPurchaseLockKey(eventID, seats[2].ID)}
+			},
+		},
+		{
+			name:        "section",
+			granularity: domain.LockGranularitySection,
+			want: func(eventID uuid.UUID, seats []*domain.Seat) []string {
+				return []string{sectionPurchaseLockKey(eventID, "A"), sectionPurchaseLockKey(eventID, "B")}
+			},
+		},
+		{
+			name:        "event",
+			granularity: domain.LockGranularityEvent,
+			want: func(eventID uuid.UUID, seats []*domain.Seat) []string {
+				return []string{eventPurchaseLockKey(eventID)}
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tt := newTestTicketing(t)
+			event, seats := tt.createLockedEvent(t, tc.granularity)
+
+			keys, err := tt.service.purchaseLockKeys(ctx, event, []uuid.UUID{seats[1].ID, seats[0].ID, seats[0].ID, seats[2].ID})
+			if err != nil {
+				t.Fatalf("purchase lock keys: %v", err)
+			}
+
+			want := tc.want(event.ID, seats)
+			slices.Sort(want)
+			if !slices.Equal(keys, want) {
+				t.Fatalf("keys = %v, want %v", keys, want)
+			}
+
+			standing, err := tt.service.purchaseLockKeys(ctx, event, nil)
+			if err != nil {
+				t.Fatalf("purchase lock keys without seats: %v", err)
+			}
+			if !slices.Equal(standing, []string{eventPurchaseLockKey(event.ID)}) {
+				t.Fatalf("keys without seats = %v, want the event lock", standing)
+			}
+		})
+	}
+}
+
+func TestLockSeatsContention(t *testing.T) {
+	tests := []struct {
+		granularity domain.LockGranularity
+		// concurrent is whether a purchase of the other seat in the section runs while the first holds its locks
+		concurrent bool
+	}{
+		{granularity: domain.LockGranularitySeat, concurrent: true},
+		{granularity: domain.LockGranularitySection},
+		{granularity: domain.LockGranularityEvent},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.granularity), func(t *testing.T) {
+			ctx := context.Background()
+			tt := newTestTicketing(t)
+			event, seats := tt.createLockedEvent(t, tc.granularity)
+
+			unlock, err := tt.service.lockSeats(ctx, event, []uuid.UUID{seats[0].ID})
+			if err != nil {
+				t.Fatalf("lock first seat: %v", err)
+			}
+
+			other, err := tt.service.lockSeats(ctx, event, []uuid.UUID{seats[1].ID})
+			if tc.concurrent != (err == nil) {
+				t.Fatalf("second purchase in the section locked = %v, want %v (err %v)", err == nil, tc.concurrent, err)
+			}
+			if other != nil {
+				other()
+			}
+
+			unlock()
+			again, err := tt.service.lockSeats(ctx, event, []uuid.UUID{seats[1].ID})
+			if err != nil {
+				t.Fatalf("lock second seat once the first purchase is done: %v", err)
+			}
+			again()
+		})
+	}
+}
+
+func TestLockSeatsReleasesHeldLocksOnFailure(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	lock := newTestLock()
+	tt.withLock(lock)
+	event, seats := tt.createLockedEvent(t, domain.LockGranularitySeat)
+	seatIDs := []uuid.UUID{seats[0].ID, seats[1].ID, seats[2].ID}
+
+	keys, err := tt.service.purchaseLockKeys(ctx, event, seatIDs)
+	if err != nil {
+		t.Fatalf("purchase lock keys: %v", err)
+	}
+
+	// Another purchase holds the last key, so the locks taken before it must be given back
+	last := keys[len(keys)-1]
+	token, acquired, err := lock.Acquire(ctx, last, time.Minute)
+	if err != nil || !acquired {
+		t.Fatalf("hold last key: acquired %v, err %v", acquired, err)
+	}
+
+	if _, err := tt.service.lockSeats(ctx, event, seatIDs); err == nil {
+		t.Fatal("locked seats while one of their keys was held")
+	}
+	for _, key := range keys[:len(keys)-1] {
+		if locked, _ := lock.IsLocked(ctx, key); locked {
+			t.Fatalf("lock %s still held after the purchase failed to lock every seat", key)
+		}
+	}
+
+	if err := lock.Release(ctx, last, token); err != nil {
+		t.Fatalf("release last key: %v", err)
+	}
+	unlock, err := tt.service.lockSeats(ctx, event, seatIDs)
+	if err != nil {
+		t.Fatalf("lock seats once free: %v", err)
+	}
+	unlock()
+	for _, key := range keys {
+		if locked, _ := lock.IsLocked(ctx, key); locked {
+			t.Fatalf("lock %s still held after unlock", key)
+		}
+	}
+}
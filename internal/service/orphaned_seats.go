@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// ReclaimOrphanedSeats returns to the pool seats that were reserved but never ticketed, e.g. because the process
+// stopped between reserving a seat and creating its ticket. A seat is orphaned once its reservation hold lapsed
+// and no reserved or confirmed ticket points to it; a seat a ticket does claim is left for that ticket's own
+// confirmation or expiry. Inventory is only taken once tickets exist, so none is returned. It reports how many
+// seats it reclaimed.
+func (s *TicketingService) ReclaimOrphanedSeats(ctx context.Context) (int, error) {
+	lapsed, err := s.seatRepo.GetLapsedReservationHolds(ctx, s.now())
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get lapsed reservation holds", "error", err)
+		return 0, fmt.Errorf("failed to get lapsed reservation holds: %w", err)
+	}
+	if len(lapsed) == 0 {
+		return 0, nil
+	}
+
+	byEvent := make(map[uuid.UUID][]*domain.Seat)
+	for _, seat := range lapsed {
+		byEvent[seat.EventID] = append(byEvent[seat.EventID], seat)
+	}
+
+	reclaimed := 0
+	for eventID, seats := range byEvent {
+		tickets, err := s.ticketRepo.GetByEventID(ctx, eventID)
+		if err != nil {
+			// Without the tickets an orphan can't be told from a ticketed seat; try the event again next run
+			s.logger.Error(ctx, "Failed to get event tickets", "event_id", eventID, "error", err)
+			continue
+		}
+
+		claimed := make(map[uuid.UUID]bool)
+		for _, ticket := range tickets {
+			if ticket.SeatID != nil && (ticket.IsReserved() || ticket.IsConfirmed()) {
+				claimed[*ticket.SeatID] = true
+			}
+		}
+
+		var ticketed []uuid.UUID
+		for _, seat := range seats {
+			if claimed[seat.ID] {
+				ticketed = append(ticketed, seat.ID)
+				continue
+			}
+
+			if err := s.seatRepo.ReleaseSeats(ctx, []uuid.UUID{seat.ID}); err != nil {
+				s.logger.Warn(ctx, "Failed to reclaim orphaned seat", "seat_id", seat.ID, "event_id", eventID, "error", err)
+				continue
+			}
+			s.logger.Warn(ctx, "Reclaimed orphaned seat", "seat_id", seat.ID, "event_id", eventID)
+			reclaimed++
+		}
+
+		if err := s.seatRepo.ClearReservationHolds(ctx, ticketed); err != nil {
+			s.logger.Warn(ctx, "Failed to clear reservation holds of ticketed seats", "event_id", eventID, "error", err)
+		}
+	}
+
+	return reclaimed, nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+// ErrPurchaseBudgetExhausted is returned when a session has used up its failed purchase attempts and must re-queue
+var ErrPurchaseBudgetExhausted = errors.New("purchase attempts exhausted, please rejoin the queue")
+
+// PurchaseAttemptError wraps a failed purchase with the number of attempts the session has left
+type PurchaseAttemptError struct {
+	Err               error
+	AttemptsRemaining int
+}
+
+// Error returns the underlying failure along with the remaining attempts
+func (e *PurchaseAttemptError) Error() string {
+	return fmt.Sprintf("%v (%d purchase attempts remaining)", e.Err, e.AttemptsRemaining)
+}
+
+// Unwrap returns the underlying failure so callers can still match it with errors.Is
+func (e *PurchaseAttemptError) Unwrap() error {
+	return e.Err
+}
+
+// SetAttemptCounter enables the per-session budget of failed purchase attempts
+func (s *TicketingService) SetAttemptCounter(attempts adapter.AttemptCounter) {
+	s.attempts = attempts
+}
+
+// purchaseAttemptKey names the failed purchase counter of a session
+func purchaseAttemptKey(sessionID string) string {
+	return fmt.Sprintf("purchase:%s", sessionID)
+}
+
+// checkPurchaseBudget rejects a session that has no failed attempts left.
+// The counter is advisory: if it can't be read the purchase goes ahead.
+func (s *TicketingService) checkPurchaseBudget(ctx context.Context, sessionID string) error {
+	if s.attempts == nil || s.config.MaxPurchaseAttempts <= 0 {
+		return nil
+	}
+
+	used, err := s.attempts.Get(ctx, purchaseAttemptKey(sessionID))
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to read purchase attempts", "session_id", sessionID, "error", err)
+		return nil
+	}
+
+	if used >= int64(s.config.MaxPurchaseAttempts) {
+		s.logger.Warn(ctx, "Purchase attempts exhausted", "session_id", sessionID, "attempts", used)
+		return ErrPurchaseBudgetExhausted
+	}
+
+	return nil
+}
+
+// recordPurchaseOutcome resets the budget after a successful purchase and charges it for a failed one.
+// Throttling errors that ask the client to come back later, and the per-user ticket limit, are not charged.
+// When the last attempt is spent the session's queue entry is removed so the user has to re-queue.
+func (s *TicketingService) recordPurchaseOutcome(ctx context.Context, sessionID string, purchaseErr error) error {
+	if s.attempts == nil || s.config.MaxPurchaseAttempts <= 0 {
+		return purchaseErr
+	}
+
+	key := purchaseAttemptKey(sessionID)
+
+	if purchaseErr == nil {
+		if err := s.attempts.Reset(ctx, key); err != nil {
+			s.logger.Warn(ctx, "Failed to reset purchase attempts", "session_id", sessionID, "error", err)
+		}
+		return nil
+	}
+
+	if errors.Is(purchaseErr, ErrPurchaseSaturated) || errors.Is(purchaseErr, ErrPurchaseNotStarted) || errors.Is(purchaseErr, ErrPurchaseLimitExceeded) {
+		return purchaseErr
+	}
+
+	used, err := s.attempts.Incr(ctx, key, s.config.PurchaseAttemptWindow)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to record purchase attempt", "session_id", sessionID, "error", err)
+		return purchaseErr
+	}
+
+	remaining := s.config.MaxPurchaseAttempts - int(used)
+	if remaining <= 0 {
+		remaining = 0
+		s.expireQueueSession(ctx, sessionID)
+	}
+
+	return &PurchaseAttemptError{Err: purchaseErr, AttemptsRemaining: remaining}
+}
+
+// expireQueueSession drops the queue entry behind a session that spent its purchase budget
+func (s *TicketingService) expireQueueSession(ctx context.Context, sessionID string) {
+	entry, err := s.queueRepo.GetBySessionID(ctx, sessionID)
+	if err != nil {
+		// The session may never have existed; the exhausted counter still blocks it
+		return
+	}
+
+	if err := s.queueRepo.RemoveUser(ctx, entry.EventID, entry.UserID); err != nil {
+		s.logger.Error(ctx, "Failed to remove exhausted session from queue", "session_id", sessionID, "error", err)
+		return
+	}
+
+	s.logger.Info(ctx, "Removed session from queue after exhausting purchase attempts",
+		"session_id", sessionID,
+		"event_id", entry.EventID,
+		"user_id", entry.UserID)
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// testAttemptCounter is an in-process AttemptCounter whose counts never lapse
+type testAttemptCounter struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func newTestAttemptCounter() *testAttemptCounter {
+	return &testAttemptCounter{counts: make(map[string]int64)}
+}
+
+func (c *testAttemptCounter) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.counts[key]++
+	return c.counts[key], nil
+}
+
+func (c *testAttemptCounter) Get(ctx context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.counts[key], nil
+}
+
+func (c *testAttemptCounter) Reset(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.counts, key)
+	return nil
+}
+
+// newBudgetTestTicketing builds a TicketingService allowing maxAttempts failed purchases per session
+func newBudgetTestTicketing(t *testing.T, maxAttempts int) (*testTicketing, *testAttemptCounter) {
+	t.Helper()
+
+	tt := newTestTicketing(t)
+	config := DefaultTicketingConfig()
+	config.MaxPurchaseAttempts = maxAttempts
+	if err := tt.service.SetConfig(config); err != nil {
+		t.Fatalf("set config: %v", err)
+	}
+	attempts := newTestAttemptCounter()
+	tt.service.SetAttemptCounter(attempts)
+	return tt, attempts
+}
+
+// expectAttemptsRemaining checks that a failed purchase reports remaining attempts left
+func expectAttemptsRemaining(t *testing.T, err error, remaining int) {
+	t.Helper()
+
+	var attemptErr *PurchaseAttemptError
+	if !errors.As(err, &attemptErr) {
+		t.Fatalf("expected a PurchaseAttemptError, got %v", err)
+	}
+	if attemptErr.AttemptsRemaining != remaining {
+		t.Fatalf("attempts remaining = %d, want %d", attemptErr.AttemptsRemaining, remaining)
+	}
+}
+
+func TestPurchaseBudgetExhausted(t *testing.T) {
+	ctx := context.Background()
+	tt, _ := newBudgetTestTicketing(t, 3)
+
+	event := tt.createEvent(t, 10, 10)
+	sold := tt.createSeat(t, event, domain.SeatStatusSold)
+	open := tt.createSeat(t, event, domain.SeatStatusAvailable)
+	userID := uuid.New()
+	sessionID := tt.activateSession(t, event.ID, userID)
+
+	for remaining := 2; remaining >= 0; remaining-- {
+		_, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &sold.ID, sessionID, PurchaseOptions{})
+		expectAttemptsRemaining(t, err, remaining)
+	}
+
+	if _, err := tt.queue.GetBySessionID(ctx, sessionID); err == nil {
+		t.Fatal("session stayed queued after spending its last purchase attempt")
+	}
+
+	if _, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &open.ID, sessionID, PurchaseOptions{}); !errors.Is(err, ErrPurchaseBudgetExhausted) {
+		t.Fatalf("expected ErrPurchaseBudgetExhausted once the budget is spent, got %v", err)
+	}
+	stored, err := tt.seats.GetByID(ctx, open.ID)
+	if err != nil {
+		t.Fatalf("get seat: %v", err)
+	}
+	if stored.Status != string(domain.SeatStatusAvailable) {
+		t.Fatalf("seat status = %s after a refused purchase, want available", stored.Status)
+	}
+}
+
+func TestPurchaseBudgetResetsOnSuccess(t *testing.T) {
+	ctx := context.Background()
+	tt, attempts := newBudgetTestTicketing(t, 3)
+
+	event := tt.createEvent(t, 10, 10)
+	sold := tt.createSeat(t, event, domain.SeatStatusSold)
+	open := tt.createSeat(t, event, domain.SeatStatusAvailable)
+	userID := uuid.New()
+	sessionID := tt.activateSession(t, event.ID, userID)
+
+	for remaining := 2; remaining >= 1; remaining-- {
+		_, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &sold.ID, sessionID, PurchaseOptions{})
+		expectAttemptsRemaining(t, err, remaining)
+	}
+
+	if _, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &open.ID, sessionID, PurchaseOptions{}); err != nil {
+		t.Fatalf("purchase with one attempt left: %v", err)
+	}
+	if used, _ := attempts.Get(ctx, purchaseAttemptKey(sessionID)); used != 0 {
+		t.Fatalf("failed attempts = %d after a successful purchase, want 0", used)
+	}
+
+	// The next failure is charged against a fresh budget
+	_, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &sold.ID, sessionID, PurchaseOptions{})
+	expectAttemptsRemaining(t, err, 2)
+}
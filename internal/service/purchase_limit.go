@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// ErrPurchaseLimitExceeded is returned when a purchase would take a user past the tickets they may hold for an event
+var ErrPurchaseLimitExceeded = errors.New("purchase would exceed the per-user ticket limit")
+
+// ticketLimitOf returns how many tickets one user may hold for an event; 0 means no limit
+func (s *TicketingService) ticketLimitOf(event *domain.Event) int {
+	if event.MaxTicketsPerUser > 0 {
+		return event.MaxTicketsPerUser
+	}
+	return s.config.MaxTicketsPerUser
+}
+
+// ticketClaim is a purchase's share of a user's ticket limit. It is given back when the purchase fails
+// unless keep was called with the tickets the purchase created.
+type ticketClaim struct {
+	service *TicketingService
+	eventID uuid.UUID
+	userID  uuid.UUID
+	count   int
+	kept    bool
+}
+
+// claimUserTickets counts count more tickets against the user's limit for the event, failing with
+// ErrPurchaseLimitExceeded when they would pass it. Call it under the purchase lock and defer release.
+func (s *TicketingService) claimUserTickets(ctx context.Context, event *domain.Event, userID uuid.UUID, count int) (*ticketClaim, error) {
+	limit := s.ticketLimitOf(event)
+	if limit <= 0 {
+		return &ticketClaim{}, nil
+	}
+
+	held, err := s.ticketRepo.IncrementUserEventCount(ctx, event.ID, userID, count, limit)
+	if errors.Is(err, repository.ErrUserTicketLimitReached) {
+		s.logger.Warn(ctx, "Purchase exceeds per-user ticket limit", "event_id", event.ID, "user_id", userID, "tickets", count, "held", held, "limit", limit)
+		return nil, fmt.Errorf("%w: at most %d tickets per user", ErrPurchaseLimitExceeded, limit)
+	}
+	if err != nil {
+		s.logger.Error(ctx, "Failed to count user tickets", "event_id", event.ID, "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to count user tickets: %w", err)
+	}
+
+	return &ticketClaim{service: s, eventID: event.ID, userID: userID, count: count}, nil
+}
+
+// keep makes the claim stick for the number of tickets a purchase created. A companion seat booked with an
+// accessible seat is counted on top of the claim without being held to the limit, so each ticket frees one
+// slot when cancelled.
+func (c *ticketClaim) keep(ctx context.Context, created int) {
+	if c.service == nil {
+		return
+	}
+	c.kept = true
+
+	if extra := created - c.count; extra > 0 {
+		if _, err := c.service.ticketRepo.IncrementUserEventCount(ctx, c.eventID, c.userID, extra, 0); err != nil {
+			c.service.logger.Error(ctx, "Failed to count companion tickets", "event_id", c.eventID, "user_id", c.userID, "error", err)
+		}
+	}
+}
+
+// release gives a claim that was not kept back to the user's limit
+func (c *ticketClaim) release(ctx context.Context) {
+	if c.service == nil || c.kept {
+		return
+	}
+
+	if err := c.service.ticketRepo.DecrementUserEventCount(ctx, c.eventID, c.userID, c.count); err != nil {
+		c.service.logger.Error(ctx, "Failed to return claimed tickets to user limit", "event_id", c.eventID, "user_id", c.userID, "error", err)
+	}
+}
+
+// releaseUserTicket frees the slot a cancelled ticket held in its holder's limit for the event
+func (s *TicketingService) releaseUserTicket(ctx context.Context, ticket *domain.Ticket) {
+	if err := s.ticketRepo.DecrementUserEventCount(ctx, ticket.EventID, ticket.UserID, 1); err != nil {
+		s.logger.Error(ctx, "Failed to free user ticket slot", "ticket_id", ticket.ID, "user_id", ticket.UserID, "error", err)
+	}
+}
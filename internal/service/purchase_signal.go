@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// MaxPurchaseSignalListSize is the most purchase signals returned by one query
+const MaxPurchaseSignalListSize = 500
+
+// maxUserAgentLength bounds how much of a client's user agent a purchase signal keeps
+const maxUserAgentLength = 256
+
+// SetAuditRepository sets the optional repository successful purchases record their signals in
+func (s *TicketingService) SetAuditRepository(auditRepo repository.AuditRepository) {
+	s.auditRepo = auditRepo
+}
+
+// recordPurchaseSignal keeps the session, address and user agent a purchase came from for fraud review.
+// Recording is best-effort: a failure is logged and never fails the purchase.
+func (s *TicketingService) recordPurchaseSignal(ctx context.Context, eventID, userID uuid.UUID, sessionID string, opts PurchaseOptions, tickets []*domain.Ticket) {
+	if s.auditRepo == nil || len(tickets) == 0 {
+		return
+	}
+
+	userAgent := opts.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = strings.ToValidUTF8(userAgent[:maxUserAgentLength], "")
+	}
+
+	signal := &domain.PurchaseSignal{
+		EventID:    eventID,
+		UserID:     userID,
+		SessionID:  sessionID,
+		TicketIDs:  make([]uuid.UUID, len(tickets)),
+		RemoteAddr: opts.RemoteAddr,
+		UserAgent:  userAgent,
+		At:         time.Now(),
+	}
+	for i, ticket := range tickets {
+		signal.TicketIDs[i] = ticket.ID
+	}
+
+	if err := s.auditRepo.AppendPurchaseSignal(ctx, signal); err != nil {
+		s.logger.Warn(ctx, "Purchase signal dropped", "event_id", eventID, "session_id", sessionID, "error", err)
+	}
+}
+
+// ListPurchaseSignals retrieves up to limit of an event's purchase signals newest first
+func (s *AccessLogService) ListPurchaseSignals(ctx context.Context, eventID uuid.UUID, limit int) ([]*domain.PurchaseSignal, error) {
+	if limit <= 0 || limit > MaxPurchaseSignalListSize {
+		return nil, fmt.Errorf("limit must be between 1 and %d", MaxPurchaseSignalListSize)
+	}
+
+	signals, err := s.auditRepo.ListPurchaseSignals(ctx, eventID, limit)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list purchase signals", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to list purchase signals: %w", err)
+	}
+
+	return signals, nil
+}
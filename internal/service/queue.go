@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,6 +12,53 @@ import (
 	"github.com/snowmerak/ticketing/lib/repository"
 )
 
+// QueueConfig holds tunable queue behavior
+type QueueConfig struct {
+	// PositionNotifyThresholds are the live queue positions at which a waiting
+	// user is notified, e.g. 10 sends "you're in the top 10"
+	PositionNotifyThresholds []int
+
+	// ActivationStaggerWindow spreads the start times of users activated together by
+	// ProcessQueueBatch across this window so they don't all hit the seat locks at once.
+	// Zero lets the whole batch start immediately.
+	ActivationStaggerWindow time.Duration
+
+	// ActivateOnlyWithInventory holds the queue while an event has nothing left to sell, so users aren't
+	// activated into a sold-out event; batches are also capped at the remaining inventory
+	ActivateOnlyWithInventory bool
+
+	// MaxSessionRefreshes caps how many times one active session may be extended; 0 allows any number
+	MaxSessionRefreshes int
+	// MaxActiveWindow caps the total time an entry may stay active counting every refresh, measured from its
+	// activation; refreshes never extend past it and 0 removes the cap
+	MaxActiveWindow time.Duration
+
+	// MinActivationInterval is the least time between two activations of the same event, so ProcessQueue and
+	// ProcessQueueBatch can't drain the queue faster than this pace; 0 disables pacing
+	MinActivationInterval time.Duration
+}
+
+// ErrSessionRefreshLimit is returned when an active session has used up its refreshes or its active window;
+// the user has to finish or give up the slot
+var ErrSessionRefreshLimit = errors.New("session can't be refreshed any further")
+
+// ErrSessionExpired is returned when an active session is refreshed after its expiry; a lapsed session can't be
+// brought back and the user has to queue again
+var ErrSessionExpired = errors.New("session has expired")
+
+// ErrNoInventoryToActivate is returned when the queue is held because the event has no tickets left
+var ErrNoInventoryToActivate = errors.New("event has no inventory; queue activation is on hold")
+
+// DefaultQueueConfig returns the default queue configuration
+func DefaultQueueConfig() QueueConfig {
+	return QueueConfig{
+		PositionNotifyThresholds: []int{10, 3},
+		ActivationStaggerWindow:  10 * time.Second,
+		MaxSessionRefreshes:      3,
+		MaxActiveWindow:          time.Hour,
+	}
+}
+
 // QueueService handles queue-related business logic
 type QueueService struct {
 	queueRepo repository.QueueRepository
@@ -18,6 +66,12 @@ type QueueService struct {
 	cache     adapter.Cache
 	lock      adapter.Lock
 	logger    adapter.Logger
+	notifier  adapter.Notifier
+	config    QueueConfig
+	cacheTTL  adapter.CacheConfig
+
+	waitroomTokens *WaitroomTokens
+	clock          adapter.Clock
 }
 
 // NewQueueService creates a new QueueService
@@ -34,7 +88,37 @@ func NewQueueService(
 		cache:     cache,
 		lock:      lock,
 		logger:    logger,
+		config:    DefaultQueueConfig(),
+		cacheTTL:  adapter.DefaultCacheConfig(),
+	}
+}
+
+// SetConfig replaces the queue configuration
+func (s *QueueService) SetConfig(config QueueConfig) {
+	s.config = config
+}
+
+// SetCacheConfig sets how long queue lengths stay cached; unset TTLs keep their defaults
+func (s *QueueService) SetCacheConfig(config adapter.CacheConfig) {
+	s.cacheTTL = config.WithDefaults()
+}
+
+// SetNotifier sets the optional notifier used to tell users about queue progress
+func (s *QueueService) SetNotifier(notifier adapter.Notifier) {
+	s.notifier = notifier
+}
+
+// SetClock sets the clock queue sessions are checked for expiry against; the wall clock is used when none is set
+func (s *QueueService) SetClock(clock adapter.Clock) {
+	s.clock = clock
+}
+
+// now returns the current time from the configured clock
+func (s *QueueService) now() time.Time {
+	if s.clock == nil {
+		return time.Now()
 	}
+	return s.clock.Now()
 }
 
 // JoinQueue adds a user to the queue for an event
@@ -48,14 +132,18 @@ func (s *QueueService) JoinQueue(ctx context.Context, eventID, userID uuid.UUID,
 		return nil, fmt.Errorf("failed to get event: %w", err)
 	}
 
-	if !event.CanPurchase() {
+	if !event.CanPurchaseAt(s.now()) {
 		s.logger.Warn(ctx, "Event not available for purchase", "event_id", eventID, "status", event.Status)
 		return nil, fmt.Errorf("event is not available for purchase")
 	}
 
+	if err := s.shedJoin(ctx, event, userID); err != nil {
+		return nil, err
+	}
+
 	// Use distributed lock to prevent race conditions
 	lockKey := fmt.Sprintf("queue_join:%s", eventID.String())
-	acquired, err := s.lock.Acquire(ctx, lockKey, 5*time.Second)
+	token, acquired, err := s.lock.Acquire(ctx, lockKey, 5*time.Second)
 	if err != nil {
 		s.logger.Error(ctx, "Failed to acquire lock", "error", err)
 		return nil, fmt.Errorf("failed to acquire lock: %w", err)
@@ -67,7 +155,7 @@ func (s *QueueService) JoinQueue(ctx context.Context, eventID, userID uuid.UUID,
 	}
 
 	defer func() {
-		if err := s.lock.Release(ctx, lockKey); err != nil {
+		if err := s.lock.Release(ctx, lockKey, token); err != nil {
 			s.logger.Error(ctx, "Failed to release lock", "error", err)
 		}
 	}()
@@ -85,6 +173,137 @@ func (s *QueueService) JoinQueue(ctx context.Context, eventID, userID uuid.UUID,
 		"position", entry.Position,
 		"status", entry.Status)
 
+	if entry.WaitroomToken == "" {
+		s.issueWaitroomToken(ctx, entry)
+	}
+
+	return entry, nil
+}
+
+// MaxQueueBatchSize is the largest group a coordinator can enqueue in one call
+const MaxQueueBatchSize = 10
+
+// QueueJoinResult reports the outcome of enqueuing one member of a batch
+type QueueJoinResult struct {
+	UserID uuid.UUID          `json:"user_id"`
+	Entry  *domain.QueueEntry `json:"entry,omitempty"`
+	Error  string             `json:"error,omitempty"`
+}
+
+// JoinQueueBatch enqueues a group of users under one coordinator session.
+// The whole group is enqueued under a single queue lock so its members get contiguous positions.
+// Each member gets its own session ID derived from the coordinator's, and a member who is already
+// queued is reported in their result without aborting the rest of the group.
+func (s *QueueService) JoinQueueBatch(ctx context.Context, eventID uuid.UUID, userIDs []uuid.UUID, sessionID string) ([]QueueJoinResult, error) {
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("at least one user is required")
+	}
+
+	if len(userIDs) > MaxQueueBatchSize {
+		return nil, fmt.Errorf("cannot enqueue more than %d users at once", MaxQueueBatchSize)
+	}
+
+	s.logger.Info(ctx, "Group joining queue", "event_id", eventID, "group_size", len(userIDs), "session_id", sessionID)
+
+	// Validate event exists and is active
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get event", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if !event.CanPurchaseAt(s.now()) {
+		s.logger.Warn(ctx, "Event not available for purchase", "event_id", eventID, "status", event.Status)
+		return nil, fmt.Errorf("event is not available for purchase")
+	}
+
+	if err := s.shedJoin(ctx, event, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	// Use the same lock as single joins so nobody slips in between group members
+	lockKey := fmt.Sprintf("queue_join:%s", eventID.String())
+	token, acquired, err := s.lock.Acquire(ctx, lockKey, 5*time.Second)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to acquire lock", "error", err)
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+
+	if !acquired {
+		s.logger.Warn(ctx, "Failed to acquire lock - queue busy", "event_id", eventID)
+		return nil, fmt.Errorf("queue is busy, please try again")
+	}
+
+	defer func() {
+		if err := s.lock.Release(ctx, lockKey, token); err != nil {
+			s.logger.Error(ctx, "Failed to release lock", "error", err)
+		}
+	}()
+
+	results := make([]QueueJoinResult, 0, len(userIDs))
+	seen := make(map[uuid.UUID]bool, len(userIDs))
+	for _, userID := range userIDs {
+		result := QueueJoinResult{UserID: userID}
+
+		switch {
+		case userID == uuid.Nil:
+			result.Error = "user ID is required"
+		case seen[userID]:
+			result.Error = "user is listed more than once"
+		default:
+			if existing, err := s.queueRepo.GetPosition(ctx, eventID, userID); err == nil && existing != nil {
+				result.Entry = existing
+				result.Error = "user is already in the queue"
+				break
+			}
+
+			entry, err := s.queueRepo.Join(ctx, eventID, userID, fmt.Sprintf("%s:%s", sessionID, userID.String()))
+			if err != nil {
+				s.logger.Error(ctx, "Failed to join queue", "user_id", userID, "error", err)
+				result.Error = "failed to join queue"
+				break
+			}
+			if entry.WaitroomToken == "" {
+				s.issueWaitroomToken(ctx, entry)
+			}
+			result.Entry = entry
+		}
+
+		seen[userID] = true
+		results = append(results, result)
+	}
+
+	s.logger.Info(ctx, "Group joined queue", "event_id", eventID, "group_size", len(userIDs))
+	return results, nil
+}
+
+// LeaveQueue removes a user from an event's queue, giving up their place or active session.
+// The removed entry is returned marked completed. Stored positions of users queued behind them are not
+// renumbered, but GetQueuePosition and GetQueueStatus report their live place, one further up.
+func (s *QueueService) LeaveQueue(ctx context.Context, eventID, userID uuid.UUID) (*domain.QueueEntry, error) {
+	s.logger.Info(ctx, "User leaving queue", "event_id", eventID, "user_id", userID)
+
+	entry, err := s.queueRepo.GetPosition(ctx, eventID, userID)
+	if err != nil {
+		s.logger.Warn(ctx, "User is not in the queue", "event_id", eventID, "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to find queue entry: %w", err)
+	}
+
+	if err := s.queueRepo.RemoveFromQueue(ctx, entry.ID); err != nil {
+		s.logger.Error(ctx, "Failed to remove user from queue", "entry_id", entry.ID, "error", err)
+		return nil, fmt.Errorf("failed to leave queue: %w", err)
+	}
+
+	// Invalidate queue length cache
+	cacheKey := fmt.Sprintf("queue_length:%s", eventID.String())
+	if err := s.cache.Delete(ctx, cacheKey); err != nil {
+		s.logger.Warn(ctx, "Failed to invalidate queue length cache", "error", err)
+	}
+
+	entry.Status = string(domain.QueueStatusCompleted)
+	entry.UpdatedAt = time.Now()
+
+	s.logger.Info(ctx, "User left queue", "event_id", eventID, "user_id", userID, "entry_id", entry.ID)
 	return entry, nil
 }
 
@@ -96,9 +315,26 @@ func (s *QueueService) GetQueuePosition(ctx context.Context, eventID, userID uui
 		return nil, fmt.Errorf("failed to get queue position: %w", err)
 	}
 
+	s.refreshPosition(ctx, entry)
+
 	return entry, nil
 }
 
+// refreshPosition replaces the position an entry was given when it joined with its live place in the queue,
+// which moves up as users ahead are activated or leave. An entry no longer in the queue keeps its stored
+// position, as does any entry when the lookup fails.
+func (s *QueueService) refreshPosition(ctx context.Context, entry *domain.QueueEntry) {
+	position, err := s.queueRepo.GetPositionInList(ctx, entry.EventID, entry.UserID)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to get live queue position", "event_id", entry.EventID, "user_id", entry.UserID, "error", err)
+		return
+	}
+
+	if position > 0 {
+		entry.Position = position
+	}
+}
+
 // GetQueueStatus retrieves queue status by session ID
 func (s *QueueService) GetQueueStatus(ctx context.Context, sessionID string) (*domain.QueueEntry, error) {
 	entry, err := s.queueRepo.GetBySessionID(ctx, sessionID)
@@ -108,14 +344,28 @@ func (s *QueueService) GetQueueStatus(ctx context.Context, sessionID string) (*d
 	}
 
 	// Check if entry has expired
-	if entry.IsExpired() {
+	if entry.IsExpiredAt(s.now()) {
 		s.logger.Info(ctx, "Queue entry expired", "session_id", sessionID, "entry_id", entry.ID)
 		return nil, fmt.Errorf("queue session has expired")
 	}
 
+	s.refreshPosition(ctx, entry)
+
 	return entry, nil
 }
 
+// GetActiveEntries retrieves the users currently holding an active purchase session for an event, soonest to
+// expire first
+func (s *QueueService) GetActiveEntries(ctx context.Context, eventID uuid.UUID) ([]*domain.QueueEntry, error) {
+	entries, err := s.queueRepo.GetActiveEntries(ctx, eventID, s.now())
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get active queue entries", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to get active queue entries: %w", err)
+	}
+
+	return entries, nil
+}
+
 // GetQueueLength retrieves the current queue length for an event
 func (s *QueueService) GetQueueLength(ctx context.Context, eventID uuid.UUID) (int, error) {
 	// Try cache first
@@ -132,8 +382,7 @@ func (s *QueueService) GetQueueLength(ctx context.Context, eventID uuid.UUID) (i
 		return 0, fmt.Errorf("failed to get queue length: %w", err)
 	}
 
-	// Cache for 30 seconds
-	if err := s.cache.Set(ctx, cacheKey, length, 30*time.Second); err != nil {
+	if err := s.cache.Set(ctx, cacheKey, length, s.cacheTTL.QueueLengthTTL); err != nil {
 		s.logger.Warn(ctx, "Failed to cache queue length", "error", err)
 	}
 
@@ -146,7 +395,7 @@ func (s *QueueService) ProcessQueue(ctx context.Context, eventID uuid.UUID) (*do
 
 	// Use distributed lock to prevent race conditions
 	lockKey := fmt.Sprintf("queue_process:%s", eventID.String())
-	acquired, err := s.lock.Acquire(ctx, lockKey, 5*time.Second)
+	token, acquired, err := s.lock.Acquire(ctx, lockKey, 5*time.Second)
 	if err != nil {
 		s.logger.Error(ctx, "Failed to acquire lock", "error", err)
 		return nil, fmt.Errorf("failed to acquire lock: %w", err)
@@ -158,17 +407,27 @@ func (s *QueueService) ProcessQueue(ctx context.Context, eventID uuid.UUID) (*do
 	}
 
 	defer func() {
-		if err := s.lock.Release(ctx, lockKey); err != nil {
+		if err := s.lock.Release(ctx, lockKey, token); err != nil {
 			s.logger.Error(ctx, "Failed to release lock", "error", err)
 		}
 	}()
 
+	now := s.now()
+	if err := s.paceActivation(ctx, eventID, now); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.activationAllowance(ctx, eventID, 1); err != nil {
+		return nil, err
+	}
+
 	// Activate next user
 	entry, err := s.queueRepo.ActivateNext(ctx, eventID)
 	if err != nil {
 		s.logger.Error(ctx, "Failed to activate next user", "error", err)
 		return nil, fmt.Errorf("failed to activate next user: %w", err)
 	}
+	s.recordActivation(ctx, eventID, now)
 
 	// Invalidate queue length cache
 	cacheKey := fmt.Sprintf("queue_length:%s", eventID.String())
@@ -181,9 +440,185 @@ func (s *QueueService) ProcessQueue(ctx context.Context, eventID uuid.UUID) (*do
 		"activated_user", entry.UserID,
 		"session_id", entry.SessionID)
 
+	s.issueWaitroomToken(ctx, entry)
+
+	s.notifyActivated(ctx, entry)
+	s.notifyPositionThresholds(ctx, eventID)
+
 	return entry, nil
 }
 
+// MaxQueueProcessBatchSize bounds how many users ProcessQueueBatch activates at once
+const MaxQueueProcessBatchSize = 100
+
+// ProcessQueueBatch activates up to count users and staggers their start times evenly across
+// the configured ActivationStaggerWindow. Each user's expiry is pushed back by their offset so
+// everyone keeps the same purchase window. Returns fewer entries if the queue runs out.
+func (s *QueueService) ProcessQueueBatch(ctx context.Context, eventID uuid.UUID, count int) ([]*domain.QueueEntry, error) {
+	if count <= 0 || count > MaxQueueProcessBatchSize {
+		return nil, fmt.Errorf("batch size must be between 1 and %d", MaxQueueProcessBatchSize)
+	}
+
+	s.logger.Info(ctx, "Processing queue batch", "event_id", eventID, "count", count)
+
+	lockKey := fmt.Sprintf("queue_process:%s", eventID.String())
+	token, acquired, err := s.lock.Acquire(ctx, lockKey, 5*time.Second)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to acquire lock", "error", err)
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+
+	if !acquired {
+		s.logger.Warn(ctx, "Failed to acquire lock - queue processing busy", "event_id", eventID)
+		return nil, fmt.Errorf("queue processing is busy, please try again")
+	}
+
+	defer func() {
+		if err := s.lock.Release(ctx, lockKey, token); err != nil {
+			s.logger.Error(ctx, "Failed to release lock", "error", err)
+		}
+	}()
+
+	now := s.now()
+	if err := s.paceActivation(ctx, eventID, now); err != nil {
+		return nil, err
+	}
+
+	count, err = s.activationAllowance(ctx, eventID, count)
+	if err != nil {
+		return nil, err
+	}
+	offsets := ActivationOffsets(count, s.config.ActivationStaggerWindow)
+
+	activated := make([]*domain.QueueEntry, 0, count)
+	for _, offset := range offsets {
+		entry, err := s.queueRepo.ActivateNext(ctx, eventID)
+		if err != nil {
+			if len(activated) == 0 {
+				s.logger.Error(ctx, "Failed to activate next user", "error", err)
+				return nil, fmt.Errorf("failed to activate next user: %w", err)
+			}
+			// The queue ran dry part way through the batch
+			break
+		}
+
+		if offset > 0 {
+			startAt := now.Add(offset)
+			entry.StartAt = &startAt
+			if entry.ExpiresAt != nil {
+				expiresAt := entry.ExpiresAt.Add(offset)
+				entry.ExpiresAt = &expiresAt
+			}
+			if err := s.queueRepo.Update(ctx, entry); err != nil {
+				s.logger.Warn(ctx, "Failed to record staggered start", "entry_id", entry.ID, "error", err)
+			}
+		}
+
+		s.issueWaitroomToken(ctx, entry)
+		activated = append(activated, entry)
+	}
+	s.recordActivation(ctx, eventID, now)
+
+	cacheKey := fmt.Sprintf("queue_length:%s", eventID.String())
+	if err := s.cache.Delete(ctx, cacheKey); err != nil {
+		s.logger.Warn(ctx, "Failed to invalidate queue length cache", "error", err)
+	}
+
+	s.logger.Info(ctx, "Queue batch processed", "event_id", eventID, "activated", len(activated))
+
+	for _, entry := range activated {
+		s.notifyActivated(ctx, entry)
+	}
+	s.notifyPositionThresholds(ctx, eventID)
+
+	return activated, nil
+}
+
+// ActivationOffsets spreads count start offsets evenly over window, the first starting immediately
+func ActivationOffsets(count int, window time.Duration) []time.Duration {
+	offsets := make([]time.Duration, count)
+	if count <= 1 || window <= 0 {
+		return offsets
+	}
+
+	step := window / time.Duration(count)
+	for i := range offsets {
+		offsets[i] = time.Duration(i) * step
+	}
+	return offsets
+}
+
+// notifyActivated tells a user that it is their turn to purchase
+func (s *QueueService) notifyActivated(ctx context.Context, entry *domain.QueueEntry) {
+	if s.notifier == nil {
+		return
+	}
+
+	subject := "It's your turn"
+	body := "You have reached the front of the queue and can now purchase tickets."
+	if entry.StartAt != nil {
+		body = fmt.Sprintf("You have reached the front of the queue and can purchase tickets from %s.", entry.StartAt.Format(time.RFC1123))
+	}
+	if entry.ExpiresAt != nil {
+		body += fmt.Sprintf(" Your turn ends at %s.", entry.ExpiresAt.Format(time.RFC1123))
+	}
+
+	if err := s.notifier.SendEmail(ctx, entry.UserID, subject, body); err != nil {
+		s.logger.Warn(ctx, "Failed to send activation notice", "entry_id", entry.ID, "error", err)
+	}
+}
+
+// notifyPositionThresholds notifies waiting users whose live position crossed a configured threshold.
+// Each entry remembers the smallest threshold it was told about, so every threshold fires at most once.
+func (s *QueueService) notifyPositionThresholds(ctx context.Context, eventID uuid.UUID) {
+	if s.notifier == nil || len(s.config.PositionNotifyThresholds) == 0 {
+		return
+	}
+
+	maxThreshold := 0
+	for _, threshold := range s.config.PositionNotifyThresholds {
+		if threshold > maxThreshold {
+			maxThreshold = threshold
+		}
+	}
+
+	head, err := s.queueRepo.GetQueueHead(ctx, eventID, maxThreshold)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to get queue head for notifications", "event_id", eventID, "error", err)
+		return
+	}
+
+	for i, entry := range head {
+		if !entry.IsWaiting() {
+			continue
+		}
+
+		position := i + 1
+		crossed := 0
+		for _, threshold := range s.config.PositionNotifyThresholds {
+			if position <= threshold && (crossed == 0 || threshold < crossed) {
+				crossed = threshold
+			}
+		}
+
+		if crossed == 0 || (entry.LastNotifiedThreshold != 0 && entry.LastNotifiedThreshold <= crossed) {
+			continue
+		}
+
+		subject := "You're almost there"
+		body := fmt.Sprintf("You are now in the top %d of the queue.", crossed)
+		if err := s.notifier.SendEmail(ctx, entry.UserID, subject, body); err != nil {
+			s.logger.Warn(ctx, "Failed to send queue position notice", "entry_id", entry.ID, "error", err)
+			continue
+		}
+
+		entry.LastNotifiedThreshold = crossed
+		if err := s.queueRepo.Update(ctx, entry); err != nil {
+			s.logger.Warn(ctx, "Failed to record queue position notice", "entry_id", entry.ID, "error", err)
+		}
+	}
+}
+
 // EstimateWaitTime estimates wait time for a user in queue
 func (s *QueueService) EstimateWaitTime(ctx context.Context, eventID, userID uuid.UUID) (time.Duration, error) {
 	entry, err := s.queueRepo.GetPosition(ctx, eventID, userID)
@@ -208,10 +643,12 @@ func (s *QueueService) IsUserActive(ctx context.Context, eventID, userID uuid.UU
 		return false, fmt.Errorf("failed to get queue position: %w", err)
 	}
 
-	return entry.IsActive() && !entry.IsExpired(), nil
+	return entry.IsActive() && !entry.IsExpiredAt(s.now()), nil
 }
 
-// RefreshSession refreshes an active session's expiration time
+// RefreshSession refreshes an active session's expiration time; a session at or past its expiry fails with
+// ErrSessionExpired. Refreshes are limited by QueueConfig.MaxSessionRefreshes and MaxActiveWindow; once either
+// is used up it fails with ErrSessionRefreshLimit and the session runs out at its current expiry.
 func (s *QueueService) RefreshSession(ctx context.Context, sessionID string) error {
 	s.logger.Info(ctx, "Refreshing session", "session_id", sessionID)
 
@@ -226,13 +663,74 @@ func (s *QueueService) RefreshSession(ctx context.Context, sessionID string) err
 		return fmt.Errorf("session is not active")
 	}
 
-	// Extend session by 15 minutes
-	newExpiry := time.Now().Add(15 * time.Minute)
+	now := s.now()
+	if entry.ExpiresAt != nil && !entry.ExpiresAt.After(now) {
+		s.logger.Warn(ctx, "Session has expired", "session_id", sessionID, "expires_at", entry.ExpiresAt)
+		return ErrSessionExpired
+	}
+
+	// Entries activated before refreshes were tracked count from now
+	if entry.ActivatedAt == nil {
+		entry.ActivatedAt = &now
+	}
+
+	if s.config.MaxSessionRefreshes > 0 && entry.RefreshCount >= s.config.MaxSessionRefreshes {
+		s.logger.Warn(ctx, "Session refresh limit reached", "session_id", sessionID, "refreshes", entry.RefreshCount)
+		return fmt.Errorf("%w: already refreshed %d times", ErrSessionRefreshLimit, entry.RefreshCount)
+	}
+
+	// Extend session by 15 minutes, but never past the end of the active window
+	newExpiry := now.Add(15 * time.Minute)
+	if s.config.MaxActiveWindow > 0 {
+		if windowEnd := entry.ActivatedAt.Add(s.config.MaxActiveWindow); newExpiry.After(windowEnd) {
+			newExpiry = windowEnd
+		}
+
+		if !newExpiry.After(now) || (entry.ExpiresAt != nil && !newExpiry.After(*entry.ExpiresAt)) {
+			s.logger.Warn(ctx, "Session active window used up", "session_id", sessionID, "activated_at", entry.ActivatedAt)
+			return fmt.Errorf("%w: active window of %s used up", ErrSessionRefreshLimit, s.config.MaxActiveWindow)
+		}
+	}
+
 	entry.ExpiresAt = &newExpiry
-	entry.UpdatedAt = time.Now()
+	entry.RefreshCount++
+
+	if err := s.queueRepo.Update(ctx, entry); err != nil {
+		s.logger.Error(ctx, "Failed to refresh session", "session_id", sessionID, "error", err)
+		return fmt.Errorf("failed to refresh session: %w", err)
+	}
+
+	// Expire the stored entry and session with the session so stale keys are reaped
+	if err := s.queueRepo.UpdateExpiry(ctx, sessionID, newExpiry, now); err != nil {
+		s.logger.Error(ctx, "Failed to extend session expiry", "session_id", sessionID, "error", err)
+		return fmt.Errorf("failed to extend session expiry: %w", err)
+	}
+
+	// The previous token expires with the previous session, so sign one for the extended session
+	s.issueWaitroomToken(ctx, entry)
 
-	// Save updated entry (this would need to be implemented in the repository)
 	s.logger.Info(ctx, "Session refreshed successfully", "session_id", sessionID)
 
 	return nil
 }
+
+// activationAllowance returns how many of the wanted users may be activated. With ActivateOnlyWithInventory
+// it is capped at the event's remaining tickets and fails with ErrNoInventoryToActivate when none are left.
+func (s *QueueService) activationAllowance(ctx context.Context, eventID uuid.UUID, want int) (int, error) {
+	if !s.config.ActivateOnlyWithInventory {
+		return want, nil
+	}
+
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get event for inventory check", "event_id", eventID, "error", err)
+		return 0, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if event.IsSoldOut() {
+		s.logger.Info(ctx, "Holding queue until inventory returns", "event_id", eventID)
+		return 0, ErrNoInventoryToActivate
+	}
+
+	return min(want, event.AvailableTickets), nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// ActivateAll lets everyone still waiting for an event in at once, for closing out a sale with inventory to
+// spare. Users are activated in queue order with a full 15 minute session and the waiting list is cleared.
+// Unless bypassCap is set, ActivateOnlyWithInventory still caps the activations at the remaining tickets and
+// leaves the rest waiting. Activation pacing does not apply, but the activation is recorded for it.
+func (s *QueueService) ActivateAll(ctx context.Context, eventID uuid.UUID, bypassCap bool) ([]*domain.QueueEntry, error) {
+	s.logger.Info(ctx, "Activating entire queue", "event_id", eventID, "bypass_cap", bypassCap)
+
+	lockKey := fmt.Sprintf("queue_process:%s", eventID.String())
+	token, acquired, err := s.lock.Acquire(ctx, lockKey, 5*time.Second)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to acquire lock", "error", err)
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+
+	if !acquired {
+		s.logger.Warn(ctx, "Failed to acquire lock - queue processing busy", "event_id", eventID)
+		return nil, fmt.Errorf("queue processing is busy, please try again")
+	}
+
+	defer func() {
+		if err := s.lock.Release(ctx, lockKey, token); err != nil {
+			s.logger.Error(ctx, "Failed to release lock", "error", err)
+		}
+	}()
+
+	limit := 0
+	if !bypassCap && s.config.ActivateOnlyWithInventory {
+		length, err := s.queueRepo.GetQueueLength(ctx, eventID)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get queue length", "event_id", eventID, "error", err)
+			return nil, fmt.Errorf("failed to get queue length: %w", err)
+		}
+		if length == 0 {
+			return []*domain.QueueEntry{}, nil
+		}
+
+		limit, err = s.activationAllowance(ctx, eventID, length)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := s.now()
+	activated, err := s.queueRepo.ActivateAll(ctx, eventID, now.Add(15*time.Minute), limit)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to activate queue", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to activate queue: %w", err)
+	}
+	if len(activated) > 0 {
+		s.recordActivation(ctx, eventID, now)
+	}
+
+	cacheKey := fmt.Sprintf("queue_length:%s", eventID.String())
+	if err := s.cache.Delete(ctx, cacheKey); err != nil {
+		s.logger.Warn(ctx, "Failed to invalidate queue length cache", "error", err)
+	}
+
+	for _, entry := range activated {
+		s.issueWaitroomToken(ctx, entry)
+		s.notifyActivated(ctx, entry)
+	}
+	s.notifyPositionThresholds(ctx, eventID)
+
+	s.logger.Info(ctx, "Queue activated", "event_id", eventID, "activated", len(activated))
+
+	return activated, nil
+}
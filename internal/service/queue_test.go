@@ -0,0 +1,584 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/pkg/clock"
+	"github.com/snowmerak/ticketing/pkg/logger"
+	"github.com/snowmerak/ticketing/pkg/repository/memory"
+)
+
+// testQueue is a QueueService backed by in-memory repositories and a manual clock, with the repositories at hand
+// for setup and assertions
+type testQueue struct {
+	service *QueueService
+	events  *memory.EventRepository
+	queue   *memory.QueueRepository
+	clock   *clock.Manual
+}
+
+// newTestQueue builds a QueueService over fresh in-memory repositories with its clock set to now
+func newTestQueue(t *testing.T) *testQueue {
+	t.Helper()
+
+	tq := &testQueue{
+		events: memory.NewEventRepository(),
+		queue:  memory.NewQueueRepository(),
+		clock:  clock.NewManual(time.Now()),
+	}
+	tq.service = NewQueueService(tq.queue, tq.events, testCache{}, newTestLock(), logger.NewLoggerWithLevel(zerolog.Disabled))
+	tq.service.SetClock(tq.clock)
+	return tq
+}
+
+// createEvent stores an active event with available tickets left
+func (tq *testQueue) createEvent(t *testing.T, available int) *domain.Event {
+	t.Helper()
+
+	now := time.Now()
+	event := &domain.Event{
+		ID:               uuid.New(),
+		Name:             "Queue Test Event",
+		StartTime:        now.Add(24 * time.Hour),
+		EndTime:          now.Add(27 * time.Hour),
+		Venue:            "Test Hall",
+		Status:           string(domain.EventStatusActive),
+		TotalTickets:     available,
+		AvailableTickets: available,
+		Currency:         "USD",
+	}
+	if err := tq.events.Create(context.Background(), event); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+	return event
+}
+
+// activate joins a user to an event's queue and activates their session at the clock's time until expiresIn
+// later, returning the session ID
+func (tq *testQueue) activate(t *testing.T, eventID uuid.UUID, expiresIn time.Duration) string {
+	t.Helper()
+	ctx := context.Background()
+
+	sessionID := uuid.NewString()
+	entry, err := tq.queue.Join(ctx, eventID, uuid.New(), sessionID)
+	if err != nil {
+		t.Fatalf("join queue: %v", err)
+	}
+
+	now := tq.clock.Now()
+	expiresAt := now.Add(expiresIn)
+	entry.Status = string(domain.QueueStatusActive)
+	entry.ActivatedAt = &now
+	entry.ExpiresAt = &expiresAt
+	if err := tq.queue.Update(ctx, entry); err != nil {
+		t.Fatalf("activate session: %v", err)
+	}
+	return sessionID
+}
+
+func TestRefreshSession(t *testing.T) {
+	tests := []struct {
+		name string
+		// advance moves the clock between activation and the refresh
+		advance   time.Duration
+		refreshes int
+		want      error
+	}{
+		{name: "active session", advance: time.Minute},
+		{name: "last refresh allowed", advance: time.Minute, refreshes: 1},
+		{name: "refresh cap reached", advance: time.Minute, refreshes: 2, want: ErrSessionRefreshLimit},
+		{name: "exactly at expiry", advance: 5 * time.Minute, want: ErrSessionExpired},
+		{name: "past expiry", advance: 10 * time.Minute, want: ErrSessionExpired},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tq := newTestQueue(t)
+			config := DefaultQueueConfig()
+			config.MaxSessionRefreshes = 2
+			tq.service.SetConfig(config)
+
+			event := tq.createEvent(t, 10)
+			sessionID := tq.activate(t, event.ID, 5*time.Minute)
+
+			entry, err := tq.queue.GetBySessionID(ctx, sessionID)
+			if err != nil {
+				t.Fatalf("get session: %v", err)
+			}
+			entry.RefreshCount = tc.refreshes
+			if err := tq.queue.Update(ctx, entry); err != nil {
+				t.Fatalf("set refresh count: %v", err)
+			}
+
+			tq.clock.Advance(tc.advance)
+			err = tq.service.RefreshSession(ctx, sessionID)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("refresh session: got %v, want %v", err, tc.want)
+			}
+
+			stored, err := tq.queue.GetBySessionID(ctx, sessionID)
+			if err != nil {
+				t.Fatalf("get session: %v", err)
+			}
+			if tc.want != nil {
+				if stored.RefreshCount != tc.refreshes {
+					t.Fatalf("refresh count = %d after a refused refresh, want %d", stored.RefreshCount, tc.refreshes)
+				}
+				return
+			}
+			if want := tq.clock.Now().Add(15 * time.Minute); stored.ExpiresAt == nil || !stored.ExpiresAt.Equal(want) {
+				t.Fatalf("expires at %v, want %v", stored.ExpiresAt, want)
+			}
+			if stored.RefreshCount != tc.refreshes+1 {
+				t.Fatalf("refresh count = %d, want %d", stored.RefreshCount, tc.refreshes+1)
+			}
+		})
+	}
+}
+
+func TestLeaveQueueMovesLaterUsersUp(t *testing.T) {
+	ctx := context.Background()
+	tq := newTestQueue(t)
+	event := tq.createEvent(t, 10)
+
+	users := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+	for i, userID := range users {
+		entry, err := tq.service.JoinQueue(ctx, event.ID, userID, uuid.NewString())
+		if err != nil {
+			t.Fatalf("join queue: %v", err)
+		}
+		if entry.Position != i+1 {
+			t.Fatalf("user %d joined at position %d, want %d", i, entry.Position, i+1)
+		}
+	}
+
+	left, err := tq.service.LeaveQueue(ctx, event.ID, users[1])
+	if err != nil {
+		t.Fatalf("leave queue: %v", err)
+	}
+	if left.UserID != users[1] || left.Status != string(domain.QueueStatusCompleted) {
+		t.Fatalf("left entry of user %s with status %s, want user %s completed", left.UserID, left.Status, users[1])
+	}
+
+	if _, err := tq.service.GetQueuePosition(ctx, event.ID, users[1]); err == nil {
+		t.Fatal("user still has a queue position after leaving")
+	}
+	if _, err := tq.service.LeaveQueue(ctx, event.ID, users[1]); err == nil {
+		t.Fatal("left the queue twice")
+	}
+
+	// Users ahead keep their place, users behind move up one
+	for i, want := range map[int]int{0: 1, 2: 2, 3: 3} {
+		entry, err := tq.service.GetQueuePosition(ctx, event.ID, users[i])
+		if err != nil {
+			t.Fatalf("get position of user %d: %v", i, err)
+		}
+		if entry.Position != want {
+			t.Fatalf("user %d at position %d after user 1 left, want %d", i, entry.Position, want)
+		}
+	}
+	if length, err := tq.service.GetQueueLength(ctx, event.ID); err != nil || length != 3 {
+		t.Fatalf("queue length = %d (err %v), want 3", length, err)
+	}
+}
+
+func TestJoinQueueBatch(t *testing.T) {
+	// nobody stands in for uuid.Nil among the members
+	const nobody = -1
+
+	tests := []struct {
+		name string
+		// members are indexes into a fresh set of users, in the order the coordinator lists them
+		members []int
+		// queued are the users who joined on their own before the batch
+		queued []int
+		// wantErrors is the error reported for each member, empty for members the batch enqueued
+		wantErrors []string
+		wantFail   bool
+	}{
+		{name: "whole group joins", members: []int{0, 1, 2}, wantErrors: []string{"", "", ""}},
+		{name: "member already queued", members: []int{0, 1, 2}, queued: []int{1}, wantErrors: []string{"", "user is already in the queue", ""}},
+		{name: "member listed twice", members: []int{0, 0, 1}, wantErrors: []string{"", "user is listed more than once", ""}},
+		{name: "member without ID", members: []int{0, nobody}, wantErrors: []string{"", "user ID is required"}},
+		{name: "empty group", wantFail: true},
+		{name: "group too large", members: make([]int, MaxQueueBatchSize+1), wantFail: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tq := newTestQueue(t)
+			event := tq.createEvent(t, 10)
+
+			users := make([]uuid.UUID, 3)
+			for i := range users {
+				users[i] = uuid.New()
+			}
+			ownSessions := make(map[uuid.UUID]string)
+			for _, i := range tc.queued {
+				ownSessions[users[i]] = uuid.NewString()
+				if _, err := tq.service.JoinQueue(ctx, event.ID, users[i], ownSessions[users[i]]); err != nil {
+					t.Fatalf("join queue alone: %v", err)
+				}
+			}
+
+			members := make([]uuid.UUID, len(tc.members))
+			for i, member := range tc.members {
+				if member != nobody {
+					members[i] = users[member]
+				}
+			}
+
+			const coordinator = "coordinator-session"
+			results, err := tq.service.JoinQueueBatch(ctx, event.ID, members, coordinator)
+			if tc.wantFail {
+				if err == nil {
+					t.Fatalf("batch of %d members was accepted", len(members))
+				}
+				if length, _ := tq.queue.GetQueueLength(ctx, event.ID); length != 0 {
+					t.Fatalf("queue length = %d after a refused batch, want 0", length)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("join queue batch: %v", err)
+			}
+			if len(results) != len(members) {
+				t.Fatalf("got %d results for %d members", len(results), len(members))
+			}
+
+			// Enqueued members follow the users already queued, in the order they were listed
+			position := len(tc.queued)
+			for i, result := range results {
+				if result.UserID != members[i] || result.Error != tc.wantErrors[i] {
+					t.Fatalf("result %d = user %s error %q, want user %s error %q", i, result.UserID, result.Error, members[i], tc.wantErrors[i])
+				}
+
+				if own, ok := ownSessions[result.UserID]; ok {
+					if result.Entry == nil || result.Entry.SessionID != own {
+						t.Fatalf("already queued member %d reported entry %+v, want their own session %s", i, result.Entry, own)
+					}
+					continue
+				}
+				if result.Error != "" {
+					continue
+				}
+
+				position++
+				want := coordinator + ":" + result.UserID.String()
+				if result.Entry == nil || result.Entry.SessionID != want || result.Entry.Position != position {
+					t.Fatalf("member %d entry %+v, want session %s at position %d", i, result.Entry, want, position)
+				}
+				if _, err := tq.queue.GetBySessionID(ctx, want); err != nil {
+					t.Fatalf("member %d session not stored: %v", i, err)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessQueueBatchStaggersStarts(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  time.Duration
+		waiting int
+		count   int
+		// want are the start offsets from the clock's time of the users activated, in queue order
+		want []time.Duration
+	}{
+		{name: "spread over the window", window: 10 * time.Second, waiting: 4, count: 4, want: []time.Duration{0, 2500 * time.Millisecond, 5 * time.Second, 7500 * time.Millisecond}},
+		{name: "no window", waiting: 3, count: 3, want: []time.Duration{0, 0, 0}},
+		{name: "single user", window: 10 * time.Second, waiting: 2, count: 1, want: []time.Duration{0}},
+		{name: "queue runs dry", window: 10 * time.Second, waiting: 3, count: 5, want: []time.Duration{0, 2 * time.Second, 4 * time.Second}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tq := newTestQueue(t)
+			config := DefaultQueueConfig()
+			config.ActivationStaggerWindow = tc.window
+			tq.service.SetConfig(config)
+
+			event := tq.createEvent(t, 10)
+			// The first user to join is activated on joining; the rest wait behind them
+			for range tc.waiting + 1 {
+				if _, err := tq.service.JoinQueue(ctx, event.ID, uuid.New(), uuid.NewString()); err != nil {
+					t.Fatalf("join queue: %v", err)
+				}
+			}
+
+			// Starts follow the injected clock, not the wall clock
+			tq.clock.Advance(time.Hour)
+			now := tq.clock.Now()
+
+			activated, err := tq.service.ProcessQueueBatch(ctx, event.ID, tc.count)
+			if err != nil {
+				t.Fatalf("process queue batch: %v", err)
+			}
+			if len(activated) != len(tc.want) {
+				t.Fatalf("activated %d users, want %d", len(activated), len(tc.want))
+			}
+
+			for i, entry := range activated {
+				offset := tc.want[i]
+				if offset == 0 {
+					if entry.StartAt != nil {
+						t.Fatalf("user %d starts at %v, want immediately", i, *entry.StartAt)
+					}
+				} else if entry.StartAt == nil || !entry.StartAt.Equal(now.Add(offset)) {
+					t.Fatalf("user %d starts at %v, want %v", i, entry.StartAt, now.Add(offset))
+				}
+
+				// Everyone keeps the same purchase window from their own start
+				if window := entry.ExpiresAt.Sub(*entry.ActivatedAt); window != 15*time.Minute+offset {
+					t.Fatalf("user %d expires %v after activation, want %v", i, window, 15*time.Minute+offset)
+				}
+
+				stored, err := tq.queue.GetBySessionID(ctx, entry.SessionID)
+				if err != nil {
+					t.Fatalf("get session: %v", err)
+				}
+				if !stored.ExpiresAt.Equal(*entry.ExpiresAt) || (offset > 0 && !stored.StartAt.Equal(*entry.StartAt)) {
+					t.Fatalf("stored user %d starts at %v expiring %v, want %v expiring %v", i, stored.StartAt, stored.ExpiresAt, entry.StartAt, entry.ExpiresAt)
+				}
+			}
+		})
+	}
+}
+
+func TestActivationAllowance(t *testing.T) {
+	tests := []struct {
+		name      string
+		gated     bool
+		available int
+		want      int
+		// allowed is how many of want may be activated, or -1 when the queue is held
+		allowed int
+	}{
+		{name: "ungated ignores inventory", available: 0, want: 5, allowed: 5},
+		{name: "inventory above the batch", gated: true, available: 10, want: 5, allowed: 5},
+		{name: "inventory equal to the batch", gated: true, available: 5, want: 5, allowed: 5},
+		{name: "batch capped at inventory", gated: true, available: 3, want: 5, allowed: 3},
+		{name: "nothing wanted", gated: true, available: 3, want: 0, allowed: 0},
+		{name: "no inventory", gated: true, available: 0, want: 5, allowed: -1},
+		{name: "oversold inventory", gated: true, available: -2, want: 5, allowed: -1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tq := newTestQueue(t)
+			config := DefaultQueueConfig()
+			config.ActivateOnlyWithInventory = tc.gated
+			tq.service.SetConfig(config)
+
+			event := tq.createEvent(t, 10)
+			event.AvailableTickets = tc.available
+			if err := tq.events.Update(ctx, event); err != nil {
+				t.Fatalf("set inventory: %v", err)
+			}
+
+			allowed, err := tq.service.activationAllowance(ctx, event.ID, tc.want)
+			if tc.allowed < 0 {
+				if !errors.Is(err, ErrNoInventoryToActivate) {
+					t.Fatalf("expected ErrNoInventoryToActivate, got %d, %v", allowed, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("activation allowance: %v", err)
+			}
+			if allowed != tc.allowed {
+				t.Fatalf("allowance = %d, want %d", allowed, tc.allowed)
+			}
+		})
+	}
+}
+
+func TestProcessQueueHeldUntilRestock(t *testing.T) {
+	ctx := context.Background()
+	tq := newTestQueue(t)
+	config := DefaultQueueConfig()
+	config.ActivateOnlyWithInventory = true
+	tq.service.SetConfig(config)
+
+	event := tq.createEvent(t, 10)
+	// The first user to join is activated on joining; the other waits behind them
+	for range 2 {
+		if _, err := tq.service.JoinQueue(ctx, event.ID, uuid.New(), uuid.NewString()); err != nil {
+			t.Fatalf("join queue: %v", err)
+		}
+	}
+
+	event.AvailableTickets = 0
+	if err := tq.events.Update(ctx, event); err != nil {
+		t.Fatalf("sell out event: %v", err)
+	}
+	if _, err := tq.service.ProcessQueue(ctx, event.ID); !errors.Is(err, ErrNoInventoryToActivate) {
+		t.Fatalf("expected ErrNoInventoryToActivate while sold out, got %v", err)
+	}
+	if _, err := tq.service.ProcessQueueBatch(ctx, event.ID, 5); !errors.Is(err, ErrNoInventoryToActivate) {
+		t.Fatalf("expected ErrNoInventoryToActivate for a batch while sold out, got %v", err)
+	}
+	if length, err := tq.queue.GetQueueLength(ctx, event.ID); err != nil || length != 2 {
+		t.Fatalf("queue length = %d (err %v) while held, want 2", length, err)
+	}
+
+	event.AvailableTickets = 1
+	if err := tq.events.Update(ctx, event); err != nil {
+		t.Fatalf("restock event: %v", err)
+	}
+	entry, err := tq.service.ProcessQueue(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("process queue after restock: %v", err)
+	}
+	if !entry.IsActive() {
+		t.Fatalf("entry status = %s after restock, want active", entry.Status)
+	}
+}
+
+// expectPaced fails the test unless err paces the activation for retryAfter
+func expectPaced(t *testing.T, err error, retryAfter time.Duration) {
+	t.Helper()
+
+	var paced *ActivationPacedError
+	if !errors.As(err, &paced) || !errors.Is(err, ErrActivationTooSoon) {
+		t.Fatalf("expected a paced activation, got %v", err)
+	}
+	if paced.RetryAfter != retryAfter {
+		t.Fatalf("retry after %v, want %v", paced.RetryAfter, retryAfter)
+	}
+}
+
+func TestProcessQueuePacing(t *testing.T) {
+	ctx := context.Background()
+	tq := newTestQueue(t)
+	config := DefaultQueueConfig()
+	config.MinActivationInterval = 30 * time.Second
+	tq.service.SetConfig(config)
+
+	event := tq.createEvent(t, 10)
+	// The first user to join is activated on joining; the rest wait behind them
+	users := make([]uuid.UUID, 5)
+	for i := range users {
+		users[i] = uuid.New()
+		if _, err := tq.service.JoinQueue(ctx, event.ID, users[i], uuid.NewString()); err != nil {
+			t.Fatalf("join queue: %v", err)
+		}
+	}
+
+	first, err := tq.service.ProcessQueue(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("first activation: %v", err)
+	}
+	if first.UserID != users[1] {
+		t.Fatalf("activated user %s, want the next in line %s", first.UserID, users[1])
+	}
+
+	// A second call moments later is paced, and so is a batch
+	tq.clock.Advance(10 * time.Second)
+	_, err = tq.service.ProcessQueue(ctx, event.ID)
+	expectPaced(t, err, 20*time.Second)
+	_, err = tq.service.ProcessQueueBatch(ctx, event.ID, 2)
+	expectPaced(t, err, 20*time.Second)
+
+	waiting, err := tq.service.GetQueuePosition(ctx, event.ID, users[2])
+	if err != nil {
+		t.Fatalf("get position: %v", err)
+	}
+	if !waiting.IsWaiting() {
+		t.Fatalf("next user is %s after a paced activation, want waiting", waiting.Status)
+	}
+
+	// Once the interval has passed the next activation goes through and restarts the interval
+	tq.clock.Advance(20 * time.Second)
+	batch, err := tq.service.ProcessQueueBatch(ctx, event.ID, 2)
+	if err != nil {
+		t.Fatalf("activation after the interval: %v", err)
+	}
+	if len(batch) != 2 || batch[0].UserID != users[2] || batch[1].UserID != users[3] {
+		t.Fatalf("activated %d users, want users 2 and 3", len(batch))
+	}
+	_, err = tq.service.ProcessQueue(ctx, event.ID)
+	expectPaced(t, err, 30*time.Second)
+}
+
+func TestProcessQueueUnpaced(t *testing.T) {
+	ctx := context.Background()
+	tq := newTestQueue(t)
+	event := tq.createEvent(t, 10)
+	for range 3 {
+		if _, err := tq.service.JoinQueue(ctx, event.ID, uuid.New(), uuid.NewString()); err != nil {
+			t.Fatalf("join queue: %v", err)
+		}
+	}
+
+	for i := range 2 {
+		if _, err := tq.service.ProcessQueue(ctx, event.ID); err != nil {
+			t.Fatalf("activation %d without pacing: %v", i, err)
+		}
+	}
+}
+
+func TestPositionThresholdNotices(t *testing.T) {
+	ctx := context.Background()
+	tq := newTestQueue(t)
+	notifier := &testNotifier{}
+	tq.service.SetNotifier(notifier)
+	config := DefaultQueueConfig()
+	config.PositionNotifyThresholds = []int{3, 2}
+	tq.service.SetConfig(config)
+
+	event := tq.createEvent(t, 10)
+	// The first user to join is activated on joining; the rest wait behind them
+	users := make([]uuid.UUID, 6)
+	for i := range users {
+		users[i] = uuid.New()
+		if _, err := tq.service.JoinQueue(ctx, event.ID, users[i], uuid.NewString()); err != nil {
+			t.Fatalf("join queue: %v", err)
+		}
+	}
+
+	// notices lists the position notices a user was sent, as their bodies
+	notices := func(userID uuid.UUID) []string {
+		var bodies []string
+		for _, email := range notifier.sent(userID) {
+			if email.subject == "You're almost there" {
+				bodies = append(bodies, email.body)
+			}
+		}
+		return bodies
+	}
+	top := func(n int) string { return fmt.Sprintf("You are now in the top %d of the queue.", n) }
+
+	// Each activation moves everyone up one; the live position counts the active user at the head
+	steps := []map[int][]string{
+		{2: {top(2)}, 3: {top(3)}},
+		{2: {top(2)}, 3: {top(3), top(2)}, 4: {top(3)}},
+		{2: {top(2)}, 3: {top(3), top(2)}, 4: {top(3), top(2)}, 5: {top(3)}},
+		{2: {top(2)}, 3: {top(3), top(2)}, 4: {top(3), top(2)}, 5: {top(3), top(2)}},
+	}
+	for step, want := range steps {
+		if _, err := tq.service.ProcessQueue(ctx, event.ID); err != nil {
+			t.Fatalf("activation %d: %v", step, err)
+		}
+		// Checking again without anyone moving sends nothing new
+		tq.service.notifyPositionThresholds(ctx, event.ID)
+
+		for i, userID := range users {
+			if got := notices(userID); !slices.Equal(got, want[i]) {
+				t.Fatalf("after activation %d user %d was sent %q, want %q", step, i, got, want[i])
+			}
+		}
+	}
+}
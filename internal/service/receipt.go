@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// TicketReceipt itemizes what was charged for a ticket, for the buyer and for fee and tax remittance
+type TicketReceipt struct {
+	TicketID    uuid.UUID             `json:"ticket_id"`
+	EventID     uuid.UUID             `json:"event_id"`
+	EventName   string                `json:"event_name,omitempty"`
+	SeatID      *uuid.UUID            `json:"seat_id,omitempty"`
+	UserID      uuid.UUID             `json:"user_id"`
+	GuestEmail  string                `json:"guest_email,omitempty"` // Set when a guest bought the ticket without an account
+	Status      string                `json:"status"`
+	Currency    string                `json:"currency,omitempty"`
+	Breakdown   domain.PriceBreakdown `json:"breakdown"`
+	IssuedAt    time.Time             `json:"issued_at"`
+	ConfirmedAt *time.Time            `json:"confirmed_at,omitempty"`
+	CancelledAt *time.Time            `json:"cancelled_at,omitempty"`
+}
+
+// GetReceipt builds the receipt of a ticket from the price breakdown fixed at purchase
+func (s *TicketingService) GetReceipt(ctx context.Context, ticketID uuid.UUID) (*TicketReceipt, error) {
+	ticket, err := s.GetTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := &TicketReceipt{
+		TicketID:    ticket.ID,
+		EventID:     ticket.EventID,
+		SeatID:      ticket.SeatID,
+		UserID:      ticket.UserID,
+		GuestEmail:  ticket.GuestEmail,
+		Status:      ticket.Status,
+		Currency:    ticket.Currency,
+		Breakdown:   ticket.PriceBreakdown(),
+		IssuedAt:    ticket.IssuedAt,
+		ConfirmedAt: ticket.ConfirmedAt,
+		CancelledAt: ticket.CancelledAt,
+	}
+
+	// The event name is a nicety; the receipt stands without it
+	if event, err := s.eventRepo.GetByID(ctx, ticket.EventID); err == nil {
+		receipt.EventName = event.Name
+	} else {
+		s.logger.Warn(ctx, "Failed to get event for receipt", "event_id", ticket.EventID, "error", err)
+	}
+
+	return receipt, nil
+}
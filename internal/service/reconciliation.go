@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// ErrDeadLetterDisabled is returned by the reconciliation methods when no dead-letter repository is configured
+var ErrDeadLetterDisabled = errors.New("dead-letter repository is not configured")
+
+// SetDeadLetterRepository sets the optional repository that keeps failed inventory writes for later replay
+func (s *TicketingService) SetDeadLetterRepository(deadLetterRepo repository.DeadLetterRepository) {
+	s.deadLetterRepo = deadLetterRepo
+}
+
+// recordFailedAction dead-letters an inventory write that failed after the purchase flow moved past it.
+// The caller has already logged the failure; without a dead-letter repository nothing more can be done.
+func (s *TicketingService) recordFailedAction(ctx context.Context, action *domain.FailedAction, cause error) {
+	if s.deadLetterRepo == nil {
+		return
+	}
+
+	now := time.Now()
+	action.ID = s.newID()
+	action.LastError = cause.Error()
+	action.Attempts = 1
+	action.CreatedAt = now
+	action.LastAttemptAt = now
+
+	if err := s.deadLetterRepo.Add(ctx, action); err != nil {
+		s.logger.Error(ctx, "Failed to dead-letter inventory action",
+			"kind", action.Kind,
+			"event_id", action.EventID,
+			"seat_id", action.SeatID,
+			"error", err)
+	}
+}
+
+// ListFailedActions lists dead-lettered inventory writes, oldest first
+func (s *TicketingService) ListFailedActions(ctx context.Context, limit, offset int) ([]*domain.FailedAction, int, error) {
+	if s.deadLetterRepo == nil {
+		return nil, 0, ErrDeadLetterDisabled
+	}
+
+	actions, total, err := s.deadLetterRepo.List(ctx, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list failed actions: %w", err)
+	}
+
+	return actions, total, nil
+}
+
+// RetryFailedAction replays a dead-lettered write. On success the action is removed from the
+// dead-letter; on failure its attempt count and last error are updated and it stays for the next retry.
+func (s *TicketingService) RetryFailedAction(ctx context.Context, id uuid.UUID) error {
+	if s.deadLetterRepo == nil {
+		return ErrDeadLetterDisabled
+	}
+
+	action, err := s.deadLetterRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get failed action: %w", err)
+	}
+
+	if replayErr := s.replayFailedAction(ctx, action); replayErr != nil {
+		s.logger.Warn(ctx, "Retry of failed action failed", "action_id", id, "kind", action.Kind, "error", replayErr)
+
+		action.Attempts++
+		action.LastError = replayErr.Error()
+		action.LastAttemptAt = time.Now()
+		if err := s.deadLetterRepo.Update(ctx, action); err != nil {
+			s.logger.Error(ctx, "Failed to record retry attempt", "action_id", id, "error", err)
+		}
+
+		return fmt.Errorf("failed to replay %s: %w", action.Kind, replayErr)
+	}
+
+	if err := s.deadLetterRepo.Remove(ctx, id); err != nil {
+		return fmt.Errorf("failed to remove replayed action: %w", err)
+	}
+
+	s.logger.Info(ctx, "Failed action replayed", "action_id", id, "kind", action.Kind, "event_id", action.EventID)
+	return nil
+}
+
+// RetryFailedActions replays up to limit of the oldest dead-lettered writes and reports how many
+// succeeded and how many are still failing
+func (s *TicketingService) RetryFailedActions(ctx context.Context, limit int) (int, int, error) {
+	actions, _, err := s.ListFailedActions(ctx, limit, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	succeeded, failed := 0, 0
+	for _, action := range actions {
+		if err := s.RetryFailedAction(ctx, action.ID); err != nil {
+			failed++
+			continue
+		}
+		succeeded++
+	}
+
+	return succeeded, failed, nil
+}
+
+// replayFailedAction performs the inventory write an action describes
+func (s *TicketingService) replayFailedAction(ctx context.Context, action *domain.FailedAction) error {
+	switch domain.FailedActionKind(action.Kind) {
+	case domain.FailedActionDecrementAvailable:
+		return s.decrementAvailableTickets(ctx, action.EventID, action.Quantity)
+	case domain.FailedActionIncrementAvailable:
+		return s.eventRepo.IncrementAvailableTickets(ctx, action.EventID, action.Quantity)
+	case domain.FailedActionIncrementZone:
+		return s.eventRepo.IncrementZoneTickets(ctx, action.EventID, action.Zone, action.Quantity)
+	case domain.FailedActionReleaseSeat:
+		if action.SeatID == nil {
+			return fmt.Errorf("release_seat action has no seat")
+		}
+		return s.seatRepo.ReleaseSeats(ctx, []uuid.UUID{*action.SeatID})
+	case domain.FailedActionMarkSeatSold:
+		if action.SeatID == nil {
+			return fmt.Errorf("mark_seat_sold action has no seat")
+		}
+		return s.seatRepo.UpdateStatus(ctx, *action.SeatID, string(domain.SeatStatusSold))
+	default:
+		return fmt.Errorf("unknown failed action kind %q", action.Kind)
+	}
+}
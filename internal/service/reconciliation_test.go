@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/pkg/logger"
+	"github.com/snowmerak/ticketing/pkg/repository/memory"
+)
+
+// errReleaseDown is the error releaseFailingSeatRepository returns while releases are failing
+var errReleaseDown = errors.New("seat store unavailable")
+
+// releaseFailingSeatRepository fails every seat release while failing is set
+type releaseFailingSeatRepository struct {
+	*memory.SeatRepository
+	failing atomic.Bool
+}
+
+func (r *releaseFailingSeatRepository) ReleaseSeats(ctx context.Context, seatIDs []uuid.UUID) error {
+	if r.failing.Load() {
+		return errReleaseDown
+	}
+	return r.SeatRepository.ReleaseSeats(ctx, seatIDs)
+}
+
+func (r *releaseFailingSeatRepository) ReleaseReservedSeats(ctx context.Context, seatIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if r.failing.Load() {
+		return nil, errReleaseDown
+	}
+	return r.SeatRepository.ReleaseReservedSeats(ctx, seatIDs)
+}
+
+func TestFailedSeatReleaseIsDeadLetteredAndRetried(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	seats := &releaseFailingSeatRepository{SeatRepository: tt.seats}
+	tt.service = NewTicketingService(tt.tickets, tt.events, seats, tt.queue, testCache{}, newTestLock(), logger.NewLoggerWithLevel(zerolog.Disabled))
+	tt.service.SetDeadLetterRepository(memory.NewDeadLetterRepository())
+
+	event := tt.createEvent(t, 10, 9)
+	seat, ticket := tt.createReservation(t, event, uuid.New(), 10*time.Minute)
+
+	// The cancellation stands even though its seat can't be given back yet
+	seats.failing.Store(true)
+	if err := tt.service.CancelTicket(ctx, ticket.ID); err != nil {
+		t.Fatalf("cancel ticket: %v", err)
+	}
+	tt.expectSeatStatus(t, seat.ID, domain.SeatStatusReserved)
+
+	actions, total, err := tt.service.ListFailedActions(ctx, 10, 0)
+	if err != nil {
+		t.Fatalf("list failed actions: %v", err)
+	}
+	if total != 1 || len(actions) != 1 {
+		t.Fatalf("dead-letter holds %d actions, want the failed seat release", total)
+	}
+	action := actions[0]
+	if action.Kind != string(domain.FailedActionReleaseSeat) || action.SeatID == nil || *action.SeatID != seat.ID ||
+		action.TicketID == nil || *action.TicketID != ticket.ID || action.Attempts != 1 || action.LastError != errReleaseDown.Error() {
+		t.Fatalf("dead-lettered %+v, want a release of seat %s for ticket %s after one attempt", action, seat.ID, ticket.ID)
+	}
+
+	// A retry while the store is still down keeps the action and counts the attempt
+	if err := tt.service.RetryFailedAction(ctx, action.ID); !errors.Is(err, errReleaseDown) {
+		t.Fatalf("expected the retry to fail with %v, got %v", errReleaseDown, err)
+	}
+	actions, _, err = tt.service.ListFailedActions(ctx, 10, 0)
+	if err != nil {
+		t.Fatalf("list failed actions: %v", err)
+	}
+	if len(actions) != 1 || actions[0].Attempts != 2 {
+		t.Fatalf("dead-letter after a failed retry = %+v, want the action with 2 attempts", actions)
+	}
+
+	// Once the store is back the retry releases the seat and clears the action
+	seats.failing.Store(false)
+	succeeded, failed, err := tt.service.RetryFailedActions(ctx, 10)
+	if err != nil {
+		t.Fatalf("retry failed actions: %v", err)
+	}
+	if succeeded != 1 || failed != 0 {
+		t.Fatalf("retried %d actions with %d failures, want 1 and 0", succeeded, failed)
+	}
+	tt.expectSeatStatus(t, seat.ID, domain.SeatStatusAvailable)
+	if _, total, err := tt.service.ListFailedActions(ctx, 10, 0); err != nil || total != 0 {
+		t.Fatalf("dead-letter holds %d actions (err %v) after a successful retry, want none", total, err)
+	}
+}
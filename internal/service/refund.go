@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// refundPolicyOf returns the refund schedule an event's cancellations follow
+func (s *TicketingService) refundPolicyOf(event *domain.Event) domain.RefundPolicy {
+	if event.RefundPolicy != nil {
+		return *event.RefundPolicy
+	}
+	return s.config.RefundPolicy
+}
+
+// recordRefund prices the refund of a cancelled confirmed ticket from how long before the event it was cancelled
+// and stores it on the ticket. A refund that can't be priced is logged and left at zero for reconciliation.
+func (s *TicketingService) recordRefund(ctx context.Context, ticketID uuid.UUID) {
+	ticket, err := s.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get cancelled ticket for refund", "ticket_id", ticketID, "error", err)
+		return
+	}
+
+	event, err := s.eventRepo.GetByID(ctx, ticket.EventID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get event for refund", "ticket_id", ticketID, "event_id", ticket.EventID, "error", err)
+		return
+	}
+
+	cancelledAt := time.Now()
+	if ticket.CancelledAt != nil {
+		cancelledAt = *ticket.CancelledAt
+	}
+
+	ticket.RefundAmount = s.refundPolicyOf(event).Refund(ticket.Price, event.StartTime, cancelledAt)
+	if err := s.ticketRepo.Update(ctx, ticket); err != nil {
+		s.logger.Error(ctx, "Failed to record refund", "ticket_id", ticketID, "refund_amount", ticket.RefundAmount, "error", err)
+		return
+	}
+
+	s.logger.Info(ctx, "Refund priced", "ticket_id", ticketID, "price", ticket.Price, "refund_amount", ticket.RefundAmount)
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/adapter"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// ErrResaleDisabled is returned by resale operations when no resale repository or payment gateway is configured
+// or the resale price cap is 0
+var ErrResaleDisabled = errors.New("resale is not enabled")
+
+// ErrResalePriceAboveCap is returned when a ticket is listed above the resale price cap
+var ErrResalePriceAboveCap = errors.New("resale price is above the allowed cap")
+
+// ErrResaleListingUnavailable is returned when a listing has already been sold or withdrawn
+var ErrResaleListingUnavailable = errors.New("resale listing is no longer available")
+
+// SetResaleRepository sets the repository holding resale listings; resale also needs a payment gateway
+func (s *TicketingService) SetResaleRepository(resaleRepo repository.ResaleRepository) {
+	s.resaleRepo = resaleRepo
+}
+
+// SetPaymentGateway sets the payment gateway that settles resales
+func (s *TicketingService) SetPaymentGateway(payments adapter.PaymentGateway) {
+	s.payments = payments
+}
+
+// resaleEnabled checks that resale is configured
+func (s *TicketingService) resaleEnabled() error {
+	if s.resaleRepo == nil || s.payments == nil || s.config.ResalePriceCapBasisPoints == 0 {
+		return ErrResaleDisabled
+	}
+	return nil
+}
+
+// ResalePriceCap returns the highest price a ticket may be listed at, relative to what it cost
+func (s *TicketingService) ResalePriceCap(ticket *domain.Ticket) int64 {
+	return ticket.Price * s.config.ResalePriceCapBasisPoints / 10000
+}
+
+// ListForResale offers a confirmed, unused ticket for resale by its holder at a price within the cap
+func (s *TicketingService) ListForResale(ctx context.Context, ticketID, sellerID uuid.UUID, price int64) (*domain.ResaleListing, error) {
+	if err := s.resaleEnabled(); err != nil {
+		return nil, err
+	}
+
+	if price <= 0 {
+		return nil, fmt.Errorf("resale price must be positive")
+	}
+
+	ticket, err := s.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get ticket", "ticket_id", ticketID, "error", err)
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	if err := s.checkTransferable(ctx, ticket, sellerID); err != nil {
+		return nil, err
+	}
+
+	if limit := s.ResalePriceCap(ticket); price > limit {
+		return nil, fmt.Errorf("%w: %d exceeds %d", ErrResalePriceAboveCap, price, limit)
+	}
+
+	listing := &domain.ResaleListing{
+		ID:       s.newID(),
+		TicketID: ticket.ID,
+		EventID:  ticket.EventID,
+		SeatID:   ticket.SeatID,
+		SellerID: sellerID,
+		Price:    price,
+		Currency: ticket.Currency,
+	}
+
+	if err := s.resaleRepo.Create(ctx, listing); err != nil {
+		if errors.Is(err, repository.ErrTicketAlreadyListed) {
+			return nil, fmt.Errorf("%w: ticket is already listed", ErrTicketNotTransferable)
+		}
+		s.logger.Error(ctx, "Failed to create resale listing", "ticket_id", ticketID, "error", err)
+		return nil, fmt.Errorf("failed to create resale listing: %w", err)
+	}
+
+	s.logger.Info(ctx, "Ticket listed for resale", "listing_id", listing.ID, "ticket_id", ticketID, "price", price)
+	return listing, nil
+}
+
+// GetResaleListings retrieves the open resale listings of an event, oldest first
+func (s *TicketingService) GetResaleListings(ctx context.Context, eventID uuid.UUID) ([]*domain.ResaleListing, error) {
+	if err := s.resaleEnabled(); err != nil {
+		return nil, err
+	}
+
+	listings, err := s.resaleRepo.ListOpenByEvent(ctx, eventID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list resale listings", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to list resale listings: %w", err)
+	}
+
+	return listings, nil
+}
+
+// CancelResaleListing withdraws an open listing on behalf of its seller
+func (s *TicketingService) CancelResaleListing(ctx context.Context, listingID, sellerID uuid.UUID) error {
+	if err := s.resaleEnabled(); err != nil {
+		return err
+	}
+
+	listing, err := s.resaleRepo.GetByID(ctx, listingID)
+	if err != nil {
+		return fmt.Errorf("failed to get resale listing: %w", err)
+	}
+
+	if listing.SellerID != sellerID {
+		return fmt.Errorf("%w: listing belongs to another seller", ErrTicketNotTransferable)
+	}
+
+	if err := s.resaleRepo.Cancel(ctx, listingID); err != nil {
+		if errors.Is(err, repository.ErrResaleListingNotAvailable) {
+			return ErrResaleListingUnavailable
+		}
+		return fmt.Errorf("failed to cancel resale listing: %w", err)
+	}
+
+	s.logger.Info(ctx, "Resale listing cancelled", "listing_id", listingID)
+	return nil
+}
+
+// BuyResaleListing sells a listed ticket to a buyer: the listing is claimed, the buyer is charged,
+// the ticket moves to the buyer with a new access token and the seller is paid out.
+// A failed charge or transfer puts the listing back on the market and refunds any charge.
+func (s *TicketingService) BuyResaleListing(ctx context.Context, listingID, buyerID uuid.UUID) (*domain.Ticket, error) {
+	if err := s.resaleEnabled(); err != nil {
+		return nil, err
+	}
+
+	listing, err := s.resaleRepo.GetByID(ctx, listingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resale listing: %w", err)
+	}
+
+	if !listing.IsListed() {
+		return nil, ErrResaleListingUnavailable
+	}
+
+	if listing.SellerID == buyerID {
+		return nil, fmt.Errorf("cannot buy your own resale listing")
+	}
+
+	// A ticket checked in or cancelled since it was listed can't be sold any more
+	ticket, err := s.ticketRepo.GetByID(ctx, listing.TicketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+	if err := s.checkTransferable(ctx, ticket, listing.SellerID); err != nil {
+		s.logger.Warn(ctx, "Withdrawing stale resale listing", "listing_id", listingID, "reason", err)
+		if err := s.resaleRepo.Cancel(ctx, listingID); err != nil && !errors.Is(err, repository.ErrResaleListingNotAvailable) {
+			s.logger.Error(ctx, "Failed to withdraw stale resale listing", "listing_id", listingID, "error", err)
+		}
+		return nil, ErrResaleListingUnavailable
+	}
+
+	listing, err = s.resaleRepo.Claim(ctx, listingID, buyerID)
+	if err != nil {
+		if errors.Is(err, repository.ErrResaleListingNotAvailable) {
+			return nil, ErrResaleListingUnavailable
+		}
+		return nil, fmt.Errorf("failed to claim resale listing: %w", err)
+	}
+
+	reference := "resale:" + listing.ID.String()
+	paymentID, err := s.payments.Charge(ctx, buyerID, listing.Price, listing.Currency, reference)
+	if err != nil {
+		s.logger.Warn(ctx, "Resale charge failed", "listing_id", listingID, "buyer_id", buyerID, "error", err)
+		s.reopenResaleListing(ctx, listingID)
+		return nil, fmt.Errorf("failed to charge buyer: %w", err)
+	}
+
+	transferred, err := s.TransferTicket(ctx, listing.TicketID, listing.SellerID, buyerID)
+	if err != nil {
+		if refundErr := s.payments.Refund(ctx, paymentID); refundErr != nil {
+			s.logger.Error(ctx, "Failed to refund resale charge", "listing_id", listingID, "payment_id", paymentID, "error", refundErr)
+		}
+		s.reopenResaleListing(ctx, listingID)
+		return nil, fmt.Errorf("failed to transfer ticket: %w", err)
+	}
+
+	// The buyer owns the ticket now; a failed payout is for finance to settle, not a reason to undo the sale
+	if err := s.payments.Payout(ctx, listing.SellerID, listing.Price, listing.Currency, reference); err != nil {
+		s.logger.Error(ctx, "Failed to pay out resale seller", "listing_id", listingID, "seller_id", listing.SellerID, "amount", listing.Price, "error", err)
+	}
+
+	listing.PaymentID = paymentID
+	if err := s.resaleRepo.Update(ctx, listing); err != nil {
+		s.logger.Error(ctx, "Failed to record resale payment", "listing_id", listingID, "payment_id", paymentID, "error", err)
+	}
+
+	s.notifyResaleSold(ctx, listing)
+
+	s.logger.Info(ctx, "Resale listing sold", "listing_id", listingID, "ticket_id", listing.TicketID, "buyer_id", buyerID)
+	return transferred, nil
+}
+
+// reopenResaleListing puts a listing whose sale fell through back on the market
+func (s *TicketingService) reopenResaleListing(ctx context.Context, listingID uuid.UUID) {
+	if err := s.resaleRepo.Reopen(ctx, listingID); err != nil {
+		s.logger.Error(ctx, "Failed to reopen resale listing", "listing_id", listingID, "error", err)
+	}
+}
+
+// notifyResaleSold tells the seller that their listing was bought
+func (s *TicketingService) notifyResaleSold(ctx context.Context, listing *domain.ResaleListing) {
+	if s.notifier == nil {
+		return
+	}
+
+	subject := "Your ticket was resold"
+	body := fmt.Sprintf("Your resale listing %s was bought and the ticket has been transferred to the buyer.", listing.ID)
+
+	if err := s.notifier.SendEmail(ctx, listing.SellerID, subject, body); err != nil {
+		s.logger.Warn(ctx, "Failed to send resale notice", "listing_id", listing.ID, "error", err)
+	}
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// AbandonReservation releases a reservation its holder walked away from, such as when the checkout tab is closed,
+// so the seat and inventory return before the reservation window runs out. It is safe to call repeatedly and
+// after expiry: the ticket is cancelled only if it is still reserved, so a reservation confirmed, expired or
+// already released by another call is left as is and false is returned. It returns true when this call released
+// the reservation.
+func (s *TicketingService) AbandonReservation(ctx context.Context, ticketID, userID uuid.UUID) (bool, error) {
+	ticket, err := s.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	if ticket.UserID != userID {
+		s.logger.Warn(ctx, "Abandon requested by another user", "ticket_id", ticketID, "user_id", userID)
+		return false, repository.ErrTicketOwnerMismatch
+	}
+
+	if !ticket.IsReserved() {
+		return false, nil
+	}
+
+	// Browsers may send the beacon more than once; only the call whose cancel wins releases the inventory
+	cancelled, err := s.cancelReservation(ctx, ticketID)
+	if errors.Is(err, repository.ErrTicketNotReserved) {
+		return false, nil
+	}
+	if err != nil {
+		s.logger.Error(ctx, "Failed to cancel abandoned reservation", "ticket_id", ticketID, "error", err)
+		return false, err
+	}
+	s.returnReservations(ctx, cancelled.EventID, []*domain.Ticket{cancelled}, "abandoned reservation")
+
+	s.logger.Info(ctx, "Abandoned reservation released", "ticket_id", ticketID, "user_id", userID)
+	return true, nil
+}
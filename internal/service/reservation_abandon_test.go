@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+func TestAbandonReservationReleasesOnce(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	event := tt.createEvent(t, 10, 9)
+	userID := uuid.New()
+	_, ticket := tt.createReservation(t, event, userID, time.Minute)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		released int
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := tt.service.AbandonReservation(ctx, ticket.ID, userID)
+			if err != nil {
+				t.Errorf("abandon reservation: %v", err)
+				return
+			}
+			if ok {
+				mu.Lock()
+				released++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if released != 1 {
+		t.Fatalf("released = %d, want 1", released)
+	}
+	if got := tt.availableTickets(t, event.ID); got != 10 {
+		t.Fatalf("available tickets = %d, want 10", got)
+	}
+}
+
+func TestAbandonReservationRejectsOtherUser(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	event := tt.createEvent(t, 10, 9)
+	_, ticket := tt.createReservation(t, event, uuid.New(), time.Minute)
+
+	if _, err := tt.service.AbandonReservation(ctx, ticket.ID, uuid.New()); !errors.Is(err, repository.ErrTicketOwnerMismatch) {
+		t.Fatalf("error = %v, want %v", err, repository.ErrTicketOwnerMismatch)
+	}
+	if got := tt.availableTickets(t, event.ID); got != 9 {
+		t.Fatalf("available tickets = %d, want 9", got)
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// ClearReservationsPageSize is how many reservations ClearReservations cancels before returning their inventory
+const ClearReservationsPageSize = 100
+
+// ReservationClearance reports what clearing an event's outstanding reservations did
+type ReservationClearance struct {
+	EventID       uuid.UUID `json:"event_id"`
+	Cancelled     int       `json:"cancelled"`
+	SeatsReleased int       `json:"seats_released"`
+	Failed        int       `json:"failed"`
+}
+
+// ClearReservations cancels every reserved, unconfirmed ticket of an event, releases their seats and
+// returns their inventory, for when an on-sale is paused or aborted. Confirmed tickets are left alone.
+// Reservations are read a page at a time from the event's reserved status index and each page is released
+// before the next is read; every ticket goes through the same conditional cancel as expiry, so one confirmed or
+// released meanwhile is skipped and failed seat or inventory returns are recorded for retry. Running it again
+// only picks up what is still reserved.
+func (s *TicketingService) ClearReservations(ctx context.Context, eventID uuid.UUID) (*ReservationClearance, error) {
+	s.logger.Info(ctx, "Clearing event reservations", "event_id", eventID)
+
+	if _, err := s.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	clearance := &ReservationClearance{EventID: eventID}
+
+	// Cancelled tickets leave the index, so the next page starts after only the ones this run left behind
+	offset := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return clearance, fmt.Errorf("clearing reservations interrupted: %w", err)
+		}
+
+		page, _, err := s.ticketRepo.GetByEventIDAndStatusPaginated(ctx, eventID, string(domain.TicketStatusReserved), offset, ClearReservationsPageSize)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get event reservations", "event_id", eventID, "offset", offset, "error", err)
+			return clearance, fmt.Errorf("failed to get event reservations: %w", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		ticketIDs := make([]uuid.UUID, len(page))
+		for i, ticket := range page {
+			ticketIDs[i] = ticket.ID
+		}
+
+		cancelled, seatsReleased := s.releaseReservations(ctx, eventID, ticketIDs, "reservation clearance")
+		clearance.Cancelled += len(cancelled)
+		clearance.SeatsReleased += seatsReleased
+
+		remaining, err := s.stillReserved(ctx, ticketIDs)
+		if err != nil {
+			return clearance, err
+		}
+		clearance.Failed += remaining
+		offset += remaining
+	}
+
+	s.invalidateEventCache(ctx, eventID)
+
+	s.logger.Info(ctx, "Event reservations cleared",
+		"event_id", eventID,
+		"cancelled", clearance.Cancelled,
+		"seats_released", clearance.SeatsReleased,
+		"failed", clearance.Failed)
+
+	return clearance, nil
+}
+
+// stillReserved counts the tickets that are still reserved, which are the ones a clearance page failed to cancel
+func (s *TicketingService) stillReserved(ctx context.Context, ticketIDs []uuid.UUID) (int, error) {
+	tickets, err := s.ticketRepo.GetByIDs(ctx, ticketIDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get cleared reservations: %w", err)
+	}
+
+	remaining := 0
+	for _, ticket := range tickets {
+		if ticket.IsReserved() {
+			remaining++
+		}
+	}
+	return remaining, nil
+}
+
+// invalidateEventCache drops the cached copy of an event and the active events list
+func (s *TicketingService) invalidateEventCache(ctx context.Context, eventID uuid.UUID) {
+	cacheKey := fmt.Sprintf("event:%s", eventID.String())
+	if err := s.cache.Delete(ctx, cacheKey); err != nil {
+		s.logger.Warn(ctx, "Failed to invalidate event cache", "error", err)
+	}
+
+	if err := s.cache.Delete(ctx, "events:active"); err != nil {
+		s.logger.Warn(ctx, "Failed to invalidate active events cache", "error", err)
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+func TestClearReservations(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+
+	reservations := ClearReservationsPageSize + 20
+	event := tt.createEvent(t, reservations+11, 10)
+
+	var users []uuid.UUID
+	for i := 0; i < reservations; i++ {
+		userID := uuid.New()
+		tt.createReservation(t, event, userID, time.Minute)
+		users = append(users, userID)
+	}
+	confirmedSeat, confirmed := tt.createReservation(t, event, uuid.New(), time.Minute)
+	if err := tt.tickets.UpdateStatus(ctx, confirmed.ID, string(domain.TicketStatusConfirmed)); err != nil {
+		t.Fatalf("confirm ticket: %v", err)
+	}
+
+	clearance, err := tt.service.ClearReservations(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("clear reservations: %v", err)
+	}
+	if clearance.Cancelled != reservations || clearance.SeatsReleased != reservations || clearance.Failed != 0 {
+		t.Fatalf("clearance = %+v, want %d cancelled and seats released", clearance, reservations)
+	}
+	if got := tt.availableTickets(t, event.ID); got != 10+reservations {
+		t.Fatalf("available tickets = %d, want %d", got, 10+reservations)
+	}
+
+	reserved, err := tt.tickets.GetByEventIDAndStatus(ctx, event.ID, string(domain.TicketStatusReserved))
+	if err != nil {
+		t.Fatalf("get reserved tickets: %v", err)
+	}
+	if len(reserved) != 0 {
+		t.Fatalf("%d reservations left after clearing", len(reserved))
+	}
+	seat, err := tt.seats.GetByID(ctx, confirmedSeat.ID)
+	if err != nil {
+		t.Fatalf("get seat: %v", err)
+	}
+	if seat.Status == string(domain.SeatStatusAvailable) {
+		t.Fatal("seat of the confirmed ticket was released")
+	}
+
+	// Each holder's slot in the per-user limit is free again
+	for _, userID := range users {
+		if _, err := tt.tickets.IncrementUserEventCount(ctx, event.ID, userID, 1, 1); err != nil {
+			t.Fatalf("user %s still holds a ticket slot: %v", userID, err)
+		}
+	}
+
+	again, err := tt.service.ClearReservations(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("clear reservations again: %v", err)
+	}
+	if again.Cancelled != 0 {
+		t.Fatalf("second clearance cancelled %d, want 0", again.Cancelled)
+	}
+	if got := tt.availableTickets(t, event.ID); got != 10+reservations {
+		t.Fatalf("available tickets after second clearance = %d, want %d", got, 10+reservations)
+	}
+}
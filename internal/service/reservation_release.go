@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// releaseReservations cancels those of an event's tickets that are still reserved and gives back what they held.
+// Each one is cancelled with the repository's conditional cancel, so a reservation confirmed or released by
+// someone else meanwhile is skipped and, of callers racing to release the same reservation, only the winner
+// returns its seat and inventory. cause names what released them in dead-lettered actions. It returns the
+// reservations this call cancelled and how many seats it released.
+func (s *TicketingService) releaseReservations(ctx context.Context, eventID uuid.UUID, ticketIDs []uuid.UUID, cause string) ([]*domain.Ticket, int) {
+	var cancelled []*domain.Ticket
+	for _, ticketID := range ticketIDs {
+		ticket, err := s.cancelReservation(ctx, ticketID)
+		if errors.Is(err, repository.ErrTicketNotReserved) {
+			continue
+		}
+		if err != nil {
+			s.logger.Error(ctx, "Failed to cancel reservation", "ticket_id", ticketID, "error", err)
+			continue
+		}
+		cancelled = append(cancelled, ticket)
+	}
+
+	seatsReleased := s.returnReservations(ctx, eventID, cancelled, cause)
+	return cancelled, seatsReleased
+}
+
+// cancelReservation cancels a ticket if it is still reserved, announces the cancellation and frees the slot it
+// held in its holder's ticket limit. It returns repository.ErrTicketNotReserved, wrapped, when the ticket was
+// confirmed or cancelled first; the caller that gets the ticket back owns returning its seat and inventory.
+func (s *TicketingService) cancelReservation(ctx context.Context, ticketID uuid.UUID) (*domain.Ticket, error) {
+	ticket, err := s.ticketRepo.CancelReservation(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel reservation: %w", err)
+	}
+
+	s.publishCancelled(ctx, ticket)
+	s.releaseUserTicket(ctx, ticket)
+	return ticket, nil
+}
+
+// returnReservations gives back what cancelled reservations of an event held: those of their seats still
+// reserved are released in one call and their inventory returned in one update, and failed writes are recorded
+// for retry. Holders are told their reservation was released, and companion tickets left reserved are released
+// along with them. It returns how many seats it released.
+func (s *TicketingService) returnReservations(ctx context.Context, eventID uuid.UUID, cancelled []*domain.Ticket, cause string) int {
+	if len(cancelled) == 0 {
+		return 0
+	}
+
+	var seatIDs []uuid.UUID
+	for _, ticket := range cancelled {
+		if ticket.SeatID != nil {
+			seatIDs = append(seatIDs, *ticket.SeatID)
+		}
+	}
+
+	seatsReleased := 0
+	if len(seatIDs) > 0 {
+		// Only seats still reserved are released, so one freed elsewhere meanwhile does not hold back the batch
+		released, err := s.seatRepo.ReleaseReservedSeats(ctx, seatIDs)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to release seats of cancelled reservations", "event_id", eventID, "seats", len(seatIDs), "error", err)
+			for _, ticket := range cancelled {
+				if ticket.SeatID == nil {
+					continue
+				}
+				s.recordFailedAction(ctx, &domain.FailedAction{
+					Kind:     string(domain.FailedActionReleaseSeat),
+					EventID:  eventID,
+					SeatID:   ticket.SeatID,
+					TicketID: &ticket.ID,
+					Reason:   "release seat after " + cause,
+				}, err)
+			}
+		} else {
+			seatsReleased = len(released)
+			if skipped := len(seatIDs) - len(released); skipped > 0 {
+				s.logger.Warn(ctx, "Seats of cancelled reservations were no longer reserved", "event_id", eventID, "skipped", skipped)
+			}
+		}
+	}
+
+	if err := s.eventRepo.IncrementAvailableTickets(ctx, eventID, len(cancelled)); err != nil {
+		s.logger.Error(ctx, "Failed to return inventory of cancelled reservations", "event_id", eventID, "quantity", len(cancelled), "error", err)
+		s.recordFailedAction(ctx, &domain.FailedAction{
+			Kind:     string(domain.FailedActionIncrementAvailable),
+			EventID:  eventID,
+			Quantity: len(cancelled),
+			Reason:   "return inventory after " + cause,
+		}, err)
+	}
+	for zone, count := range zoneCounts(cancelled) {
+		s.returnZoneTickets(ctx, eventID, zone, count, "return zone tickets after "+cause)
+	}
+
+	for _, ticket := range cancelled {
+		s.notifyReservationReleased(ctx, ticket)
+
+		// A companion normally goes alongside its accessible seat; release one that was left behind
+		if ticket.CompanionTicketID == nil {
+			continue
+		}
+		companion, err := s.ticketRepo.GetByID(ctx, *ticket.CompanionTicketID)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get companion ticket", "ticket_id", *ticket.CompanionTicketID, "error", err)
+		} else if companion.IsReserved() {
+			_, companionSeats := s.releaseReservations(ctx, companion.EventID, []uuid.UUID{companion.ID}, cause)
+			seatsReleased += companionSeats
+		}
+	}
+
+	return seatsReleased
+}
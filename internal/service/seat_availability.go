@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// SeatAvailability is a seat of an event's seat map flagged with whether another buyer currently holds it
+type SeatAvailability struct {
+	*domain.Seat
+	Held bool `json:"held"`
+}
+
+// SetSeatHoldRepository sets the seat hold repository used to list held seats; without it held seats are
+// found by their status, which may still include holds that lapsed but weren't swept yet
+func (s *EventService) SetSeatHoldRepository(seatHoldRepo repository.SeatHoldRepository) {
+	s.seatHoldRepo = seatHoldRepo
+}
+
+// GetSeatAvailability retrieves the available seats of an event and, when includeHeld is set, the seats
+// held by buyers who haven't bought them yet flagged as held, so a seat map can grey them out instead
+// of offering them. Held seats are never served from the cache; holds come and go within minutes.
+func (s *EventService) GetSeatAvailability(ctx context.Context, eventID uuid.UUID, includeHeld bool) ([]SeatAvailability, error) {
+	available, err := s.GetAvailableSeats(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	seats := make([]SeatAvailability, 0, len(available))
+	for _, seat := range available {
+		seats = append(seats, SeatAvailability{Seat: seat})
+	}
+
+	if !includeHeld {
+		return seats, nil
+	}
+
+	held, err := s.getHeldSeats(ctx, eventID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get held seats", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to get held seats: %w", err)
+	}
+
+	for _, seat := range held {
+		seats = append(seats, SeatAvailability{Seat: seat, Held: true})
+	}
+
+	return seats, nil
+}
+
+// getHeldSeats lists an event's held seats from the hold index, or by seat status when no hold repository is set
+func (s *EventService) getHeldSeats(ctx context.Context, eventID uuid.UUID) ([]*domain.Seat, error) {
+	if s.seatHoldRepo != nil {
+		return s.seatHoldRepo.GetHeldByEventID(ctx, eventID)
+	}
+
+	seats, err := s.seatRepo.GetByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	held := make([]*domain.Seat, 0)
+	for _, seat := range seats {
+		if seat.IsHeld() {
+			held = append(held, seat)
+		}
+	}
+
+	return held, nil
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// SeatTicketMismatchKind identifies how a seat and its tickets disagree
+type SeatTicketMismatchKind string
+
+const (
+	// MismatchSoldSeatWithoutTicket is a sold seat that no confirmed ticket points to
+	MismatchSoldSeatWithoutTicket SeatTicketMismatchKind = "sold_seat_without_ticket"
+	// MismatchConfirmedTicketOnUnsoldSeat is a confirmed ticket whose seat is not sold or does not exist
+	MismatchConfirmedTicketOnUnsoldSeat SeatTicketMismatchKind = "confirmed_ticket_on_unsold_seat"
+	// MismatchReservedSeatWithoutTicket is a reserved seat with no unexpired reservation or confirmed ticket
+	MismatchReservedSeatWithoutTicket SeatTicketMismatchKind = "reserved_seat_without_active_ticket"
+)
+
+// SeatTicketMismatch is a single inconsistency between an event's seats and its tickets
+type SeatTicketMismatch struct {
+	Kind         SeatTicketMismatchKind `json:"kind"`
+	SeatID       uuid.UUID              `json:"seat_id"`
+	SeatStatus   string                 `json:"seat_status,omitempty"` // Empty when the seat does not exist
+	TicketID     *uuid.UUID             `json:"ticket_id,omitempty"`
+	TicketStatus string                 `json:"ticket_status,omitempty"`
+}
+
+// SeatTicketConsistency reports how an event's seat statuses line up with its seated tickets
+type SeatTicketConsistency struct {
+	EventID        uuid.UUID            `json:"event_id"`
+	SeatsChecked   int                  `json:"seats_checked"`
+	TicketsChecked int                  `json:"tickets_checked"`
+	Consistent     bool                 `json:"consistent"`
+	Mismatches     []SeatTicketMismatch `json:"mismatches"`
+}
+
+// AuditSeatTicketConsistency compares an event's seats with its tickets and reports every sold seat without a
+// confirmed ticket, every confirmed seated ticket whose seat is not sold and every reserved seat without an active
+// ticket. It only reads; fixing what it finds is left to the operator. Seats and tickets are read separately, so
+// purchases running at the same time can show up as transient mismatches.
+func (s *EventService) AuditSeatTicketConsistency(ctx context.Context, eventID uuid.UUID) (*SeatTicketConsistency, error) {
+	if s.ticketRepo == nil {
+		return nil, fmt.Errorf("ticket repository is not configured")
+	}
+
+	if _, err := s.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	seats, err := s.seatRepo.GetByEventID(ctx, eventID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get event seats", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to get event seats: %w", err)
+	}
+
+	tickets, err := s.ticketRepo.GetByEventID(ctx, eventID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get event tickets", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to get event tickets: %w", err)
+	}
+
+	report := &SeatTicketConsistency{
+		EventID:    eventID,
+		Mismatches: []SeatTicketMismatch{},
+	}
+
+	seatsByID := make(map[uuid.UUID]*domain.Seat, len(seats))
+	for _, seat := range seats {
+		seatsByID[seat.ID] = seat
+	}
+	report.SeatsChecked = len(seats)
+
+	// confirmed and active record which seats are covered by a confirmed ticket and by any live ticket
+	confirmed := make(map[uuid.UUID]bool)
+	active := make(map[uuid.UUID]bool)
+	now := s.now()
+	for _, ticket := range tickets {
+		if ticket.SeatID == nil {
+			continue
+		}
+		report.TicketsChecked++
+
+		seatID := *ticket.SeatID
+		switch {
+		case ticket.IsConfirmed():
+			confirmed[seatID] = true
+			active[seatID] = true
+
+			seat, ok := seatsByID[seatID]
+			if !ok || seat.Status != string(domain.SeatStatusSold) {
+				ticketID := ticket.ID
+				mismatch := SeatTicketMismatch{
+					Kind:         MismatchConfirmedTicketOnUnsoldSeat,
+					SeatID:       seatID,
+					TicketID:     &ticketID,
+					TicketStatus: ticket.Status,
+				}
+				if ok {
+					mismatch.SeatStatus = seat.Status
+				}
+				report.Mismatches = append(report.Mismatches, mismatch)
+			}
+		case ticket.IsReserved() && !ticket.IsExpiredAt(now):
+			active[seatID] = true
+		}
+	}
+
+	for _, seat := range seats {
+		switch domain.SeatStatus(seat.Status) {
+		case domain.SeatStatusSold:
+			if !confirmed[seat.ID] {
+				report.Mismatches = append(report.Mismatches, SeatTicketMismatch{
+					Kind:       MismatchSoldSeatWithoutTicket,
+					SeatID:     seat.ID,
+					SeatStatus: seat.Status,
+				})
+			}
+		case domain.SeatStatusReserved:
+			if !active[seat.ID] {
+				report.Mismatches = append(report.Mismatches, SeatTicketMismatch{
+					Kind:       MismatchReservedSeatWithoutTicket,
+					SeatID:     seat.ID,
+					SeatStatus: seat.Status,
+				})
+			}
+		}
+	}
+
+	report.Consistent = len(report.Mismatches) == 0
+
+	if !report.Consistent {
+		s.logger.Warn(ctx, "Seat and ticket records disagree", "event_id", eventID, "mismatches", len(report.Mismatches))
+	}
+
+	return report, nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+func TestAuditSeatTicketConsistency(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	events := newTestEvents(tt)
+	event := tt.createEvent(t, 10, 10)
+
+	// Seats whose tickets agree with them
+	tt.createSeat(t, event, domain.SeatStatusAvailable)
+	tt.createReservation(t, event, uuid.New(), 10*time.Minute)
+	_, confirmed := tt.createReservation(t, event, uuid.New(), 10*time.Minute)
+	if err := tt.service.ConfirmTicket(ctx, confirmed.ID); err != nil {
+		t.Fatalf("confirm ticket: %v", err)
+	}
+
+	report, err := events.AuditSeatTicketConsistency(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("audit consistent event: %v", err)
+	}
+	if !report.Consistent || len(report.Mismatches) != 0 {
+		t.Fatalf("consistent event reported %d mismatches: %+v", len(report.Mismatches), report.Mismatches)
+	}
+
+	// Seed one of each mismatch
+	soldWithoutTicket := tt.createSeat(t, event, domain.SeatStatusSold)
+	lapsed, _ := tt.createReservation(t, event, uuid.New(), -time.Minute)
+	unsold := tt.createSeat(t, event, domain.SeatStatusAvailable)
+	stray := &domain.Ticket{
+		ID:       uuid.New(),
+		EventID:  event.ID,
+		SeatID:   &unsold.ID,
+		UserID:   uuid.New(),
+		Currency: event.Currency,
+		Status:   string(domain.TicketStatusConfirmed),
+		IssuedAt: time.Now(),
+	}
+	if err := tt.tickets.Create(ctx, stray); err != nil {
+		t.Fatalf("create stray ticket: %v", err)
+	}
+
+	report, err = events.AuditSeatTicketConsistency(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("audit event: %v", err)
+	}
+	if report.Consistent {
+		t.Fatal("event with seeded mismatches reported consistent")
+	}
+	if report.SeatsChecked != 6 || report.TicketsChecked != 4 {
+		t.Fatalf("checked %d seats and %d tickets, want 6 and 4", report.SeatsChecked, report.TicketsChecked)
+	}
+
+	want := map[SeatTicketMismatchKind]uuid.UUID{
+		MismatchSoldSeatWithoutTicket:       soldWithoutTicket.ID,
+		MismatchReservedSeatWithoutTicket:   lapsed.ID,
+		MismatchConfirmedTicketOnUnsoldSeat: unsold.ID,
+	}
+	if len(report.Mismatches) != len(want) {
+		t.Fatalf("got %d mismatches, want %d: %+v", len(report.Mismatches), len(want), report.Mismatches)
+	}
+	for _, mismatch := range report.Mismatches {
+		if seatID, ok := want[mismatch.Kind]; !ok || mismatch.SeatID != seatID {
+			t.Fatalf("unexpected mismatch %+v", mismatch)
+		}
+		if mismatch.Kind == MismatchConfirmedTicketOnUnsoldSeat {
+			if mismatch.TicketID == nil || *mismatch.TicketID != stray.ID || mismatch.SeatStatus != string(domain.SeatStatusAvailable) {
+				t.Fatalf("confirmed ticket mismatch %+v, want ticket %s on an available seat", mismatch, stray.ID)
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// MaxSeatFallbackAttempts bounds how many alternative seats a purchase tries when its seat is taken
+const MaxSeatFallbackAttempts = 5
+
+// ErrNoFallbackSeat is returned when a purchase allowing a fallback finds its seat taken and no comparable seat free
+var ErrNoFallbackSeat = errors.New("requested seat is taken and no comparable seat is available")
+
+// reserveFallbackSeat reserves the seat nearest to a taken one on the seat map among the available general seats
+// of the same section and price tier, trying the next nearest when another buyer wins the race for it
+func (s *TicketingService) reserveFallbackSeat(ctx context.Context, event *domain.Event, taken *domain.Seat) (*domain.Seat, error) {
+	candidates, err := s.fallbackCandidates(ctx, event, taken)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candidates) > MaxSeatFallbackAttempts {
+		candidates = candidates[:MaxSeatFallbackAttempts]
+	}
+
+	for _, candidate := range candidates {
+		err := s.seatRepo.ReserveSeats(ctx, []uuid.UUID{candidate.ID})
+		if err == nil {
+			s.logger.Info(ctx, "Reserved fallback seat", "requested_seat_id", taken.ID, "seat_id", candidate.ID)
+			candidate.Status = string(domain.SeatStatusReserved)
+			return candidate, nil
+		}
+
+		if !errors.Is(err, repository.ErrSeatNotAvailable) {
+			s.logger.Error(ctx, "Failed to reserve fallback seat", "seat_id", candidate.ID, "error", err)
+			return nil, fmt.Errorf("failed to reserve fallback seat: %w", err)
+		}
+	}
+
+	s.logger.Warn(ctx, "No fallback seat available", "requested_seat_id", taken.ID, "section", taken.Section, "price", taken.Price)
+	return nil, ErrNoFallbackSeat
+}
+
+// fallbackCandidates lists the available general seats comparable to a taken seat, nearest first;
+// equally near seats come in the event's seat ranking order
+func (s *TicketingService) fallbackCandidates(ctx context.Context, event *domain.Event, taken *domain.Seat) ([]*domain.Seat, error) {
+	seats, err := s.seatRepo.GetBySection(ctx, taken.EventID, taken.Section)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get section seats", "section", taken.Section, "error", err)
+		return nil, fmt.Errorf("failed to get section seats: %w", err)
+	}
+
+	var candidates []*domain.Seat
+	for _, seat := range seats {
+		if seat.ID == taken.ID || !seat.IsAvailable() || seat.Price != taken.Price {
+			continue
+		}
+		if seat.IsAccessible || seat.IsCompanion() {
+			continue
+		}
+		candidates = append(candidates, seat)
+	}
+
+	ranks := seatRanks(s.seatRankerFor(event), candidates)
+	sort.Slice(candidates, func(i, j int) bool {
+		di, dj := seatDistance(taken, candidates[i]), seatDistance(taken, candidates[j])
+		if di != dj {
+			return di < dj
+		}
+		return ranks[candidates[i]] < ranks[candidates[j]]
+	})
+
+	return candidates, nil
+}
+
+// seatDistance is the squared distance between two seats on the seat map
+func seatDistance(a, b *domain.Seat) int {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	return dx*dx + dy*dy
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// createSeatAt stores a seat of section A at column x of the seat map with a price and status
+func (tt *testTicketing) createSeatAt(t *testing.T, event *domain.Event, x int, price int64, status domain.SeatStatus) *domain.Seat {
+	t.Helper()
+
+	seat := tt.createSeat(t, event, status)
+	seat.X = x
+	seat.Price = price
+	if err := tt.seats.Update(context.Background(), seat); err != nil {
+		t.Fatalf("place seat: %v", err)
+	}
+	return seat
+}
+
+// placedSeat is a seat of section A at column x of the seat map
+type placedSeat struct {
+	x      int
+	price  int64
+	status domain.SeatStatus
+}
+
+func TestPurchaseSeatFallback(t *testing.T) {
+	tests := []struct {
+		name string
+		// others are the seats placed beside the taken one at column 0
+		others []placedSeat
+		allow  bool
+		// want is the index in others of the seat the purchase should get, or -1 when it should fail
+		want    int
+		wantErr error
+	}{
+		{
+			name: "nearest comparable seat is assigned",
+			others: []placedSeat{
+				{x: 5, price: 10000, status: domain.SeatStatusAvailable},
+				{x: 2, price: 10000, status: domain.SeatStatusAvailable},
+				{x: 1, price: 20000, status: domain.SeatStatusAvailable},
+			},
+			allow: true,
+			want:  1,
+		},
+		{
+			name: "no comparable seat left",
+			others: []placedSeat{
+				{x: 1, price: 10000, status: domain.SeatStatusSold},
+				{x: 2, price: 20000, status: domain.SeatStatusAvailable},
+			},
+			allow:   true,
+			want:    -1,
+			wantErr: ErrNoFallbackSeat,
+		},
+		{
+			name: "fallback not allowed",
+			others: []placedSeat{
+				{x: 1, price: 10000, status: domain.SeatStatusAvailable},
+			},
+			want: -1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tt := newTestTicketing(t)
+			event := tt.createEvent(t, 10, 10)
+
+			taken := tt.createSeatAt(t, event, 0, 10000, domain.SeatStatusSold)
+			others := make([]*domain.Seat, len(tc.others))
+			for i, other := range tc.others {
+				others[i] = tt.createSeatAt(t, event, other.x, other.price, other.status)
+			}
+
+			userID := uuid.New()
+			sessionID := tt.activateSession(t, event.ID, userID)
+			ticket, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &taken.ID, sessionID, PurchaseOptions{AllowSeatFallback: tc.allow})
+
+			if tc.want < 0 {
+				if err == nil {
+					t.Fatalf("purchase of a taken seat succeeded with seat %v", ticket.SeatID)
+				}
+				if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				for i, other := range others {
+					got, err := tt.seats.GetByID(ctx, other.ID)
+					if err != nil {
+						t.Fatalf("get seat %d: %v", i, err)
+					}
+					if got.Status != string(tc.others[i].status) {
+						t.Errorf("seat %d status = %s after a failed purchase, want %s", i, got.Status, tc.others[i].status)
+					}
+				}
+				if got := tt.availableTickets(t, event.ID); got != 10 {
+					t.Fatalf("available tickets = %d after a failed purchase, want 10", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("purchase with fallback: %v", err)
+			}
+			want := others[tc.want]
+			if ticket.SeatID == nil || *ticket.SeatID != want.ID {
+				t.Fatalf("ticket seat = %v, want the nearest comparable seat %s", ticket.SeatID, want.ID)
+			}
+			got, err := tt.seats.GetByID(ctx, want.ID)
+			if err != nil {
+				t.Fatalf("get fallback seat: %v", err)
+			}
+			if got.Status != string(domain.SeatStatusReserved) {
+				t.Fatalf("fallback seat status = %s, want reserved", got.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// SeatHoldTTL is how long a seat held during selection stays reserved for the user before anyone else can take it
+const SeatHoldTTL = 10 * time.Minute
+
+// ErrSeatHoldExpired is returned when a user tries to buy a held seat whose hold lapsed or was never theirs
+var ErrSeatHoldExpired = errors.New("seat hold has expired")
+
+// SetSeatHoldRepository enables expiring per-user seat holds; held seats can then be bought by their holder
+func (s *TicketingService) SetSeatHoldRepository(seatHoldRepo repository.SeatHoldRepository) {
+	s.seatHoldRepo = seatHoldRepo
+}
+
+// purchaseHeldSeat turns the user's hold on a seat into a reserved ticket in one atomic step,
+// so a hold that lapses mid-purchase can't be bought after someone else took the seat
+func (s *TicketingService) purchaseHeldSeat(ctx context.Context, event *domain.Event, userID uuid.UUID, guestEmail string, seat *domain.Seat, callbackURL string) (*domain.Ticket, error) {
+	ticket := s.newSeatReservation(event, userID, guestEmail, seat)
+	ticket.CallbackURL = callbackURL
+
+	if err := s.seatHoldRepo.ConvertToTicket(ctx, ticket); err != nil {
+		if errors.Is(err, repository.ErrSeatHoldNotFound) {
+			s.logger.Warn(ctx, "Seat hold expired or not owned by user", "seat_id", seat.ID, "user_id", userID)
+			return nil, ErrSeatHoldExpired
+		}
+
+		s.logger.Error(ctx, "Failed to convert seat hold", "seat_id", seat.ID, "error", err)
+		return nil, fmt.Errorf("failed to convert seat hold: %w", err)
+	}
+
+	if err := s.decrementAvailableTickets(ctx, event.ID, 1); err != nil {
+		s.logger.Error(ctx, "Failed to decrement available tickets", "error", err)
+		s.recordFailedAction(ctx, &domain.FailedAction{
+			Kind:     string(domain.FailedActionDecrementAvailable),
+			EventID:  event.ID,
+			SeatID:   &seat.ID,
+			TicketID: &ticket.ID,
+			Quantity: 1,
+			Reason:   "decrement available tickets after held seat purchase",
+		}, err)
+	}
+
+	return ticket, nil
+}
+
+// ReleaseExpiredSeatHolds returns seats whose holds lapsed to the available pool; run it periodically
+func (s *TicketingService) ReleaseExpiredSeatHolds(ctx context.Context) (int, error) {
+	if s.seatHoldRepo == nil {
+		return 0, nil
+	}
+
+	released, err := s.seatHoldRepo.ReleaseExpired(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to release expired seat holds", "error", err)
+		return 0, fmt.Errorf("failed to release expired seat holds: %w", err)
+	}
+
+	if released > 0 {
+		s.logger.Info(ctx, "Released expired seat holds", "released", released)
+	}
+
+	return released, nil
+}
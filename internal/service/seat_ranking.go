@@ -0,0 +1,164 @@
+package service
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// SeatRanker orders candidate seats from best to worst wherever the service picks seats for a buyer
+type SeatRanker interface {
+	// Rank sorts seats in place, best seat first
+	Rank(seats []*domain.Seat)
+}
+
+// FrontToBackRanker prefers rows nearer the stage, then lower seat numbers within a row
+type FrontToBackRanker struct{}
+
+// Rank sorts seats by section, row and seat number
+func (FrontToBackRanker) Rank(seats []*domain.Seat) {
+	sort.SliceStable(seats, func(i, j int) bool {
+		return frontToBackLess(seats[i], seats[j])
+	})
+}
+
+// CenterOutRanker prefers seats nearest the middle of their section on the seat map, breaking ties front to back
+type CenterOutRanker struct{}
+
+// Rank sorts seats by their distance from the center of their section's bounding box on the seat map
+func (CenterOutRanker) Rank(seats []*domain.Seat) {
+	type box struct{ minX, maxX, minY, maxY int }
+
+	boxes := make(map[string]*box)
+	for _, seat := range seats {
+		b, ok := boxes[seat.Section]
+		if !ok {
+			boxes[seat.Section] = &box{minX: seat.X, maxX: seat.X, minY: seat.Y, maxY: seat.Y}
+			continue
+		}
+		b.minX, b.maxX = min(b.minX, seat.X), max(b.maxX, seat.X)
+		b.minY, b.maxY = min(b.minY, seat.Y), max(b.maxY, seat.Y)
+	}
+
+	// Distances are doubled so the center stays on integer coordinates
+	offCenter := func(seat *domain.Seat) int {
+		b := boxes[seat.Section]
+		dx, dy := 2*seat.X-(b.minX+b.maxX), 2*seat.Y-(b.minY+b.maxY)
+		return dx*dx + dy*dy
+	}
+
+	sort.SliceStable(seats, func(i, j int) bool {
+		di, dj := offCenter(seats[i]), offCenter(seats[j])
+		if di != dj {
+			return di < dj
+		}
+		return frontToBackLess(seats[i], seats[j])
+	})
+}
+
+// PriceAscendingRanker prefers cheaper seats, breaking ties front to back
+type PriceAscendingRanker struct{}
+
+// Rank sorts seats by price
+func (PriceAscendingRanker) Rank(seats []*domain.Seat) {
+	sort.SliceStable(seats, func(i, j int) bool {
+		if seats[i].Price != seats[j].Price {
+			return seats[i].Price < seats[j].Price
+		}
+		return frontToBackLess(seats[i], seats[j])
+	})
+}
+
+// SetSeatRanker sets the ranker used for events that don't choose a seat ranking; FrontToBackRanker by default
+func (s *TicketingService) SetSeatRanker(ranker SeatRanker) {
+	s.seatRanker = ranker
+}
+
+// seatRankerFor returns the ranker an event chose, falling back to the configured default
+func (s *TicketingService) seatRankerFor(event *domain.Event) SeatRanker {
+	switch domain.SeatRanking(event.SeatRanking) {
+	case domain.SeatRankingFrontToBack:
+		return FrontToBackRanker{}
+	case domain.SeatRankingCenterOut:
+		return CenterOutRanker{}
+	case domain.SeatRankingPriceAscending:
+		return PriceAscendingRanker{}
+	}
+
+	if s.seatRanker != nil {
+		return s.seatRanker
+	}
+	return FrontToBackRanker{}
+}
+
+// seatRanks returns each seat's position once the ranker has ordered them, 0 being the best
+func seatRanks(ranker SeatRanker, seats []*domain.Seat) map[*domain.Seat]int {
+	ranked := append([]*domain.Seat(nil), seats...)
+	ranker.Rank(ranked)
+
+	ranks := make(map[*domain.Seat]int, len(ranked))
+	for i, seat := range ranked {
+		ranks[seat] = i
+	}
+	return ranks
+}
+
+// frontToBackLess orders seats by section, row and number, comparing labels as numbers where they are numbers
+func frontToBackLess(a, b *domain.Seat) bool {
+	if a.Section != b.Section {
+		return a.Section < b.Section
+	}
+	if c := compareSeatLabels(a.Row, b.Row); c != 0 {
+		return c < 0
+	}
+	if c := compareSeatLabels(a.Number, b.Number); c != 0 {
+		return c < 0
+	}
+	return a.ID.String() < b.ID.String()
+}
+
+// compareSeatLabels compares two row or seat labels. Labels that parse as ordinals compare numerically and come
+// before labels that don't, which compare as plain strings.
+func compareSeatLabels(a, b string) int {
+	oa, okA := seatLabelOrdinal(a)
+	ob, okB := seatLabelOrdinal(b)
+
+	switch {
+	case okA && okB:
+		return oa - ob
+	case okA:
+		return -1
+	case okB:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// seatLabelOrdinal parses a row or seat label into its position: "7" and "007" are 7, and letter labels count
+// like spreadsheet columns, so "A" is 1, "Z" is 26 and "AA" is 27. Surrounding space and letter case are ignored.
+func seatLabelOrdinal(label string) (int, bool) {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return 0, false
+	}
+
+	if n, err := strconv.Atoi(label); err == nil {
+		return n, n >= 0
+	}
+
+	// Longer letter labels would overflow and no venue has them
+	if len(label) > 6 {
+		return 0, false
+	}
+
+	n := 0
+	for _, r := range strings.ToUpper(label) {
+		if r < 'A' || r > 'Z' {
+			return 0, false
+		}
+		n = n*26 + int(r-'A'+1)
+	}
+	return n, true
+}
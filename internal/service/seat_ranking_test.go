@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// rankedSeat is a seat of section A placed on the seat map with a price
+type rankedSeat struct {
+	name        string
+	row, number string
+	x, y        int
+	price       int64
+}
+
+// rankedSeats is one available set where each built-in ranker prefers a different seat:
+// "front" is on the first row, "center" is nearest the middle and "cheap" costs least
+var rankedSeats = []rankedSeat{
+	{name: "cheap", row: "2", number: "1", x: 4, y: 1, price: 10000},
+	{name: "center", row: "1", number: "2", x: 2, y: 0, price: 30000},
+	{name: "front", row: "1", number: "1", x: 0, y: 0, price: 30000},
+}
+
+// createRankedSeats stores rankedSeats for an event and returns them by name
+func (tt *testTicketing) createRankedSeats(t *testing.T, event *domain.Event) map[uuid.UUID]string {
+	t.Helper()
+
+	names := make(map[uuid.UUID]string, len(rankedSeats))
+	for _, placed := range rankedSeats {
+		seat := tt.createSeat(t, event, domain.SeatStatusAvailable)
+		seat.Row, seat.Number = placed.row, placed.number
+		seat.X, seat.Y = placed.x, placed.y
+		seat.Price = placed.price
+		if err := tt.seats.Update(context.Background(), seat); err != nil {
+			t.Fatalf("place seat: %v", err)
+		}
+		names[seat.ID] = placed.name
+	}
+	return names
+}
+
+func TestSeatRankersPickDifferentSeats(t *testing.T) {
+	tests := []struct {
+		ranker SeatRanker
+		want   string
+	}{
+		{ranker: FrontToBackRanker{}, want: "front"},
+		{ranker: CenterOutRanker{}, want: "center"},
+		{ranker: PriceAscendingRanker{}, want: "cheap"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			seats := make([]*domain.Seat, len(rankedSeats))
+			names := make(map[*domain.Seat]string, len(rankedSeats))
+			for i, placed := range rankedSeats {
+				seats[i] = &domain.Seat{ID: uuid.New(), Section: "A", Row: placed.row, Number: placed.number, X: placed.x, Y: placed.y, Price: placed.price}
+				names[seats[i]] = placed.name
+			}
+
+			tc.ranker.Rank(seats)
+			if got := names[seats[0]]; got != tc.want {
+				t.Fatalf("best seat = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSeatRankingPerEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		ranking domain.SeatRanking
+		// fallback is the server-wide ranker, nil to keep the built-in default
+		fallback SeatRanker
+		want     string
+	}{
+		{name: "server default", want: "front"},
+		{name: "configured server default", fallback: PriceAscendingRanker{}, want: "cheap"},
+		{name: "event center out", ranking: domain.SeatRankingCenterOut, fallback: PriceAscendingRanker{}, want: "center"},
+		{name: "event price ascending", ranking: domain.SeatRankingPriceAscending, want: "cheap"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tt := newTestTicketing(t)
+			if tc.fallback != nil {
+				tt.service.SetSeatRanker(tc.fallback)
+			}
+
+			event := tt.createEvent(t, 10, 10)
+			event.SeatRanking = string(tc.ranking)
+			if err := tt.events.Update(ctx, event); err != nil {
+				t.Fatalf("update event: %v", err)
+			}
+			names := tt.createRankedSeats(t, event)
+
+			blocks, err := tt.service.SuggestAdjacentSeats(ctx, event.ID, "A", 1)
+			if err != nil {
+				t.Fatalf("suggest seats: %v", err)
+			}
+			if len(blocks) != len(rankedSeats) {
+				t.Fatalf("got %d suggestions, want %d", len(blocks), len(rankedSeats))
+			}
+			if got := names[blocks[0][0].ID]; got != tc.want {
+				t.Fatalf("first suggestion = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSeatLabelOrdinal(t *testing.T) {
+	tests := []struct {
+		label string
+		want  int
+		ok    bool
+	}{
+		{label: "7", want: 7, ok: true},
+		{label: "007", want: 7, ok: true},
+		{label: " 12 ", want: 12, ok: true},
+		{label: "A", want: 1, ok: true},
+		{label: "z", want: 26, ok: true},
+		{label: "AA", want: 27, ok: true},
+		{label: "-1"},
+		{label: "A1"},
+		{label: ""},
+		{label: "ABCDEFG"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.label, func(t *testing.T) {
+			got, ok := seatLabelOrdinal(tc.label)
+			if ok != tc.ok || (ok && got != tc.want) {
+				t.Fatalf("seatLabelOrdinal(%q) = %d, %v; want %d, %v", tc.label, got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+
+	// Numbered rows sort numerically, before rows that aren't labels at all
+	if compareSeatLabels("9", "10") >= 0 || compareSeatLabels("Z", "AA") >= 0 || compareSeatLabels("10", "Box") >= 0 {
+		t.Fatal("seat labels compare as strings instead of positions")
+	}
+}
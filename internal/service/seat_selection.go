@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// MaxSeatSelectionHolds is the number of seats a single interactive selection may hold at once
+const MaxSeatSelectionHolds = 10
+
+// ErrSectionHoldLimit is returned when a selection already holds as many seats of a section as one buyer may
+var ErrSectionHoldLimit = errors.New("too many seats held in this section")
+
+// SeatSelection tracks the seats held by one interactive client connection.
+// Holds live only as long as the connection: Close releases everything still held.
+// With a seat hold repository configured, holds also lapse after SeatHoldTTL and the user can buy a held seat.
+type SeatSelection struct {
+	service *TicketingService
+	eventID uuid.UUID
+	userID  uuid.UUID
+
+	mu     sync.Mutex
+	held   map[uuid.UUID]string // Section of each held seat
+	closed bool
+}
+
+// OpenSeatSelection starts a connection-scoped seat selection for a user
+func (s *TicketingService) OpenSeatSelection(ctx context.Context, eventID, userID uuid.UUID) (*SeatSelection, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if !event.IsSeatedEvent {
+		return nil, fmt.Errorf("seat selection is only available for seated events")
+	}
+
+	if !event.CanPurchaseAt(s.now()) {
+		return nil, fmt.Errorf("event is not available for purchase")
+	}
+
+	s.logger.Info(ctx, "Seat selection opened", "event_id", eventID, "user_id", userID)
+
+	return &SeatSelection{
+		service: s,
+		eventID: eventID,
+		userID:  userID,
+		held:    make(map[uuid.UUID]string),
+	}, nil
+}
+
+// Hold reserves a seat for this selection
+func (sel *SeatSelection) Hold(ctx context.Context, seatID uuid.UUID) (*domain.Seat, error) {
+	sel.mu.Lock()
+	defer sel.mu.Unlock()
+
+	if sel.closed {
+		return nil, fmt.Errorf("seat selection is closed")
+	}
+
+	if _, ok := sel.held[seatID]; ok {
+		return nil, fmt.Errorf("seat is already held by this selection")
+	}
+
+	if len(sel.held) >= MaxSeatSelectionHolds {
+		return nil, fmt.Errorf("cannot hold more than %d seats at once", MaxSeatSelectionHolds)
+	}
+
+	seat, err := sel.service.seatRepo.GetByID(ctx, seatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seat: %w", err)
+	}
+
+	if seat.EventID != sel.eventID {
+		return nil, fmt.Errorf("seat does not belong to this event")
+	}
+
+	if seat.IsRestricted() {
+		return nil, ErrAccessibleSeatRestricted
+	}
+
+	if limit := sel.service.config.MaxHoldsPerSection; limit > 0 && sel.heldInSection(seat.Section) >= limit {
+		return nil, fmt.Errorf("%w: at most %d seats in section %s", ErrSectionHoldLimit, limit, seat.Section)
+	}
+
+	if err := sel.holdSeat(ctx, seat); err != nil {
+		return nil, fmt.Errorf("failed to hold seat: %w", err)
+	}
+
+	sel.held[seatID] = seat.Section
+
+	return seat, nil
+}
+
+// Release gives back a seat held by this selection
+func (sel *SeatSelection) Release(ctx context.Context, seatID uuid.UUID) error {
+	sel.mu.Lock()
+	defer sel.mu.Unlock()
+
+	if _, ok := sel.held[seatID]; !ok {
+		return fmt.Errorf("seat is not held by this selection")
+	}
+
+	if err := sel.releaseSeat(ctx, seatID); err != nil {
+		return fmt.Errorf("failed to release seat: %w", err)
+	}
+
+	delete(sel.held, seatID)
+	return nil
+}
+
+// heldInSection counts the seats this selection holds in a section
+func (sel *SeatSelection) heldInSection(section string) int {
+	count := 0
+	for _, heldSection := range sel.held {
+		if heldSection == section {
+			count++
+		}
+	}
+	return count
+}
+
+// holdSeat takes a seat for the user, as an expiring hold when holds are enabled and as a plain reservation otherwise
+func (sel *SeatSelection) holdSeat(ctx context.Context, seat *domain.Seat) error {
+	if sel.service.seatHoldRepo == nil {
+		if err := sel.service.seatRepo.ReserveSeats(ctx, []uuid.UUID{seat.ID}); err != nil {
+			return err
+		}
+		seat.Status = string(domain.SeatStatusReserved)
+		return nil
+	}
+
+	if err := sel.service.seatHoldRepo.Hold(ctx, seat.ID, sel.userID, SeatHoldTTL); err != nil {
+		return err
+	}
+	seat.Status = string(domain.SeatStatusHeld)
+	return nil
+}
+
+// releaseSeat gives back a seat taken by holdSeat. A hold that already lapsed or was bought is nothing to release.
+func (sel *SeatSelection) releaseSeat(ctx context.Context, seatID uuid.UUID) error {
+	if sel.service.seatHoldRepo == nil {
+		return sel.service.seatRepo.ReleaseSeats(ctx, []uuid.UUID{seatID})
+	}
+
+	if err := sel.service.seatHoldRepo.Release(ctx, seatID, sel.userID); err != nil && !errors.Is(err, repository.ErrSeatHoldNotFound) {
+		return err
+	}
+	return nil
+}
+
+// Held returns the IDs of the seats currently held by this selection
+func (sel *SeatSelection) Held() []uuid.UUID {
+	sel.mu.Lock()
+	defer sel.mu.Unlock()
+
+	seatIDs := make([]uuid.UUID, 0, len(sel.held))
+	for seatID := range sel.held {
+		seatIDs = append(seatIDs, seatID)
+	}
+	return seatIDs
+}
+
+// Close releases every seat still held; it is called when the client disconnects
+func (sel *SeatSelection) Close(ctx context.Context) error {
+	sel.mu.Lock()
+	defer sel.mu.Unlock()
+
+	if sel.closed {
+		return nil
+	}
+	sel.closed = true
+
+	if len(sel.held) == 0 {
+		return nil
+	}
+
+	// Release seats one by one so a single failure does not strand the rest
+	var errs []error
+	for seatID := range sel.held {
+		if err := sel.releaseSeat(ctx, seatID); err != nil {
+			sel.service.logger.Error(ctx, "Failed to release seat on disconnect",
+				"event_id", sel.eventID,
+				"user_id", sel.userID,
+				"seat_id", seatID,
+				"error", err)
+			errs = append(errs, fmt.Errorf("failed to release seat %s: %w", seatID, err))
+		}
+	}
+
+	released := len(sel.held) - len(errs)
+	sel.held = make(map[uuid.UUID]string)
+	sel.service.logger.Info(ctx, "Seat selection closed", "event_id", sel.eventID, "user_id", sel.userID, "released", released)
+	return errors.Join(errs...)
+}
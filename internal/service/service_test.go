@@ -0,0 +1,435 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/snowmerak/ticketing/lib/adapter"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/pkg/logger"
+	"github.com/snowmerak/ticketing/pkg/repository/memory"
+)
+
+// errCacheMiss is returned by testCache for every read
+var errCacheMiss = errors.New("cache miss")
+
+// testCache is a Cache that never holds anything, so services always read through to the repositories
+type testCache struct{}
+
+func (testCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return nil
+}
+
+func (testCache) Get(ctx context.Context, key string) (interface{}, error) {
+	return nil, errCacheMiss
+}
+
+func (testCache) Delete(ctx context.Context, key string) error {
+	return nil
+}
+
+func (testCache) Exists(ctx context.Context, key string) (bool, error) {
+	return false, nil
+}
+
+func (testCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return nil
+}
+
+func (testCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return 0, nil
+}
+
+// testLock is an in-process Lock
+type testLock struct {
+	mu    sync.Mutex
+	locks map[string]testLockHold
+}
+
+// testLockHold is the holder of a testLock key and when the hold lapses
+type testLockHold struct {
+	token     string
+	expiresAt time.Time
+}
+
+func newTestLock() *testLock {
+	return &testLock{locks: make(map[string]testLockHold)}
+}
+
+// held returns the live hold on key; the caller holds l.mu
+func (l *testLock) held(key string) (testLockHold, bool) {
+	hold, ok := l.locks[key]
+	if ok && !time.Now().Before(hold.expiresAt) {
+		delete(l.locks, key)
+		return testLockHold{}, false
+	}
+	return hold, ok
+}
+
+func (l *testLock) Acquire(ctx context.Context, key string, expiration time.Duration) (string, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.held(key); ok {
+		return "", false, nil
+	}
+	token := uuid.NewString()
+	l.locks[key] = testLockHold{token: token, expiresAt: time.Now().Add(expiration)}
+	return token, true, nil
+}
+
+func (l *testLock) Release(ctx context.Context, key, token string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if hold, ok := l.held(key); ok && hold.token == token {
+		delete(l.locks, key)
+	}
+	return nil
+}
+
+func (l *testLock) Extend(ctx context.Context, key, token string, expiration time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	hold, ok := l.held(key)
+	if !ok || hold.token != token {
+		return adapter.ErrLockNotHeld
+	}
+	l.locks[key] = testLockHold{token: token, expiresAt: time.Now().Add(expiration)}
+	return nil
+}
+
+func (l *testLock) IsLocked(ctx context.Context, key string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, ok := l.held(key)
+	return ok, nil
+}
+
+func (l *testLock) TTL(ctx context.Context, key string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	hold, ok := l.held(key)
+	if !ok {
+		return 0, nil
+	}
+	return time.Until(hold.expiresAt), nil
+}
+
+func (l *testLock) ForceRelease(ctx context.Context, key string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, ok := l.held(key)
+	delete(l.locks, key)
+	return ok, nil
+}
+
+// sentEmail is an email a testNotifier was asked to send
+type sentEmail struct {
+	userID  uuid.UUID
+	subject string
+	body    string
+}
+
+// testNotifier records the notifications it is asked to send
+type testNotifier struct {
+	mu     sync.Mutex
+	emails []sentEmail
+}
+
+func (n *testNotifier) SendEmail(ctx context.Context, userID uuid.UUID, subject, body string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.emails = append(n.emails, sentEmail{userID: userID, subject: subject, body: body})
+	return nil
+}
+
+func (n *testNotifier) SendSMS(ctx context.Context, userID uuid.UUID, message string) error {
+	return nil
+}
+
+// sent returns the emails sent to a user so far
+func (n *testNotifier) sent(userID uuid.UUID) []sentEmail {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var emails []sentEmail
+	for _, email := range n.emails {
+		if email.userID == userID {
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// testTicketing is a TicketingService backed by in-memory repositories, with the repositories at hand for setup
+// and assertions
+type testTicketing struct {
+	service *TicketingService
+	events  *memory.EventRepository
+	seats   *memory.SeatRepository
+	tickets *memory.TicketRepository
+	queue   *memory.QueueRepository
+}
+
+// newTestTicketing builds a TicketingService over fresh in-memory repositories
+func newTestTicketing(t *testing.T) *testTicketing {
+	t.Helper()
+
+	tt := &testTicketing{
+		events:  memory.NewEventRepository(),
+		seats:   memory.NewSeatRepository(0),
+		tickets: memory.NewTicketRepository(),
+		queue:   memory.NewQueueRepository(),
+	}
+	tt.service = NewTicketingService(tt.tickets, tt.events, tt.seats, tt.queue, testCache{}, newTestLock(), logger.NewLoggerWithLevel(zerolog.Disabled))
+	return tt
+}
+
+// createEvent stores an active event with totalTickets tickets of which available are left
+func (tt *testTicketing) createEvent(t *testing.T, totalTickets, available int) *domain.Event {
+	t.Helper()
+
+	now := time.Now()
+	event := &domain.Event{
+		ID:               uuid.New(),
+		Name:             "Service Test Event",
+		StartTime:        now.Add(24 * time.Hour),
+		EndTime:          now.Add(27 * time.Hour),
+		Venue:            "Test Hall",
+		Status:           string(domain.EventStatusActive),
+		TotalTickets:     totalTickets,
+		AvailableTickets: available,
+		IsSeatedEvent:    true,
+		Currency:         "USD",
+	}
+	if err := tt.events.Create(context.Background(), event); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+	return event
+}
+
+// createSeat stores a seat of an event in the given status
+func (tt *testTicketing) createSeat(t *testing.T, event *domain.Event, status domain.SeatStatus) *domain.Seat {
+	t.Helper()
+
+	seat := &domain.Seat{
+		ID:      uuid.New(),
+		EventID: event.ID,
+		Section: "A",
+		Row:     "1",
+		Number:  uuid.NewString()[:8],
+		Price:   10000,
+		Status:  string(status),
+	}
+	if err := tt.seats.Create(context.Background(), seat); err != nil {
+		t.Fatalf("create seat: %v", err)
+	}
+	return seat
+}
+
+// createReservation stores a reserved seat and its ticket for a user, expiring after expiresIn, counted against
+// the user's ticket limit as a purchase would
+func (tt *testTicketing) createReservation(t *testing.T, event *domain.Event, userID uuid.UUID, expiresIn time.Duration) (*domain.Seat, *domain.Ticket) {
+	t.Helper()
+	ctx := context.Background()
+
+	seat := tt.createSeat(t, event, domain.SeatStatusReserved)
+
+	expiresAt := time.Now().Add(expiresIn)
+	ticket := &domain.Ticket{
+		ID:        uuid.New(),
+		EventID:   event.ID,
+		SeatID:    &seat.ID,
+		UserID:    userID,
+		Price:     seat.Price,
+		Currency:  event.Currency,
+		Status:    string(domain.TicketStatusReserved),
+		IssuedAt:  time.Now(),
+		ExpiresAt: &expiresAt,
+	}
+	if err := tt.tickets.Create(ctx, ticket); err != nil {
+		t.Fatalf("create ticket: %v", err)
+	}
+	if _, err := tt.tickets.IncrementUserEventCount(ctx, event.ID, userID, 1, 0); err != nil {
+		t.Fatalf("count user ticket: %v", err)
+	}
+	return seat, ticket
+}
+
+// availableTickets returns an event's stored available ticket count
+func (tt *testTicketing) availableTickets(t *testing.T, eventID uuid.UUID) int {
+	t.Helper()
+
+	event, err := tt.events.GetByID(context.Background(), eventID)
+	if err != nil {
+		t.Fatalf("get event: %v", err)
+	}
+	return event.AvailableTickets
+}
+
+// activateSession joins a user to an event's queue and activates their session from now on, returning the
+// session ID
+func (tt *testTicketing) activateSession(t *testing.T, eventID, userID uuid.UUID) string {
+	t.Helper()
+	ctx := context.Background()
+
+	sessionID := uuid.NewString()
+	entry, err := tt.queue.Join(ctx, eventID, userID, sessionID)
+	if err != nil {
+		t.Fatalf("join queue: %v", err)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(15 * time.Minute)
+	entry.Status = string(domain.QueueStatusActive)
+	entry.ActivatedAt = &now
+	entry.ExpiresAt = &expiresAt
+	if err := tt.queue.Update(ctx, entry); err != nil {
+		t.Fatalf("activate session: %v", err)
+	}
+	return sessionID
+}
+
+// testSemaphore is an in-process Semaphore that records the most slots it ever had taken at once
+type testSemaphore struct {
+	mu   sync.Mutex
+	held map[string]map[string]struct{}
+	peak int
+}
+
+func newTestSemaphore() *testSemaphore {
+	return &testSemaphore{held: make(map[string]map[string]struct{})}
+}
+
+func (s *testSemaphore) Acquire(ctx context.Context, key string, limit int, expiration time.Duration) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.held[key]) >= limit {
+		return "", false, nil
+	}
+	if s.held[key] == nil {
+		s.held[key] = make(map[string]struct{})
+	}
+	token := uuid.NewString()
+	s.held[key][token] = struct{}{}
+	s.peak = max(s.peak, len(s.held[key]))
+	return token, true, nil
+}
+
+func (s *testSemaphore) Release(ctx context.Context, key, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.held[key], token)
+	return nil
+}
+
+// inUse returns how many slots of a key are taken
+func (s *testSemaphore) inUse(key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.held[key])
+}
+
+func TestPurchaseSemaphore(t *testing.T) {
+	const limit = 3
+
+	setup := func(t *testing.T, available int) (*testTicketing, *testSemaphore, *domain.Event) {
+		t.Helper()
+		tt := newTestTicketing(t)
+		semaphore := newTestSemaphore()
+		tt.service.SetSemaphore(semaphore)
+
+		event := tt.createEvent(t, available, available)
+		event.IsSeatedEvent = false
+		event.StandingPrice = 5000
+		event.MaxConcurrentPurchases = limit
+		if err := tt.events.Update(context.Background(), event); err != nil {
+			t.Fatalf("make event standing: %v", err)
+		}
+		return tt, semaphore, event
+	}
+
+	t.Run("saturated event refuses without touching inventory", func(t *testing.T) {
+		ctx := context.Background()
+		tt, semaphore, event := setup(t, 10)
+		key := "purchase:" + event.ID.String()
+		for range limit {
+			if _, ok, _ := semaphore.Acquire(ctx, key, limit, time.Minute); !ok {
+				t.Fatal("could not take a slot to saturate the event")
+			}
+		}
+
+		userID := uuid.New()
+		sessionID := tt.activateSession(t, event.ID, userID)
+		if _, err := tt.service.PurchaseTicket(ctx, event.ID, userID, nil, sessionID, PurchaseOptions{}); !errors.Is(err, ErrPurchaseSaturated) {
+			t.Fatalf("expected ErrPurchaseSaturated, got %v", err)
+		}
+		if got := tt.availableTickets(t, event.ID); got != 10 {
+			t.Fatalf("available tickets = %d after a refused purchase, want 10", got)
+		}
+		if held, err := tt.tickets.GetByUserAndEvent(ctx, userID, event.ID); err != nil || len(held) != 0 {
+			t.Fatalf("user holds %d tickets (err %v) after a refused purchase, want none", len(held), err)
+		}
+	})
+
+	t.Run("concurrent buyers never oversell or exceed the limit", func(t *testing.T) {
+		const buyers, available = 40, 15
+		ctx := context.Background()
+		tt, semaphore, event := setup(t, available)
+
+		type buyer struct {
+			userID    uuid.UUID
+			sessionID string
+		}
+		all := make([]buyer, buyers)
+		for i := range all {
+			userID := uuid.New()
+			all[i] = buyer{userID: userID, sessionID: tt.activateSession(t, event.ID, userID)}
+		}
+
+		var (
+			wg     sync.WaitGroup
+			mu     sync.Mutex
+			bought int
+		)
+		for _, b := range all {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := tt.service.PurchaseTicket(ctx, event.ID, b.userID, nil, b.sessionID, PurchaseOptions{}); err == nil {
+					mu.Lock()
+					bought++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if bought == 0 || bought > available {
+			t.Fatalf("sold %d tickets of %d", bought, available)
+		}
+		if got := tt.availableTickets(t, event.ID); got != available-bought {
+			t.Fatalf("available tickets = %d after selling %d of %d", got, bought, available)
+		}
+		if semaphore.peak > limit {
+			t.Fatalf("%d purchases ran at once, want at most %d", semaphore.peak, limit)
+		}
+		if inUse := semaphore.inUse("purchase:" + event.ID.String()); inUse != 0 {
+			t.Fatalf("%d purchase slots still taken after every purchase finished", inUse)
+		}
+	})
+}
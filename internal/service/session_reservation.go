@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// ErrNoActiveReservation is returned when a queue session has no reservation waiting to be confirmed
+var ErrNoActiveReservation = errors.New("session has no reservation in progress")
+
+// SessionReservation is the checkout a queue session has in progress: the user's unexpired reserved tickets
+// for the session's event and how long the earliest of them has left
+type SessionReservation struct {
+	SessionID        string           `json:"session_id"`
+	EventID          uuid.UUID        `json:"event_id"`
+	UserID           uuid.UUID        `json:"user_id"`
+	Tickets          []*domain.Ticket `json:"tickets"`
+	ExpiresAt        time.Time        `json:"expires_at"`
+	RemainingSeconds int64            `json:"remaining_seconds"`
+}
+
+// GetSessionReservation recovers the reservation a queue session has in progress, so a user who reloads
+// mid-checkout can pick it up again. Reservations are found through the session's user and event; it returns
+// ErrNoActiveReservation when every reserved ticket has been confirmed, cancelled or has expired.
+func (s *TicketingService) GetSessionReservation(ctx context.Context, sessionID string) (*SessionReservation, error) {
+	entry, err := s.queueRepo.GetBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queue session: %w", err)
+	}
+
+	tickets, err := s.ticketRepo.GetByUserID(ctx, entry.UserID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get user tickets", "user_id", entry.UserID, "error", err)
+		return nil, fmt.Errorf("failed to get user tickets: %w", err)
+	}
+
+	now := s.now()
+	reserved := make([]*domain.Ticket, 0, len(tickets))
+	for _, ticket := range tickets {
+		if ticket.EventID != entry.EventID || ticket.ExpiresAt == nil || !ticket.CanBeConfirmedAt(now) {
+			continue
+		}
+		reserved = append(reserved, ticket)
+	}
+
+	if len(reserved) == 0 {
+		return nil, ErrNoActiveReservation
+	}
+
+	sort.Slice(reserved, func(i, j int) bool { return reserved[i].ExpiresAt.Before(*reserved[j].ExpiresAt) })
+
+	// The checkout lapses with its earliest reservation
+	expiresAt := *reserved[0].ExpiresAt
+	return &SessionReservation{
+		SessionID:        sessionID,
+		EventID:          entry.EventID,
+		UserID:           entry.UserID,
+		Tickets:          reserved,
+		ExpiresAt:        expiresAt,
+		RemainingSeconds: max(int64(time.Until(expiresAt)/time.Second), 0),
+	}, nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+func TestStandingTicketsUseEventPrice(t *testing.T) {
+	tests := []struct {
+		name          string
+		standingPrice int64
+		feeFlat       int64
+		want          int64
+	}{
+		{name: "free", standingPrice: 0, want: 0},
+		{name: "configured price", standingPrice: 12345, want: 12345},
+		{name: "configured price with fee", standingPrice: 7000, feeFlat: 250, want: 7250},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tt := newTestTicketing(t)
+			config := DefaultTicketingConfig()
+			config.FeePolicy = domain.FeePolicy{FeeFlat: tc.feeFlat}
+			if err := tt.service.SetConfig(config); err != nil {
+				t.Fatalf("set config: %v", err)
+			}
+
+			event := tt.createEvent(t, 10, 10)
+			event.IsSeatedEvent = false
+			event.StandingPrice = tc.standingPrice
+			if err := tt.events.Update(ctx, event); err != nil {
+				t.Fatalf("make event standing: %v", err)
+			}
+
+			userID := uuid.New()
+			sessionID := tt.activateSession(t, event.ID, userID)
+			ticket, err := tt.service.PurchaseTicket(ctx, event.ID, userID, nil, sessionID, PurchaseOptions{})
+			if err != nil {
+				t.Fatalf("purchase standing ticket: %v", err)
+			}
+			if ticket.Price != tc.want || ticket.PriceBreakdown().Face != tc.standingPrice {
+				t.Fatalf("ticket price = %d with face %d, want %d with face %d", ticket.Price, ticket.PriceBreakdown().Face, tc.want, tc.standingPrice)
+			}
+		})
+	}
+}
+
+func TestStandingPriceValidation(t *testing.T) {
+	ctx := context.Background()
+	events := newTestEvents(newTestTicketing(t))
+
+	event := newEventRequest("USD")
+	event.IsSeatedEvent = false
+	event.StandingPrice = -1
+	if err := events.CreateEvent(ctx, event); err == nil {
+		t.Fatal("created a standing event with a negative price")
+	}
+
+	// Events stored before standing prices existed decode as free
+	var stored domain.Event
+	if err := json.Unmarshal([]byte(`{"name":"Old Event","is_seated_event":false}`), &stored); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	if stored.StandingPrice != 0 {
+		t.Fatalf("standing price of an event stored without one = %d, want 0", stored.StandingPrice)
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+var (
+	// ErrStandingZoneRequired is returned when a standing ticket of an event sold per zone names no zone
+	ErrStandingZoneRequired = errors.New("standing zone is required")
+
+	// ErrUnknownStandingZone is returned when a purchase names a zone the event does not have
+	ErrUnknownStandingZone = errors.New("unknown standing zone")
+
+	// ErrStandingZoneSoldOut is returned when the zone a purchase names has no tickets left
+	ErrStandingZoneSoldOut = errors.New("standing zone is sold out")
+)
+
+// StandingZoneAvailability reports a standing zone's capacity and how many of its tickets are left
+type StandingZoneAvailability struct {
+	Name      string `json:"name"`
+	Capacity  int    `json:"capacity"`
+	Available int    `json:"available"`
+}
+
+// standingZoneFor checks the zone a standing purchase asked for against the event's zones. Events without
+// zones sell from the whole event and take no zone.
+func standingZoneFor(event *domain.Event, zone string) (string, error) {
+	if !event.HasStandingZones() {
+		if zone != "" {
+			return "", fmt.Errorf("%w: event %s is not sold per zone", ErrUnknownStandingZone, event.ID)
+		}
+		return "", nil
+	}
+
+	if zone == "" {
+		return "", ErrStandingZoneRequired
+	}
+	if _, ok := event.StandingZone(zone); !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownStandingZone, zone)
+	}
+
+	return zone, nil
+}
+
+// takeZoneTicket takes one ticket off a standing zone's counter; a zone that is empty fails with
+// ErrStandingZoneSoldOut
+func (s *TicketingService) takeZoneTicket(ctx context.Context, eventID uuid.UUID, zone string) error {
+	if zone == "" {
+		return nil
+	}
+
+	if err := s.eventRepo.DecrementZoneTickets(ctx, eventID, zone, 1); err != nil {
+		if errors.Is(err, repository.ErrStandingZoneSoldOut) {
+			s.logger.Warn(ctx, "Standing zone sold out", "event_id", eventID, "zone", zone)
+			return fmt.Errorf("%w: %q", ErrStandingZoneSoldOut, zone)
+		}
+		s.logger.Error(ctx, "Failed to decrement standing zone tickets", "event_id", eventID, "zone", zone, "error", err)
+		return fmt.Errorf("failed to reserve zone ticket: %w", err)
+	}
+
+	return nil
+}
+
+// returnZoneTickets gives tickets back to a standing zone's counter, dead-lettering the write if it fails
+func (s *TicketingService) returnZoneTickets(ctx context.Context, eventID uuid.UUID, zone string, count int, reason string) {
+	if zone == "" || count <= 0 {
+		return
+	}
+
+	if err := s.eventRepo.IncrementZoneTickets(ctx, eventID, zone, count); err != nil {
+		s.logger.Error(ctx, "Failed to return standing zone tickets", "event_id", eventID, "zone", zone, "quantity", count, "error", err)
+		s.recordFailedAction(ctx, &domain.FailedAction{
+			Kind:     string(domain.FailedActionIncrementZone),
+			EventID:  eventID,
+			Zone:     zone,
+			Quantity: count,
+			Reason:   reason,
+		}, err)
+	}
+}
+
+// zoneCounts tallies tickets per standing zone, skipping tickets that hold no zone
+func zoneCounts(tickets []*domain.Ticket) map[string]int {
+	counts := make(map[string]int)
+	for _, ticket := range tickets {
+		if ticket.Zone != "" {
+			counts[ticket.Zone]++
+		}
+	}
+	return counts
+}
+
+// initStandingZones seeds the ticket counter of each of the event's zones that has none yet from its capacity
+func (s *EventService) initStandingZones(ctx context.Context, event *domain.Event) error {
+	for _, zone := range event.StandingZones {
+		if err := s.eventRepo.InitZoneTickets(ctx, event.ID, zone.Name, zone.Capacity); err != nil {
+			s.logger.Error(ctx, "Failed to init standing zone tickets", "event_id", event.ID, "zone", zone.Name, "error", err)
+			return fmt.Errorf("failed to init standing zone %q: %w", zone.Name, err)
+		}
+	}
+	return nil
+}
+
+// GetStandingZones retrieves the capacity and tickets left of each of an event's standing zones
+func (s *EventService) GetStandingZones(ctx context.Context, eventID uuid.UUID) ([]StandingZoneAvailability, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get event", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	names := make([]string, len(event.StandingZones))
+	for i, zone := range event.StandingZones {
+		names[i] = zone.Name
+	}
+
+	left, err := s.eventRepo.GetZoneTickets(ctx, eventID, names)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get standing zone tickets", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to get standing zone tickets: %w", err)
+	}
+
+	zones := make([]StandingZoneAvailability, len(event.StandingZones))
+	for i, zone := range event.StandingZones {
+		zones[i] = StandingZoneAvailability{
+			Name:      zone.Name,
+			Capacity:  zone.Capacity,
+			Available: left[zone.Name],
+		}
+	}
+
+	return zones, nil
+}
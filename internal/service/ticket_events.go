@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/adapter"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// Topics a TicketingService publishes ticket transitions on
+const (
+	TopicTicketReserved  = "ticket.reserved"
+	TopicTicketConfirmed = "ticket.confirmed"
+	TopicTicketCancelled = "ticket.cancelled"
+)
+
+// TicketTransition is the message published when a ticket is reserved, confirmed or cancelled
+type TicketTransition struct {
+	TicketID  uuid.UUID `json:"ticket_id"`
+	EventID   uuid.UUID `json:"event_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// nopEventPublisher drops every message; it is the publisher until one is set
+type nopEventPublisher struct{}
+
+// Publish drops the message
+func (nopEventPublisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	return nil
+}
+
+// SetEventPublisher sets the publisher ticket transitions are sent to; nil restores the default, which
+// publishes nothing
+func (s *TicketingService) SetEventPublisher(publisher adapter.EventPublisher) {
+	if publisher == nil {
+		publisher = nopEventPublisher{}
+	}
+	s.publisher = publisher
+}
+
+// publishTicketTransition publishes that a ticket moved to status on its topic. It runs after the transition is
+// stored, so a failure is only logged; the transition stands either way.
+func (s *TicketingService) publishTicketTransition(ctx context.Context, topic string, ticketID, eventID, userID uuid.UUID, status domain.TicketStatus) {
+	payload, err := json.Marshal(TicketTransition{
+		TicketID:  ticketID,
+		EventID:   eventID,
+		UserID:    userID,
+		Status:    string(status),
+		Timestamp: s.now(),
+	})
+	if err != nil {
+		s.logger.Error(ctx, "Failed to marshal ticket transition", "ticket_id", ticketID, "topic", topic, "error", err)
+		return
+	}
+
+	if err := s.publisher.Publish(ctx, topic, payload); err != nil {
+		s.logger.Warn(ctx, "Failed to publish ticket transition", "ticket_id", ticketID, "topic", topic, "error", err)
+	}
+}
+
+// publishReserved publishes a ticket.reserved message for each ticket a purchase reserved, companion tickets
+// included
+func (s *TicketingService) publishReserved(ctx context.Context, tickets []*domain.Ticket) {
+	for _, ticket := range tickets {
+		s.publishTicketTransition(ctx, TopicTicketReserved, ticket.ID, ticket.EventID, ticket.UserID, domain.TicketStatusReserved)
+		if ticket.CompanionTicketID != nil {
+			s.publishTicketTransition(ctx, TopicTicketReserved, *ticket.CompanionTicketID, ticket.EventID, ticket.UserID, domain.TicketStatusReserved)
+		}
+	}
+}
+
+// publishCancelled publishes a ticket.cancelled message for a ticket that was just cancelled
+func (s *TicketingService) publishCancelled(ctx context.Context, ticket *domain.Ticket) {
+	s.publishTicketTransition(ctx, TopicTicketCancelled, ticket.ID, ticket.EventID, ticket.UserID, domain.TicketStatusCancelled)
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// publishedTransition is a ticket transition a testPublisher was sent
+type publishedTransition struct {
+	topic      string
+	transition TicketTransition
+}
+
+// testPublisher records the ticket transitions it is sent
+type testPublisher struct {
+	mu        sync.Mutex
+	published []publishedTransition
+}
+
+func (p *testPublisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	var transition TicketTransition
+	if err := json.Unmarshal(payload, &transition); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.published = append(p.published, publishedTransition{topic: topic, transition: transition})
+	return nil
+}
+
+// take returns the transitions published since the last call
+func (p *testPublisher) take() []publishedTransition {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	published := p.published
+	p.published = nil
+	return published
+}
+
+// expectPublished fails the test unless exactly one transition of ticketID to status was published on topic
+// since the last check, or nothing was when topic is empty
+func expectPublished(t *testing.T, publisher *testPublisher, topic string, ticketID uuid.UUID, status domain.TicketStatus) {
+	t.Helper()
+
+	published := publisher.take()
+	if topic == "" {
+		if len(published) != 0 {
+			t.Fatalf("published %d transitions, want none: %+v", len(published), published)
+		}
+		return
+	}
+
+	if len(published) != 1 {
+		t.Fatalf("published %d transitions, want one on %s: %+v", len(published), topic, published)
+	}
+	got := published[0]
+	if got.topic != topic || got.transition.TicketID != ticketID || got.transition.Status != string(status) {
+		t.Fatalf("published %s for ticket %s as %q, want %s for ticket %s as %q",
+			got.topic, got.transition.TicketID, got.transition.Status, topic, ticketID, status)
+	}
+}
+
+func TestPurchasePublishesReservation(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	publisher := &testPublisher{}
+	tt.service.SetEventPublisher(publisher)
+
+	event := tt.createEvent(t, 2, 2)
+	seat := tt.createSeat(t, event, domain.SeatStatusAvailable)
+	userID := uuid.New()
+	sessionID := tt.activateSession(t, event.ID, userID)
+
+	ticket, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &seat.ID, sessionID, PurchaseOptions{})
+	if err != nil {
+		t.Fatalf("purchase: %v", err)
+	}
+	expectPublished(t, publisher, TopicTicketReserved, ticket.ID, domain.TicketStatusReserved)
+
+	// The seat is taken now, so a second purchase of it fails and publishes nothing
+	otherID := uuid.New()
+	otherSession := tt.activateSession(t, event.ID, otherID)
+	if _, err := tt.service.PurchaseTicket(ctx, event.ID, otherID, &seat.ID, otherSession, PurchaseOptions{}); err == nil {
+		t.Fatal("purchase of a reserved seat succeeded")
+	}
+	expectPublished(t, publisher, "", uuid.Nil, "")
+}
+
+func TestTicketTransitionsPublishOnce(t *testing.T) {
+	tests := []struct {
+		name string
+		// prepare moves the reservation to where the transition starts from, if it doesn't start reserved
+		prepare func(ctx context.Context, s *TicketingService, ticketID uuid.UUID) error
+		// transition moves the reservation on and returns the topic and status it should publish
+		transition func(ctx context.Context, s *TicketingService, ticketID uuid.UUID) (string, domain.TicketStatus, error)
+		// again repeats the transition, which should now fail
+		again func(ctx context.Context, s *TicketingService, ticketID uuid.UUID) error
+	}{
+		{
+			name: "confirm",
+			transition: func(ctx context.Context, s *TicketingService, ticketID uuid.UUID) (string, domain.TicketStatus, error) {
+				return TopicTicketConfirmed, domain.TicketStatusConfirmed, s.ConfirmTicket(ctx, ticketID)
+			},
+			again: func(ctx context.Context, s *TicketingService, ticketID uuid.UUID) error {
+				return s.ConfirmTicket(ctx, ticketID)
+			},
+		},
+		{
+			name: "cancel",
+			transition: func(ctx context.Context, s *TicketingService, ticketID uuid.UUID) (string, domain.TicketStatus, error) {
+				return TopicTicketCancelled, domain.TicketStatusCancelled, s.CancelTicket(ctx, ticketID)
+			},
+			again: func(ctx context.Context, s *TicketingService, ticketID uuid.UUID) error {
+				return s.CancelTicket(ctx, ticketID)
+			},
+		},
+		{
+			name: "cancel after confirming",
+			prepare: func(ctx context.Context, s *TicketingService, ticketID uuid.UUID) error {
+				return s.ConfirmTicket(ctx, ticketID)
+			},
+			transition: func(ctx context.Context, s *TicketingService, ticketID uuid.UUID) (string, domain.TicketStatus, error) {
+				return TopicTicketCancelled, domain.TicketStatusCancelled, s.CancelTicket(ctx, ticketID)
+			},
+			again: func(ctx context.Context, s *TicketingService, ticketID uuid.UUID) error {
+				return s.ConfirmTicket(ctx, ticketID)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tt := newTestTicketing(t)
+			publisher := &testPublisher{}
+			tt.service.SetEventPublisher(publisher)
+
+			event := tt.createEvent(t, 10, 9)
+			_, ticket := tt.createReservation(t, event, uuid.New(), time.Minute)
+			if tc.prepare != nil {
+				if err := tc.prepare(ctx, tt.service, ticket.ID); err != nil {
+					t.Fatalf("prepare: %v", err)
+				}
+				publisher.take()
+			}
+
+			topic, status, err := tc.transition(ctx, tt.service, ticket.ID)
+			if err != nil {
+				t.Fatalf("transition: %v", err)
+			}
+			expectPublished(t, publisher, topic, ticket.ID, status)
+
+			if err := tc.again(ctx, tt.service, ticket.ID); err == nil {
+				t.Fatal("repeated transition succeeded")
+			}
+			expectPublished(t, publisher, "", uuid.Nil, "")
+		})
+	}
+}
+
+func TestConfirmExpiredReservationPublishesNothing(t *testing.T) {
+	tt := newTestTicketing(t)
+	publisher := &testPublisher{}
+	tt.service.SetEventPublisher(publisher)
+
+	event := tt.createEvent(t, 10, 9)
+	_, ticket := tt.createReservation(t, event, uuid.New(), -time.Minute)
+
+	if err := tt.service.ConfirmTicket(context.Background(), ticket.ID); err == nil {
+		t.Fatal("confirmation of an expired reservation succeeded")
+	}
+	expectPublished(t, publisher, "", uuid.Nil, "")
+}
+
+func TestExpiryPublishesEachReleasedReservationOnce(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+	publisher := &testPublisher{}
+	tt.service.SetEventPublisher(publisher)
+
+	event := tt.createEvent(t, 10, 9)
+	_, ticket := tt.createReservation(t, event, uuid.New(), -time.Minute)
+
+	if _, err := tt.service.ReleaseExpiredReservations(ctx); err != nil {
+		t.Fatalf("release expired reservations: %v", err)
+	}
+	expectPublished(t, publisher, TopicTicketCancelled, ticket.ID, domain.TicketStatusCancelled)
+
+	if _, err := tt.service.ReleaseExpiredReservations(ctx); err != nil {
+		t.Fatalf("release expired reservations again: %v", err)
+	}
+	expectPublished(t, publisher, "", uuid.Nil, "")
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// ErrTicketNotTransferable is returned when a ticket is not a confirmed, unused ticket held by the sender
+var ErrTicketNotTransferable = errors.New("ticket can't be transferred")
+
+// TransferTicket hands a confirmed ticket that hasn't been checked in to another user.
+// The gate access token is replaced, so the previous holder's copy no longer admits anyone. Guests hand
+// on their tickets like account holders, and the ticket stops carrying their email.
+func (s *TicketingService) TransferTicket(ctx context.Context, ticketID, fromUserID, toUserID uuid.UUID) (*domain.Ticket, error) {
+	if fromUserID == toUserID {
+		return nil, fmt.Errorf("%w: sender and recipient are the same user", ErrTicketNotTransferable)
+	}
+
+	ticket, err := s.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get ticket", "ticket_id", ticketID, "error", err)
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	if err := s.checkTransferable(ctx, ticket, fromUserID); err != nil {
+		return nil, err
+	}
+
+	accessToken, err := newAccessToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	if err := s.ticketRepo.Transfer(ctx, ticketID, fromUserID, toUserID, accessToken); err != nil {
+		if errors.Is(err, repository.ErrTicketOwnerMismatch) {
+			return nil, fmt.Errorf("%w: ticket is not held by the sender", ErrTicketNotTransferable)
+		}
+		s.logger.Error(ctx, "Failed to transfer ticket", "ticket_id", ticketID, "error", err)
+		return nil, fmt.Errorf("failed to transfer ticket: %w", err)
+	}
+
+	ticket.UserID = toUserID
+	ticket.GuestEmail = ""
+	ticket.AccessToken = accessToken
+
+	s.logger.Info(ctx, "Ticket transferred", "ticket_id", ticketID, "from_user_id", fromUserID, "to_user_id", toUserID)
+	return ticket, nil
+}
+
+// checkTransferable reports why a ticket can't change hands, if it can't
+func (s *TicketingService) checkTransferable(ctx context.Context, ticket *domain.Ticket, holderID uuid.UUID) error {
+	switch {
+	case ticket.UserID != holderID:
+		return fmt.Errorf("%w: ticket is not held by the sender", ErrTicketNotTransferable)
+	case ticket.IsCheckedIn():
+		return fmt.Errorf("%w: ticket is already checked in", ErrTicketNotTransferable)
+	case !ticket.IsConfirmed():
+		return fmt.Errorf("%w: ticket is not confirmed", ErrTicketNotTransferable)
+	case ticket.CompanionTicketID != nil:
+		return fmt.Errorf("%w: an accessible seat can't be separated from its companion seat", ErrTicketNotTransferable)
+	}
+
+	// The companion side of an accessible booking stays with the accessible seat's holder
+	if ticket.SeatID != nil {
+		seat, err := s.seatRepo.GetByID(ctx, *ticket.SeatID)
+		if err != nil {
+			return fmt.Errorf("failed to get seat: %w", err)
+		}
+		if seat.IsCompanion() {
+			return fmt.Errorf("%w: a companion seat can't be separated from its accessible seat", ErrTicketNotTransferable)
+		}
+	}
+
+	return nil
+}
@@ -2,7 +2,11 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,6 +15,119 @@ import (
 	"github.com/snowmerak/ticketing/lib/repository"
 )
 
+// ErrPurchaseSaturated is returned when an event already has its maximum number of purchases in flight
+var ErrPurchaseSaturated = errors.New("too many purchases in progress for this event")
+
+// ErrPurchaseNotStarted is returned when an activated user tries to purchase before their staggered start time
+var ErrPurchaseNotStarted = errors.New("purchase window has not started yet")
+
+// ErrAccessibleSeatRestricted is returned when an accessible or companion seat is requested by a general purchase
+var ErrAccessibleSeatRestricted = errors.New("seat is reserved for accessible bookings")
+
+// ErrTicketAlreadyCheckedIn is returned when a ticket is scanned at the gate a second time
+var ErrTicketAlreadyCheckedIn = errors.New("ticket is already checked in")
+
+// TicketingConfig holds tunable purchase behavior
+type TicketingConfig struct {
+	// MaxPurchaseAttempts is how many failed purchases a session may make before it has to re-queue; 0 disables the budget
+	MaxPurchaseAttempts int
+	// PurchaseAttemptWindow is how long a session's failed attempts are remembered, counted from the first failure
+	PurchaseAttemptWindow time.Duration
+	// FeePolicy prices the service fee and tax added to each ticket's face value; the zero policy charges face value only
+	FeePolicy domain.FeePolicy
+	// ResalePriceCapBasisPoints caps resale prices as a share of what the ticket cost, in basis points; 0 disables resale
+	ResalePriceCapBasisPoints int64
+	// MaxSeatsPerTransaction is the venue's cap on seats reserved by a single multi-seat purchase
+	MaxSeatsPerTransaction int
+	// PurchaseLockWait is how long a purchase waits in line for a contended purchase lock when a fair lock is
+	// configured; without one, a held lock fails the purchase at once
+	PurchaseLockWait time.Duration
+	// CapacityAlertThresholds are the sold shares, in percent, at which an event raises a one-time capacity
+	// alert; none disables the alerts
+	CapacityAlertThresholds []int
+	// MaxTicketsPerUser caps how many tickets one user may hold for an event, counting reservations and
+	// confirmed tickets until they are cancelled; events may set their own cap and 0 means no limit
+	MaxTicketsPerUser int
+	// MaxHoldsPerSection caps how many seats of one section a seat selection may hold at once, so a single buyer
+	// can't sit on a whole premium section; 0 leaves only the per-selection cap
+	MaxHoldsPerSection int
+	// RefundPolicy prices the refund of a confirmed ticket by how long before the event it is cancelled;
+	// events may set their own schedule
+	RefundPolicy domain.RefundPolicy
+	// CallbackHosts are the hosts a purchase's confirmation callback URL may point at; none rejects every
+	// callback URL
+	CallbackHosts []string
+	// ExpiryNoticeWindow is how long before a reservation lapses its holder is warned, once, that it is about
+	// to expire; 0 disables the warning
+	ExpiryNoticeWindow time.Duration
+}
+
+// DefaultTicketingConfig returns the default ticketing configuration
+func DefaultTicketingConfig() TicketingConfig {
+	return TicketingConfig{
+		MaxPurchaseAttempts:       5,
+		PurchaseAttemptWindow:     15 * time.Minute,
+		ResalePriceCapBasisPoints: 10000,
+		MaxSeatsPerTransaction:    8,
+		PurchaseLockWait:          2 * time.Second,
+		CapacityAlertThresholds:   []int{90, 95, 99},
+		MaxHoldsPerSection:        4,
+		RefundPolicy:              domain.DefaultRefundPolicy(),
+		ExpiryNoticeWindow:        2 * time.Minute,
+	}
+}
+
+// AccessListEntry is a gate system's view of a ticket
+type AccessListEntry struct {
+	TicketID    uuid.UUID  `json:"ticket_id"`
+	SeatID      *uuid.UUID `json:"seat_id,omitempty"`
+	GANumber    *int64     `json:"ga_number,omitempty"`
+	AccessToken string     `json:"access_token"`
+	Status      string     `json:"status"`
+	Valid       bool       `json:"valid"` // Confirmed and not yet checked in
+	CheckedInAt *time.Time `json:"checked_in_at,omitempty"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// AccessList is a batch of access list changes and the cursor to pass on the next sync
+type AccessList struct {
+	Entries []AccessListEntry `json:"entries"`
+	Cursor  time.Time         `json:"cursor"`
+}
+
+// PurchaseOptions holds the optional parts of a purchase request
+type PurchaseOptions struct {
+	// Accessible requests an accessible seat; its companion seat is reserved alongside it
+	Accessible bool
+
+	// WaitroomToken is the signed token the user received on activation; required once waitroom tokens are enabled
+	WaitroomToken string
+
+	// AllowSeatFallback reserves the nearest available seat of the same section and price tier when the requested
+	// general seat is taken; the ticket's seat then differs from the one requested
+	AllowSeatFallback bool
+
+	// GuestToken buys as a guest identified by a verified email instead of an account; the user ID must be
+	// the guest's pseudo user ID
+	GuestToken string
+
+	// RemoteAddr and UserAgent identify the client the purchase came from; they are kept as purchase signals
+	// for fraud review when an audit repository is set
+	RemoteAddr string
+	UserAgent  string
+
+	// IdempotencyKey makes a retried PurchaseTicket return the ticket the first attempt created instead of
+	// purchasing again; keys are scoped to the user and remembered for IdempotencyKeyTTL
+	IdempotencyKey string
+
+	// StandingZone names the zone a standing ticket admits to; required for events sold per zone
+	StandingZone string
+
+	// CallbackURL is POSTed a signed notice when the ticket is confirmed; its host must be one of
+	// TicketingConfig.CallbackHosts
+	CallbackURL string
+}
+
 // TicketingService handles ticket purchasing logic
 type TicketingService struct {
 	ticketRepo repository.TicketRepository
@@ -20,6 +137,28 @@ type TicketingService struct {
 	cache      adapter.Cache
 	lock       adapter.Lock
 	logger     adapter.Logger
+	notifier   adapter.Notifier
+	semaphore  adapter.Semaphore
+	counter    adapter.RateCounter
+	attempts   adapter.AttemptCounter
+	config     TicketingConfig
+
+	waitroomTokens *WaitroomTokens
+
+	deadLetterRepo repository.DeadLetterRepository
+	seatHoldRepo   repository.SeatHoldRepository
+	resaleRepo     repository.ResaleRepository
+	payments       adapter.PaymentGateway
+	fairLock       adapter.FairLock
+	ids            adapter.IDGenerator
+	clock          adapter.Clock
+	alerter        adapter.CapacityAlerter
+	seatRanker     SeatRanker
+	auditRepo      repository.AuditRepository
+	guestTokens    *GuestTokens
+	idempotency    adapter.IdempotencyStore
+	callbacks      adapter.CallbackSender
+	publisher      adapter.EventPublisher
 }
 
 // NewTicketingService creates a new TicketingService
@@ -40,17 +179,242 @@ func NewTicketingService(
 		cache:      cache,
 		lock:       lock,
 		logger:     logger,
+		config:     DefaultTicketingConfig(),
+		publisher:  nopEventPublisher{},
+	}
+}
+
+// SetConfig replaces the ticketing configuration
+func (s *TicketingService) SetConfig(config TicketingConfig) error {
+	if config.MaxPurchaseAttempts > 0 && config.PurchaseAttemptWindow <= 0 {
+		return fmt.Errorf("purchase attempt window must be positive when attempts are limited")
+	}
+
+	if !config.FeePolicy.IsValid() {
+		return fmt.Errorf("fee policy rates and amounts must be non-negative")
+	}
+
+	if config.ResalePriceCapBasisPoints < 0 {
+		return fmt.Errorf("resale price cap must be non-negative")
+	}
+
+	if config.MaxSeatsPerTransaction <= 0 {
+		return fmt.Errorf("max seats per transaction must be positive")
+	}
+
+	if config.PurchaseLockWait < 0 {
+		return fmt.Errorf("purchase lock wait must be non-negative")
+	}
+
+	if config.MaxTicketsPerUser < 0 {
+		return fmt.Errorf("max tickets per user must be non-negative")
+	}
+
+	if config.MaxHoldsPerSection < 0 {
+		return fmt.Errorf("max holds per section must be non-negative")
+	}
+
+	if config.ExpiryNoticeWindow < 0 {
+		return fmt.Errorf("expiry notice window must be non-negative")
+	}
+
+	if !config.RefundPolicy.IsValid() {
+		return fmt.Errorf("refund policy tiers must have non-negative, distinct notices and refund between 0 and 100 percent")
+	}
+
+	for _, threshold := range config.CapacityAlertThresholds {
+		if threshold <= 0 || threshold > 100 {
+			return fmt.Errorf("capacity alert thresholds must be between 1 and 100 percent")
+		}
+	}
+
+	s.config = config
+	return nil
+}
+
+// SetNotifier sets the optional notifier used to tell users about reservation changes
+func (s *TicketingService) SetNotifier(notifier adapter.Notifier) {
+	s.notifier = notifier
+}
+
+// SetIDGenerator sets the generator used for the IDs of new tickets, resale listings and dead letters;
+// random UUIDs are used when none is set
+func (s *TicketingService) SetIDGenerator(ids adapter.IDGenerator) {
+	s.ids = ids
+}
+
+// newID returns a new ID from the configured generator
+func (s *TicketingService) newID() uuid.UUID {
+	if s.ids == nil {
+		return uuid.New()
+	}
+	return s.ids.NewID()
+}
+
+// SetClock sets the clock reservations and queue sessions are checked for expiry against; the wall clock is
+// used when none is set
+func (s *TicketingService) SetClock(clock adapter.Clock) {
+	s.clock = clock
+}
+
+// now returns the current time from the configured clock
+func (s *TicketingService) now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock.Now()
+}
+
+// SetFairLock sets the optional fair lock used for purchase locks. Contended purchases then wait in line,
+// up to TicketingConfig.PurchaseLockWait, and are let through in arrival order instead of failing at once.
+func (s *TicketingService) SetFairLock(fairLock adapter.FairLock) {
+	s.fairLock = fairLock
+}
+
+// SetSemaphore sets the optional semaphore used to cap concurrent purchases per event
+func (s *TicketingService) SetSemaphore(semaphore adapter.Semaphore) {
+	s.semaphore = semaphore
+}
+
+// PurchaseTicket purchases a ticket for an event.
+// Each failed attempt is charged to the session's purchase budget; once it runs out the session is
+// removed from the queue and further attempts fail with ErrPurchaseBudgetExhausted.
+// A purchase repeated with the same idempotency key returns the ticket the first one created.
+// A user still holding a reservation from an earlier queue session gets it back instead of a second one; asking
+// for a different seat fails with ErrCarriedReservationHeld naming the held seat.
+func (s *TicketingService) PurchaseTicket(ctx context.Context, eventID, userID uuid.UUID, seatID *uuid.UUID, sessionID string, opts PurchaseOptions) (*domain.Ticket, error) {
+	if err := s.validateCallbackURL(opts.CallbackURL); err != nil {
+		s.logger.Warn(ctx, "Callback URL rejected", "user_id", userID, "error", err)
+		return nil, err
 	}
+
+	replayed, claimed, err := s.claimPurchase(ctx, eventID, userID, opts.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if replayed != nil {
+		return replayed, nil
+	}
+
+	// A reservation kept from an earlier queue session is returned rather than reserving a second ticket
+	if carried := s.carriedReservations(ctx, eventID, userID, sessionID); len(carried) > 0 {
+		var requested []uuid.UUID
+		if seatID != nil {
+			requested = []uuid.UUID{*seatID}
+		}
+		matched, err := carriedForSeats(carried, requested)
+		if err != nil {
+			s.logger.Warn(ctx, "Purchase asks for another seat than the reservation held", "event_id", eventID, "user_id", userID, "seat_id", seatID, "error", err)
+			s.settlePurchase(ctx, claimed, nil)
+			return nil, err
+		}
+		s.settlePurchase(ctx, claimed, matched[0])
+		return matched[0], nil
+	}
+
+	if err := s.checkPurchaseBudget(ctx, sessionID); err != nil {
+		s.settlePurchase(ctx, claimed, nil)
+		return nil, err
+	}
+
+	ticket, err := s.purchaseTicket(ctx, eventID, userID, seatID, sessionID, opts)
+	if err := s.recordPurchaseOutcome(ctx, sessionID, err); err != nil {
+		s.settlePurchase(ctx, claimed, nil)
+		return nil, err
+	}
+	s.settlePurchase(ctx, claimed, ticket)
+
+	s.recordPurchaseSignal(ctx, eventID, userID, sessionID, opts, []*domain.Ticket{ticket})
+	s.publishReserved(ctx, []*domain.Ticket{ticket})
+
+	return ticket, nil
 }
 
-// PurchaseTicket purchases a ticket for an event
-func (s *TicketingService) PurchaseTicket(ctx context.Context, eventID, userID uuid.UUID, seatID *uuid.UUID, sessionID string) (*domain.Ticket, error) {
+// purchaseTicket runs a single purchase attempt
+func (s *TicketingService) purchaseTicket(ctx context.Context, eventID, userID uuid.UUID, seatID *uuid.UUID, sessionID string, opts PurchaseOptions) (*domain.Ticket, error) {
 	s.logger.Info(ctx, "Starting ticket purchase",
 		"event_id", eventID,
 		"user_id", userID,
 		"seat_id", seatID,
-		"session_id", sessionID)
+		"session_id", sessionID,
+		"accessible", opts.Accessible)
 
+	event, err := s.authorizePurchase(ctx, eventID, userID, sessionID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	guestEmail, err := s.authorizeGuest(event, userID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := s.acquirePurchaseSlot(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// Use distributed lock for atomic ticket purchase
+	var seatIDs []uuid.UUID
+	if seatID != nil {
+		seatIDs = []uuid.UUID{*seatID}
+	}
+
+	unlock, err := s.lockSeats(ctx, event, seatIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	claim, err := s.claimUserTickets(ctx, event, userID, 1)
+	if err != nil {
+		return nil, err
+	}
+	defer claim.release(ctx)
+
+	var ticket *domain.Ticket
+	var price int64
+
+	if event.IsSeatedEvent {
+		// Handle seated event
+		if seatID == nil {
+			return nil, fmt.Errorf("seat ID is required for seated events")
+		}
+
+		ticket, err = s.purchaseSeatedTicket(ctx, event, userID, guestEmail, *seatID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to purchase seated ticket: %w", err)
+		}
+		price = ticket.Price
+	} else {
+		// Handle standing event
+		ticket, err = s.purchaseStandingTicket(ctx, event, userID, guestEmail, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to purchase standing ticket: %w", err)
+		}
+		price = ticket.Price
+	}
+
+	created := 1
+	if ticket.CompanionTicketID != nil {
+		created++
+	}
+	claim.keep(ctx, created)
+
+	s.logger.Info(ctx, "Ticket purchased successfully",
+		"ticket_id", ticket.ID,
+		"event_id", eventID,
+		"user_id", userID,
+		"price", price)
+
+	s.recordRate(ctx, purchaseCounterKey(eventID))
+
+	return ticket, nil
+}
+
+// authorizePurchase checks that a session may purchase for the event right now and returns the event
+func (s *TicketingService) authorizePurchase(ctx context.Context, eventID, userID uuid.UUID, sessionID string, opts PurchaseOptions) (*domain.Event, error) {
 	// Verify user is active in queue
 	queueEntry, err := s.queueRepo.GetBySessionID(ctx, sessionID)
 	if err != nil {
@@ -58,11 +422,12 @@ func (s *TicketingService) PurchaseTicket(ctx context.Context, eventID, userID u
 		return nil, fmt.Errorf("invalid session: %w", err)
 	}
 
-	if !queueEntry.IsActive() || queueEntry.IsExpired() {
+	now := s.now()
+	if !queueEntry.IsActive() || queueEntry.IsExpiredAt(now) {
 		s.logger.Warn(ctx, "Queue session not active or expired",
 			"session_id", sessionID,
 			"status", queueEntry.Status,
-			"expired", queueEntry.IsExpired())
+			"expired", queueEntry.IsExpiredAt(now))
 		return nil, fmt.Errorf("queue session is not active or has expired")
 	}
 
@@ -75,6 +440,16 @@ func (s *TicketingService) PurchaseTicket(ctx context.Context, eventID, userID u
 		return nil, fmt.Errorf("queue entry does not match request")
 	}
 
+	if err := s.verifyWaitroomToken(opts.WaitroomToken, sessionID, eventID, userID); err != nil {
+		s.logger.Warn(ctx, "Rejected waitroom token", "session_id", sessionID, "user_id", userID)
+		return nil, err
+	}
+
+	if !queueEntry.HasStarted(now) {
+		s.logger.Warn(ctx, "Purchase attempted before staggered start", "session_id", sessionID, "start_at", queueEntry.StartAt)
+		return nil, ErrPurchaseNotStarted
+	}
+
 	// Get event details
 	event, err := s.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
@@ -82,68 +457,87 @@ func (s *TicketingService) PurchaseTicket(ctx context.Context, eventID, userID u
 		return nil, fmt.Errorf("failed to get event: %w", err)
 	}
 
-	if !event.CanPurchase() {
+	if !event.CanPurchaseAt(s.now()) {
 		s.logger.Warn(ctx, "Event not available for purchase", "event_id", eventID, "status", event.Status)
 		return nil, fmt.Errorf("event is not available for purchase")
 	}
 
-	// Use distributed lock for atomic ticket purchase
-	lockKey := fmt.Sprintf("ticket_purchase:%s", eventID.String())
-	if seatID != nil {
-		lockKey = fmt.Sprintf("ticket_purchase:%s:%s", eventID.String(), seatID.String())
+	return event, nil
+}
+
+// acquirePurchaseSlot takes one of the event's concurrent purchase slots; the returned func gives it back
+func (s *TicketingService) acquirePurchaseSlot(ctx context.Context, event *domain.Event) (func(), error) {
+	// Throttle purchases per event before taking any seat or inventory lock
+	if s.semaphore == nil || event.MaxConcurrentPurchases <= 0 {
+		return func() {}, nil
 	}
 
-	acquired, err := s.lock.Acquire(ctx, lockKey, 10*time.Second)
+	semaphoreKey := fmt.Sprintf("purchase:%s", event.ID.String())
+	token, acquired, err := s.semaphore.Acquire(ctx, semaphoreKey, event.MaxConcurrentPurchases, 30*time.Second)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to acquire lock", "error", err)
-		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+		s.logger.Error(ctx, "Failed to acquire purchase slot", "event_id", event.ID, "error", err)
+		return nil, fmt.Errorf("failed to acquire purchase slot: %w", err)
 	}
 
 	if !acquired {
-		s.logger.Warn(ctx, "Failed to acquire lock - purchase busy", "event_id", eventID)
-		return nil, fmt.Errorf("ticket purchase is busy, please try again")
+		s.logger.Warn(ctx, "Purchase concurrency limit reached", "event_id", event.ID, "limit", event.MaxConcurrentPurchases)
+		s.recordRate(ctx, lockFailureCounterKey(event.ID))
+		return nil, ErrPurchaseSaturated
 	}
 
-	defer func() {
-		if err := s.lock.Release(ctx, lockKey); err != nil {
-			s.logger.Error(ctx, "Failed to release lock", "error", err)
-		}
-	}()
-
-	var ticket *domain.Ticket
-	var price int64
-
-	if event.IsSeatedEvent {
-		// Handle seated event
-		if seatID == nil {
-			return nil, fmt.Errorf("seat ID is required for seated events")
+	return func() {
+		if err := s.semaphore.Release(ctx, semaphoreKey, token); err != nil {
+			s.logger.Error(ctx, "Failed to release purchase slot", "event_id", event.ID, "error", err)
 		}
+	}, nil
+}
 
-		ticket, err = s.purchaseSeatedTicket(ctx, event, userID, *seatID)
+// acquirePurchaseLock takes a purchase lock, waiting in line for it when a fair lock is configured;
+// the returned func releases it
+func (s *TicketingService) acquirePurchaseLock(ctx context.Context, eventID uuid.UUID, lockKey string) (func(), error) {
+	if s.fairLock != nil {
+		token, acquired, err := s.fairLock.AcquireWait(ctx, lockKey, 10*time.Second, s.config.PurchaseLockWait)
 		if err != nil {
-			return nil, fmt.Errorf("failed to purchase seated ticket: %w", err)
+			s.logger.Error(ctx, "Failed to acquire fair lock", "error", err)
+			return nil, fmt.Errorf("failed to acquire lock: %w", err)
 		}
-		price = ticket.Price
-	} else {
-		// Handle standing event
-		ticket, err = s.purchaseStandingTicket(ctx, event, userID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to purchase standing ticket: %w", err)
+
+		if !acquired {
+			s.logger.Warn(ctx, "Timed out waiting for lock - purchase busy", "event_id", eventID, "wait", s.config.PurchaseLockWait)
+			s.recordRate(ctx, lockFailureCounterKey(eventID))
+			return nil, fmt.Errorf("ticket purchase is busy, please try again")
 		}
-		price = ticket.Price
+
+		return func() {
+			if err := s.fairLock.Release(ctx, lockKey, token); err != nil {
+				s.logger.Error(ctx, "Failed to release fair lock", "error", err)
+			}
+		}, nil
 	}
 
-	s.logger.Info(ctx, "Ticket purchased successfully",
-		"ticket_id", ticket.ID,
-		"event_id", eventID,
-		"user_id", userID,
-		"price", price)
+	token, acquired, err := s.lock.Acquire(ctx, lockKey, 10*time.Second)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to acquire lock", "error", err)
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
 
-	return ticket, nil
+	if !acquired {
+		s.logger.Warn(ctx, "Failed to acquire lock - purchase busy", "event_id", eventID)
+		s.recordRate(ctx, lockFailureCounterKey(eventID))
+		return nil, fmt.Errorf("ticket purchase is busy, please try again")
+	}
+
+	return func() {
+		if err := s.lock.Release(ctx, lockKey, token); err != nil {
+			s.logger.Error(ctx, "Failed to release lock", "error", err)
+		}
+	}, nil
 }
 
-// purchaseSeatedTicket handles the purchase of a seated ticket
-func (s *TicketingService) purchaseSeatedTicket(ctx context.Context, event *domain.Event, userID, seatID uuid.UUID) (*domain.Ticket, error) {
+// purchaseSeatedTicket handles the purchase of a seated ticket.
+// An accessible seat can only be bought with opts.Accessible, and its companion seat is reserved
+// and ticketed in the same purchase; companion seats can't be bought on their own.
+func (s *TicketingService) purchaseSeatedTicket(ctx context.Context, event *domain.Event, userID uuid.UUID, guestEmail string, seatID uuid.UUID, opts PurchaseOptions) (*domain.Ticket, error) {
 	// Get seat details
 	seat, err := s.seatRepo.GetByID(ctx, seatID)
 	if err != nil {
@@ -155,92 +549,248 @@ func (s *TicketingService) purchaseSeatedTicket(ctx context.Context, event *doma
 		return nil, fmt.Errorf("seat does not belong to this event")
 	}
 
-	if !seat.IsAvailable() {
+	// Seats held during selection are never restricted, so the hold can go straight to a ticket
+	if seat.IsHeld() && s.seatHoldRepo != nil {
+		return s.purchaseHeldSeat(ctx, event, userID, guestEmail, seat, opts.CallbackURL)
+	}
+
+	// Accessible seats and companion pairs are never swapped for another seat
+	fallbackAllowed := opts.AllowSeatFallback && !opts.Accessible && !seat.IsAccessible && !seat.IsCompanion()
+
+	if !seat.CanBeReserved() && !fallbackAllowed {
 		s.logger.Warn(ctx, "Seat not available", "seat_id", seatID, "status", seat.Status)
 		return nil, fmt.Errorf("seat is not available")
 	}
 
-	// Reserve the seat
-	if err := s.seatRepo.ReserveSeats(ctx, []uuid.UUID{seatID}); err != nil {
-		s.logger.Error(ctx, "Failed to reserve seat", "seat_id", seatID, "error", err)
-		return nil, fmt.Errorf("failed to reserve seat: %w", err)
+	if seat.IsCompanion() {
+		s.logger.Warn(ctx, "Companion seat requested on its own", "seat_id", seatID)
+		return nil, fmt.Errorf("companion seats are booked with their accessible seat: %w", ErrAccessibleSeatRestricted)
 	}
 
-	// Create ticket
-	ticket := &domain.Ticket{
-		ID:        uuid.New(),
-		EventID:   event.ID,
-		SeatID:    &seatID,
-		UserID:    userID,
-		Price:     seat.Price,
-		Status:    string(domain.TicketStatusReserved),
-		IssuedAt:  time.Now(),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	if seat.IsAccessible && !opts.Accessible {
+		s.logger.Warn(ctx, "Accessible seat requested by a general purchase", "seat_id", seatID)
+		return nil, ErrAccessibleSeatRestricted
 	}
 
-	// Set expiration (15 minutes to confirm)
-	expiry := time.Now().Add(15 * time.Minute)
-	ticket.ExpiresAt = &expiry
+	seatIDs := []uuid.UUID{seatID}
+
+	var companion *domain.Seat
+	if seat.IsAccessible && seat.CompanionSeatID != nil {
+		companion, err = s.seatRepo.GetByID(ctx, *seat.CompanionSeatID)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get companion seat", "seat_id", *seat.CompanionSeatID, "error", err)
+			return nil, fmt.Errorf("failed to get companion seat: %w", err)
+		}
+		seatIDs = append(seatIDs, companion.ID)
+	}
+
+	// Reserve the seat, and its companion in the same all-or-nothing call
+	if err := s.seatRepo.ReserveSeats(ctx, seatIDs); err != nil {
+		if !fallbackAllowed || !errors.Is(err, repository.ErrSeatNotAvailable) {
+			s.logger.Error(ctx, "Failed to reserve seat", "seat_ids", seatIDs, "error", err)
+			return nil, fmt.Errorf("failed to reserve seat: %w", err)
+		}
+
+		// The seat went to someone else first; take the nearest comparable seat instead
+		fallback, err := s.reserveFallbackSeat(ctx, event, seat)
+		if err != nil {
+			return nil, err
+		}
+		seat, seatID, seatIDs = fallback, fallback.ID, []uuid.UUID{fallback.ID}
+	}
+
+	// Create ticket
+	ticket := s.newSeatReservation(event, userID, guestEmail, seat)
+	ticket.CallbackURL = opts.CallbackURL
+
+	var companionTicket *domain.Ticket
+	if companion != nil {
+		companionTicket = s.newSeatReservation(event, userID, guestEmail, companion)
+		ticket.CompanionTicketID = &companionTicket.ID
+	}
 
 	if err := s.ticketRepo.Create(ctx, ticket); err != nil {
+		if errors.Is(err, repository.ErrSeatAlreadyTicketed) {
+			// The seat was available yet another ticket still holds it; keep the seat reserved for that
+			// ticket rather than releasing it into a double-book
+			s.logger.Error(ctx, "Seat is already mapped to another ticket", "seat_id", seatID, "event_id", event.ID)
+			s.releaseSeatsAfterFailure(ctx, event.ID, seatIDs[1:], "release companion seat after ticket creation failure")
+			return nil, fmt.Errorf("failed to create ticket: %w", err)
+		}
+
 		s.logger.Error(ctx, "Failed to create ticket", "error", err)
 
 		// Release the seat if ticket creation fails
-		if err := s.seatRepo.ReleaseSeats(ctx, []uuid.UUID{seatID}); err != nil {
-			s.logger.Error(ctx, "Failed to release seat after ticket creation failure", "seat_id", seatID, "error", err)
-		}
+		s.releaseSeatsAfterFailure(ctx, event.ID, seatIDs, "release seat after ticket creation failure")
 
 		return nil, fmt.Errorf("failed to create ticket: %w", err)
 	}
 
+	if companionTicket != nil {
+		if err := s.ticketRepo.Create(ctx, companionTicket); err != nil {
+			s.logger.Error(ctx, "Failed to create companion ticket", "seat_id", companion.ID, "error", err)
+
+			// Undo the accessible seat's ticket too; the pair is only sold together
+			if err := s.ticketRepo.CancelTicket(ctx, ticket.ID); err != nil {
+				s.logger.Error(ctx, "Failed to cancel ticket after companion failure", "ticket_id", ticket.ID, "error", err)
+			}
+
+			release := seatIDs
+			if errors.Is(err, repository.ErrSeatAlreadyTicketed) {
+				release = seatIDs[:1]
+			}
+			s.releaseSeatsAfterFailure(ctx, event.ID, release, "release seat after companion ticket failure")
+
+			return nil, fmt.Errorf("failed to create companion ticket: %w", err)
+		}
+	}
+
 	// Decrement available tickets
-	if err := s.eventRepo.DecrementAvailableTickets(ctx, event.ID, 1); err != nil {
+	if err := s.decrementAvailableTickets(ctx, event.ID, len(seatIDs)); err != nil {
 		s.logger.Error(ctx, "Failed to decrement available tickets", "error", err)
-		// Note: In a real system, you might want to rollback the ticket creation here
+		// The ticket stands; replay the decrement later so the counter catches up
+		s.recordFailedAction(ctx, &domain.FailedAction{
+			Kind:     string(domain.FailedActionDecrementAvailable),
+			EventID:  event.ID,
+			SeatID:   &seatID,
+			TicketID: &ticket.ID,
+			Quantity: len(seatIDs),
+			Reason:   "decrement available tickets after seated reservation",
+		}, err)
 	}
 
 	return ticket, nil
 }
 
-// purchaseStandingTicket handles the purchase of a standing ticket
-func (s *TicketingService) purchaseStandingTicket(ctx context.Context, event *domain.Event, userID uuid.UUID) (*domain.Ticket, error) {
+// newSeatReservation builds a reserved ticket for a seat that must be confirmed within 15 minutes,
+// priced at the seat's face value under the fee policy. Guest buyers' tickets carry their email.
+func (s *TicketingService) newSeatReservation(event *domain.Event, userID uuid.UUID, guestEmail string, seat *domain.Seat) *domain.Ticket {
+	seatID := seat.ID
+	now := s.now()
+	expiry := now.Add(15 * time.Minute)
+
+	ticket := &domain.Ticket{
+		ID:         s.newID(),
+		EventID:    event.ID,
+		SeatID:     &seatID,
+		UserID:     userID,
+		GuestEmail: guestEmail,
+		Currency:   event.Currency,
+		Status:     string(domain.TicketStatusReserved),
+		IssuedAt:   now,
+		ExpiresAt:  &expiry,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	ticket.SetPrice(seat.Price, s.config.FeePolicy)
+
+	return ticket
+}
+
+// releaseSeatsAfterFailure gives back seats reserved by a purchase that failed, dead-lettering any seat
+// that could not be released
+func (s *TicketingService) releaseSeatsAfterFailure(ctx context.Context, eventID uuid.UUID, seatIDs []uuid.UUID, reason string) {
+	if len(seatIDs) == 0 {
+		return
+	}
+
+	if err := s.seatRepo.ReleaseSeats(ctx, seatIDs); err != nil {
+		s.logger.Error(ctx, "Failed to release seats after purchase failure", "seat_ids", seatIDs, "error", err)
+		for _, seatID := range seatIDs {
+			s.recordFailedAction(ctx, &domain.FailedAction{
+				Kind:    string(domain.FailedActionReleaseSeat),
+				EventID: eventID,
+				SeatID:  &seatID,
+				Reason:  reason,
+			}, err)
+		}
+	}
+}
+
+// purchaseStandingTicket handles the purchase of a standing ticket. Events sold per zone take the ticket off
+// the zone named in opts.StandingZone as well as the event's.
+func (s *TicketingService) purchaseStandingTicket(ctx context.Context, event *domain.Event, userID uuid.UUID, guestEmail string, opts PurchaseOptions) (*domain.Ticket, error) {
+	zone, err := standingZoneFor(event, opts.StandingZone)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if tickets are available
 	if event.AvailableTickets <= 0 {
 		s.logger.Warn(ctx, "No tickets available", "event_id", event.ID)
 		return nil, fmt.Errorf("no tickets available")
 	}
 
-	// Decrement available tickets first
-	if err := s.eventRepo.DecrementAvailableTickets(ctx, event.ID, 1); err != nil {
+	// Take the zone's ticket first so a sold-out zone never touches the event's count
+	if err := s.takeZoneTicket(ctx, event.ID, zone); err != nil {
+		return nil, err
+	}
+
+	// Then decrement the event's available tickets
+	if err := s.decrementAvailableTickets(ctx, event.ID, 1); err != nil {
 		s.logger.Error(ctx, "Failed to decrement available tickets", "error", err)
+		s.returnZoneTickets(ctx, event.ID, zone, 1, "return zone ticket after inventory failure")
 		return nil, fmt.Errorf("failed to reserve ticket: %w", err)
 	}
 
-	// Create ticket (assuming a base price for standing tickets)
+	// Create ticket at the event's standing face value
+	now := s.now()
 	ticket := &domain.Ticket{
-		ID:        uuid.New(),
-		EventID:   event.ID,
-		SeatID:    nil, // No seat for standing events
-		UserID:    userID,
-		Price:     5000, // $50.00 in cents (this could be configurable)
-		Status:    string(domain.TicketStatusReserved),
-		IssuedAt:  time.Now(),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:          s.newID(),
+		EventID:     event.ID,
+		SeatID:      nil, // No seat for standing events
+		UserID:      userID,
+		GuestEmail:  guestEmail,
+		CallbackURL: opts.CallbackURL,
+		Zone:        zone,
+		Currency:    event.Currency,
+		Status:      string(domain.TicketStatusReserved),
+		IssuedAt:    now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
 	}
+	ticket.SetPrice(event.StandingPrice, s.config.FeePolicy)
 
 	// Set expiration (15 minutes to confirm)
-	expiry := time.Now().Add(15 * time.Minute)
+	expiry := now.Add(15 * time.Minute)
 	ticket.ExpiresAt = &expiry
 
+	// Assign a sequential admission number if the event uses numbered GA tickets
+	if event.NumberedStanding {
+		number, err := s.ticketRepo.NextGANumber(ctx, event.ID)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to allocate GA number", "event_id", event.ID, "error", err)
+
+			if err := s.eventRepo.IncrementAvailableTickets(ctx, event.ID, 1); err != nil {
+				s.logger.Error(ctx, "Failed to increment available tickets after GA number failure", "error", err)
+				s.recordFailedAction(ctx, &domain.FailedAction{
+					Kind:     string(domain.FailedActionIncrementAvailable),
+					EventID:  event.ID,
+					Quantity: 1,
+					Reason:   "return inventory after GA number failure",
+				}, err)
+			}
+			s.returnZoneTickets(ctx, event.ID, zone, 1, "return zone ticket after GA number failure")
+
+			return nil, fmt.Errorf("failed to allocate GA number: %w", err)
+		}
+		ticket.GANumber = &number
+	}
+
 	if err := s.ticketRepo.Create(ctx, ticket); err != nil {
 		s.logger.Error(ctx, "Failed to create ticket", "error", err)
 
 		// Increment back the available tickets if ticket creation fails
 		if err := s.eventRepo.IncrementAvailableTickets(ctx, event.ID, 1); err != nil {
 			s.logger.Error(ctx, "Failed to increment available tickets after ticket creation failure", "error", err)
+			s.recordFailedAction(ctx, &domain.FailedAction{
+				Kind:     string(domain.FailedActionIncrementAvailable),
+				EventID:  event.ID,
+				Quantity: 1,
+				Reason:   "return inventory after ticket creation failure",
+			}, err)
 		}
+		s.returnZoneTickets(ctx, event.ID, zone, 1, "return zone ticket after ticket creation failure")
 
 		return nil, fmt.Errorf("failed to create ticket: %w", err)
 	}
@@ -258,18 +808,28 @@ func (s *TicketingService) ConfirmTicket(ctx context.Context, ticketID uuid.UUID
 		return fmt.Errorf("failed to get ticket: %w", err)
 	}
 
-	if !ticket.IsReserved() {
-		s.logger.Warn(ctx, "Ticket is not reserved", "ticket_id", ticketID, "status", ticket.Status)
-		return fmt.Errorf("ticket is not reserved")
-	}
-
-	if ticket.IsExpired() {
+	if !ticket.CanBeConfirmedAt(s.now()) {
+		if !ticket.IsReserved() {
+			s.logger.Warn(ctx, "Ticket is not reserved", "ticket_id", ticketID, "status", ticket.Status)
+			return fmt.Errorf("ticket is not reserved")
+		}
 		s.logger.Warn(ctx, "Ticket reservation has expired", "ticket_id", ticketID)
 		return fmt.Errorf("ticket reservation has expired")
 	}
 
-	// Confirm the ticket
-	if err := s.ticketRepo.ConfirmTicket(ctx, ticketID); err != nil {
+	accessToken, err := newAccessToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	// Confirm the ticket and issue its gate access token in a single conditional write, so a confirmation
+	// racing the expiry sweep or a cancellation never revives a reservation that was already released
+	ticket, err = s.ticketRepo.ConfirmReservation(ctx, ticketID, accessToken, s.now())
+	if err != nil {
+		if errors.Is(err, repository.ErrTicketNotReserved) {
+			s.logger.Warn(ctx, "Ticket was released before it could be confirmed", "ticket_id", ticketID)
+			return fmt.Errorf("failed to confirm ticket: %w", err)
+		}
 		s.logger.Error(ctx, "Failed to confirm ticket", "ticket_id", ticketID, "error", err)
 		return fmt.Errorf("failed to confirm ticket: %w", err)
 	}
@@ -278,10 +838,26 @@ func (s *TicketingService) ConfirmTicket(ctx context.Context, ticketID uuid.UUID
 	if ticket.SeatID != nil {
 		if err := s.seatRepo.UpdateStatus(ctx, *ticket.SeatID, string(domain.SeatStatusSold)); err != nil {
 			s.logger.Error(ctx, "Failed to update seat status", "seat_id", *ticket.SeatID, "error", err)
-			// Note: In a real system, you might want to rollback the ticket confirmation here
+			s.recordFailedAction(ctx, &domain.FailedAction{
+				Kind:     string(domain.FailedActionMarkSeatSold),
+				EventID:  ticket.EventID,
+				SeatID:   ticket.SeatID,
+				TicketID: &ticket.ID,
+				Reason:   "mark seat sold after confirmation",
+			}, err)
 		}
 	}
 
+	// An accessible seat and its companion are confirmed together
+	if ticket.CompanionTicketID != nil {
+		if err := s.ConfirmTicket(ctx, *ticket.CompanionTicketID); err != nil {
+			s.logger.Error(ctx, "Failed to confirm companion ticket", "ticket_id", *ticket.CompanionTicketID, "error", err)
+		}
+	}
+
+	s.publishTicketTransition(ctx, TopicTicketConfirmed, ticket.ID, ticket.EventID, ticket.UserID, domain.TicketStatusConfirmed)
+	s.sendConfirmationCallback(ctx, ticket)
+
 	s.logger.Info(ctx, "Ticket confirmed successfully", "ticket_id", ticketID)
 	return nil
 }
@@ -296,9 +872,23 @@ func (s *TicketingService) CancelTicket(ctx context.Context, ticketID uuid.UUID)
 		return fmt.Errorf("failed to get ticket: %w", err)
 	}
 
-	if ticket.IsCancelled() {
-		s.logger.Warn(ctx, "Ticket is already cancelled", "ticket_id", ticketID)
-		return fmt.Errorf("ticket is already cancelled")
+	if !ticket.CanBeCancelled() {
+		s.logger.Warn(ctx, "Ticket is already cancelled", "ticket_id", ticketID, "status", ticket.Status)
+		return fmt.Errorf("failed to cancel ticket: %w", repository.ErrTicketAlreadyCancelled)
+	}
+
+	// A reservation is released through the conditional cancel, so racing the expiry sweep or a confirmation
+	// never returns its inventory twice
+	if ticket.IsReserved() {
+		cancelled, err := s.cancelReservation(ctx, ticketID)
+		if err != nil {
+			s.logger.Warn(ctx, "Failed to cancel reservation", "ticket_id", ticketID, "error", err)
+			return err
+		}
+		s.returnReservations(ctx, cancelled.EventID, []*domain.Ticket{cancelled}, "cancellation")
+
+		s.logger.Info(ctx, "Ticket cancelled successfully", "ticket_id", ticketID)
+		return nil
 	}
 
 	// Cancel the ticket
@@ -306,23 +896,157 @@ func (s *TicketingService) CancelTicket(ctx context.Context, ticketID uuid.UUID)
 		s.logger.Error(ctx, "Failed to cancel ticket", "ticket_id", ticketID, "error", err)
 		return fmt.Errorf("failed to cancel ticket: %w", err)
 	}
+	s.publishCancelled(ctx, ticket)
+
+	s.releaseUserTicket(ctx, ticket)
+
+	if ticket.IsConfirmed() {
+		s.recordRefund(ctx, ticket.ID)
+	}
 
 	// Release the seat if it's a seated event
 	if ticket.SeatID != nil {
 		if err := s.seatRepo.ReleaseSeats(ctx, []uuid.UUID{*ticket.SeatID}); err != nil {
 			s.logger.Error(ctx, "Failed to release seat", "seat_id", *ticket.SeatID, "error", err)
+			s.recordFailedAction(ctx, &domain.FailedAction{
+				Kind:     string(domain.FailedActionReleaseSeat),
+				EventID:  ticket.EventID,
+				SeatID:   ticket.SeatID,
+				TicketID: &ticket.ID,
+				Reason:   "release seat after cancellation",
+			}, err)
 		}
 	}
 
 	// Increment available tickets
 	if err := s.eventRepo.IncrementAvailableTickets(ctx, ticket.EventID, 1); err != nil {
 		s.logger.Error(ctx, "Failed to increment available tickets", "error", err)
+		s.recordFailedAction(ctx, &domain.FailedAction{
+			Kind:     string(domain.FailedActionIncrementAvailable),
+			EventID:  ticket.EventID,
+			TicketID: &ticket.ID,
+			Quantity: 1,
+			Reason:   "return inventory after cancellation",
+		}, err)
+	}
+	s.returnZoneTickets(ctx, ticket.EventID, ticket.Zone, 1, "return zone ticket after cancellation")
+
+	// An accessible seat and its companion are released together
+	if ticket.CompanionTicketID != nil {
+		companion, err := s.ticketRepo.GetByID(ctx, *ticket.CompanionTicketID)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get companion ticket", "ticket_id", *ticket.CompanionTicketID, "error", err)
+		} else if !companion.IsCancelled() {
+			if err := s.CancelTicket(ctx, companion.ID); err != nil {
+				s.logger.Error(ctx, "Failed to cancel companion ticket", "ticket_id", companion.ID, "error", err)
+			}
+		}
 	}
 
 	s.logger.Info(ctx, "Ticket cancelled successfully", "ticket_id", ticketID)
 	return nil
 }
 
+// notifyReservationReleased tells the holder that their reservation has been released
+func (s *TicketingService) notifyReservationReleased(ctx context.Context, ticket *domain.Ticket) {
+	if s.notifier == nil {
+		return
+	}
+
+	subject := "Your ticket reservation was released"
+	body := fmt.Sprintf("Your reservation %s was released and its ticket is available to other buyers again.", ticket.ID)
+
+	if err := s.notifier.SendEmail(ctx, ticket.UserID, subject, body); err != nil {
+		s.logger.Warn(ctx, "Failed to send reservation release notice", "ticket_id", ticket.ID, "error", err)
+	}
+}
+
+// notifyReservationExpiring warns the holder that their reservation lapses soon unless they confirm it
+func (s *TicketingService) notifyReservationExpiring(ctx context.Context, ticket *domain.Ticket) {
+	subject := "Your ticket reservation is about to expire"
+	body := fmt.Sprintf("Your reservation %s expires at %s. Confirm it before then to keep your ticket.", ticket.ID, ticket.ExpiresAt.Format(time.RFC1123))
+
+	if err := s.notifier.SendEmail(ctx, ticket.UserID, subject, body); err != nil {
+		s.logger.Warn(ctx, "Failed to send reservation expiry notice", "ticket_id", ticket.ID, "error", err)
+	}
+}
+
+// CheckInTicket admits a confirmed ticket at the venue
+func (s *TicketingService) CheckInTicket(ctx context.Context, ticketID uuid.UUID) (*domain.Ticket, error) {
+	// The repository checks and records the admission in one step, so a ticket scanned at two gates at once
+	// is admitted once
+	ticket, err := s.ticketRepo.CheckIn(ctx, ticketID, s.now())
+	if err != nil {
+		if errors.Is(err, repository.ErrTicketAlreadyCheckedIn) {
+			s.logger.Warn(ctx, "Ticket already checked in", "ticket_id", ticketID)
+			return nil, ErrTicketAlreadyCheckedIn
+		}
+		if errors.Is(err, repository.ErrTicketNotConfirmed) {
+			return nil, fmt.Errorf("only confirmed tickets can be checked in")
+		}
+		s.logger.Error(ctx, "Failed to check in ticket", "ticket_id", ticketID, "error", err)
+		return nil, fmt.Errorf("failed to check in ticket: %w", err)
+	}
+
+	s.logger.Info(ctx, "Ticket checked in", "ticket_id", ticketID, "event_id", ticket.EventID)
+	return ticket, nil
+}
+
+// GetAccessList returns the tickets of an event that hold a gate access token and changed after since.
+// A nil since returns the full list. Entries are ordered by UpdatedAt so gate systems can sync incrementally
+// by passing the returned cursor back as since.
+func (s *TicketingService) GetAccessList(ctx context.Context, eventID uuid.UUID, since *time.Time) (*AccessList, error) {
+	tickets, err := s.ticketRepo.GetByEventID(ctx, eventID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get event tickets", "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to get event tickets: %w", err)
+	}
+
+	list := &AccessList{Entries: []AccessListEntry{}}
+	if since != nil {
+		list.Cursor = *since
+	}
+
+	for _, ticket := range tickets {
+		if ticket.AccessToken == "" {
+			continue
+		}
+		if since != nil && !ticket.UpdatedAt.After(*since) {
+			continue
+		}
+
+		list.Entries = append(list.Entries, AccessListEntry{
+			TicketID:    ticket.ID,
+			SeatID:      ticket.SeatID,
+			GANumber:    ticket.GANumber,
+			AccessToken: ticket.AccessToken,
+			Status:      ticket.Status,
+			Valid:       ticket.IsAdmissible(),
+			CheckedInAt: ticket.CheckedInAt,
+			UpdatedAt:   ticket.UpdatedAt,
+		})
+
+		if ticket.UpdatedAt.After(list.Cursor) {
+			list.Cursor = ticket.UpdatedAt
+		}
+	}
+
+	sort.Slice(list.Entries, func(i, j int) bool {
+		return list.Entries[i].UpdatedAt.Before(list.Entries[j].UpdatedAt)
+	})
+
+	return list, nil
+}
+
+// newAccessToken generates a random, URL-safe gate access token
+func newAccessToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
 // GetUserTickets retrieves all tickets for a user
 func (s *TicketingService) GetUserTickets(ctx context.Context, userID uuid.UUID) ([]*domain.Ticket, error) {
 	tickets, err := s.ticketRepo.GetByUserID(ctx, userID)
@@ -334,6 +1058,54 @@ func (s *TicketingService) GetUserTickets(ctx context.Context, userID uuid.UUID)
 	return tickets, nil
 }
 
+// GetUserEventTickets retrieves a user's tickets for one event
+func (s *TicketingService) GetUserEventTickets(ctx context.Context, userID, eventID uuid.UUID) ([]*domain.Ticket, error) {
+	tickets, err := s.ticketRepo.GetByUserAndEvent(ctx, userID, eventID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get user event tickets", "user_id", userID, "event_id", eventID, "error", err)
+		return nil, fmt.Errorf("failed to get user event tickets: %w", err)
+	}
+
+	return tickets, nil
+}
+
+// GetEventTickets retrieves a page of an event's tickets along with the event's total number of tickets
+func (s *TicketingService) GetEventTickets(ctx context.Context, eventID uuid.UUID, limit, offset int) ([]*domain.Ticket, int, error) {
+	tickets, total, err := s.ticketRepo.GetByEventIDPaginated(ctx, eventID, offset, limit)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get event tickets", "event_id", eventID, "error", err)
+		return nil, 0, fmt.Errorf("failed to get event tickets: %w", err)
+	}
+
+	return tickets, total, nil
+}
+
+// GetEventTicketsByStatus retrieves a page of an event's tickets in one status, such as the confirmed
+// tickets to check in, along with how many of the event's tickets are in that status
+func (s *TicketingService) GetEventTicketsByStatus(ctx context.Context, eventID uuid.UUID, status string, limit, offset int) ([]*domain.Ticket, int, error) {
+	if !domain.TicketStatus(status).IsValid() {
+		return nil, 0, fmt.Errorf("unknown ticket status %q", status)
+	}
+
+	tickets, err := s.ticketRepo.GetByEventIDAndStatus(ctx, eventID, status)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get event tickets by status", "event_id", eventID, "status", status, "error", err)
+		return nil, 0, fmt.Errorf("failed to get event tickets: %w", err)
+	}
+
+	total := len(tickets)
+	if offset >= total || limit <= 0 {
+		return []*domain.Ticket{}, total, nil
+	}
+
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	return tickets[offset:end], total, nil
+}
+
 // GetTicket retrieves a ticket by ID
 func (s *TicketingService) GetTicket(ctx context.Context, ticketID uuid.UUID) (*domain.Ticket, error) {
 	ticket, err := s.ticketRepo.GetByID(ctx, ticketID)
@@ -344,3 +1116,40 @@ func (s *TicketingService) GetTicket(ctx context.Context, ticketID uuid.UUID) (*
 
 	return ticket, nil
 }
+
+// MaxTicketBatchSize bounds how many tickets GetTickets fetches in one call
+const MaxTicketBatchSize = 100
+
+// TicketLookup is one requested ticket ID and the ticket it resolved to, if any
+type TicketLookup struct {
+	ID     uuid.UUID      `json:"id"`
+	Found  bool           `json:"found"`
+	Ticket *domain.Ticket `json:"ticket,omitempty"`
+}
+
+// GetTickets retrieves several tickets in one round trip, answering every requested ID in request order
+// and marking the ones that don't exist as not found
+func (s *TicketingService) GetTickets(ctx context.Context, ids []uuid.UUID) ([]TicketLookup, error) {
+	if len(ids) > MaxTicketBatchSize {
+		return nil, fmt.Errorf("cannot fetch more than %d tickets at once", MaxTicketBatchSize)
+	}
+
+	tickets, err := s.ticketRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get tickets by IDs", "count", len(ids), "error", err)
+		return nil, fmt.Errorf("failed to get tickets: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]*domain.Ticket, len(tickets))
+	for _, ticket := range tickets {
+		byID[ticket.ID] = ticket
+	}
+
+	lookups := make([]TicketLookup, len(ids))
+	for i, id := range ids {
+		ticket, ok := byID[id]
+		lookups[i] = TicketLookup{ID: id, Found: ok, Ticket: ticket}
+	}
+
+	return lookups, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/pkg/clock"
+)
+
+func TestTicketTimestampsFollowClock(t *testing.T) {
+	tests := []struct {
+		name   string
+		seated bool
+	}{
+		{name: "seated", seated: true},
+		{name: "standing", seated: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tt := newTestTicketing(t)
+			start := time.Now().Add(time.Minute).Truncate(time.Second)
+			manual := clock.NewManual(start)
+			tt.service.SetClock(manual)
+
+			event := tt.createEvent(t, 10, 10)
+			var seatID *uuid.UUID
+			if tc.seated {
+				seatID = &tt.createSeat(t, event, domain.SeatStatusAvailable).ID
+			} else {
+				event.IsSeatedEvent = false
+				event.StandingPrice = 5000
+				if err := tt.events.Update(ctx, event); err != nil {
+					t.Fatalf("make event standing: %v", err)
+				}
+			}
+			userID := uuid.New()
+			sessionID := tt.activateSession(t, event.ID, userID)
+
+			ticket, err := tt.service.PurchaseTicket(ctx, event.ID, userID, seatID, sessionID, PurchaseOptions{})
+			if err != nil {
+				t.Fatalf("purchase ticket: %v", err)
+			}
+			if !ticket.IssuedAt.Equal(start) {
+				t.Fatalf("issued at %v, want the clock's %v", ticket.IssuedAt, start)
+			}
+			if ticket.ExpiresAt == nil || !ticket.ExpiresAt.Equal(start.Add(15*time.Minute)) {
+				t.Fatalf("expires at %v, want %v", ticket.ExpiresAt, start.Add(15*time.Minute))
+			}
+
+			manual.Advance(time.Minute)
+			if err := tt.service.ConfirmTicket(ctx, ticket.ID); err != nil {
+				t.Fatalf("confirm ticket: %v", err)
+			}
+			confirmed, err := tt.tickets.GetByID(ctx, ticket.ID)
+			if err != nil {
+				t.Fatalf("get ticket: %v", err)
+			}
+			if confirmed.ConfirmedAt == nil || !confirmed.ConfirmedAt.Equal(manual.Now()) {
+				t.Fatalf("confirmed at %v, want the clock's %v", confirmed.ConfirmedAt, manual.Now())
+			}
+
+			manual.Advance(time.Hour)
+			admitted, err := tt.service.CheckInTicket(ctx, ticket.ID)
+			if err != nil {
+				t.Fatalf("check in ticket: %v", err)
+			}
+			if admitted.CheckedInAt == nil || !admitted.CheckedInAt.Equal(manual.Now()) {
+				t.Fatalf("checked in at %v, want the clock's %v", admitted.CheckedInAt, manual.Now())
+			}
+		})
+	}
+}
+
+func TestResolveGuestFollowsClock(t *testing.T) {
+	tt := newTestTicketing(t)
+	manual := clock.NewManual(time.Now())
+	tt.service.SetClock(manual)
+
+	tokens, err := NewGuestTokens(bytes.Repeat([]byte("k"), 32))
+	if err != nil {
+		t.Fatalf("new guest tokens: %v", err)
+	}
+	tt.service.SetGuestTokens(tokens)
+
+	token, err := tokens.Issue("guest@example.com", time.Hour)
+	if err != nil {
+		t.Fatalf("issue guest token: %v", err)
+	}
+
+	if _, err := tt.service.ResolveGuest(token); err != nil {
+		t.Fatalf("resolve guest before the token lapses: %v", err)
+	}
+
+	manual.Advance(2 * time.Hour)
+	if _, err := tt.service.ResolveGuest(token); !errors.Is(err, ErrInvalidGuestToken) {
+		t.Fatalf("expected ErrInvalidGuestToken once the clock passes the token's expiry, got %v", err)
+	}
+}
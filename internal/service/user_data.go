@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// UserDataExport is everything the system stores about a user, gathered for a data subject access request
+type UserDataExport struct {
+	UserID       uuid.UUID            `json:"user_id"`
+	ExportedAt   time.Time            `json:"exported_at"`
+	Tickets      []*domain.Ticket     `json:"tickets"`
+	QueueEntries []*domain.QueueEntry `json:"queue_entries"`
+}
+
+// UserDataDeletion summarizes what DeleteUserData removed
+type UserDataDeletion struct {
+	UserID               uuid.UUID `json:"user_id"`
+	TicketsDeleted       int       `json:"tickets_deleted"`
+	ReservationsReleased int       `json:"reservations_released"`
+	QueueEntriesRemoved  int       `json:"queue_entries_removed"`
+}
+
+// ExportUserData gathers a user's tickets and queue entries into a single document
+func (s *TicketingService) ExportUserData(ctx context.Context, userID uuid.UUID) (*UserDataExport, error) {
+	tickets, err := s.ticketRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get user tickets for export", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to get user tickets: %w", err)
+	}
+
+	entries, err := s.queueRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get user queue entries for export", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to get user queue entries: %w", err)
+	}
+
+	if tickets == nil {
+		tickets = []*domain.Ticket{}
+	}
+	if entries == nil {
+		entries = []*domain.QueueEntry{}
+	}
+
+	s.logger.Info(ctx, "User data exported", "user_id", userID, "tickets", len(tickets), "queue_entries", len(entries))
+
+	return &UserDataExport{
+		UserID:       userID,
+		ExportedAt:   time.Now(),
+		Tickets:      tickets,
+		QueueEntries: entries,
+	}, nil
+}
+
+// DeleteUserData erases a user's tickets and queue entries.
+// Open reservations are cancelled first so their seats and inventory return to sale; seats of
+// confirmed tickets stay sold because the sale itself stands, only the personal record is removed.
+func (s *TicketingService) DeleteUserData(ctx context.Context, userID uuid.UUID) (*UserDataDeletion, error) {
+	s.logger.Info(ctx, "Deleting user data", "user_id", userID)
+
+	deletion := &UserDataDeletion{UserID: userID}
+
+	entries, err := s.queueRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user queue entries: %w", err)
+	}
+
+	for _, entry := range entries {
+		if err := s.queueRepo.RemoveUser(ctx, entry.EventID, userID); err != nil {
+			s.logger.Error(ctx, "Failed to remove queue entry", "user_id", userID, "event_id", entry.EventID, "error", err)
+			return deletion, fmt.Errorf("failed to remove queue entry: %w", err)
+		}
+		deletion.QueueEntriesRemoved++
+	}
+
+	tickets, err := s.ticketRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return deletion, fmt.Errorf("failed to get user tickets: %w", err)
+	}
+
+	for _, ticket := range tickets {
+		// Re-read the ticket since cancelling an accessible seat also cancels its companion
+		current, err := s.ticketRepo.GetByID(ctx, ticket.ID)
+		if err != nil {
+			return deletion, fmt.Errorf("failed to get ticket %s: %w", ticket.ID, err)
+		}
+
+		if current.IsReserved() {
+			if err := s.CancelTicket(ctx, ticket.ID); err != nil {
+				return deletion, fmt.Errorf("failed to release reservation %s: %w", ticket.ID, err)
+			}
+			deletion.ReservationsReleased++
+		}
+
+		if err := s.ticketRepo.Delete(ctx, ticket.ID); err != nil {
+			s.logger.Error(ctx, "Failed to delete ticket", "user_id", userID, "ticket_id", ticket.ID, "error", err)
+			return deletion, fmt.Errorf("failed to delete ticket %s: %w", ticket.ID, err)
+		}
+		deletion.TicketsDeleted++
+	}
+
+	s.logger.Info(ctx, "User data deleted",
+		"user_id", userID,
+		"tickets_deleted", deletion.TicketsDeleted,
+		"queue_entries_removed", deletion.QueueEntriesRemoved)
+
+	return deletion, nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+func TestExportAndDeleteUserData(t *testing.T) {
+	ctx := context.Background()
+	tt := newTestTicketing(t)
+
+	userID, otherID := uuid.New(), uuid.New()
+
+	// A reservation bought through the queue, a confirmed ticket and a place in a third event's queue
+	reservedEvent := tt.createEvent(t, 10, 10)
+	reservedSeat := tt.createSeat(t, reservedEvent, domain.SeatStatusAvailable)
+	sessionID := tt.activateSession(t, reservedEvent.ID, userID)
+	reservation, err := tt.service.PurchaseTicket(ctx, reservedEvent.ID, userID, &reservedSeat.ID, sessionID, PurchaseOptions{})
+	if err != nil {
+		t.Fatalf("purchase ticket: %v", err)
+	}
+
+	confirmedEvent := tt.createEvent(t, 10, 9)
+	confirmedSeat, confirmed := tt.createReservation(t, confirmedEvent, userID, 10*time.Minute)
+	if err := tt.service.ConfirmTicket(ctx, confirmed.ID); err != nil {
+		t.Fatalf("confirm ticket: %v", err)
+	}
+
+	queuedEvent := tt.createEvent(t, 10, 10)
+	tt.activateSession(t, queuedEvent.ID, otherID)
+	if _, err := tt.queue.Join(ctx, queuedEvent.ID, userID, uuid.NewString()); err != nil {
+		t.Fatalf("join queue: %v", err)
+	}
+
+	// Someone else's ticket in the same event is left alone
+	_, othersTicket := tt.createReservation(t, reservedEvent, otherID, 10*time.Minute)
+
+	export, err := tt.service.ExportUserData(ctx, userID)
+	if err != nil {
+		t.Fatalf("export user data: %v", err)
+	}
+	exported := make(map[uuid.UUID]bool)
+	for _, ticket := range export.Tickets {
+		exported[ticket.ID] = true
+	}
+	if len(export.Tickets) != 2 || !exported[reservation.ID] || !exported[confirmed.ID] {
+		t.Fatalf("export holds %d tickets, want the reservation %s and confirmed ticket %s", len(export.Tickets), reservation.ID, confirmed.ID)
+	}
+	queued := make(map[uuid.UUID]bool)
+	for _, entry := range export.QueueEntries {
+		if entry.UserID != userID {
+			t.Fatalf("export holds the queue entry of user %s", entry.UserID)
+		}
+		queued[entry.EventID] = true
+	}
+	if len(export.QueueEntries) != 2 || !queued[reservedEvent.ID] || !queued[queuedEvent.ID] {
+		t.Fatalf("export holds %d queue entries, want the sessions of events %s and %s", len(export.QueueEntries), reservedEvent.ID, queuedEvent.ID)
+	}
+
+	deletion, err := tt.service.DeleteUserData(ctx, userID)
+	if err != nil {
+		t.Fatalf("delete user data: %v", err)
+	}
+	if deletion.TicketsDeleted != 2 || deletion.ReservationsReleased != 1 || deletion.QueueEntriesRemoved != 2 {
+		t.Fatalf("deletion = %+v, want 2 tickets deleted, 1 reservation released and 2 queue entries removed", deletion)
+	}
+
+	export, err = tt.service.ExportUserData(ctx, userID)
+	if err != nil {
+		t.Fatalf("export after deletion: %v", err)
+	}
+	if len(export.Tickets) != 0 || len(export.QueueEntries) != 0 {
+		t.Fatalf("export after deletion holds %d tickets and %d queue entries, want none", len(export.Tickets), len(export.QueueEntries))
+	}
+	if _, err := tt.queue.GetBySessionID(ctx, sessionID); err == nil {
+		t.Fatal("purchase session still stored after deletion")
+	}
+
+	// The reservation's seat goes back on sale while the confirmed sale stands
+	tt.expectSeatStatus(t, reservedSeat.ID, domain.SeatStatusAvailable)
+	tt.expectSeatStatus(t, confirmedSeat.ID, domain.SeatStatusSold)
+	if got := tt.availableTickets(t, reservedEvent.ID); got != 10 {
+		t.Fatalf("available tickets = %d after the reservation was released, want 10", got)
+	}
+	if _, err := tt.tickets.GetByID(ctx, othersTicket.ID); err != nil {
+		t.Fatalf("another user's ticket was removed: %v", err)
+	}
+}
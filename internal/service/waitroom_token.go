@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// ErrInvalidWaitroomToken is returned when a purchase presents a missing, forged, mismatched or expired waitroom token
+var ErrInvalidWaitroomToken = errors.New("invalid or expired waitroom token")
+
+// WaitroomClaims is what a waitroom token vouches for: this session of this user reached the
+// front of this event's queue and may purchase until ExpiresAt
+type WaitroomClaims struct {
+	SessionID string    `json:"sid"`
+	EventID   uuid.UUID `json:"eid"`
+	UserID    uuid.UUID `json:"uid"`
+	ExpiresAt int64     `json:"exp"` // Unix seconds
+}
+
+// WaitroomTokens signs and verifies waitroom tokens with HMAC-SHA256.
+// A token is base64url(claims JSON) "." base64url(signature).
+type WaitroomTokens struct {
+	secret []byte
+}
+
+// NewWaitroomTokens creates a WaitroomTokens with a shared secret of at least 32 bytes
+func NewWaitroomTokens(secret []byte) (*WaitroomTokens, error) {
+	if len(secret) < 32 {
+		return nil, fmt.Errorf("waitroom token secret must be at least 32 bytes")
+	}
+
+	return &WaitroomTokens{secret: secret}, nil
+}
+
+// Issue signs a token for an active queue entry, valid until the entry's session expires
+func (w *WaitroomTokens) Issue(entry *domain.QueueEntry) (string, error) {
+	if !entry.IsActive() || entry.ExpiresAt == nil {
+		return "", fmt.Errorf("waitroom tokens are only issued to active entries")
+	}
+
+	payload, err := json.Marshal(WaitroomClaims{
+		SessionID: entry.SessionID,
+		EventID:   entry.EventID,
+		UserID:    entry.UserID,
+		ExpiresAt: entry.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal waitroom claims: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(w.sign(encoded)), nil
+}
+
+// Verify checks a token's signature and expiry and returns its claims
+func (w *WaitroomTokens) Verify(token string, now time.Time) (*WaitroomClaims, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidWaitroomToken
+	}
+
+	given, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(given, w.sign(encoded)) {
+		return nil, ErrInvalidWaitroomToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidWaitroomToken
+	}
+
+	var claims WaitroomClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidWaitroomToken
+	}
+
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, ErrInvalidWaitroomToken
+	}
+
+	return &claims, nil
+}
+
+// sign computes the HMAC of the encoded claims
+func (w *WaitroomTokens) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, w.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// SetWaitroomTokens enables signed waitroom tokens for users activated by this service
+func (s *QueueService) SetWaitroomTokens(tokens *WaitroomTokens) {
+	s.waitroomTokens = tokens
+}
+
+// issueWaitroomToken signs a token for a newly active or refreshed entry and stores it on the entry
+// so the user can read it from their queue status
+func (s *QueueService) issueWaitroomToken(ctx context.Context, entry *domain.QueueEntry) {
+	if s.waitroomTokens == nil || !entry.IsActive() {
+		return
+	}
+
+	token, err := s.waitroomTokens.Issue(entry)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to issue waitroom token", "entry_id", entry.ID, "error", err)
+		return
+	}
+
+	entry.WaitroomToken = token
+	if err := s.queueRepo.Update(ctx, entry); err != nil {
+		s.logger.Error(ctx, "Failed to store waitroom token", "entry_id", entry.ID, "error", err)
+	}
+}
+
+// SetWaitroomTokens makes purchases require a valid waitroom token signed with the same secret as the queue's
+func (s *TicketingService) SetWaitroomTokens(tokens *WaitroomTokens) {
+	s.waitroomTokens = tokens
+}
+
+// verifyWaitroomToken checks that a purchase's token was issued for this session, event and user and is unexpired
+func (s *TicketingService) verifyWaitroomToken(token, sessionID string, eventID, userID uuid.UUID) error {
+	if s.waitroomTokens == nil {
+		return nil
+	}
+
+	claims, err := s.waitroomTokens.Verify(token, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if claims.SessionID != sessionID || claims.EventID != eventID || claims.UserID != userID {
+		return ErrInvalidWaitroomToken
+	}
+
+	return nil
+}
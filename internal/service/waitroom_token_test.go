@@ -0,0 +1,157 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// newTestWaitroomTokens returns WaitroomTokens signing with a secret made of fill
+func newTestWaitroomTokens(t *testing.T, fill byte) *WaitroomTokens {
+	t.Helper()
+
+	tokens, err := NewWaitroomTokens(bytes.Repeat([]byte{fill}, 32))
+	if err != nil {
+		t.Fatalf("new waitroom tokens: %v", err)
+	}
+	return tokens
+}
+
+// issueWaitroomToken signs a token for an active entry of a session expiring at expiresAt
+func issueWaitroomToken(t *testing.T, tokens *WaitroomTokens, sessionID string, eventID, userID uuid.UUID, expiresAt time.Time) string {
+	t.Helper()
+
+	token, err := tokens.Issue(&domain.QueueEntry{
+		SessionID: sessionID,
+		EventID:   eventID,
+		UserID:    userID,
+		Status:    string(domain.QueueStatusActive),
+		ExpiresAt: &expiresAt,
+	})
+	if err != nil {
+		t.Fatalf("issue waitroom token: %v", err)
+	}
+	return token
+}
+
+func TestWaitroomTokensVerify(t *testing.T) {
+	tokens := newTestWaitroomTokens(t, 'a')
+	eventID, userID := uuid.New(), uuid.New()
+	now := time.Now()
+	valid := issueWaitroomToken(t, tokens, "session-1", eventID, userID, now.Add(time.Minute))
+
+	// A payload swapped for other claims keeps the original signature
+	other := issueWaitroomToken(t, tokens, "session-2", eventID, uuid.New(), now.Add(time.Minute))
+	otherPayload, _, _ := strings.Cut(other, ".")
+	_, validSignature, _ := strings.Cut(valid, ".")
+
+	tests := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{name: "valid", token: valid, ok: true},
+		{name: "signed with another secret", token: issueWaitroomToken(t, newTestWaitroomTokens(t, 'b'), "session-1", eventID, userID, now.Add(time.Minute))},
+		{name: "payload swapped under the signature", token: otherPayload + "." + validSignature},
+		{name: "expired", token: issueWaitroomToken(t, tokens, "session-1", eventID, userID, now.Add(-time.Second))},
+		{name: "malformed", token: "not-a-token"},
+		{name: "empty", token: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			claims, err := tokens.Verify(tc.token, now)
+			if !tc.ok {
+				if !errors.Is(err, ErrInvalidWaitroomToken) {
+					t.Fatalf("expected ErrInvalidWaitroomToken, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("verify: %v", err)
+			}
+			if claims.SessionID != "session-1" || claims.EventID != eventID || claims.UserID != userID {
+				t.Fatalf("unexpected claims %+v", claims)
+			}
+		})
+	}
+}
+
+func TestPurchaseRequiresWaitroomToken(t *testing.T) {
+	tests := []struct {
+		name string
+		// token returns the token presented with the purchase of the session
+		token func(t *testing.T, tokens *WaitroomTokens, sessionID string, eventID, userID uuid.UUID) string
+		ok    bool
+	}{
+		{
+			name: "valid token",
+			token: func(t *testing.T, tokens *WaitroomTokens, sessionID string, eventID, userID uuid.UUID) string {
+				return issueWaitroomToken(t, tokens, sessionID, eventID, userID, time.Now().Add(time.Minute))
+			},
+			ok: true,
+		},
+		{
+			name: "missing token",
+			token: func(t *testing.T, tokens *WaitroomTokens, sessionID string, eventID, userID uuid.UUID) string {
+				return ""
+			},
+		},
+		{
+			name: "forged token",
+			token: func(t *testing.T, tokens *WaitroomTokens, sessionID string, eventID, userID uuid.UUID) string {
+				return issueWaitroomToken(t, newTestWaitroomTokens(t, 'f'), sessionID, eventID, userID, time.Now().Add(time.Minute))
+			},
+		},
+		{
+			name: "expired token",
+			token: func(t *testing.T, tokens *WaitroomTokens, sessionID string, eventID, userID uuid.UUID) string {
+				return issueWaitroomToken(t, tokens, sessionID, eventID, userID, time.Now().Add(-time.Second))
+			},
+		},
+		{
+			name: "token of another session",
+			token: func(t *testing.T, tokens *WaitroomTokens, sessionID string, eventID, userID uuid.UUID) string {
+				return issueWaitroomToken(t, tokens, uuid.NewString(), eventID, userID, time.Now().Add(time.Minute))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tt := newTestTicketing(t)
+			tokens := newTestWaitroomTokens(t, 'a')
+			tt.service.SetWaitroomTokens(tokens)
+
+			event := tt.createEvent(t, 10, 10)
+			seat := tt.createSeat(t, event, domain.SeatStatusAvailable)
+			userID := uuid.New()
+			sessionID := tt.activateSession(t, event.ID, userID)
+
+			opts := PurchaseOptions{WaitroomToken: tc.token(t, tokens, sessionID, event.ID, userID)}
+			ticket, err := tt.service.PurchaseTicket(ctx, event.ID, userID, &seat.ID, sessionID, opts)
+			if !tc.ok {
+				if !errors.Is(err, ErrInvalidWaitroomToken) {
+					t.Fatalf("expected ErrInvalidWaitroomToken, got %v", err)
+				}
+				if got := tt.availableTickets(t, event.ID); got != 10 {
+					t.Fatalf("available tickets = %d after a refused purchase, want 10", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("purchase ticket: %v", err)
+			}
+			if ticket.SeatID == nil || *ticket.SeatID != seat.ID {
+				t.Fatalf("ticket seat = %v, want %s", ticket.SeatID, seat.ID)
+			}
+		})
+	}
+}
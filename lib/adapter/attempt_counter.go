@@ -0,0 +1,18 @@
+package adapter
+
+import (
+	"context"
+	"time"
+)
+
+// AttemptCounter defines the interface for expiring per-key attempt counters
+type AttemptCounter interface {
+	// Incr adds one attempt for a key and returns the new count; the TTL starts with the first attempt
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+
+	// Get returns the current count for a key, or 0 when none is recorded
+	Get(ctx context.Context, key string) (int64, error)
+
+	// Reset clears the count for a key
+	Reset(ctx context.Context, key string) error
+}
@@ -25,3 +25,71 @@ type Cache interface {
 	// TTL returns the time to live for a key
 	TTL(ctx context.Context, key string) (time.Duration, error)
 }
+
+// CacheConfig holds how long cached reads are served before they are fetched again. Repositories use it for
+// client-side caching of Redis reads and services for the values they put in the Cache.
+type CacheConfig struct {
+	// EventTTL covers single events
+	EventTTL time.Duration
+	// EventListTTL covers the list of all events
+	EventListTTL time.Duration
+	// ActiveEventsTTL covers the list of active events
+	ActiveEventsTTL time.Duration
+	// EventsByStatusTTL covers the per-status event indexes
+	EventsByStatusTTL time.Duration
+	// SeatTTL covers single seats
+	SeatTTL time.Duration
+	// SeatListTTL covers the list of an event's seats
+	SeatListTTL time.Duration
+	// AvailableSeatsTTL covers an event's available seats, which change with every purchase
+	AvailableSeatsTTL time.Duration
+	// TicketTTL covers single tickets
+	TicketTTL time.Duration
+	// UserTicketsTTL covers the list of a user's tickets
+	UserTicketsTTL time.Duration
+	// QueueEntryTTL covers queue entries and positions
+	QueueEntryTTL time.Duration
+	// QueueLengthTTL covers queue lengths
+	QueueLengthTTL time.Duration
+}
+
+// DefaultCacheConfig returns the default cache configuration
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		EventTTL:          1 * time.Hour,
+		EventListTTL:      2 * time.Minute,
+		ActiveEventsTTL:   5 * time.Minute,
+		EventsByStatusTTL: 2 * time.Minute,
+		SeatTTL:           30 * time.Minute,
+		SeatListTTL:       10 * time.Minute,
+		AvailableSeatsTTL: 1 * time.Minute,
+		TicketTTL:         15 * time.Minute,
+		UserTicketsTTL:    5 * time.Minute,
+		QueueEntryTTL:     1 * time.Minute,
+		QueueLengthTTL:    30 * time.Second,
+	}
+}
+
+// WithDefaults returns the configuration with every unset or negative TTL replaced by its default
+func (c CacheConfig) WithDefaults() CacheConfig {
+	defaults := DefaultCacheConfig()
+	fill := func(ttl *time.Duration, fallback time.Duration) {
+		if *ttl <= 0 {
+			*ttl = fallback
+		}
+	}
+
+	fill(&c.EventTTL, defaults.EventTTL)
+	fill(&c.EventListTTL, defaults.EventListTTL)
+	fill(&c.ActiveEventsTTL, defaults.ActiveEventsTTL)
+	fill(&c.EventsByStatusTTL, defaults.EventsByStatusTTL)
+	fill(&c.SeatTTL, defaults.SeatTTL)
+	fill(&c.SeatListTTL, defaults.SeatListTTL)
+	fill(&c.AvailableSeatsTTL, defaults.AvailableSeatsTTL)
+	fill(&c.TicketTTL, defaults.TicketTTL)
+	fill(&c.UserTicketsTTL, defaults.UserTicketsTTL)
+	fill(&c.QueueEntryTTL, defaults.QueueEntryTTL)
+	fill(&c.QueueLengthTTL, defaults.QueueLengthTTL)
+
+	return c
+}
@@ -0,0 +1,10 @@
+package adapter
+
+import "context"
+
+// CallbackSender defines the interface for delivering server-to-server callbacks to buyer-supplied URLs
+type CallbackSender interface {
+	// Send POSTs a JSON payload to url, signed so the receiver can tell it came from this server; a response
+	// outside 2xx is an error
+	Send(ctx context.Context, url string, payload []byte) error
+}
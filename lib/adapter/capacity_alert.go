@@ -0,0 +1,13 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// CapacityAlerter defines the interface for publishing early warnings as an event approaches sold-out
+type CapacityAlerter interface {
+	// CapacityThresholdCrossed publishes an alert; it is called once per event and threshold
+	CapacityThresholdCrossed(ctx context.Context, alert domain.CapacityAlert) error
+}
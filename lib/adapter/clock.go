@@ -0,0 +1,9 @@
+package adapter
+
+import "time"
+
+// Clock defines the interface for reading the current time, so expiry checks can run against a fixed time
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+}
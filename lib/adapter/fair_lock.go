@@ -0,0 +1,20 @@
+package adapter
+
+import (
+	"context"
+	"time"
+)
+
+// FairLock defines the interface for distributed locks that are granted to waiters in the order they asked.
+// A fair lock shares its keys with the Lock of the same backend: a key held through either one is held for both,
+// so callers that take a key plainly and callers that queue for it still exclude each other, though a plain
+// Acquire does not wait its turn in line.
+type FairLock interface {
+	// AcquireWait queues for a lock and blocks until it is granted or wait has passed, whichever comes first.
+	// Waiters are granted the lock in the order they called AcquireWait, and a granted lock lapses after expiration.
+	// It returns a token that must be passed to Release, and false if the wait ran out.
+	AcquireWait(ctx context.Context, key string, expiration, wait time.Duration) (string, bool, error)
+
+	// Release releases a lock granted by AcquireWait; a lock that already lapsed or was granted to someone else is left alone
+	Release(ctx context.Context, key, token string) error
+}
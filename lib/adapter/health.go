@@ -0,0 +1,12 @@
+package adapter
+
+import "context"
+
+// HealthChecker defines the interface for dependencies that report readiness
+type HealthChecker interface {
+	// Name identifies the dependency in health reports
+	Name() string
+
+	// Check returns an error if the dependency is not ready to serve traffic
+	Check(ctx context.Context) error
+}
@@ -0,0 +1,9 @@
+package adapter
+
+import "github.com/google/uuid"
+
+// IDGenerator defines the interface for generating the IDs of new events, seats, tickets and queue entries
+type IDGenerator interface {
+	// NewID returns a new, unique ID
+	NewID() uuid.UUID
+}
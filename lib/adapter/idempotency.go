@@ -0,0 +1,20 @@
+package adapter
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotencyStore defines the interface for remembering the outcome of a request by its idempotency key,
+// so a retried request can be answered without running it again
+type IdempotencyStore interface {
+	// Claim stores value under key with the given TTL unless the key is already taken. It reports true when the
+	// key was claimed, or false along with the value already stored.
+	Claim(ctx context.Context, key, value string, ttl time.Duration) (string, bool, error)
+
+	// Set replaces the value of a claimed key, keeping its expiry
+	Set(ctx context.Context, key, value string) error
+
+	// Release removes a key so the request can be made again
+	Release(ctx context.Context, key string) error
+}
@@ -2,20 +2,32 @@ package adapter
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrLockNotHeld is returned when extending a lock that has expired, was released or is now held by someone else
+var ErrLockNotHeld = errors.New("lock is not held")
+
 // Lock defines the interface for distributed locking operations
 type Lock interface {
-	// Acquire attempts to acquire a lock with a timeout
-	Acquire(ctx context.Context, key string, expiration time.Duration) (bool, error)
+	// Acquire attempts to acquire a lock that lapses after expiration.
+	// It returns a token naming this holder that must be passed to Release and Extend, and false if the lock is taken.
+	Acquire(ctx context.Context, key string, expiration time.Duration) (string, bool, error)
 
-	// Release releases a lock
-	Release(ctx context.Context, key string) error
+	// Release releases a lock taken with token; a lock that already lapsed or was taken by someone else is left alone
+	Release(ctx context.Context, key, token string) error
 
-	// Extend extends the expiration time of a lock
-	Extend(ctx context.Context, key string, expiration time.Duration) error
+	// Extend resets the expiration time of a lock taken with token; it fails with ErrLockNotHeld when the lock
+	// lapsed, was released or is now held under another token
+	Extend(ctx context.Context, key, token string, expiration time.Duration) error
 
 	// IsLocked checks if a key is locked
 	IsLocked(ctx context.Context, key string) (bool, error)
+
+	// TTL returns how long a lock has left: 0 when it is not held and a negative duration when it never expires
+	TTL(ctx context.Context, key string) (time.Duration, error)
+
+	// ForceRelease deletes a lock whoever holds it and reports whether one was held; for clearing stuck locks
+	ForceRelease(ctx context.Context, key string) (bool, error)
 }
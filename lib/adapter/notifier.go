@@ -0,0 +1,16 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Notifier defines the interface for delivering notifications to users
+type Notifier interface {
+	// SendEmail sends an email to a user
+	SendEmail(ctx context.Context, userID uuid.UUID, subject, body string) error
+
+	// SendSMS sends a text message to a user
+	SendSMS(ctx context.Context, userID uuid.UUID, message string) error
+}
@@ -0,0 +1,20 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// PaymentGateway defines the interface for moving money between users and the platform
+type PaymentGateway interface {
+	// Charge takes amount (in cents of currency) from a user and returns the payment ID;
+	// reference identifies what the charge is for and makes retries idempotent
+	Charge(ctx context.Context, userID uuid.UUID, amount int64, currency, reference string) (string, error)
+
+	// Refund returns a charge to the user it was taken from
+	Refund(ctx context.Context, paymentID string) error
+
+	// Payout sends amount (in cents of currency) to a user
+	Payout(ctx context.Context, userID uuid.UUID, amount int64, currency, reference string) error
+}
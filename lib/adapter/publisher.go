@@ -0,0 +1,10 @@
+package adapter
+
+import "context"
+
+// EventPublisher defines the interface for publishing domain events to downstream systems such as email or
+// analytics
+type EventPublisher interface {
+	// Publish appends a JSON payload to a topic
+	Publish(ctx context.Context, topic string, payload []byte) error
+}
@@ -0,0 +1,15 @@
+package adapter
+
+import (
+	"context"
+	"time"
+)
+
+// RateCounter defines the interface for rolling-window event counters
+type RateCounter interface {
+	// Incr records one occurrence for a key at the current time
+	Incr(ctx context.Context, key string) error
+
+	// Count returns how many occurrences were recorded for a key within the trailing window
+	Count(ctx context.Context, key string, window time.Duration) (int64, error)
+}
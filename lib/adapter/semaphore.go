@@ -0,0 +1,16 @@
+package adapter
+
+import (
+	"context"
+	"time"
+)
+
+// Semaphore defines the interface for distributed counting semaphores
+type Semaphore interface {
+	// Acquire attempts to take one of limit slots for a key; a slot held longer than expiration is reclaimed.
+	// It returns a token that must be passed to Release, and false if every slot is taken.
+	Acquire(ctx context.Context, key string, limit int, expiration time.Duration) (string, bool, error)
+
+	// Release returns a slot taken by Acquire
+	Release(ctx context.Context, key, token string) error
+}
@@ -0,0 +1,12 @@
+package adapter
+
+import "context"
+
+// Worker defines the interface for a background job that runs alongside the API, such as expiry sweeps
+type Worker interface {
+	// Name identifies the worker in logs
+	Name() string
+
+	// Run works until ctx is cancelled, finishing the cycle in progress before it returns
+	Run(ctx context.Context) error
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccessLogEntry records one request to a sensitive endpoint, such as seat availability or purchase,
+// for security investigations. Unlike ticket state changes it is written per request, including failed ones.
+type AccessLogEntry struct {
+	ID         string     `json:"id"`       // Assigned by the store; entries sort by it
+	Endpoint   string     `json:"endpoint"` // Route template, e.g. /tickets/purchase
+	Method     string     `json:"method"`
+	UserID     *uuid.UUID `json:"user_id,omitempty"`
+	SessionID  string     `json:"session_id,omitempty"`
+	Status     int        `json:"status"` // HTTP status of the response
+	RemoteAddr string     `json:"remote_addr,omitempty"`
+	At         time.Time  `json:"at"`
+}
+
+// PurchaseSignal records where a successful purchase came from, so fraud reviews can spot one session
+// buying across many events or a session whose address changes between purchases
+type PurchaseSignal struct {
+	ID         string      `json:"id"` // Assigned by the store; signals of an event sort by it
+	EventID    uuid.UUID   `json:"event_id"`
+	UserID     uuid.UUID   `json:"user_id"`
+	SessionID  string      `json:"session_id"`
+	TicketIDs  []uuid.UUID `json:"ticket_ids"`
+	RemoteAddr string      `json:"remote_addr,omitempty"`
+	UserAgent  string      `json:"user_agent,omitempty"`
+	At         time.Time   `json:"at"`
+}
@@ -1,6 +1,10 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,18 +12,32 @@ import (
 
 // Event represents a ticketing event
 type Event struct {
-	ID               uuid.UUID `json:"id"`
-	Name             string    `json:"name"`
-	Description      string    `json:"description"`
-	StartTime        time.Time `json:"start_time"`
-	EndTime          time.Time `json:"end_time"`
-	Venue            string    `json:"venue"`
-	Status           string    `json:"status"` // "active", "inactive", "sold_out"
-	TotalTickets     int       `json:"total_tickets"`
-	AvailableTickets int       `json:"available_tickets"`
-	IsSeatedEvent    bool      `json:"is_seated_event"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID                     uuid.UUID      `json:"id"`
+	Name                   string         `json:"name"`
+	Description            string         `json:"description"`
+	StartTime              time.Time      `json:"start_time"`
+	EndTime                time.Time      `json:"end_time"`
+	Venue                  string         `json:"venue"`
+	Status                 string         `json:"status"` // "active", "inactive", "sold_out"
+	TotalTickets           int            `json:"total_tickets"`
+	AvailableTickets       int            `json:"available_tickets"`
+	IsSeatedEvent          bool           `json:"is_seated_event"`
+	NumberedStanding       bool           `json:"numbered_standing"`                  // Issue sequential GA numbers to standing tickets
+	StandingPrice          int64          `json:"standing_price"`                     // Face value of a standing ticket in cents; events stored without it read as 0
+	StandingZones          []StandingZone `json:"standing_zones,omitempty"`           // Zones standing tickets are sold from, each with its own capacity; none sells from the whole event
+	MaxConcurrentPurchases int            `json:"max_concurrent_purchases,omitempty"` // Purchases allowed in flight at once; 0 means unlimited
+	MaxTicketsPerUser      int            `json:"max_tickets_per_user,omitempty"`     // Tickets one user may hold for the event; 0 uses the server default
+	QueueHighWaterMark     int            `json:"queue_high_water_mark,omitempty"`    // New queue joins are shed once this many users are waiting; 0 never sheds
+	ActiveHighWaterMark    int            `json:"active_high_water_mark,omitempty"`   // New queue joins are shed once this many sessions are active; 0 never sheds
+	Currency               string         `json:"currency"`                           // ISO 4217 code all prices of the event are in
+	ImageURL               string         `json:"image_url,omitempty"`                // Banner image shown on the event page
+	ThumbnailURL           string         `json:"thumbnail_url,omitempty"`            // Small image shown in catalog listings
+	SeatRanking            string         `json:"seat_ranking,omitempty"`             // How seats are picked for buyers; the server default when empty
+	LockGranularity        string         `json:"lock_granularity,omitempty"`         // What one purchase lock covers; a single seat when empty
+	AllowGuestCheckout     bool           `json:"allow_guest_checkout,omitempty"`     // Buyers without an account may purchase with a verified email
+	RefundPolicy           *RefundPolicy  `json:"refund_policy,omitempty"`            // Refund schedule for cancellations; the server default when nil
+	CreatedAt              time.Time      `json:"created_at"`
+	UpdatedAt              time.Time      `json:"updated_at"`
 }
 
 // EventStatus represents the status of an event
@@ -31,6 +49,86 @@ const (
 	EventStatusSoldOut  EventStatus = "sold_out"
 )
 
+// EventStatuses lists every valid event status
+var EventStatuses = []EventStatus{
+	EventStatusActive,
+	EventStatusInactive,
+	EventStatusSoldOut,
+}
+
+// IsValid checks if the status is a known event status
+func (s EventStatus) IsValid() bool {
+	for _, status := range EventStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// SeatRanking names the order in which seats are offered when the service picks them for a buyer
+type SeatRanking string
+
+const (
+	SeatRankingFrontToBack    SeatRanking = "front_to_back"
+	SeatRankingCenterOut      SeatRanking = "center_out"
+	SeatRankingPriceAscending SeatRanking = "price_ascending"
+)
+
+// SeatRankings lists every valid seat ranking
+var SeatRankings = []SeatRanking{
+	SeatRankingFrontToBack,
+	SeatRankingCenterOut,
+	SeatRankingPriceAscending,
+}
+
+// IsValid checks if the ranking is a known seat ranking
+func (r SeatRanking) IsValid() bool {
+	for _, ranking := range SeatRankings {
+		if r == ranking {
+			return true
+		}
+	}
+	return false
+}
+
+// LockGranularity names what one purchase lock covers. Finer locks let more purchases run at once at the
+// cost of more lock keys; coarser locks serialize purchases and are easier to reason about.
+type LockGranularity string
+
+const (
+	LockGranularitySeat    LockGranularity = "seat"
+	LockGranularitySection LockGranularity = "section"
+	LockGranularityEvent   LockGranularity = "event"
+)
+
+// LockGranularities lists every valid lock granularity
+var LockGranularities = []LockGranularity{
+	LockGranularitySeat,
+	LockGranularitySection,
+	LockGranularityEvent,
+}
+
+// IsValid checks if the granularity is a known lock granularity
+func (g LockGranularity) IsValid() bool {
+	for _, granularity := range LockGranularities {
+		if g == granularity {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidMediaURL checks if a link is an absolute http or https URL with a host; event media is optional, so
+// callers check for the empty string themselves
+func IsValidMediaURL(link string) bool {
+	u, err := url.ParseRequestURI(link)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
 // IsActive checks if the event is active
 func (e *Event) IsActive() bool {
 	return e.Status == string(EventStatusActive)
@@ -43,6 +141,45 @@ func (e *Event) IsSoldOut() bool {
 
 // CanPurchase checks if tickets can be purchased for this event
 func (e *Event) CanPurchase() bool {
-	now := time.Now()
+	return e.CanPurchaseAt(time.Now())
+}
+
+// CanPurchaseAt checks if tickets can be purchased for this event as of now
+func (e *Event) CanPurchaseAt(now time.Time) bool {
 	return e.IsActive() && !e.IsSoldOut() && now.Before(e.EndTime)
 }
+
+// Fingerprint identifies events that are likely duplicates of one another: the same name and venue, ignoring
+// case and spacing, starting on the same UTC day
+func (e *Event) Fingerprint() string {
+	key := normalizeEventText(e.Name) + "\x00" + normalizeEventText(e.Venue) + "\x00" + e.StartTime.UTC().Format(time.DateOnly)
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeEventText lowercases text and collapses its runs of whitespace
+func normalizeEventText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+// PercentSold returns the share of the event's tickets that are gone, in whole percent rounded down
+func (e *Event) PercentSold() int {
+	if e.TotalTickets <= 0 {
+		return 0
+	}
+	sold := e.TotalTickets - e.AvailableTickets
+	if sold < 0 {
+		sold = 0
+	}
+	return sold * 100 / e.TotalTickets
+}
+
+// CapacityAlert reports that an event's sales crossed a configured sold-share threshold
+type CapacityAlert struct {
+	EventID          uuid.UUID `json:"event_id"`
+	Threshold        int       `json:"threshold"`    // Percent sold that was crossed
+	PercentSold      int       `json:"percent_sold"` // Percent sold when the alert fired
+	TotalTickets     int       `json:"total_tickets"`
+	AvailableTickets int       `json:"available_tickets"`
+	CrossedAt        time.Time `json:"crossed_at"`
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventCanPurchaseAt(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		status    EventStatus
+		available int
+		endTime   time.Time
+		want      bool
+	}{
+		{name: "active with tickets", status: EventStatusActive, available: 10, endTime: now.Add(time.Hour), want: true},
+		{name: "active without tickets", status: EventStatusActive, available: 0, endTime: now.Add(time.Hour), want: false},
+		{name: "active and ended", status: EventStatusActive, available: 10, endTime: now.Add(-time.Hour), want: false},
+		{name: "active and ending now", status: EventStatusActive, available: 10, endTime: now, want: false},
+		{name: "inactive", status: EventStatusInactive, available: 10, endTime: now.Add(time.Hour), want: false},
+		{name: "sold out", status: EventStatusSoldOut, available: 10, endTime: now.Add(time.Hour), want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			event := &Event{Status: string(tc.status), AvailableTickets: tc.available, EndTime: tc.endTime}
+
+			if got := event.CanPurchaseAt(now); got != tc.want {
+				t.Errorf("CanPurchaseAt() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
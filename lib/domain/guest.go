@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// guestNamespace is the UUIDv5 namespace guest user IDs are derived in
+var guestNamespace = uuid.MustParse("5f0c7a52-2b1e-4c8e-9d0a-6b3f1e7c4a21")
+
+// NormalizeGuestEmail returns the canonical form of a guest's email, so differently cased or padded
+// spellings of one address identify the same guest
+func NormalizeGuestEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// GuestUserID derives the pseudo user ID a guest buying without an account is known by. The same email
+// always yields the same ID, so per-user limits apply to a guest as to any account.
+func GuestUserID(email string) uuid.UUID {
+	return uuid.NewSHA1(guestNamespace, []byte(NormalizeGuestEmail(email)))
+}
@@ -0,0 +1,46 @@
+package domain
+
+// PriceBreakdown itemizes what a ticket costs; all amounts are in cents
+type PriceBreakdown struct {
+	Face  int64 `json:"face"`  // Face value set by the event organizer
+	Fee   int64 `json:"fee"`   // Service fee
+	Tax   int64 `json:"tax"`   // Tax charged on the taxable amount
+	Total int64 `json:"total"` // What the buyer pays
+}
+
+// FeePolicy decides the service fee and tax charged on top of a face value.
+// Rates are in basis points (1/100 of a percent); the zero policy charges face value only.
+type FeePolicy struct {
+	FeeRateBasisPoints int64 // Percentage fee on the face value
+	FeeFlat            int64 // Fixed fee per ticket in cents
+	TaxRateBasisPoints int64 // Tax rate
+	TaxIncludesFee     bool  // Whether the fee is taxed along with the face value
+}
+
+// IsValid checks that the policy has no negative amounts
+func (p FeePolicy) IsValid() bool {
+	return p.FeeRateBasisPoints >= 0 && p.FeeFlat >= 0 && p.TaxRateBasisPoints >= 0
+}
+
+// Breakdown computes the fee, tax and total for a face value, rounding each percentage half up to the cent
+func (p FeePolicy) Breakdown(face int64) PriceBreakdown {
+	fee := applyBasisPoints(face, p.FeeRateBasisPoints) + p.FeeFlat
+
+	taxable := face
+	if p.TaxIncludesFee {
+		taxable += fee
+	}
+	tax := applyBasisPoints(taxable, p.TaxRateBasisPoints)
+
+	return PriceBreakdown{
+		Face:  face,
+		Fee:   fee,
+		Tax:   tax,
+		Total: face + fee + tax,
+	}
+}
+
+// applyBasisPoints returns amount * basisPoints / 10000 rounded half up
+func applyBasisPoints(amount, basisPoints int64) int64 {
+	return (amount*basisPoints + 5000) / 10000
+}
@@ -0,0 +1,90 @@
+package domain
+
+import "testing"
+
+func TestFeePolicyBreakdown(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy FeePolicy
+		face   int64
+		want   PriceBreakdown
+	}{
+		{name: "zero policy", policy: FeePolicy{}, face: 10000, want: PriceBreakdown{Face: 10000, Total: 10000}},
+		{name: "free ticket", policy: FeePolicy{FeeRateBasisPoints: 1000, TaxRateBasisPoints: 1000}, face: 0, want: PriceBreakdown{}},
+		{name: "percentage fee", policy: FeePolicy{FeeRateBasisPoints: 1000}, face: 10000, want: PriceBreakdown{Face: 10000, Fee: 1000, Total: 11000}},
+		{name: "flat fee", policy: FeePolicy{FeeFlat: 250}, face: 10000, want: PriceBreakdown{Face: 10000, Fee: 250, Total: 10250}},
+		{name: "percentage and flat fee", policy: FeePolicy{FeeRateBasisPoints: 500, FeeFlat: 100}, face: 10000, want: PriceBreakdown{Face: 10000, Fee: 600, Total: 10600}},
+		{name: "tax on face only", policy: FeePolicy{FeeRateBasisPoints: 1000, TaxRateBasisPoints: 1000}, face: 10000, want: PriceBreakdown{Face: 10000, Fee: 1000, Tax: 1000, Total: 12000}},
+		{name: "tax on face and fee", policy: FeePolicy{FeeRateBasisPoints: 1000, TaxRateBasisPoints: 1000, TaxIncludesFee: true}, face: 10000, want: PriceBreakdown{Face: 10000, Fee: 1000, Tax: 1100, Total: 12100}},
+		{name: "fee rounds half up", policy: FeePolicy{FeeRateBasisPoints: 250}, face: 1020, want: PriceBreakdown{Face: 1020, Fee: 26, Total: 1046}},
+		{name: "fee rounds down below half", policy: FeePolicy{FeeRateBasisPoints: 250}, face: 1019, want: PriceBreakdown{Face: 1019, Fee: 25, Total: 1044}},
+		{name: "tax rounds half up", policy: FeePolicy{TaxRateBasisPoints: 850}, face: 1000, want: PriceBreakdown{Face: 1000, Tax: 85, Total: 1085}},
+		{name: "tax of rounded fee", policy: FeePolicy{FeeRateBasisPoints: 333, TaxRateBasisPoints: 2000, TaxIncludesFee: true}, face: 999, want: PriceBreakdown{Face: 999, Fee: 33, Tax: 206, Total: 1238}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.policy.Breakdown(tc.face)
+			if got != tc.want {
+				t.Errorf("Breakdown(%d) = %+v, want %+v", tc.face, got, tc.want)
+			}
+			if got.Total != got.Face+got.Fee+got.Tax {
+				t.Errorf("total %d is not face + fee + tax of %+v", got.Total, got)
+			}
+		})
+	}
+}
+
+func TestFeePolicyIsValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy FeePolicy
+		want   bool
+	}{
+		{name: "zero", policy: FeePolicy{}, want: true},
+		{name: "all set", policy: FeePolicy{FeeRateBasisPoints: 1000, FeeFlat: 100, TaxRateBasisPoints: 800, TaxIncludesFee: true}, want: true},
+		{name: "negative fee rate", policy: FeePolicy{FeeRateBasisPoints: -1}, want: false},
+		{name: "negative flat fee", policy: FeePolicy{FeeFlat: -1}, want: false},
+		{name: "negative tax rate", policy: FeePolicy{TaxRateBasisPoints: -1}, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.policy.IsValid(); got != tc.want {
+				t.Errorf("IsValid() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTicketPriceBreakdown(t *testing.T) {
+	tests := []struct {
+		name   string
+		ticket *Ticket
+		want   PriceBreakdown
+	}{
+		{name: "recorded breakdown", ticket: &Ticket{Price: 12100, Breakdown: &PriceBreakdown{Face: 10000, Fee: 1000, Tax: 1100, Total: 12100}}, want: PriceBreakdown{Face: 10000, Fee: 1000, Tax: 1100, Total: 12100}},
+		{name: "sold before breakdowns", ticket: &Ticket{Price: 10000}, want: PriceBreakdown{Face: 10000, Total: 10000}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.ticket.PriceBreakdown(); got != tc.want {
+				t.Errorf("PriceBreakdown() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTicketSetPrice(t *testing.T) {
+	ticket := &Ticket{}
+	ticket.SetPrice(10000, FeePolicy{FeeRateBasisPoints: 1000, TaxRateBasisPoints: 1000, TaxIncludesFee: true})
+
+	want := PriceBreakdown{Face: 10000, Fee: 1000, Tax: 1100, Total: 12100}
+	if ticket.Breakdown == nil || *ticket.Breakdown != want {
+		t.Fatalf("breakdown = %+v, want %+v", ticket.Breakdown, want)
+	}
+	if ticket.Price != want.Total {
+		t.Errorf("price = %d, want the breakdown total %d", ticket.Price, want.Total)
+	}
+}
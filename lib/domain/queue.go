@@ -8,16 +8,21 @@ import (
 
 // QueueEntry represents a user's position in the ticketing queue
 type QueueEntry struct {
-	ID        uuid.UUID  `json:"id"`
-	EventID   uuid.UUID  `json:"event_id"`
-	UserID    uuid.UUID  `json:"user_id"`
-	Position  int        `json:"position"`
-	Status    string     `json:"status"` // "waiting", "active", "expired", "completed"
-	SessionID string     `json:"session_id"`
-	EnteredAt time.Time  `json:"entered_at"`
-	ExpiresAt *time.Time `json:"expires_at,omitempty"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID                    uuid.UUID  `json:"id"`
+	EventID               uuid.UUID  `json:"event_id"`
+	UserID                uuid.UUID  `json:"user_id"`
+	Position              int        `json:"position"`
+	Status                string     `json:"status"` // "waiting", "active", "expired", "completed"
+	SessionID             string     `json:"session_id"`
+	EnteredAt             time.Time  `json:"entered_at"`
+	ExpiresAt             *time.Time `json:"expires_at,omitempty"`
+	StartAt               *time.Time `json:"start_at,omitempty"`                // Staggered moment an activated user may begin purchasing
+	WaitroomToken         string     `json:"waitroom_token,omitempty"`          // Signed proof of activation presented at purchase
+	LastNotifiedThreshold int        `json:"last_notified_threshold,omitempty"` // Smallest position threshold the user was told about
+	ActivatedAt           *time.Time `json:"activated_at,omitempty"`            // When the entry first became active
+	RefreshCount          int        `json:"refresh_count,omitempty"`           // How many times the active session was extended
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
 }
 
 // QueueStatus represents the status of a queue entry
@@ -30,6 +35,46 @@ const (
 	QueueStatusCompleted QueueStatus = "completed"
 )
 
+// QueueStatuses lists every valid queue status
+var QueueStatuses = []QueueStatus{
+	QueueStatusWaiting,
+	QueueStatusActive,
+	QueueStatusExpired,
+	QueueStatusCompleted,
+}
+
+// queueStatusTransitions lists the statuses each status may move to; expired and completed are final
+var queueStatusTransitions = map[QueueStatus][]QueueStatus{
+	QueueStatusWaiting: {QueueStatusActive, QueueStatusExpired, QueueStatusCompleted},
+	QueueStatusActive:  {QueueStatusExpired, QueueStatusCompleted},
+}
+
+// IsValid checks if the status is a known queue status
+func (s QueueStatus) IsValid() bool {
+	for _, status := range QueueStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// CanTransitionTo checks if an entry in this status may move to next; keeping the same status is always allowed
+func (s QueueStatus) CanTransitionTo(next QueueStatus) bool {
+	if !s.IsValid() || !next.IsValid() {
+		return false
+	}
+	if s == next {
+		return true
+	}
+	for _, status := range queueStatusTransitions[s] {
+		if status == next {
+			return true
+		}
+	}
+	return false
+}
+
 // IsWaiting checks if the queue entry is waiting
 func (q *QueueEntry) IsWaiting() bool {
 	return q.Status == string(QueueStatusWaiting)
@@ -42,10 +87,23 @@ func (q *QueueEntry) IsActive() bool {
 
 // IsExpired checks if the queue entry has expired
 func (q *QueueEntry) IsExpired() bool {
+	return q.IsExpiredAt(time.Now())
+}
+
+// IsExpiredAt checks if the queue entry has expired as of now
+func (q *QueueEntry) IsExpiredAt(now time.Time) bool {
 	if q.ExpiresAt == nil {
 		return false
 	}
-	return time.Now().After(*q.ExpiresAt)
+	return now.After(*q.ExpiresAt)
+}
+
+// HasStarted checks if an active entry has reached its staggered start time
+func (q *QueueEntry) HasStarted(now time.Time) bool {
+	if q.StartAt == nil {
+		return true
+	}
+	return !now.Before(*q.StartAt)
 }
 
 // IsCompleted checks if the queue entry is completed
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FailedActionKind identifies which compensating or follow-up write failed
+type FailedActionKind string
+
+const (
+	FailedActionDecrementAvailable FailedActionKind = "decrement_available_tickets"
+	FailedActionIncrementAvailable FailedActionKind = "increment_available_tickets"
+	FailedActionReleaseSeat        FailedActionKind = "release_seat"
+	FailedActionMarkSeatSold       FailedActionKind = "mark_seat_sold"
+	FailedActionIncrementZone      FailedActionKind = "increment_zone_tickets"
+)
+
+// FailedAction is a dead-lettered inventory write that left seats or counters inconsistent.
+// It carries everything needed to replay the write later.
+type FailedAction struct {
+	ID            uuid.UUID  `json:"id"`
+	Kind          string     `json:"kind"`
+	EventID       uuid.UUID  `json:"event_id"`
+	SeatID        *uuid.UUID `json:"seat_id,omitempty"`
+	TicketID      *uuid.UUID `json:"ticket_id,omitempty"`
+	Quantity      int        `json:"quantity,omitempty"`
+	Zone          string     `json:"zone,omitempty"` // Standing zone whose counter the write adjusts
+	Reason        string     `json:"reason"`         // What the service was doing when the write failed
+	LastError     string     `json:"last_error"`
+	Attempts      int        `json:"attempts"`
+	CreatedAt     time.Time  `json:"created_at"`
+	LastAttemptAt time.Time  `json:"last_attempt_at"`
+}
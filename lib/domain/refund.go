@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"time"
+)
+
+// RefundTier refunds a share of a ticket's price when it is cancelled at least MinNoticeHours before the event starts
+type RefundTier struct {
+	MinNoticeHours    int   `json:"min_notice_hours"`
+	RefundBasisPoints int64 `json:"refund_basis_points"` // Share of the price refunded, in basis points
+}
+
+// RefundPolicy is a schedule of refund tiers. A cancellation gets the tier with the longest notice it still
+// meets and nothing once it meets none; the zero policy refunds nothing.
+type RefundPolicy struct {
+	Tiers []RefundTier `json:"tiers"`
+}
+
+// DefaultRefundPolicy refunds everything a week or more out and half until two hours before the event
+func DefaultRefundPolicy() RefundPolicy {
+	return RefundPolicy{
+		Tiers: []RefundTier{
+			{MinNoticeHours: 7 * 24, RefundBasisPoints: 10000},
+			{MinNoticeHours: 2, RefundBasisPoints: 5000},
+		},
+	}
+}
+
+// IsValid checks that every tier has a non-negative notice, refunds between 0 and 100% and a notice of its own
+func (p RefundPolicy) IsValid() bool {
+	seen := make(map[int]bool, len(p.Tiers))
+	for _, tier := range p.Tiers {
+		if tier.MinNoticeHours < 0 || tier.RefundBasisPoints < 0 || tier.RefundBasisPoints > 10000 {
+			return false
+		}
+		if seen[tier.MinNoticeHours] {
+			return false
+		}
+		seen[tier.MinNoticeHours] = true
+	}
+	return true
+}
+
+// RefundBasisPoints returns the share of the price refunded for a cancellation at cancelledAt of an event
+// starting at startTime
+func (p RefundPolicy) RefundBasisPoints(startTime, cancelledAt time.Time) int64 {
+	notice := startTime.Sub(cancelledAt)
+
+	best := -1
+	var basisPoints int64
+	for _, tier := range p.Tiers {
+		if notice >= time.Duration(tier.MinNoticeHours)*time.Hour && tier.MinNoticeHours > best {
+			best = tier.MinNoticeHours
+			basisPoints = tier.RefundBasisPoints
+		}
+	}
+	return basisPoints
+}
+
+// Refund returns the amount of price refunded for a cancellation at cancelledAt, rounded half up to the cent
+func (p RefundPolicy) Refund(price int64, startTime, cancelledAt time.Time) int64 {
+	return applyBasisPoints(price, p.RefundBasisPoints(startTime, cancelledAt))
+}
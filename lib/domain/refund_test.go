@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRefundPolicyRefund(t *testing.T) {
+	start := time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		policy RefundPolicy
+		notice time.Duration
+		price  int64
+		want   int64
+	}{
+		{name: "default well ahead", policy: DefaultRefundPolicy(), notice: 30 * 24 * time.Hour, price: 10000, want: 10000},
+		{name: "default exactly a week out", policy: DefaultRefundPolicy(), notice: 7 * 24 * time.Hour, price: 10000, want: 10000},
+		{name: "default just under a week out", policy: DefaultRefundPolicy(), notice: 7*24*time.Hour - time.Second, price: 10000, want: 5000},
+		{name: "default exactly two hours out", policy: DefaultRefundPolicy(), notice: 2 * time.Hour, price: 10000, want: 5000},
+		{name: "default just under two hours out", policy: DefaultRefundPolicy(), notice: 2*time.Hour - time.Second, price: 10000, want: 0},
+		{name: "default after the start", policy: DefaultRefundPolicy(), notice: -time.Hour, price: 10000, want: 0},
+		{name: "half refund rounds half up", policy: DefaultRefundPolicy(), notice: 3 * time.Hour, price: 10001, want: 5001},
+		{name: "zero policy", policy: RefundPolicy{}, notice: 30 * 24 * time.Hour, price: 10000, want: 0},
+		{
+			name:   "tiers out of order",
+			policy: RefundPolicy{Tiers: []RefundTier{{MinNoticeHours: 1, RefundBasisPoints: 2500}, {MinNoticeHours: 48, RefundBasisPoints: 9000}, {MinNoticeHours: 24, RefundBasisPoints: 7500}}},
+			notice: 30 * time.Hour,
+			price:  10000,
+			want:   7500,
+		},
+		{
+			name:   "zero notice tier after the start",
+			policy: RefundPolicy{Tiers: []RefundTier{{MinNoticeHours: 0, RefundBasisPoints: 1000}}},
+			notice: -time.Minute,
+			price:  10000,
+			want:   0,
+		},
+		{
+			name:   "zero notice tier up to the start",
+			policy: RefundPolicy{Tiers: []RefundTier{{MinNoticeHours: 0, RefundBasisPoints: 1000}}},
+			notice: 0,
+			price:  10000,
+			want:   1000,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.policy.Refund(tc.price, start, start.Add(-tc.notice)); got != tc.want {
+				t.Errorf("Refund(%d) with %s notice = %d, want %d", tc.price, tc.notice, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRefundPolicyIsValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RefundPolicy
+		want   bool
+	}{
+		{name: "default", policy: DefaultRefundPolicy(), want: true},
+		{name: "zero", policy: RefundPolicy{}, want: true},
+		{name: "full and nothing", policy: RefundPolicy{Tiers: []RefundTier{{MinNoticeHours: 0, RefundBasisPoints: 0}, {MinNoticeHours: 1, RefundBasisPoints: 10000}}}, want: true},
+		{name: "negative notice", policy: RefundPolicy{Tiers: []RefundTier{{MinNoticeHours: -1, RefundBasisPoints: 5000}}}, want: false},
+		{name: "negative refund", policy: RefundPolicy{Tiers: []RefundTier{{MinNoticeHours: 1, RefundBasisPoints: -1}}}, want: false},
+		{name: "over 100%", policy: RefundPolicy{Tiers: []RefundTier{{MinNoticeHours: 1, RefundBasisPoints: 10001}}}, want: false},
+		{name: "repeated notice", policy: RefundPolicy{Tiers: []RefundTier{{MinNoticeHours: 24, RefundBasisPoints: 5000}, {MinNoticeHours: 24, RefundBasisPoints: 7500}}}, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.policy.IsValid(); got != tc.want {
+				t.Errorf("IsValid() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResaleListing is a confirmed ticket offered by its holder to other users
+type ResaleListing struct {
+	ID        uuid.UUID  `json:"id"`
+	TicketID  uuid.UUID  `json:"ticket_id"`
+	EventID   uuid.UUID  `json:"event_id"`
+	SeatID    *uuid.UUID `json:"seat_id,omitempty"`
+	SellerID  uuid.UUID  `json:"seller_id"`
+	BuyerID   *uuid.UUID `json:"buyer_id,omitempty"`
+	Price     int64      `json:"price"` // Asking price in cents, capped relative to the ticket's face value
+	Currency  string     `json:"currency,omitempty"`
+	Status    string     `json:"status"`               // "listed", "sold", "cancelled"
+	PaymentID string     `json:"payment_id,omitempty"` // Buyer's charge, recorded once the sale settles
+	SoldAt    *time.Time `json:"sold_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// ResaleStatus represents the status of a resale listing
+type ResaleStatus string
+
+const (
+	ResaleStatusListed    ResaleStatus = "listed"
+	ResaleStatusSold      ResaleStatus = "sold"
+	ResaleStatusCancelled ResaleStatus = "cancelled"
+)
+
+// IsListed checks if the listing is still open for purchase
+func (l *ResaleListing) IsListed() bool {
+	return l.Status == string(ResaleStatusListed)
+}
+
+// IsSold checks if the listing has been bought
+func (l *ResaleListing) IsSold() bool {
+	return l.Status == string(ResaleStatusSold)
+}
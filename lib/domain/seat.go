@@ -8,13 +8,24 @@ import (
 
 // Seat represents a seat in a venue
 type Seat struct {
-	ID        uuid.UUID `json:"id"`
-	EventID   uuid.UUID `json:"event_id"`
-	Section   string    `json:"section"`
-	Row       string    `json:"row"`
-	Number    string    `json:"number"`
-	Price     int64     `json:"price"`  // Price in cents
-	Status    string    `json:"status"` // "available", "reserved", "sold"
+	ID      uuid.UUID `json:"id"`
+	EventID uuid.UUID `json:"event_id"`
+	Section string    `json:"section"`
+	Row     string    `json:"row"`
+	Number  string    `json:"number"`
+	X       int       `json:"x"`      // Horizontal position on the seat map
+	Y       int       `json:"y"`      // Vertical position on the seat map
+	Price   int64     `json:"price"`  // Price in cents
+	Status  string    `json:"status"` // "available", "held", "reserved", "sold"
+
+	// IsAccessible marks a wheelchair or otherwise accessible seat that general buyers can't book
+	IsAccessible bool `json:"is_accessible,omitempty"`
+	// CompanionSeatID links an accessible seat and its companion seat to each other; the pair is booked together
+	CompanionSeatID *uuid.UUID `json:"companion_seat_id,omitempty"`
+
+	// Version is bumped on every write; an update only lands when it was made from the stored version
+	Version int64 `json:"version"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -24,15 +35,27 @@ type SeatStatus string
 
 const (
 	SeatStatusAvailable SeatStatus = "available"
+	SeatStatusHeld      SeatStatus = "held"
 	SeatStatusReserved  SeatStatus = "reserved"
 	SeatStatusSold      SeatStatus = "sold"
 )
 
+// Touch records a write to the seat, bumping its version and update time
+func (s *Seat) Touch(at time.Time) {
+	s.Version++
+	s.UpdatedAt = at
+}
+
 // IsAvailable checks if the seat is available
 func (s *Seat) IsAvailable() bool {
 	return s.Status == string(SeatStatusAvailable)
 }
 
+// IsHeld checks if the seat is held by a user who has not bought it yet
+func (s *Seat) IsHeld() bool {
+	return s.Status == string(SeatStatusHeld)
+}
+
 // IsReserved checks if the seat is reserved
 func (s *Seat) IsReserved() bool {
 	return s.Status == string(SeatStatusReserved)
@@ -43,6 +66,26 @@ func (s *Seat) IsSold() bool {
 	return s.Status == string(SeatStatusSold)
 }
 
+// CanBeReserved checks if the seat may be reserved for a purchase
+func (s *Seat) CanBeReserved() bool {
+	return s.IsAvailable()
+}
+
+// CanBeReleased checks if the seat is reserved and may be returned to the available pool
+func (s *Seat) CanBeReleased() bool {
+	return s.IsReserved()
+}
+
+// IsCompanion checks if the seat is the companion of an accessible seat
+func (s *Seat) IsCompanion() bool {
+	return !s.IsAccessible && s.CompanionSeatID != nil
+}
+
+// IsRestricted checks if the seat is held back for accessible bookings
+func (s *Seat) IsRestricted() bool {
+	return s.IsAccessible || s.IsCompanion()
+}
+
 // GetDisplayName returns a human-readable seat identifier
 func (s *Seat) GetDisplayName() string {
 	if s.Row != "" && s.Number != "" {
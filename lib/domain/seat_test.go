@@ -0,0 +1,30 @@
+package domain
+
+import "testing"
+
+func TestSeatTransitions(t *testing.T) {
+	tests := []struct {
+		status       SeatStatus
+		wantReserved bool
+		wantReleased bool
+	}{
+		{status: SeatStatusAvailable, wantReserved: true, wantReleased: false},
+		{status: SeatStatusHeld, wantReserved: false, wantReleased: false},
+		{status: SeatStatusReserved, wantReserved: false, wantReleased: true},
+		{status: SeatStatusSold, wantReserved: false, wantReleased: false},
+		{status: SeatStatus("blocked"), wantReserved: false, wantReleased: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.status), func(t *testing.T) {
+			seat := &Seat{Status: string(tc.status)}
+
+			if got := seat.CanBeReserved(); got != tc.wantReserved {
+				t.Errorf("CanBeReserved() = %v, want %v", got, tc.wantReserved)
+			}
+			if got := seat.CanBeReleased(); got != tc.wantReleased {
+				t.Errorf("CanBeReleased() = %v, want %v", got, tc.wantReleased)
+			}
+		})
+	}
+}
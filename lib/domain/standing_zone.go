@@ -0,0 +1,43 @@
+package domain
+
+// MaxStandingZoneNameLength bounds a standing zone's name, which becomes part of its counter key
+const MaxStandingZoneNameLength = 64
+
+// StandingZone is a named area of a standing event (floor, balcony) with a capacity of its own
+type StandingZone struct {
+	Name     string `json:"name"`
+	Capacity int    `json:"capacity"`
+}
+
+// HasStandingZones checks if the event's standing tickets are sold per zone
+func (e *Event) HasStandingZones() bool {
+	return len(e.StandingZones) > 0
+}
+
+// StandingZone returns the event's zone with the given name
+func (e *Event) StandingZone(name string) (StandingZone, bool) {
+	for _, zone := range e.StandingZones {
+		if zone.Name == name {
+			return zone, true
+		}
+	}
+	return StandingZone{}, false
+}
+
+// StandingZonesValid checks that every zone has a name of its own and a positive capacity, and that the
+// capacities add up to the event's total tickets
+func (e *Event) StandingZonesValid() bool {
+	seen := make(map[string]bool, len(e.StandingZones))
+	capacity := 0
+	for _, zone := range e.StandingZones {
+		if zone.Name == "" || len(zone.Name) > MaxStandingZoneNameLength || zone.Capacity <= 0 {
+			return false
+		}
+		if seen[zone.Name] {
+			return false
+		}
+		seen[zone.Name] = true
+		capacity += zone.Capacity
+	}
+	return capacity == e.TotalTickets
+}
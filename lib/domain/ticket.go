@@ -8,16 +8,28 @@ import (
 
 // Ticket represents a purchased ticket
 type Ticket struct {
-	ID        uuid.UUID  `json:"id"`
-	EventID   uuid.UUID  `json:"event_id"`
-	SeatID    *uuid.UUID `json:"seat_id,omitempty"` // nil for standing events
-	UserID    uuid.UUID  `json:"user_id"`
-	Price     int64      `json:"price"`  // Price in cents
-	Status    string     `json:"status"` // "reserved", "confirmed", "cancelled"
-	IssuedAt  time.Time  `json:"issued_at"`
-	ExpiresAt *time.Time `json:"expires_at,omitempty"` // For temporary reservations
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID                uuid.UUID       `json:"id"`
+	EventID           uuid.UUID       `json:"event_id"`
+	SeatID            *uuid.UUID      `json:"seat_id,omitempty"` // nil for standing events
+	UserID            uuid.UUID       `json:"user_id"`
+	GuestEmail        string          `json:"guest_email,omitempty"`         // Verified email of a guest buyer, whose UserID is derived from it
+	CallbackURL       string          `json:"callback_url,omitempty"`        // Buyer's endpoint POSTed to when the ticket is confirmed
+	Price             int64           `json:"price"`                         // Total price in cents
+	Breakdown         *PriceBreakdown `json:"breakdown,omitempty"`           // Face value, fee and tax making up Price, fixed at purchase
+	Currency          string          `json:"currency,omitempty"`            // ISO 4217 code inherited from the event at purchase
+	Status            string          `json:"status"`                        // "reserved", "confirmed", "cancelled"
+	GANumber          *int64          `json:"ga_number,omitempty"`           // Sequential admission number for numbered standing tickets
+	Zone              string          `json:"zone,omitempty"`                // Standing zone the ticket admits to, for events sold per zone
+	CompanionTicketID *uuid.UUID      `json:"companion_ticket_id,omitempty"` // Ticket for the companion seat booked with an accessible seat
+	AccessToken       string          `json:"access_token,omitempty"`        // Opaque token scanned at the gate, issued on confirmation
+	CheckedInAt       *time.Time      `json:"checked_in_at,omitempty"`       // When the ticket was admitted at the venue
+	ConfirmedAt       *time.Time      `json:"confirmed_at,omitempty"`
+	CancelledAt       *time.Time      `json:"cancelled_at,omitempty"`
+	RefundAmount      int64           `json:"refund_amount,omitempty"` // Refund owed in cents, priced when a confirmed ticket is cancelled
+	IssuedAt          time.Time       `json:"issued_at"`
+	ExpiresAt         *time.Time      `json:"expires_at,omitempty"` // For temporary reservations
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
 }
 
 // TicketStatus represents the status of a ticket
@@ -29,12 +41,54 @@ const (
 	TicketStatusCancelled TicketStatus = "cancelled"
 )
 
+// IsValid reports whether the status is one a ticket can be in
+func (s TicketStatus) IsValid() bool {
+	switch s {
+	case TicketStatusReserved, TicketStatusConfirmed, TicketStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// SetStatus changes the ticket status and records when it was confirmed or cancelled
+func (t *Ticket) SetStatus(status string, at time.Time) {
+	t.Status = status
+
+	switch TicketStatus(status) {
+	case TicketStatusConfirmed:
+		t.ConfirmedAt = &at
+	case TicketStatusCancelled:
+		t.CancelledAt = &at
+	}
+}
+
+// SetPrice prices the ticket at a face value under a fee policy, storing the breakdown and its total
+func (t *Ticket) SetPrice(face int64, policy FeePolicy) {
+	breakdown := policy.Breakdown(face)
+	t.Breakdown = &breakdown
+	t.Price = breakdown.Total
+}
+
+// PriceBreakdown returns the ticket's price breakdown; tickets sold before breakdowns were recorded
+// are reported as face value only
+func (t *Ticket) PriceBreakdown() PriceBreakdown {
+	if t.Breakdown != nil {
+		return *t.Breakdown
+	}
+	return PriceBreakdown{Face: t.Price, Total: t.Price}
+}
+
 // IsExpired checks if the ticket reservation has expired
 func (t *Ticket) IsExpired() bool {
+	return t.IsExpiredAt(time.Now())
+}
+
+// IsExpiredAt checks if the ticket reservation has expired as of now
+func (t *Ticket) IsExpiredAt(now time.Time) bool {
 	if t.ExpiresAt == nil {
 		return false
 	}
-	return time.Now().After(*t.ExpiresAt)
+	return now.After(*t.ExpiresAt)
 }
 
 // IsConfirmed checks if the ticket is confirmed
@@ -47,7 +101,32 @@ func (t *Ticket) IsReserved() bool {
 	return t.Status == string(TicketStatusReserved)
 }
 
+// IsCheckedIn checks if the ticket has been admitted at the venue
+func (t *Ticket) IsCheckedIn() bool {
+	return t.CheckedInAt != nil
+}
+
+// IsAdmissible checks if the ticket may still be used to enter the venue
+func (t *Ticket) IsAdmissible() bool {
+	return t.IsConfirmed() && !t.IsCheckedIn()
+}
+
 // IsCancelled checks if the ticket is cancelled
 func (t *Ticket) IsCancelled() bool {
 	return t.Status == string(TicketStatusCancelled)
 }
+
+// CanBeConfirmed checks if the ticket is a reservation that has not expired and may be confirmed
+func (t *Ticket) CanBeConfirmed() bool {
+	return t.CanBeConfirmedAt(time.Now())
+}
+
+// CanBeConfirmedAt checks if the ticket is a reservation that has not expired as of now and may be confirmed
+func (t *Ticket) CanBeConfirmedAt(now time.Time) bool {
+	return t.IsReserved() && !t.IsExpiredAt(now)
+}
+
+// CanBeCancelled checks if the ticket may be cancelled; reserved and confirmed tickets can, cancelled ones can't
+func (t *Ticket) CanBeCancelled() bool {
+	return t.IsReserved() || t.IsConfirmed()
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTicketTransitions(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Minute)
+	earlier := now.Add(-time.Minute)
+
+	tests := []struct {
+		name          string
+		status        TicketStatus
+		expiresAt     *time.Time
+		wantConfirmed bool
+		wantCancelled bool
+	}{
+		{name: "live reservation", status: TicketStatusReserved, expiresAt: &later, wantConfirmed: true, wantCancelled: true},
+		{name: "reservation without expiry", status: TicketStatusReserved, wantConfirmed: true, wantCancelled: true},
+		{name: "reservation expiring now", status: TicketStatusReserved, expiresAt: &now, wantConfirmed: true, wantCancelled: true},
+		{name: "expired reservation", status: TicketStatusReserved, expiresAt: &earlier, wantConfirmed: false, wantCancelled: true},
+		{name: "confirmed", status: TicketStatusConfirmed, wantConfirmed: false, wantCancelled: true},
+		{name: "confirmed past its reservation expiry", status: TicketStatusConfirmed, expiresAt: &earlier, wantConfirmed: false, wantCancelled: true},
+		{name: "cancelled", status: TicketStatusCancelled, wantConfirmed: false, wantCancelled: false},
+		{name: "cancelled before expiry", status: TicketStatusCancelled, expiresAt: &later, wantConfirmed: false, wantCancelled: false},
+		{name: "unknown status", status: TicketStatus("refunded"), expiresAt: &later, wantConfirmed: false, wantCancelled: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ticket := &Ticket{Status: string(tc.status), ExpiresAt: tc.expiresAt}
+
+			if got := ticket.CanBeConfirmedAt(now); got != tc.wantConfirmed {
+				t.Errorf("CanBeConfirmedAt() = %v, want %v", got, tc.wantConfirmed)
+			}
+			if got := ticket.CanBeCancelled(); got != tc.wantCancelled {
+				t.Errorf("CanBeCancelled() = %v, want %v", got, tc.wantCancelled)
+			}
+		})
+	}
+}
+
+func TestTicketCanBeConfirmedUsesWallClock(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+
+	if (&Ticket{Status: string(TicketStatusReserved), ExpiresAt: &past}).CanBeConfirmed() {
+		t.Error("CanBeConfirmed() = true for a reservation that lapsed a minute ago")
+	}
+	if !(&Ticket{Status: string(TicketStatusReserved), ExpiresAt: &future}).CanBeConfirmed() {
+		t.Error("CanBeConfirmed() = false for a reservation lapsing in an hour")
+	}
+}
+
+func TestTicketStatusIsValid(t *testing.T) {
+	tests := []struct {
+		status TicketStatus
+		want   bool
+	}{
+		{TicketStatusReserved, true},
+		{TicketStatusConfirmed, true},
+		{TicketStatusCancelled, true},
+		{TicketStatus(""), false},
+		{TicketStatus("Reserved"), false},
+		{TicketStatus("refunded"), false},
+	}
+
+	for _, tc := range tests {
+		if got := tc.status.IsValid(); got != tc.want {
+			t.Errorf("TicketStatus(%q).IsValid() = %v, want %v", tc.status, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// AccessLogFilter selects access log entries
+type AccessLogFilter struct {
+	UserID *uuid.UUID // Only entries for this user; nil matches every user
+	Limit  int        // Maximum number of entries returned
+}
+
+// AuditRepository defines the interface for the bounded request-level access log and the per-event
+// purchase signals kept for fraud review
+type AuditRepository interface {
+	// AppendAccess records an access, evicting the oldest entries once the log is full
+	AppendAccess(ctx context.Context, entry *domain.AccessLogEntry) error
+
+	// ListAccess retrieves matching entries newest first
+	ListAccess(ctx context.Context, filter AccessLogFilter) ([]*domain.AccessLogEntry, error)
+
+	// AppendPurchaseSignal records a purchase's signals, evicting the event's oldest signals once its log is full
+	AppendPurchaseSignal(ctx context.Context, signal *domain.PurchaseSignal) error
+
+	// ListPurchaseSignals retrieves up to limit of an event's purchase signals newest first
+	ListPurchaseSignals(ctx context.Context, eventID uuid.UUID, limit int) ([]*domain.PurchaseSignal, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// DeadLetterRepository defines the interface for durably storing failed inventory writes
+type DeadLetterRepository interface {
+	// Add records a failed action
+	Add(ctx context.Context, action *domain.FailedAction) error
+
+	// GetByID retrieves a failed action by its ID
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.FailedAction, error)
+
+	// List retrieves failed actions oldest first, along with the total count
+	List(ctx context.Context, offset, limit int) ([]*domain.FailedAction, int, error)
+
+	// Update persists a new attempt count and error for a failed action
+	Update(ctx context.Context, action *domain.FailedAction) error
+
+	// Remove deletes a failed action once it has been replayed
+	Remove(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,68 @@
+package repository
+
+import "errors"
+
+var (
+	// ErrSeatNotFound is returned when a seat does not exist
+	ErrSeatNotFound = errors.New("seat not found")
+
+	// ErrSeatNotAvailable is returned when a seat can't be held because it is not available
+	ErrSeatNotAvailable = errors.New("seat is not available")
+
+	// ErrSeatVersionConflict is returned when a seat is updated from a copy that another write has since replaced
+	ErrSeatVersionConflict = errors.New("seat was changed by another update")
+
+	// ErrSeatHoldNotFound is returned when a seat hold has expired or belongs to another user
+	ErrSeatHoldNotFound = errors.New("seat hold not found")
+
+	// ErrSeatAlreadyTicketed is returned when a ticket is created for a seat that is already mapped to another ticket
+	ErrSeatAlreadyTicketed = errors.New("seat is already mapped to a ticket")
+
+	// ErrStandingZoneNotFound is returned when a standing zone has no ticket counter
+	ErrStandingZoneNotFound = errors.New("standing zone not found")
+
+	// ErrStandingZoneSoldOut is returned when a standing zone has fewer tickets left than requested
+	ErrStandingZoneSoldOut = errors.New("standing zone is sold out")
+
+	// ErrUserTicketLimitReached is returned when counting more tickets for a user would take them past their event limit
+	ErrUserTicketLimitReached = errors.New("user ticket limit reached")
+
+	// ErrConfirmationTokenNotFound is returned when a confirmation token is unknown, already used or expired
+	ErrConfirmationTokenNotFound = errors.New("confirmation token not found")
+
+	// ErrFailedActionNotFound is returned when a dead-lettered action does not exist
+	ErrFailedActionNotFound = errors.New("failed action not found")
+
+	// ErrQueueEntryNotFound is returned when no queue entry exists for an entry ID
+	ErrQueueEntryNotFound = errors.New("queue entry not found")
+
+	// ErrInvalidQueueStatus is returned when a queue entry is written with a status that is not a QueueStatus
+	ErrInvalidQueueStatus = errors.New("invalid queue status")
+
+	// ErrQueueStatusTransition is returned when a queue entry can't move from its current status to the requested one
+	ErrQueueStatusTransition = errors.New("queue status transition not allowed")
+
+	// ErrTicketNotReserved is returned when a reservation is cancelled after it was confirmed or cancelled
+	ErrTicketNotReserved = errors.New("ticket is not reserved")
+
+	// ErrTicketNotConfirmed is returned when a ticket that was never confirmed, or was cancelled, is checked in
+	ErrTicketNotConfirmed = errors.New("ticket is not confirmed")
+
+	// ErrTicketAlreadyCheckedIn is returned when a ticket is checked in a second time
+	ErrTicketAlreadyCheckedIn = errors.New("ticket is already checked in")
+
+	// ErrTicketAlreadyCancelled is returned when a ticket is cancelled a second time
+	ErrTicketAlreadyCancelled = errors.New("ticket is already cancelled")
+
+	// ErrTicketOwnerMismatch is returned when a ticket is transferred by someone who does not hold it
+	ErrTicketOwnerMismatch = errors.New("ticket is not held by this user")
+
+	// ErrTicketAlreadyListed is returned when a ticket that already has an open resale listing is listed again
+	ErrTicketAlreadyListed = errors.New("ticket is already listed for resale")
+
+	// ErrResaleListingNotFound is returned when a resale listing does not exist
+	ErrResaleListingNotFound = errors.New("resale listing not found")
+
+	// ErrResaleListingNotAvailable is returned when a resale listing is no longer open
+	ErrResaleListingNotAvailable = errors.New("resale listing is not available")
+)
@@ -15,18 +15,27 @@ type EventRepository interface {
 	// GetByID retrieves an event by its ID
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Event, error)
 
+	// GetByIDs retrieves the events with the given IDs in request order, skipping any that don't exist
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Event, error)
+
 	// Update updates an existing event
 	Update(ctx context.Context, event *domain.Event) error
 
 	// Delete deletes an event by its ID
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// GetIDsByFingerprint retrieves the IDs of the events whose Fingerprint is the given one
+	GetIDsByFingerprint(ctx context.Context, fingerprint string) ([]uuid.UUID, error)
+
 	// List retrieves all events with pagination
 	List(ctx context.Context, offset, limit int) ([]*domain.Event, error)
 
 	// GetActiveEvents retrieves all active events
 	GetActiveEvents(ctx context.Context) ([]*domain.Event, error)
 
+	// GetByStatus retrieves events with the given status with pagination, along with the total number of matches
+	GetByStatus(ctx context.Context, status string, offset, limit int) ([]*domain.Event, int, error)
+
 	// UpdateAvailableTickets updates the available ticket count
 	UpdateAvailableTickets(ctx context.Context, eventID uuid.UUID, count int) error
 
@@ -35,4 +44,23 @@ type EventRepository interface {
 
 	// IncrementAvailableTickets increments available tickets atomically
 	IncrementAvailableTickets(ctx context.Context, eventID uuid.UUID, count int) error
+
+	// InitZoneTickets seeds a standing zone's ticket counter with count; a zone that already has a counter
+	// keeps it, so re-saving an event never restocks tickets that were sold
+	InitZoneTickets(ctx context.Context, eventID uuid.UUID, zone string, count int) error
+
+	// DecrementZoneTickets takes tickets off a standing zone's counter atomically, failing with
+	// ErrStandingZoneSoldOut when fewer are left and ErrStandingZoneNotFound when the zone has no counter
+	DecrementZoneTickets(ctx context.Context, eventID uuid.UUID, zone string, count int) error
+
+	// IncrementZoneTickets returns tickets to a standing zone's counter atomically
+	IncrementZoneTickets(ctx context.Context, eventID uuid.UUID, zone string, count int) error
+
+	// GetZoneTickets retrieves how many tickets are left in each of the given zones; zones without a counter
+	// are left out
+	GetZoneTickets(ctx context.Context, eventID uuid.UUID, zones []string) (map[string]int, error)
+
+	// MarkCapacityAlert records that the event's sold-share alert for a threshold (percent sold) fired;
+	// it reports true only for the first caller, so each threshold fires once per event
+	MarkCapacityAlert(ctx context.Context, eventID uuid.UUID, threshold int) (bool, error)
 }
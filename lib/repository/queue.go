@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/snowmerak/ticketing/lib/domain"
@@ -15,26 +16,67 @@ type QueueRepository interface {
 	// GetPosition retrieves a user's position in the queue
 	GetPosition(ctx context.Context, eventID, userID uuid.UUID) (*domain.QueueEntry, error)
 
+	// GetPositionInList returns a user's live 1-based index in the event's queue list, counting the active user
+	// at its head, or 0 when the user is not in the list. Unlike an entry's Position, fixed when the user
+	// joined, it moves up as users ahead are activated or leave
+	GetPositionInList(ctx context.Context, eventID, userID uuid.UUID) (int, error)
+
 	// GetBySessionID retrieves queue entry by session ID
 	GetBySessionID(ctx context.Context, sessionID string) (*domain.QueueEntry, error)
 
+	// GetByID retrieves a queue entry by its entry ID
+	GetByID(ctx context.Context, entryID uuid.UUID) (*domain.QueueEntry, error)
+
 	// GetNextInQueue retrieves the next user in queue for an event
 	GetNextInQueue(ctx context.Context, eventID uuid.UUID) (*domain.QueueEntry, error)
 
 	// GetQueueLength retrieves the current queue length for an event
 	GetQueueLength(ctx context.Context, eventID uuid.UUID) (int, error)
 
-	// UpdateStatus updates the status of a queue entry
+	// GetQueueHead retrieves up to count entries from the front of the queue in order
+	GetQueueHead(ctx context.Context, eventID uuid.UUID, count int) ([]*domain.QueueEntry, error)
+
+	// Update persists changes to an existing queue entry
+	Update(ctx context.Context, entry *domain.QueueEntry) error
+
+	// UpdateExpiry moves the expiry of the queue entry behind a session, stamping the entry as updated at now;
+	// the stored entry and session expire with it, so stale sessions are reaped. It fails with
+	// ErrQueueEntryNotFound for an unknown session
+	UpdateExpiry(ctx context.Context, sessionID string, expiresAt, now time.Time) error
+
+	// GetByUserID retrieves every queue entry of a user across events
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.QueueEntry, error)
+
+	// RemoveUser removes a user's entry, queue slot and session from an event queue
+	RemoveUser(ctx context.Context, eventID, userID uuid.UUID) error
+
+	// UpdateStatus moves a queue entry to a new status, rejecting unknown statuses and disallowed transitions
 	UpdateStatus(ctx context.Context, entryID uuid.UUID, status string) error
 
 	// ActivateNext activates the next user in queue
 	ActivateNext(ctx context.Context, eventID uuid.UUID) (*domain.QueueEntry, error)
 
-	// RemoveFromQueue removes a user from the queue
+	// ActivateAll activates the waiting users of an event in queue order in one step, with sessions expiring at
+	// expiresAt. At most limit users are activated (0 means everyone); once no one is left waiting the queue
+	// list is cleared, otherwise the last activated user stays at its head as ActivateNext expects
+	ActivateAll(ctx context.Context, eventID uuid.UUID, expiresAt time.Time, limit int) ([]*domain.QueueEntry, error)
+
+	// RemoveFromQueue removes a user from the queue, resolving the entry through its entry ID and deleting its
+	// queue slot, entry and session. Users behind them keep their stored positions; their live ones move up
 	RemoveFromQueue(ctx context.Context, entryID uuid.UUID) error
 
-	// GetActiveEntries retrieves all active queue entries for an event
-	GetActiveEntries(ctx context.Context, eventID uuid.UUID) ([]*domain.QueueEntry, error)
+	// GetActiveCount counts users holding an active session for an event that is unexpired as of now
+	GetActiveCount(ctx context.Context, eventID uuid.UUID, now time.Time) (int, error)
+
+	// GetLastActivation retrieves when a user was last activated for an event; the zero time means never
+	GetLastActivation(ctx context.Context, eventID uuid.UUID) (time.Time, error)
+
+	// SetLastActivation records when a user was last activated for an event
+	SetLastActivation(ctx context.Context, eventID uuid.UUID, at time.Time) error
+
+	// GetActiveEntries retrieves the entries holding an active session for an event that is unexpired as of
+	// now, soonest to expire first
+	GetActiveEntries(ctx context.Context, eventID uuid.UUID, now time.Time) ([]*domain.QueueEntry, error)
 
 	// GetExpiredEntries retrieves all expired queue entries
 	GetExpiredEntries(ctx context.Context) ([]*domain.QueueEntry, error)
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// ResaleRepository defines the interface for resale listing data operations
+type ResaleRepository interface {
+	// Create stores a new open listing, returning ErrTicketAlreadyListed if the ticket already has one
+	Create(ctx context.Context, listing *domain.ResaleListing) error
+
+	// GetByID retrieves a listing by its ID, returning ErrResaleListingNotFound if it does not exist
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ResaleListing, error)
+
+	// ListOpenByEvent retrieves the open listings of an event, oldest first
+	ListOpenByEvent(ctx context.Context, eventID uuid.UUID) ([]*domain.ResaleListing, error)
+
+	// Claim atomically marks an open listing sold to a buyer and takes it off the market,
+	// returning ErrResaleListingNotAvailable if it is no longer listed
+	Claim(ctx context.Context, id, buyerID uuid.UUID) (*domain.ResaleListing, error)
+
+	// Reopen puts a claimed listing back on the market when its sale could not be settled
+	Reopen(ctx context.Context, id uuid.UUID) error
+
+	// Cancel withdraws an open listing, returning ErrResaleListingNotAvailable if it is no longer listed
+	Cancel(ctx context.Context, id uuid.UUID) error
+
+	// Update persists changes to an existing listing
+	Update(ctx context.Context, listing *domain.ResaleListing) error
+}
@@ -2,17 +2,30 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/snowmerak/ticketing/lib/domain"
 )
 
+// DefaultReservationHold is how long a seat reserved by ReserveSeats may stay reserved without a ticket before
+// it counts as orphaned; a little longer than a ticket's reservation window, so a ticketed seat is released by
+// its ticket's expiry first
+const DefaultReservationHold = 16 * time.Minute
+
+// SeatPriceFilter selects the seats affected by a bulk price update
+type SeatPriceFilter struct {
+	Section string // Only seats in this section; empty matches every section
+	Price   *int64 // Only seats currently at this price tier; nil matches any price
+}
+
 // SeatRepository defines the interface for seat data operations
 type SeatRepository interface {
 	// Create creates a new seat
 	Create(ctx context.Context, seat *domain.Seat) error
 
-	// CreateBatch creates multiple seats in a single transaction
+	// CreateBatch creates multiple seats in as few round trips as the backend allows. A seat that fails to be
+	// written is reported in the returned error without stopping the rest of the batch.
 	CreateBatch(ctx context.Context, seats []*domain.Seat) error
 
 	// GetByID retrieves a seat by its ID
@@ -21,24 +34,44 @@ type SeatRepository interface {
 	// GetByEventID retrieves all seats for an event
 	GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain.Seat, error)
 
-	// GetAvailableByEventID retrieves available seats for an event
+	// GetAvailableByEventID retrieves available seats for an event; an event with none yields an empty slice and no error
 	GetAvailableByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain.Seat, error)
 
+	// CountAvailable counts an event's available seats without loading them; an event with none counts zero
+	CountAvailable(ctx context.Context, eventID uuid.UUID) (int, error)
+
 	// GetBySection retrieves seats by section
 	GetBySection(ctx context.Context, eventID uuid.UUID, section string) ([]*domain.Seat, error)
 
-	// Update updates an existing seat
+	// Update updates an existing seat if its stored version still matches seat.Version, bumping the version;
+	// otherwise it fails with ErrSeatVersionConflict and the caller has to re-read the seat
 	Update(ctx context.Context, seat *domain.Seat) error
 
-	// UpdateStatus updates seat status
+	// UpdateStatus updates seat status, re-reading the seat and retrying a bounded number of times on version conflicts
 	UpdateStatus(ctx context.Context, seatID uuid.UUID, status string) error
 
-	// ReserveSeats reserves multiple seats atomically
+	// UpdatePrices reprices unsold seats of an event matching the filter and returns how many changed
+	UpdatePrices(ctx context.Context, eventID uuid.UUID, filter SeatPriceFilter, price int64) (int, error)
+
+	// ReserveSeats reserves multiple seats atomically. Each seat is also given a reservation hold lasting the
+	// repository's hold duration, after which the seat counts as orphaned unless a ticket claims it
 	ReserveSeats(ctx context.Context, seatIDs []uuid.UUID) error
 
-	// ReleaseSeats releases reserved seats atomically
+	// ReleaseSeats releases reserved seats atomically, dropping their reservation holds
 	ReleaseSeats(ctx context.Context, seatIDs []uuid.UUID) error
 
+	// ReleaseReservedSeats releases those of the seats that are still reserved, dropping their reservation holds,
+	// and returns the IDs it released. Unlike ReleaseSeats it skips seats that are missing or no longer reserved,
+	// so one seat released elsewhere does not hold back the rest of a batch
+	ReleaseReservedSeats(ctx context.Context, seatIDs []uuid.UUID) ([]uuid.UUID, error)
+
+	// GetLapsedReservationHolds returns the seats still reserved whose reservation hold lapsed by now. Holds of
+	// seats that are no longer reserved, or no longer exist, are dropped along the way
+	GetLapsedReservationHolds(ctx context.Context, now time.Time) ([]*domain.Seat, error)
+
+	// ClearReservationHolds drops the reservation holds of seats, e.g. once a ticket is found to claim them
+	ClearReservationHolds(ctx context.Context, seatIDs []uuid.UUID) error
+
 	// Delete deletes a seat by its ID
 	Delete(ctx context.Context, id uuid.UUID) error
 
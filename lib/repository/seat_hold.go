@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+)
+
+// SeatHoldRepository defines the interface for expiring per-user seat holds.
+// A held seat is out of the available pool until the hold is released, lapses or is turned into a ticket.
+type SeatHoldRepository interface {
+	// Hold marks an available seat, or a held seat whose hold has lapsed, as held by a user for ttl
+	Hold(ctx context.Context, seatID, userID uuid.UUID, ttl time.Duration) error
+
+	// Release drops a user's hold and returns the seat to the available pool
+	Release(ctx context.Context, seatID, userID uuid.UUID) error
+
+	// ConvertToTicket atomically checks that the ticket's user still holds its seat, moves the seat from
+	// held to reserved and creates the reserved ticket. If the hold has lapsed the seat is made available
+	// again and ErrSeatHoldNotFound is returned.
+	ConvertToTicket(ctx context.Context, ticket *domain.Ticket) error
+
+	// GetHeldByEventID retrieves an event's seats under a live hold, whoever holds them;
+	// an event with none yields an empty slice and no error
+	GetHeldByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain.Seat, error)
+
+	// ReleaseExpired returns seats whose holds lapsed to the available pool and reports how many were released
+	ReleaseExpired(ctx context.Context) (int, error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/snowmerak/ticketing/lib/domain"
@@ -15,12 +16,30 @@ type TicketRepository interface {
 	// GetByID retrieves a ticket by its ID
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Ticket, error)
 
+	// GetByIDs retrieves the tickets with the given IDs in request order, skipping any that don't exist
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Ticket, error)
+
 	// GetByUserID retrieves all tickets for a user
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Ticket, error)
 
+	// GetByUserAndEvent retrieves a user's tickets for one event; a user with none yields an empty slice and no error
+	GetByUserAndEvent(ctx context.Context, userID, eventID uuid.UUID) ([]*domain.Ticket, error)
+
 	// GetByEventID retrieves all tickets for an event
 	GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain.Ticket, error)
 
+	// GetByEventIDPaginated retrieves a page of an event's tickets in a stable order along with the event's
+	// total number of tickets; an offset past the end returns an empty page
+	GetByEventIDPaginated(ctx context.Context, eventID uuid.UUID, offset, limit int) ([]*domain.Ticket, int, error)
+
+	// GetByEventIDAndStatus retrieves an event's tickets in one status; an event with none yields an empty slice and no error
+	GetByEventIDAndStatus(ctx context.Context, eventID uuid.UUID, status string) ([]*domain.Ticket, error)
+
+	// GetByEventIDAndStatusPaginated retrieves a page of an event's tickets in one status, read from the status
+	// index in a stable order, along with how many tickets are in that status; an offset past the end returns an
+	// empty page
+	GetByEventIDAndStatusPaginated(ctx context.Context, eventID uuid.UUID, status string, offset, limit int) ([]*domain.Ticket, int, error)
+
 	// GetBySeatID retrieves a ticket by seat ID
 	GetBySeatID(ctx context.Context, seatID uuid.UUID) (*domain.Ticket, error)
 
@@ -30,15 +49,61 @@ type TicketRepository interface {
 	// UpdateStatus updates ticket status
 	UpdateStatus(ctx context.Context, ticketID uuid.UUID, status string) error
 
-	// GetExpiredReservations retrieves all expired reservations
-	GetExpiredReservations(ctx context.Context) ([]*domain.Ticket, error)
+	// Transfer moves a ticket from one holder to another, replaces its gate access token and drops the
+	// previous holder's guest email, returning ErrTicketOwnerMismatch if fromUserID no longer holds it
+	Transfer(ctx context.Context, ticketID, fromUserID, toUserID uuid.UUID, accessToken string) error
+
+	// GetExpiredReservations retrieves the reservations whose confirmation window lapsed as of now
+	GetExpiredReservations(ctx context.Context, now time.Time) ([]*domain.Ticket, error)
+
+	// GetReservationsExpiringBy retrieves the reservations whose confirmation window lapses at or before by,
+	// including ones that already lapsed
+	GetReservationsExpiringBy(ctx context.Context, by time.Time) ([]*domain.Ticket, error)
+
+	// MarkExpiryNotice records that a reservation's holder was warned it is about to lapse; it reports true only
+	// for the first caller, so each reservation is warned once. The mark is dropped after ttl
+	MarkExpiryNotice(ctx context.Context, ticketID uuid.UUID, ttl time.Duration) (bool, error)
 
 	// ConfirmTicket confirms a reserved ticket
 	ConfirmTicket(ctx context.Context, ticketID uuid.UUID) error
 
-	// CancelTicket cancels a ticket and updates its status
+	// ConfirmReservation confirms a ticket only while it is still reserved, stamping it confirmed at at and issuing
+	// its gate access token, and returns the confirmed ticket. The check and the confirmation are one atomic step:
+	// it returns ErrTicketNotReserved if the ticket was confirmed or cancelled first, so a confirmation racing the
+	// expiry sweep either wins outright or sees the reservation already released.
+	ConfirmReservation(ctx context.Context, ticketID uuid.UUID, accessToken string, at time.Time) (*domain.Ticket, error)
+
+	// CheckIn records that a confirmed ticket was admitted at the venue at at and returns the admitted ticket,
+	// checking and writing in one step: it returns ErrTicketAlreadyCheckedIn if it was admitted before, so a
+	// ticket scanned at two gates at once admits once, and ErrTicketNotConfirmed if it is not confirmed
+	CheckIn(ctx context.Context, ticketID uuid.UUID, at time.Time) (*domain.Ticket, error)
+
+	// CancelTicket cancels a ticket and frees its seat mapping, returning ErrTicketAlreadyCancelled if it already
+	// was; the check and the cancellation are one atomic step, so a ticket is cancelled once however many callers race
 	CancelTicket(ctx context.Context, ticketID uuid.UUID) error
 
+	// CancelReservation cancels a ticket only while it is still reserved and frees its seat mapping, returning the
+	// cancelled ticket. The check and the cancellation are one atomic step: it returns ErrTicketNotReserved if the
+	// ticket was confirmed or cancelled first, so of callers racing to release a reservation exactly one wins.
+	CancelReservation(ctx context.Context, ticketID uuid.UUID) (*domain.Ticket, error)
+
+	// NextGANumber atomically allocates the next general admission number for an event
+	NextGANumber(ctx context.Context, eventID uuid.UUID) (int64, error)
+
+	// IncrementUserEventCount atomically adds count to the number of tickets a user holds for an event and returns
+	// the new number, or ErrUserTicketLimitReached without counting anything if that would exceed limit; 0 means no limit
+	IncrementUserEventCount(ctx context.Context, eventID, userID uuid.UUID, count, limit int) (int, error)
+
+	// DecrementUserEventCount takes count off the number of tickets a user holds for an event, never going below zero
+	DecrementUserEventCount(ctx context.Context, eventID, userID uuid.UUID, count int) error
+
+	// SaveConfirmationToken stores a single-use confirmation token hash for a ticket that lapses after ttl
+	SaveConfirmationToken(ctx context.Context, tokenHash string, ticketID uuid.UUID, ttl time.Duration) error
+
+	// ConsumeConfirmationToken atomically looks up and deletes a confirmation token hash,
+	// returning ErrConfirmationTokenNotFound if it is unknown, used or expired
+	ConsumeConfirmationToken(ctx context.Context, tokenHash string) (uuid.UUID, error)
+
 	// Delete deletes a ticket by its ID
 	Delete(ctx context.Context, id uuid.UUID) error
 }
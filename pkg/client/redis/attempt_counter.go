@@ -0,0 +1,62 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/rueidis"
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+// AttemptCounter implementation using one expiring Redis counter per key
+type AttemptCounter struct {
+	client *Client
+}
+
+// NewAttemptCounter creates a new AttemptCounter implementation
+func NewAttemptCounter(client *Client) *AttemptCounter {
+	return &AttemptCounter{
+		client: client,
+	}
+}
+
+// Compile-time check to ensure AttemptCounter implements adapter.AttemptCounter
+var _ adapter.AttemptCounter = (*AttemptCounter)(nil)
+
+// incrAttemptScript increments a counter and starts its expiry on the first attempt
+var incrAttemptScript = RegisterScript("attempt_counter_incr", `
+	local count = redis.call("INCR", KEYS[1])
+	if count == 1 then
+		redis.call("PEXPIRE", KEYS[1], ARGV[1])
+	end
+	return count
+`)
+
+// Incr adds one attempt for a key and returns the new count
+func (c *AttemptCounter) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	ttlMs := strconv.FormatInt(ttl.Milliseconds(), 10)
+	cmd := c.client.rdb.B().Eval().Script(incrAttemptScript).Numkeys(1).Key(attemptKey(key)).Arg(ttlMs).Build()
+	return c.client.rdb.Do(ctx, cmd).AsInt64()
+}
+
+// Get returns the current count for a key, or 0 when none is recorded
+func (c *AttemptCounter) Get(ctx context.Context, key string) (int64, error) {
+	cmd := c.client.rdb.B().Get().Key(attemptKey(key)).Build()
+	count, err := c.client.rdb.Do(ctx, cmd).AsInt64()
+	if rueidis.IsRedisNil(err) {
+		return 0, nil
+	}
+	return count, err
+}
+
+// Reset clears the count for a key
+func (c *AttemptCounter) Reset(ctx context.Context, key string) error {
+	cmd := c.client.rdb.B().Del().Key(attemptKey(key)).Build()
+	return c.client.rdb.Do(ctx, cmd).Error()
+}
+
+// attemptKey names the Redis counter for a key
+func attemptKey(key string) string {
+	return "attempts:" + key
+}
@@ -3,6 +3,7 @@ package redis
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/redis/rueidis"
@@ -12,27 +13,59 @@ import (
 
 // Client represents a Redis client wrapper
 type Client struct {
-	rdb    rueidis.Client
-	logger zerolog.Logger
+	rdb            rueidis.Client
+	logger         zerolog.Logger
+	cachingEnabled bool
 }
 
-// NewClient creates a new Redis client
+// NewClient creates a new Redis client.
+// Client-side caching needs RESP3 and CLIENT TRACKING; when the server or a proxy in front of it doesn't support
+// them the client is created with caching disabled, so DoCache falls back to a plain Do.
 func NewClient(addr, password string, db int, logger zerolog.Logger) *Client {
-	client, err := rueidis.NewClient(rueidis.ClientOption{
+	client, cachingEnabled, err := connect(rueidis.ClientOption{
 		InitAddress: []string{addr},
 		Password:    password,
 		SelectDB:    db,
-	})
+	}, rueidis.NewClient, logger)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to create Redis client")
 	}
 
 	return &Client{
-		rdb:    client,
-		logger: logger,
+		rdb:            client,
+		logger:         logger,
+		cachingEnabled: cachingEnabled,
 	}
 }
 
+// connect creates a rueidis client with client-side caching, retrying once without it when the server reports
+// rueidis.ErrNoCache. It reports whether caching ended up enabled.
+func connect(option rueidis.ClientOption, newClient func(rueidis.ClientOption) (rueidis.Client, error), logger zerolog.Logger) (rueidis.Client, bool, error) {
+	if option.DisableCache {
+		client, err := newClient(option)
+		return client, false, err
+	}
+
+	client, err := newClient(option)
+	if err == nil {
+		return client, true, nil
+	}
+	if !errors.Is(err, rueidis.ErrNoCache) {
+		return nil, false, err
+	}
+
+	logger.Warn().Err(err).Msg("Redis doesn't support client-side caching; cached reads fall back to plain commands")
+
+	option.DisableCache = true
+	client, err = newClient(option)
+	return client, false, err
+}
+
+// CachingEnabled reports whether reads made with DoCache are cached on the client
+func (c *Client) CachingEnabled() bool {
+	return c.cachingEnabled
+}
+
 // Close closes the Redis connection
 func (c *Client) Close() error {
 	c.rdb.Close()
@@ -0,0 +1,153 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+const (
+	// fairLockMinBackoff and fairLockMaxBackoff bound the pause between a waiter's checks for its turn
+	fairLockMinBackoff = 5 * time.Millisecond
+	fairLockMaxBackoff = 100 * time.Millisecond
+
+	// fairLockDeadlineGrace keeps a waiter in line a little past its own deadline, so clock skew between
+	// app servers never drops a waiter that is still polling
+	fairLockDeadlineGrace = time.Second
+)
+
+// FairLock implementation using a Redis sorted set of waiters ordered by arrival
+type FairLock struct {
+	client *Client
+}
+
+// NewFairLock creates a new FairLock implementation
+func NewFairLock(client *Client) *FairLock {
+	return &FairLock{
+		client: client,
+	}
+}
+
+// Compile-time check to ensure FairLock implements adapter.FairLock
+var _ adapter.FairLock = (*FairLock)(nil)
+
+// fairLockEnqueueScript numbers a waiter and puts it at the back of the line.
+// KEYS: sequence, waiters (token by arrival number), deadlines (token by the time it gives up)
+var fairLockEnqueueScript = RegisterScript("fair_lock_enqueue", `
+	local seq = redis.call("INCR", KEYS[1])
+	redis.call("ZADD", KEYS[2], seq, ARGV[1])
+	redis.call("ZADD", KEYS[3], tonumber(ARGV[2]), ARGV[1])
+	local ttl = tonumber(ARGV[3])
+	redis.call("PEXPIRE", KEYS[1], ttl)
+	redis.call("PEXPIRE", KEYS[2], ttl)
+	redis.call("PEXPIRE", KEYS[3], ttl)
+	return seq
+`)
+
+// fairLockTryScript drops waiters that gave up without leaving, then grants the lock to the caller if it is
+// free and the caller is first in line. KEYS: owner, waiters, deadlines
+var fairLockTryScript = RegisterScript("fair_lock_try", `
+	local now = tonumber(ARGV[2])
+	local abandoned = redis.call("ZRANGEBYSCORE", KEYS[3], "-inf", now)
+	for _, token in ipairs(abandoned) do
+		redis.call("ZREM", KEYS[2], token)
+		redis.call("ZREM", KEYS[3], token)
+	end
+	local owner = redis.call("GET", KEYS[1])
+	if owner then
+		if owner == ARGV[1] then
+			return 1
+		end
+		return 0
+	end
+	local head = redis.call("ZRANGE", KEYS[2], 0, 0)
+	if head[1] ~= ARGV[1] then
+		return 0
+	end
+	redis.call("SET", KEYS[1], ARGV[1], "PX", tonumber(ARGV[3]))
+	redis.call("ZREM", KEYS[2], ARGV[1])
+	redis.call("ZREM", KEYS[3], ARGV[1])
+	return 1
+`)
+
+// fairLockLeaveScript takes a waiter out of line, and releases the lock if the waiter holds it.
+// KEYS: owner, waiters, deadlines
+var fairLockLeaveScript = RegisterScript("fair_lock_leave", `
+	redis.call("ZREM", KEYS[2], ARGV[1])
+	redis.call("ZREM", KEYS[3], ARGV[1])
+	if redis.call("GET", KEYS[1]) == ARGV[1] then
+		return redis.call("DEL", KEYS[1])
+	end
+	return 0
+`)
+
+// fairLockKeys returns the owner, waiters, deadlines and sequence keys of a fair lock. The owner key is the one
+// Lock uses for the same key, so a fair lock and a plain lock on one key exclude each other and lock admin
+// tooling sees either holder; the line of waiters is kept apart, under fair_lock:, so it never looks like a lock.
+func fairLockKeys(key string) (owner, waiters, deadlines, sequence string) {
+	prefix := "fair_lock:" + key
+	return "lock:" + key, prefix + ":waiters", prefix + ":deadlines", prefix + ":seq"
+}
+
+// AcquireWait queues for a lock and blocks until it is granted or wait has passed, whichever comes first
+func (l *FairLock) AcquireWait(ctx context.Context, key string, expiration, wait time.Duration) (string, bool, error) {
+	owner, waiters, deadlines, sequence := fairLockKeys(key)
+	token := uuid.New().String()
+
+	// A waiter that crashes is dropped from the line once its wait would have ended
+	deadline := time.Now().Add(wait)
+	dropAt := strconv.FormatInt(deadline.Add(fairLockDeadlineGrace).UnixMilli(), 10)
+	ttl := strconv.FormatInt((wait + fairLockDeadlineGrace + expiration).Milliseconds(), 10)
+	cmd := l.client.rdb.B().Eval().Script(fairLockEnqueueScript).Numkeys(3).Key(sequence, waiters, deadlines).Arg(token, dropAt, ttl).Build()
+	if err := l.client.rdb.Do(ctx, cmd).Error(); err != nil {
+		return "", false, err
+	}
+
+	expiry := strconv.FormatInt(expiration.Milliseconds(), 10)
+	backoff := fairLockMinBackoff
+	for {
+		cmd := l.client.rdb.B().Eval().Script(fairLockTryScript).Numkeys(3).Key(owner, waiters, deadlines).Arg(token, strconv.FormatInt(time.Now().UnixMilli(), 10), expiry).Build()
+		acquired, err := l.client.rdb.Do(ctx, cmd).AsInt64()
+		if err != nil {
+			l.leave(context.WithoutCancel(ctx), key, token)
+			return "", false, err
+		}
+
+		if acquired == 1 {
+			return token, true, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			l.leave(context.WithoutCancel(ctx), key, token)
+			return "", false, nil
+		}
+
+		timer := time.NewTimer(min(backoff, remaining))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			l.leave(context.WithoutCancel(ctx), key, token)
+			return "", false, ctx.Err()
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, fairLockMaxBackoff)
+	}
+}
+
+// Release releases a lock granted by AcquireWait
+func (l *FairLock) Release(ctx context.Context, key, token string) error {
+	owner, waiters, deadlines, _ := fairLockKeys(key)
+
+	cmd := l.client.rdb.B().Eval().Script(fairLockLeaveScript).Numkeys(3).Key(owner, waiters, deadlines).Arg(token).Build()
+	return l.client.rdb.Do(ctx, cmd).Error()
+}
+
+// leave takes a waiter that stopped waiting out of line; a failure only delays the line until its deadline passes
+func (l *FairLock) leave(ctx context.Context, key, token string) {
+	_ = l.Release(ctx, key, token)
+}
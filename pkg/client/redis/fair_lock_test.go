@@ -0,0 +1,72 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestFairLockExcludesPlainLock(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	fair, plain := NewFairLock(client), NewLock(client)
+	key := "fair:" + uuid.NewString()
+
+	token, acquired, err := fair.AcquireWait(ctx, key, time.Minute, time.Second)
+	if err != nil || !acquired {
+		t.Fatalf("fair acquire of a free lock: acquired %v, error %v", acquired, err)
+	}
+
+	if _, acquired, err := plain.Acquire(ctx, key, time.Minute); err != nil || acquired {
+		t.Fatalf("plain acquire of a fair-held lock: acquired %v, error %v", acquired, err)
+	}
+	if locked, err := plain.IsLocked(ctx, key); err != nil || !locked {
+		t.Fatalf("plain view of a fair-held lock: locked %v, error %v", locked, err)
+	}
+
+	if err := fair.Release(ctx, key, token); err != nil {
+		t.Fatalf("fair release: %v", err)
+	}
+	plainToken, acquired, err := plain.Acquire(ctx, key, time.Minute)
+	if err != nil || !acquired {
+		t.Fatalf("plain acquire after fair release: acquired %v, error %v", acquired, err)
+	}
+	if err := plain.Release(ctx, key, plainToken); err != nil {
+		t.Fatalf("plain release: %v", err)
+	}
+}
+
+func TestPlainLockExcludesFairLock(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	fair, plain := NewFairLock(client), NewLock(client)
+	key := "fair:" + uuid.NewString()
+
+	token, acquired, err := plain.Acquire(ctx, key, time.Minute)
+	if err != nil || !acquired {
+		t.Fatalf("plain acquire of a free lock: acquired %v, error %v", acquired, err)
+	}
+
+	if _, acquired, err := fair.AcquireWait(ctx, key, time.Minute, 100*time.Millisecond); err != nil || acquired {
+		t.Fatalf("fair acquire of a plain-held lock: acquired %v, error %v", acquired, err)
+	}
+
+	if err := plain.Release(ctx, key, token); err != nil {
+		t.Fatalf("plain release: %v", err)
+	}
+	fairToken, acquired, err := fair.AcquireWait(ctx, key, time.Minute, time.Second)
+	if err != nil || !acquired {
+		t.Fatalf("fair acquire after plain release: acquired %v, error %v", acquired, err)
+	}
+
+	// The fair holder shows up to lock admin tooling and can be force released there
+	released, err := plain.ForceRelease(ctx, key)
+	if err != nil || !released {
+		t.Fatalf("force release of a fair-held lock: released %v, error %v", released, err)
+	}
+	if err := fair.Release(ctx, key, fairToken); err != nil {
+		t.Fatalf("fair release after force release: %v", err)
+	}
+}
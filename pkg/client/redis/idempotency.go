@@ -0,0 +1,68 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/rueidis"
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+// IdempotencyStore implementation using one expiring Redis string per key
+type IdempotencyStore struct {
+	client *Client
+}
+
+// NewIdempotencyStore creates a new IdempotencyStore implementation
+func NewIdempotencyStore(client *Client) *IdempotencyStore {
+	return &IdempotencyStore{
+		client: client,
+	}
+}
+
+// Compile-time check to ensure IdempotencyStore implements adapter.IdempotencyStore
+var _ adapter.IdempotencyStore = (*IdempotencyStore)(nil)
+
+// claimIdempotencyScript sets a key only when it is free and otherwise returns the value already stored
+var claimIdempotencyScript = RegisterScript("idempotency_claim", `
+	if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+		return false
+	end
+	return redis.call("GET", KEYS[1])
+`)
+
+// Claim stores value under key unless the key is already taken, in which case the stored value is returned
+func (s *IdempotencyStore) Claim(ctx context.Context, key, value string, ttl time.Duration) (string, bool, error) {
+	ttlMs := strconv.FormatInt(ttl.Milliseconds(), 10)
+	cmd := s.client.rdb.B().Eval().Script(claimIdempotencyScript).Numkeys(1).Key(idempotencyKey(key)).Arg(value, ttlMs).Build()
+	existing, err := s.client.rdb.Do(ctx, cmd).ToString()
+	if rueidis.IsRedisNil(err) {
+		return "", true, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return existing, false, nil
+}
+
+// Set replaces the value of a claimed key, keeping its expiry
+func (s *IdempotencyStore) Set(ctx context.Context, key, value string) error {
+	cmd := s.client.rdb.B().Set().Key(idempotencyKey(key)).Value(value).Xx().Keepttl().Build()
+	err := s.client.rdb.Do(ctx, cmd).Error()
+	if rueidis.IsRedisNil(err) {
+		return nil
+	}
+	return err
+}
+
+// Release removes a key so the request can be made again
+func (s *IdempotencyStore) Release(ctx context.Context, key string) error {
+	cmd := s.client.rdb.B().Del().Key(idempotencyKey(key)).Build()
+	return s.client.rdb.Do(ctx, cmd).Error()
+}
+
+// idempotencyKey names the Redis string for a key
+func idempotencyKey(key string) string {
+	return "idempotency:" + key
+}
@@ -2,8 +2,11 @@ package redis
 
 import (
 	"context"
+	"strconv"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/redis/rueidis"
 	"github.com/snowmerak/ticketing/lib/adapter"
 )
 
@@ -22,59 +25,68 @@ func NewLock(client *Client) *Lock {
 // Compile-time check to ensure Lock implements adapter.Lock
 var _ adapter.Lock = (*Lock)(nil)
 
-// Acquire attempts to acquire a lock with a timeout
-func (l *Lock) Acquire(ctx context.Context, key string, expiration time.Duration) (bool, error) {
+// Acquire attempts to acquire a lock that lapses after expiration, returning the token it is held under
+func (l *Lock) Acquire(ctx context.Context, key string, expiration time.Duration) (string, bool, error) {
 	lockKey := "lock:" + key
-
-	// Try to set the lock with NX (only if not exists) and EX (expiration)
-	cmd := l.client.rdb.B().Set().Key(lockKey).Value("1").Nx().Ex(expiration).Build()
-	result := l.client.rdb.Do(ctx, cmd)
-
-	if result.Error() != nil {
-		return false, result.Error()
+	token := uuid.New().String()
+
+	// Try to set the lock with NX (only if not exists) and PX (expiration), storing the holder's token
+	cmd := l.client.rdb.B().Set().Key(lockKey).Value(token).Nx().Px(expiration).Build()
+	err := l.client.rdb.Do(ctx, cmd).Error()
+	if rueidis.IsRedisNil(err) {
+		// NX refused the SET: someone else holds the lock
+		return "", false, nil
 	}
-
-	// Check if the SET operation was successful (key was set)
-	resultStr, err := result.ToString()
 	if err != nil {
-		return false, err
+		return "", false, err
 	}
 
-	return resultStr == "OK", nil
+	return token, true, nil
 }
 
-// Release releases a lock
-func (l *Lock) Release(ctx context.Context, key string) error {
+// releaseLockScript deletes a lock only while it is still held under the caller's token
+var releaseLockScript = RegisterScript("lock_release", `
+	if redis.call("GET", KEYS[1]) == ARGV[1] then
+		return redis.call("DEL", KEYS[1])
+	else
+		return 0
+	end
+`)
+
+// Release releases a lock taken with token, leaving it alone if it lapsed or was taken by someone else
+func (l *Lock) Release(ctx context.Context, key, token string) error {
 	lockKey := "lock:" + key
 
-	// Use Lua script to atomically check and delete the lock
-	script := `
-		if redis.call("GET", KEYS[1]) then
-			return redis.call("DEL", KEYS[1])
-		else
-			return 0
-		end
-	`
-
-	cmd := l.client.rdb.B().Eval().Script(script).Numkeys(1).Key(lockKey).Build()
+	cmd := l.client.rdb.B().Eval().Script(releaseLockScript).Numkeys(1).Key(lockKey).Arg(token).Build()
 	return l.client.rdb.Do(ctx, cmd).Error()
 }
 
-// Extend extends the expiration time of a lock
-func (l *Lock) Extend(ctx context.Context, key string, expiration time.Duration) error {
+// extendLockScript extends a lock only while it is still held under the caller's token
+var extendLockScript = RegisterScript("lock_extend", `
+	if redis.call("GET", KEYS[1]) == ARGV[1] then
+		return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	else
+		return 0
+	end
+`)
+
+// Extend resets the expiration time of a lock taken with token, failing with adapter.ErrLockNotHeld when
+// it lapsed, was released or is now held under another token
+func (l *Lock) Extend(ctx context.Context, key, token string, expiration time.Duration) error {
 	lockKey := "lock:" + key
+	millis := strconv.FormatInt(expiration.Milliseconds(), 10)
 
-	// Use Lua script to atomically check existence and extend expiration
-	script := `
-		if redis.call("GET", KEYS[1]) then
-			return redis.call("EXPIRE", KEYS[1], ARGV[1])
-		else
-			return 0
-		end
-	`
+	cmd := l.client.rdb.B().Eval().Script(extendLockScript).Numkeys(1).Key(lockKey).Arg(token, millis).Build()
+	extended, err := l.client.rdb.Do(ctx, cmd).AsInt64()
+	if err != nil {
+		return err
+	}
 
-	cmd := l.client.rdb.B().Eval().Script(script).Numkeys(1).Key(lockKey).Arg(string(rune(int(expiration.Seconds())))).Build()
-	return l.client.rdb.Do(ctx, cmd).Error()
+	if extended == 0 {
+		return adapter.ErrLockNotHeld
+	}
+
+	return nil
 }
 
 // IsLocked checks if a key is locked
@@ -90,3 +102,38 @@ func (l *Lock) IsLocked(ctx context.Context, key string) (bool, error) {
 	count, err := result.ToInt64()
 	return count > 0, err
 }
+
+// TTL returns how long a lock has left: 0 when it is not held and a negative duration when it never expires
+func (l *Lock) TTL(ctx context.Context, key string) (time.Duration, error) {
+	lockKey := "lock:" + key
+
+	cmd := l.client.rdb.B().Pttl().Key(lockKey).Build()
+	ttl, err := l.client.rdb.Do(ctx, cmd).AsInt64()
+	if err != nil {
+		return 0, err
+	}
+
+	switch ttl {
+	case -2:
+		// Key does not exist
+		return 0, nil
+	case -1:
+		// Key exists without an expiry
+		return -1, nil
+	}
+
+	return time.Duration(ttl) * time.Millisecond, nil
+}
+
+// ForceRelease deletes a lock whoever holds it and reports whether one was held
+func (l *Lock) ForceRelease(ctx context.Context, key string) (bool, error) {
+	lockKey := "lock:" + key
+
+	cmd := l.client.rdb.B().Del().Key(lockKey).Build()
+	deleted, err := l.client.rdb.Do(ctx, cmd).AsInt64()
+	if err != nil {
+		return false, err
+	}
+
+	return deleted > 0, nil
+}
@@ -0,0 +1,62 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/adapter"
+	"github.com/snowmerak/ticketing/pkg/repository/repotest"
+)
+
+func TestLock(t *testing.T) {
+	repotest.RunLockTests(t, func(t *testing.T) adapter.Lock {
+		return NewLock(newTestClient(t))
+	})
+}
+
+func TestLockExtend(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	lock := NewLock(client)
+	key := "extend:" + uuid.NewString()
+
+	token, acquired, err := lock.Acquire(ctx, key, time.Second)
+	if err != nil || !acquired {
+		t.Fatalf("acquire: acquired %v, error %v", acquired, err)
+	}
+
+	if err := lock.Extend(ctx, key, token, time.Hour); err != nil {
+		t.Fatalf("extend: %v", err)
+	}
+
+	// Read the expiry Redis holds rather than going through Lock.TTL
+	rdb := client.GetRedisClient()
+	pttl, err := rdb.Do(ctx, rdb.B().Pttl().Key("lock:"+key).Build()).AsInt64()
+	if err != nil {
+		t.Fatalf("pttl: %v", err)
+	}
+	if remaining := time.Duration(pttl) * time.Millisecond; remaining <= 59*time.Minute || remaining > time.Hour {
+		t.Fatalf("PTTL after extend = %v, want just under an hour", remaining)
+	}
+
+	if err := lock.Extend(ctx, key, uuid.NewString(), time.Hour); !errors.Is(err, adapter.ErrLockNotHeld) {
+		t.Fatalf("extend under another token: got %v, want %v", err, adapter.ErrLockNotHeld)
+	}
+
+	if err := lock.Release(ctx, key, token); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if err := lock.Extend(ctx, key, token, time.Hour); !errors.Is(err, adapter.ErrLockNotHeld) {
+		t.Fatalf("extend after release: got %v, want %v", err, adapter.ErrLockNotHeld)
+	}
+	pttl, err = rdb.Do(ctx, rdb.B().Pttl().Key("lock:"+key).Build()).AsInt64()
+	if err != nil {
+		t.Fatalf("pttl after release: %v", err)
+	}
+	if pttl != -2 {
+		t.Fatalf("PTTL after a refused extend = %d, want -2 (no key)", pttl)
+	}
+}
@@ -0,0 +1,55 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+// DefaultPublisherMaxLen is roughly how many messages each topic's stream keeps
+const DefaultPublisherMaxLen = 100000
+
+// EventPublisher implementation appending each message to a capped Redis stream per topic.
+// Consumers read a topic with XREAD or a consumer group on its stream key, and the message is the "payload" field.
+type EventPublisher struct {
+	client *Client
+	maxLen int64
+}
+
+// NewEventPublisher creates a new EventPublisher whose streams keep about maxLen messages; 0 uses
+// DefaultPublisherMaxLen
+func NewEventPublisher(client *Client, maxLen int64) *EventPublisher {
+	if maxLen <= 0 {
+		maxLen = DefaultPublisherMaxLen
+	}
+
+	return &EventPublisher{
+		client: client,
+		maxLen: maxLen,
+	}
+}
+
+// Compile-time check to ensure EventPublisher implements adapter.EventPublisher
+var _ adapter.EventPublisher = (*EventPublisher)(nil)
+
+// Publish appends a payload to the topic's stream; the stream is trimmed approximately to maxLen as it grows
+func (p *EventPublisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	cmd := p.client.rdb.B().Xadd().Key(TopicStreamKey(topic)).
+		Maxlen().Almost().Threshold(strconv.FormatInt(p.maxLen, 10)).
+		Id("*").FieldValue().
+		FieldValue("payload", string(payload)).
+		Build()
+
+	if err := p.client.rdb.Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", topic, err)
+	}
+
+	return nil
+}
+
+// TopicStreamKey returns the key of the stream a topic is published on
+func TopicStreamKey(topic string) string {
+	return "stream:" + topic
+}
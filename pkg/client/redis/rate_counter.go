@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+// DefaultRateCounterRetention is how long per-second buckets are kept, bounding the largest countable window
+const DefaultRateCounterRetention = 5 * time.Minute
+
+// RateCounter implementation using one Redis counter per second.
+// Incr is a single INCR and Count is a single MGET over the window's buckets, so neither scans.
+type RateCounter struct {
+	client    *Client
+	retention time.Duration
+}
+
+// NewRateCounter creates a new RateCounter implementation
+func NewRateCounter(client *Client) *RateCounter {
+	return &RateCounter{
+		client:    client,
+		retention: DefaultRateCounterRetention,
+	}
+}
+
+// Compile-time check to ensure RateCounter implements adapter.RateCounter
+var _ adapter.RateCounter = (*RateCounter)(nil)
+
+// incrRateBucketScript increments a bucket and sets its expiry the first time it is created
+var incrRateBucketScript = RegisterScript("rate_counter_incr", `
+	local count = redis.call("INCR", KEYS[1])
+	if count == 1 then
+		redis.call("EXPIRE", KEYS[1], ARGV[1])
+	end
+	return count
+`)
+
+// Incr records one occurrence for a key at the current time
+func (c *RateCounter) Incr(ctx context.Context, key string) error {
+	bucketKey := rateBucketKey(key, time.Now().Unix())
+	ttl := strconv.FormatInt(int64(c.retention.Seconds()), 10)
+
+	cmd := c.client.rdb.B().Eval().Script(incrRateBucketScript).Numkeys(1).Key(bucketKey).Arg(ttl).Build()
+	return c.client.rdb.Do(ctx, cmd).Error()
+}
+
+// Count returns how many occurrences were recorded for a key within the trailing window
+func (c *RateCounter) Count(ctx context.Context, key string, window time.Duration) (int64, error) {
+	if window > c.retention {
+		return 0, fmt.Errorf("window %s exceeds counter retention %s", window, c.retention)
+	}
+
+	seconds := int64(window.Seconds())
+	if seconds <= 0 {
+		return 0, nil
+	}
+
+	now := time.Now().Unix()
+	keys := make([]string, 0, seconds)
+	for i := int64(0); i < seconds; i++ {
+		keys = append(keys, rateBucketKey(key, now-i))
+	}
+
+	cmd := c.client.rdb.B().Mget().Key(keys...).Build()
+	values, err := c.client.rdb.Do(ctx, cmd).ToArray()
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, value := range values {
+		count, err := value.AsInt64()
+		if err != nil {
+			// Missing buckets come back as nil
+			continue
+		}
+		total += count
+	}
+
+	return total, nil
+}
+
+// rateBucketKey names the bucket holding a key's count for one second
+func rateBucketKey(key string, unix int64) string {
+	return fmt.Sprintf("rate:%s:%d", key, unix)
+}
@@ -0,0 +1,81 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+// Script is a named Lua script used by the Redis implementations
+type Script struct {
+	Name   string
+	Source string
+}
+
+var (
+	scriptsMu sync.RWMutex
+	scripts   []Script
+)
+
+// RegisterScript records a Lua script so LoadScripts can verify it before traffic arrives, and returns its source
+func RegisterScript(name, source string) string {
+	scriptsMu.Lock()
+	defer scriptsMu.Unlock()
+
+	scripts = append(scripts, Script{Name: name, Source: source})
+	return source
+}
+
+// RegisteredScripts returns every script registered so far
+func RegisteredScripts() []Script {
+	scriptsMu.RLock()
+	defer scriptsMu.RUnlock()
+
+	return append([]Script(nil), scripts...)
+}
+
+// LoadScripts runs SCRIPT LOAD for every registered script and reports each one that fails,
+// surfacing disabled scripting or a broken script at startup instead of at purchase time
+func (c *Client) LoadScripts(ctx context.Context) error {
+	var errs []error
+	for _, script := range RegisteredScripts() {
+		cmd := c.rdb.B().ScriptLoad().Script(script.Source).Build()
+		if err := c.rdb.Do(ctx, cmd).Error(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to load script %s: %w", script.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ScriptHealthChecker reports Redis as ready only when it is reachable and every registered script loads
+type ScriptHealthChecker struct {
+	client *Client
+}
+
+// NewScriptHealthChecker creates a new ScriptHealthChecker
+func NewScriptHealthChecker(client *Client) *ScriptHealthChecker {
+	return &ScriptHealthChecker{
+		client: client,
+	}
+}
+
+// Compile-time check to ensure ScriptHealthChecker implements adapter.HealthChecker
+var _ adapter.HealthChecker = (*ScriptHealthChecker)(nil)
+
+// Name identifies the dependency in health reports
+func (h *ScriptHealthChecker) Name() string {
+	return "redis"
+}
+
+// Check pings Redis and loads every registered script
+func (h *ScriptHealthChecker) Check(ctx context.Context) error {
+	if err := h.client.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping redis: %w", err)
+	}
+
+	return h.client.LoadScripts(ctx)
+}
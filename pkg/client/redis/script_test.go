@@ -0,0 +1,45 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// registerTestScript registers a script for the duration of a test, restoring the registry afterwards
+func registerTestScript(t *testing.T, name, source string) {
+	t.Helper()
+
+	scriptsMu.RLock()
+	registered := len(scripts)
+	scriptsMu.RUnlock()
+	t.Cleanup(func() {
+		scriptsMu.Lock()
+		defer scriptsMu.Unlock()
+		scripts = scripts[:registered]
+	})
+
+	RegisterScript(name, source)
+}
+
+func TestScriptHealthCheckerLoadsRegisteredScripts(t *testing.T) {
+	checker := NewScriptHealthChecker(newTestClient(t))
+	registerTestScript(t, "test_valid", `return redis.call("GET", KEYS[1])`)
+
+	if err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("readiness failed with every script valid: %v", err)
+	}
+}
+
+func TestScriptHealthCheckerFailsOnMalformedScript(t *testing.T) {
+	checker := NewScriptHealthChecker(newTestClient(t))
+	registerTestScript(t, "test_malformed", `return redis.call("GET", KEYS[1]`)
+
+	err := checker.Check(context.Background())
+	if err == nil {
+		t.Fatal("readiness passed with a malformed script registered")
+	}
+	if !strings.Contains(err.Error(), "test_malformed") {
+		t.Fatalf("readiness error %q does not name the malformed script", err)
+	}
+}
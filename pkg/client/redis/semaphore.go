@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+// Semaphore implementation using Redis sorted sets
+type Semaphore struct {
+	client *Client
+}
+
+// NewSemaphore creates a new Semaphore implementation
+func NewSemaphore(client *Client) *Semaphore {
+	return &Semaphore{
+		client: client,
+	}
+}
+
+// Compile-time check to ensure Semaphore implements adapter.Semaphore
+var _ adapter.Semaphore = (*Semaphore)(nil)
+
+// acquireSemaphoreScript reclaims stale slots, checks capacity and takes a slot
+var acquireSemaphoreScript = RegisterScript("semaphore_acquire", `
+	local now = tonumber(ARGV[1])
+	local expiration = tonumber(ARGV[2])
+	redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - expiration)
+	if redis.call("ZCARD", KEYS[1]) >= tonumber(ARGV[3]) then
+		return 0
+	end
+	redis.call("ZADD", KEYS[1], now, ARGV[4])
+	redis.call("PEXPIRE", KEYS[1], expiration)
+	return 1
+`)
+
+// Acquire attempts to take one of limit slots for a key
+func (s *Semaphore) Acquire(ctx context.Context, key string, limit int, expiration time.Duration) (string, bool, error) {
+	semaphoreKey := "semaphore:" + key
+	token := uuid.New().String()
+
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	ttl := strconv.FormatInt(expiration.Milliseconds(), 10)
+	cmd := s.client.rdb.B().Eval().Script(acquireSemaphoreScript).Numkeys(1).Key(semaphoreKey).Arg(now, ttl, strconv.Itoa(limit), token).Build()
+	result := s.client.rdb.Do(ctx, cmd)
+	if result.Error() != nil {
+		return "", false, result.Error()
+	}
+
+	acquired, err := result.ToInt64()
+	if err != nil {
+		return "", false, err
+	}
+
+	if acquired == 0 {
+		return "", false, nil
+	}
+
+	return token, true, nil
+}
+
+// Release returns a slot taken by Acquire
+func (s *Semaphore) Release(ctx context.Context, key, token string) error {
+	semaphoreKey := "semaphore:" + key
+
+	cmd := s.client.rdb.B().Zrem().Key(semaphoreKey).Member(token).Build()
+	return s.client.rdb.Do(ctx, cmd).Error()
+}
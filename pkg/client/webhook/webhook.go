@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+const (
+	// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the timestamp, a ".", and the body
+	SignatureHeader = "X-Ticketing-Signature"
+	// TimestampHeader carries the Unix time in seconds the callback was signed at
+	TimestampHeader = "X-Ticketing-Timestamp"
+)
+
+// DefaultTimeout bounds a single callback delivery, response included
+const DefaultTimeout = 5 * time.Second
+
+// Sender delivers callbacks as signed HTTP POSTs
+type Sender struct {
+	secret []byte
+	client *http.Client
+}
+
+// Compile-time check to ensure Sender implements adapter.CallbackSender
+var _ adapter.CallbackSender = (*Sender)(nil)
+
+// NewSender creates a Sender signing with a shared secret of at least 32 bytes. Redirects are not followed, so
+// a callback only ever reaches the host it was addressed to.
+func NewSender(secret []byte) (*Sender, error) {
+	if len(secret) < 32 {
+		return nil, fmt.Errorf("webhook secret must be at least 32 bytes")
+	}
+
+	return &Sender{
+		secret: secret,
+		client: &http.Client{
+			Timeout: DefaultTimeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}, nil
+}
+
+// Send POSTs a JSON payload to url with its signature and signing time in the headers
+func (s *Sender) Send(ctx context.Context, url string, payload []byte) error {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build callback request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(s.secret, timestamp, payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver callback: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("callback answered with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign computes the hex HMAC-SHA256 a callback sent at timestamp with body is signed with
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a received callback's signature header against its timestamp header and body; receivers should
+// also reject timestamps too far from their own clock to keep old callbacks from being replayed
+func Verify(secret []byte, timestamp string, body []byte, signature string) bool {
+	given, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+
+	expected, _ := hex.DecodeString(Sign(secret, timestamp, body))
+	return hmac.Equal(given, expected)
+}
@@ -0,0 +1,62 @@
+package clock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+// System reads the wall clock; it is what services use when no clock is set
+type System struct{}
+
+// NewSystem creates a new System clock
+func NewSystem() *System {
+	return &System{}
+}
+
+// Compile-time check to ensure System implements adapter.Clock
+var _ adapter.Clock = (*System)(nil)
+
+// Now returns the wall-clock time
+func (c *System) Now() time.Time {
+	return time.Now()
+}
+
+// Manual is a clock that only moves when told to, for deterministic tests of expiry and staggering
+type Manual struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewManual creates a new Manual clock stopped at now
+func NewManual(now time.Time) *Manual {
+	return &Manual{now: now}
+}
+
+// Compile-time check to ensure Manual implements adapter.Clock
+var _ adapter.Clock = (*Manual)(nil)
+
+// Now returns the time the clock is stopped at
+func (c *Manual) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Set stops the clock at now
+func (c *Manual) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+}
+
+// Advance moves the clock forward by d
+func (c *Manual) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
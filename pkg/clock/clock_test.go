@@ -0,0 +1,70 @@
+package clock
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSystemNow(t *testing.T) {
+	before := time.Now()
+	got := NewSystem().Now()
+	after := time.Now()
+
+	if got.Before(before) || got.After(after) {
+		t.Errorf("Now() = %v, want between %v and %v", got, before, after)
+	}
+}
+
+func TestManual(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		move func(c *Manual)
+		want time.Time
+	}{
+		{name: "stopped at start", move: func(c *Manual) {}, want: start},
+		{name: "advanced", move: func(c *Manual) { c.Advance(90 * time.Second) }, want: start.Add(90 * time.Second)},
+		{name: "advanced twice", move: func(c *Manual) { c.Advance(time.Minute); c.Advance(time.Hour) }, want: start.Add(61 * time.Minute)},
+		{name: "advanced backwards", move: func(c *Manual) { c.Advance(-time.Minute) }, want: start.Add(-time.Minute)},
+		{name: "set", move: func(c *Manual) { c.Set(start.Add(24 * time.Hour)) }, want: start.Add(24 * time.Hour)},
+		{name: "set then advanced", move: func(c *Manual) { c.Set(start.Add(-time.Hour)); c.Advance(time.Minute) }, want: start.Add(-59 * time.Minute)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewManual(start)
+			tc.move(c)
+
+			if got := c.Now(); !got.Equal(tc.want) {
+				t.Errorf("Now() = %v, want %v", got, tc.want)
+			}
+			// Reading the clock does not move it
+			if got := c.Now(); !got.Equal(tc.want) {
+				t.Errorf("second Now() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestManualConcurrentAdvance(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := NewManual(start)
+
+	const advances = 100
+	var wg sync.WaitGroup
+	for range advances {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Advance(time.Second)
+			c.Now()
+		}()
+	}
+	wg.Wait()
+
+	if got, want := c.Now(), start.Add(advances*time.Second); !got.Equal(want) {
+		t.Errorf("Now() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,133 @@
+package idgen
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+func TestSequential(t *testing.T) {
+	tests := []struct {
+		name string
+		seed uint32
+		want []string
+	}{
+		{
+			name: "seed 1",
+			seed: 1,
+			want: []string{
+				"00000001-0000-0000-0000-000000000001",
+				"00000001-0000-0000-0000-000000000002",
+				"00000001-0000-0000-0000-000000000003",
+			},
+		},
+		{
+			name: "seed 0",
+			seed: 0,
+			want: []string{
+				"00000000-0000-0000-0000-000000000001",
+				"00000000-0000-0000-0000-000000000002",
+			},
+		},
+		{
+			name: "largest seed",
+			seed: 0xffffffff,
+			want: []string{
+				"ffffffff-0000-0000-0000-000000000001",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewSequential(tc.seed)
+			for i, want := range tc.want {
+				if got := g.NewID().String(); got != want {
+					t.Errorf("ID %d = %s, want %s", i+1, got, want)
+				}
+			}
+
+			// Another generator with the same seed starts over
+			if got := NewSequential(tc.seed).NewID().String(); got != tc.want[0] {
+				t.Errorf("first ID of a fresh generator = %s, want %s", got, tc.want[0])
+			}
+		})
+	}
+}
+
+func TestSequentialConcurrent(t *testing.T) {
+	g := NewSequential(7)
+
+	const ids = 1000
+	var (
+		mu   sync.Mutex
+		seen = make(map[uuid.UUID]bool, ids)
+		wg   sync.WaitGroup
+	)
+	for range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := g.NewID()
+
+			mu.Lock()
+			defer mu.Unlock()
+			if seen[id] {
+				t.Errorf("ID %s generated twice", id)
+			}
+			seen[id] = true
+		}()
+	}
+	wg.Wait()
+
+	// The sequence has no gaps, so the next ID follows the last handed out
+	if got, want := g.NewID().String(), "00000007-0000-0000-0000-0000000003e9"; got != want {
+		t.Errorf("ID after %d = %s, want %s", ids, got, want)
+	}
+}
+
+func TestRandomGenerators(t *testing.T) {
+	tests := []struct {
+		name    string
+		gen     adapter.IDGenerator
+		version uuid.Version
+	}{
+		{name: "v4", gen: NewUUIDv4(), version: 4},
+		{name: "v7", gen: NewUUIDv7(), version: 7},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			seen := make(map[uuid.UUID]bool)
+			for range 100 {
+				id := tc.gen.NewID()
+				if id.Version() != tc.version {
+					t.Fatalf("ID %s is version %d, want %d", id, id.Version(), tc.version)
+				}
+				if id.Variant() != uuid.RFC4122 {
+					t.Fatalf("ID %s has variant %s, want RFC 4122", id, id.Variant())
+				}
+				if seen[id] {
+					t.Fatalf("ID %s generated twice", id)
+				}
+				seen[id] = true
+			}
+		})
+	}
+}
+
+func TestUUIDv7Increases(t *testing.T) {
+	g := NewUUIDv7()
+
+	prev := g.NewID()
+	for range 1000 {
+		id := g.NewID()
+		if bytes.Compare(id[:], prev[:]) <= 0 {
+			t.Fatalf("ID %s does not sort after %s", id, prev)
+		}
+		prev = id
+	}
+}
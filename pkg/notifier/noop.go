@@ -0,0 +1,29 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+// NoopNotifier is a Notifier that silently discards every notification
+type NoopNotifier struct{}
+
+// NewNoopNotifier creates a new NoopNotifier
+func NewNoopNotifier() *NoopNotifier {
+	return &NoopNotifier{}
+}
+
+// Compile-time check to ensure NoopNotifier implements adapter.Notifier
+var _ adapter.Notifier = (*NoopNotifier)(nil)
+
+// SendEmail discards the email
+func (n *NoopNotifier) SendEmail(ctx context.Context, userID uuid.UUID, subject, body string) error {
+	return nil
+}
+
+// SendSMS discards the text message
+func (n *NoopNotifier) SendSMS(ctx context.Context, userID uuid.UUID, message string) error {
+	return nil
+}
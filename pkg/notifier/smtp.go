@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+// ErrSMSNotSupported is returned when an SMS is sent through a notifier that only delivers email
+var ErrSMSNotSupported = errors.New("sms delivery is not supported by this notifier")
+
+// AddressResolver looks up the email address of a user
+type AddressResolver func(ctx context.Context, userID uuid.UUID) (string, error)
+
+// SMTPNotifier implements adapter.Notifier by sending plain-text email over SMTP
+type SMTPNotifier struct {
+	addr    string
+	from    string
+	auth    smtp.Auth
+	resolve AddressResolver
+}
+
+// NewSMTPNotifier creates a new SMTPNotifier.
+// addr is the host:port of the SMTP server and auth may be nil for unauthenticated relays.
+func NewSMTPNotifier(addr, from string, auth smtp.Auth, resolve AddressResolver) *SMTPNotifier {
+	return &SMTPNotifier{
+		addr:    addr,
+		from:    from,
+		auth:    auth,
+		resolve: resolve,
+	}
+}
+
+// Compile-time check to ensure SMTPNotifier implements adapter.Notifier
+var _ adapter.Notifier = (*SMTPNotifier)(nil)
+
+// SendEmail sends an email to a user
+func (n *SMTPNotifier) SendEmail(ctx context.Context, userID uuid.UUID, subject, body string) error {
+	to, err := n.resolve(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve email address: %w", err)
+	}
+
+	var msg strings.Builder
+	msg.WriteString("From: " + n.from + "\r\n")
+	msg.WriteString("To: " + to + "\r\n")
+	msg.WriteString("Subject: " + subject + "\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(body)
+
+	if err := smtp.SendMail(n.addr, n.auth, n.from, []string{to}, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// SendSMS is not supported by the SMTP notifier
+func (n *SMTPNotifier) SendSMS(ctx context.Context, userID uuid.UUID, message string) error {
+	return ErrSMSNotSupported
+}
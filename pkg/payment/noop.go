@@ -0,0 +1,34 @@
+package payment
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+// NoopPaymentGateway is a PaymentGateway that approves every charge and payout without moving money
+type NoopPaymentGateway struct{}
+
+// NewNoopPaymentGateway creates a new NoopPaymentGateway
+func NewNoopPaymentGateway() *NoopPaymentGateway {
+	return &NoopPaymentGateway{}
+}
+
+// Compile-time check to ensure NoopPaymentGateway implements adapter.PaymentGateway
+var _ adapter.PaymentGateway = (*NoopPaymentGateway)(nil)
+
+// Charge approves the charge and returns a fresh payment ID
+func (g *NoopPaymentGateway) Charge(ctx context.Context, userID uuid.UUID, amount int64, currency, reference string) (string, error) {
+	return uuid.NewString(), nil
+}
+
+// Refund approves the refund
+func (g *NoopPaymentGateway) Refund(ctx context.Context, paymentID string) error {
+	return nil
+}
+
+// Payout approves the payout
+func (g *NoopPaymentGateway) Payout(ctx context.Context, userID uuid.UUID, amount int64, currency, reference string) error {
+	return nil
+}
@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// defaultAccessLogMaxLen is how many entries the in-memory access log keeps when no bound is given
+const defaultAccessLogMaxLen = 10000
+
+// purchaseSignalsPerEvent is how many purchase signals are kept for each event
+const purchaseSignalsPerEvent = 1000
+
+// AuditRepository implements repository.AuditRepository with a bounded in-process slice
+type AuditRepository struct {
+	mu      sync.RWMutex
+	entries []*domain.AccessLogEntry
+	signals map[uuid.UUID][]*domain.PurchaseSignal
+	maxLen  int
+	nextID  int64
+}
+
+// NewAuditRepository creates a new in-memory AuditRepository keeping at most maxLen entries; 0 uses a default
+func NewAuditRepository(maxLen int) *AuditRepository {
+	if maxLen <= 0 {
+		maxLen = defaultAccessLogMaxLen
+	}
+
+	return &AuditRepository{
+		signals: make(map[uuid.UUID][]*domain.PurchaseSignal),
+		maxLen:  maxLen,
+	}
+}
+
+// Compile-time check to ensure AuditRepository implements repository.AuditRepository
+var _ repository.AuditRepository = (*AuditRepository)(nil)
+
+// AppendAccess records an access, dropping the oldest entry once the log is full
+func (r *AuditRepository) AppendAccess(ctx context.Context, entry *domain.AccessLogEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	entry.ID = fmt.Sprintf("%020d", r.nextID)
+
+	stored := *entry
+	r.entries = append(r.entries, &stored)
+	if len(r.entries) > r.maxLen {
+		r.entries = r.entries[len(r.entries)-r.maxLen:]
+	}
+
+	return nil
+}
+
+// ListAccess retrieves matching entries newest first
+func (r *AuditRepository) ListAccess(ctx context.Context, filter repository.AccessLogFilter) ([]*domain.AccessLogEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var entries []*domain.AccessLogEntry
+	for i := len(r.entries) - 1; i >= 0 && len(entries) < filter.Limit; i-- {
+		stored := r.entries[i]
+		if filter.UserID != nil && (stored.UserID == nil || *stored.UserID != *filter.UserID) {
+			continue
+		}
+
+		entry := *stored
+		entries = append(entries, &entry)
+	}
+
+	return entries, nil
+}
+
+// AppendPurchaseSignal records a purchase's signals, dropping the event's oldest signal once its log is full
+func (r *AuditRepository) AppendPurchaseSignal(ctx context.Context, signal *domain.PurchaseSignal) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	signal.ID = fmt.Sprintf("%020d", r.nextID)
+
+	stored := *signal
+	stored.TicketIDs = append([]uuid.UUID(nil), signal.TicketIDs...)
+	signals := append(r.signals[signal.EventID], &stored)
+	if len(signals) > purchaseSignalsPerEvent {
+		signals = signals[len(signals)-purchaseSignalsPerEvent:]
+	}
+	r.signals[signal.EventID] = signals
+
+	return nil
+}
+
+// ListPurchaseSignals retrieves up to limit of an event's purchase signals newest first
+func (r *AuditRepository) ListPurchaseSignals(ctx context.Context, eventID uuid.UUID, limit int) ([]*domain.PurchaseSignal, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored := r.signals[eventID]
+	var signals []*domain.PurchaseSignal
+	for i := len(stored) - 1; i >= 0 && len(signals) < limit; i-- {
+		signal := *stored[i]
+		signal.TicketIDs = append([]uuid.UUID(nil), stored[i].TicketIDs...)
+		signals = append(signals, &signal)
+	}
+
+	return signals, nil
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/snowmerak/ticketing/lib/repository"
+	"github.com/snowmerak/ticketing/pkg/repository/repotest"
+)
+
+func TestAuditRepository(t *testing.T) {
+	repotest.RunAuditRepositoryTests(t, func(t *testing.T) repository.AuditRepository {
+		return NewAuditRepository(0)
+	})
+}
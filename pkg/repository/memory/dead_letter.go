@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// DeadLetterRepository implements repository.DeadLetterRepository using in-process maps
+type DeadLetterRepository struct {
+	mu      sync.RWMutex
+	actions map[uuid.UUID]*domain.FailedAction
+}
+
+// NewDeadLetterRepository creates a new in-memory DeadLetterRepository
+func NewDeadLetterRepository() *DeadLetterRepository {
+	return &DeadLetterRepository{
+		actions: make(map[uuid.UUID]*domain.FailedAction),
+	}
+}
+
+// Compile-time check to ensure DeadLetterRepository implements repository.DeadLetterRepository
+var _ repository.DeadLetterRepository = (*DeadLetterRepository)(nil)
+
+// Add records a failed action
+func (r *DeadLetterRepository) Add(ctx context.Context, action *domain.FailedAction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *action
+	r.actions[action.ID] = &stored
+	return nil
+}
+
+// GetByID retrieves a failed action by its ID
+func (r *DeadLetterRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.FailedAction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	action, ok := r.actions[id]
+	if !ok {
+		return nil, repository.ErrFailedActionNotFound
+	}
+
+	result := *action
+	return &result, nil
+}
+
+// List retrieves failed actions oldest first, along with the total count
+func (r *DeadLetterRepository) List(ctx context.Context, offset, limit int) ([]*domain.FailedAction, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	all := make([]*domain.FailedAction, 0, len(r.actions))
+	for _, action := range r.actions {
+		result := *action
+		all = append(all, &result)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].CreatedAt.Before(all[j].CreatedAt)
+	})
+
+	if offset >= len(all) || limit <= 0 {
+		return []*domain.FailedAction{}, len(all), nil
+	}
+
+	end := offset + limit
+	if end > len(all) {
+		end = len(all)
+	}
+
+	return all[offset:end], len(all), nil
+}
+
+// Update persists a new attempt count and error for a failed action
+func (r *DeadLetterRepository) Update(ctx context.Context, action *domain.FailedAction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.actions[action.ID]; !ok {
+		return repository.ErrFailedActionNotFound
+	}
+
+	stored := *action
+	r.actions[action.ID] = &stored
+	return nil
+}
+
+// Remove deletes a failed action once it has been replayed
+func (r *DeadLetterRepository) Remove(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.actions[id]; !ok {
+		return repository.ErrFailedActionNotFound
+	}
+
+	delete(r.actions, id)
+	return nil
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/snowmerak/ticketing/lib/repository"
+	"github.com/snowmerak/ticketing/pkg/repository/repotest"
+)
+
+func TestDeadLetterRepository(t *testing.T) {
+	repotest.RunDeadLetterRepositoryTests(t, func(t *testing.T) repository.DeadLetterRepository {
+		return NewDeadLetterRepository()
+	})
+}
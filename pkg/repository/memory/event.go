@@ -0,0 +1,325 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// EventRepository implements repository.EventRepository using in-process maps
+type EventRepository struct {
+	mu     sync.RWMutex
+	events map[uuid.UUID]*domain.Event
+	alerts map[uuid.UUID]map[int]struct{}
+	zones  map[uuid.UUID]map[string]int
+}
+
+// NewEventRepository creates a new in-memory EventRepository
+func NewEventRepository() *EventRepository {
+	return &EventRepository{
+		events: make(map[uuid.UUID]*domain.Event),
+		alerts: make(map[uuid.UUID]map[int]struct{}),
+		zones:  make(map[uuid.UUID]map[string]int),
+	}
+}
+
+// Compile-time check to ensure EventRepository implements repository.EventRepository
+var _ repository.EventRepository = (*EventRepository)(nil)
+
+// Create creates a new event
+func (r *EventRepository) Create(ctx context.Context, event *domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event.CreatedAt = time.Now()
+	event.UpdatedAt = time.Now()
+
+	stored := *event
+	r.events[event.ID] = &stored
+
+	return nil
+}
+
+// GetByID retrieves an event by its ID
+func (r *EventRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.events[id]
+	if !ok {
+		return nil, fmt.Errorf("event not found")
+	}
+
+	event := *stored
+	return &event, nil
+}
+
+// GetByIDs retrieves the events with the given IDs in request order, skipping any that don't exist
+func (r *EventRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := []*domain.Event{}
+	for _, id := range ids {
+		stored, ok := r.events[id]
+		if !ok {
+			continue
+		}
+
+		event := *stored
+		events = append(events, &event)
+	}
+
+	return events, nil
+}
+
+// Update updates an existing event
+func (r *EventRepository) Update(ctx context.Context, event *domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event.UpdatedAt = time.Now()
+
+	stored := *event
+	r.events[event.ID] = &stored
+
+	return nil
+}
+
+// Delete deletes an event by its ID
+func (r *EventRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.events, id)
+	delete(r.alerts, id)
+	delete(r.zones, id)
+	return nil
+}
+
+// GetIDsByFingerprint retrieves the IDs of the events whose Fingerprint is the given one
+func (r *EventRepository) GetIDsByFingerprint(ctx context.Context, fingerprint string) ([]uuid.UUID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := []uuid.UUID{}
+	for _, event := range r.sortedEvents(func(event *domain.Event) bool { return event.Fingerprint() == fingerprint }) {
+		ids = append(ids, event.ID)
+	}
+
+	return ids, nil
+}
+
+// List retrieves all events with pagination
+func (r *EventRepository) List(ctx context.Context, offset, limit int) ([]*domain.Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	all := r.sortedEvents(func(*domain.Event) bool { return true })
+
+	var events []*domain.Event
+	if offset >= len(all) {
+		return events, nil
+	}
+
+	end := offset + limit
+	if end > len(all) {
+		end = len(all)
+	}
+
+	return all[offset:end], nil
+}
+
+// GetActiveEvents retrieves all active events
+func (r *EventRepository) GetActiveEvents(ctx context.Context) ([]*domain.Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sortedEvents(func(event *domain.Event) bool {
+		return event.Status == string(domain.EventStatusActive)
+	}), nil
+}
+
+// GetByStatus retrieves events with the given status with pagination, along with the total number of matches
+func (r *EventRepository) GetByStatus(ctx context.Context, status string, offset, limit int) ([]*domain.Event, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := r.sortedEvents(func(event *domain.Event) bool {
+		return event.Status == status
+	})
+
+	var events []*domain.Event
+	if offset >= len(matched) {
+		return events, len(matched), nil
+	}
+
+	end := offset + limit
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	return matched[offset:end], len(matched), nil
+}
+
+// UpdateAvailableTickets updates the available ticket count
+func (r *EventRepository) UpdateAvailableTickets(ctx context.Context, eventID uuid.UUID, count int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event, ok := r.events[eventID]
+	if !ok {
+		return fmt.Errorf("event not found")
+	}
+
+	event.AvailableTickets = count
+	event.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// DecrementAvailableTickets decrements available tickets atomically
+func (r *EventRepository) DecrementAvailableTickets(ctx context.Context, eventID uuid.UUID, count int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event, ok := r.events[eventID]
+	if !ok {
+		return fmt.Errorf("event not found")
+	}
+
+	if event.AvailableTickets < count {
+		return fmt.Errorf("insufficient tickets available")
+	}
+
+	event.AvailableTickets -= count
+	event.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// IncrementAvailableTickets increments available tickets atomically
+func (r *EventRepository) IncrementAvailableTickets(ctx context.Context, eventID uuid.UUID, count int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event, ok := r.events[eventID]
+	if !ok {
+		return fmt.Errorf("event not found")
+	}
+
+	event.AvailableTickets += count
+	event.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// InitZoneTickets seeds a standing zone's ticket counter unless the zone already has one
+func (r *EventRepository) InitZoneTickets(ctx context.Context, eventID uuid.UUID, zone string, count int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	counters, ok := r.zones[eventID]
+	if !ok {
+		counters = make(map[string]int)
+		r.zones[eventID] = counters
+	}
+	if _, ok := counters[zone]; !ok {
+		counters[zone] = count
+	}
+
+	return nil
+}
+
+// DecrementZoneTickets takes tickets off a standing zone's counter atomically
+func (r *EventRepository) DecrementZoneTickets(ctx context.Context, eventID uuid.UUID, zone string, count int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	left, ok := r.zones[eventID][zone]
+	if !ok {
+		return repository.ErrStandingZoneNotFound
+	}
+	if left < count {
+		return repository.ErrStandingZoneSoldOut
+	}
+
+	r.zones[eventID][zone] = left - count
+	return nil
+}
+
+// IncrementZoneTickets returns tickets to a standing zone's counter atomically
+func (r *EventRepository) IncrementZoneTickets(ctx context.Context, eventID uuid.UUID, zone string, count int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	left, ok := r.zones[eventID][zone]
+	if !ok {
+		return repository.ErrStandingZoneNotFound
+	}
+
+	r.zones[eventID][zone] = left + count
+	return nil
+}
+
+// GetZoneTickets retrieves how many tickets are left in each of the given zones
+func (r *EventRepository) GetZoneTickets(ctx context.Context, eventID uuid.UUID, zones []string) (map[string]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	left := make(map[string]int, len(zones))
+	for _, zone := range zones {
+		if count, ok := r.zones[eventID][zone]; ok {
+			left[zone] = count
+		}
+	}
+
+	return left, nil
+}
+
+// sortedEvents returns copies of the events matching the predicate ordered by creation time
+func (r *EventRepository) sortedEvents(match func(*domain.Event) bool) []*domain.Event {
+	var events []*domain.Event
+	for _, stored := range r.events {
+		if !match(stored) {
+			continue
+		}
+
+		event := *stored
+		events = append(events, &event)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].CreatedAt.Equal(events[j].CreatedAt) {
+			return events[i].ID.String() < events[j].ID.String()
+		}
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+
+	return events
+}
+
+// MarkCapacityAlert records that a capacity threshold fired, reporting true the first time
+func (r *EventRepository) MarkCapacityAlert(ctx context.Context, eventID uuid.UUID, threshold int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fired, ok := r.alerts[eventID]
+	if !ok {
+		fired = make(map[int]struct{})
+		r.alerts[eventID] = fired
+	}
+
+	if _, ok := fired[threshold]; ok {
+		return false, nil
+	}
+	fired[threshold] = struct{}{}
+
+	return true, nil
+}
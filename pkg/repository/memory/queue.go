@@ -0,0 +1,546 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/adapter"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// queueEntryKey identifies a queue entry by event and user
+type queueEntryKey struct {
+	eventID uuid.UUID
+	userID  uuid.UUID
+}
+
+// QueueRepository implements repository.QueueRepository using in-process maps
+type QueueRepository struct {
+	mu             sync.RWMutex
+	queues         map[uuid.UUID][]uuid.UUID
+	entries        map[queueEntryKey]*domain.QueueEntry
+	sessions       map[string]queueEntryKey
+	byID           map[uuid.UUID]queueEntryKey
+	lastActivation map[uuid.UUID]time.Time
+	ids            adapter.IDGenerator
+}
+
+// NewQueueRepository creates a new in-memory QueueRepository
+func NewQueueRepository() *QueueRepository {
+	return &QueueRepository{
+		queues:         make(map[uuid.UUID][]uuid.UUID),
+		entries:        make(map[queueEntryKey]*domain.QueueEntry),
+		sessions:       make(map[string]queueEntryKey),
+		byID:           make(map[uuid.UUID]queueEntryKey),
+		lastActivation: make(map[uuid.UUID]time.Time),
+	}
+}
+
+// Compile-time check to ensure QueueRepository implements repository.QueueRepository
+var _ repository.QueueRepository = (*QueueRepository)(nil)
+
+// SetIDGenerator sets the generator used for new queue entry IDs; random UUIDs are used when none is set
+func (r *QueueRepository) SetIDGenerator(ids adapter.IDGenerator) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ids = ids
+}
+
+// Join adds a user to the queue for an event
+func (r *QueueRepository) Join(ctx context.Context, eventID, userID uuid.UUID, sessionID string) (*domain.QueueEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := queueEntryKey{eventID: eventID, userID: userID}
+	if existing, ok := r.entries[key]; ok {
+		entry := *existing
+		return &entry, nil
+	}
+
+	length := len(r.queues[eventID])
+
+	entry := &domain.QueueEntry{
+		ID:        r.newIDLocked(),
+		EventID:   eventID,
+		UserID:    userID,
+		Position:  length + 1,
+		Status:    string(domain.QueueStatusWaiting),
+		SessionID: sessionID,
+		EnteredAt: time.Now(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	// If this is the first person in queue, activate them immediately
+	if length == 0 {
+		entry.Status = string(domain.QueueStatusActive)
+		now := time.Now()
+		expiry := now.Add(15 * time.Minute)
+		entry.ExpiresAt = &expiry
+		entry.ActivatedAt = &now
+	}
+
+	r.queues[eventID] = append(r.queues[eventID], userID)
+	r.entries[key] = entry
+	r.sessions[sessionID] = key
+	r.byID[entry.ID] = key
+
+	result := *entry
+	return &result, nil
+}
+
+// GetPosition retrieves a user's position in the queue
+func (r *QueueRepository) GetPosition(ctx context.Context, eventID, userID uuid.UUID) (*domain.QueueEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.entries[queueEntryKey{eventID: eventID, userID: userID}]
+	if !ok {
+		return nil, repository.ErrQueueEntryNotFound
+	}
+
+	entry := *stored
+	return &entry, nil
+}
+
+// GetBySessionID retrieves queue entry by session ID
+func (r *QueueRepository) GetBySessionID(ctx context.Context, sessionID string) (*domain.QueueEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.sessions[sessionID]
+	if !ok {
+		return nil, fmt.Errorf("session not found")
+	}
+
+	stored, ok := r.entries[key]
+	if !ok {
+		return nil, repository.ErrQueueEntryNotFound
+	}
+
+	entry := *stored
+	return &entry, nil
+}
+
+// GetByID retrieves a queue entry by its entry ID
+func (r *QueueRepository) GetByID(ctx context.Context, entryID uuid.UUID) (*domain.QueueEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.byID[entryID]
+	if !ok {
+		return nil, repository.ErrQueueEntryNotFound
+	}
+
+	stored, ok := r.entries[key]
+	if !ok {
+		return nil, repository.ErrQueueEntryNotFound
+	}
+
+	entry := *stored
+	return &entry, nil
+}
+
+// GetNextInQueue retrieves the next user in queue for an event
+func (r *QueueRepository) GetNextInQueue(ctx context.Context, eventID uuid.UUID) (*domain.QueueEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	queue := r.queues[eventID]
+	if len(queue) == 0 {
+		return nil, fmt.Errorf("queue is empty")
+	}
+
+	stored, ok := r.entries[queueEntryKey{eventID: eventID, userID: queue[0]}]
+	if !ok {
+		return nil, repository.ErrQueueEntryNotFound
+	}
+
+	entry := *stored
+	return &entry, nil
+}
+
+// GetPositionInList returns a user's live 1-based index in the event's queue list, or 0 when they are not in it
+func (r *QueueRepository) GetPositionInList(ctx context.Context, eventID, userID uuid.UUID) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i, queued := range r.queues[eventID] {
+		if queued == userID {
+			return i + 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// GetQueueLength retrieves the current queue length for an event
+func (r *QueueRepository) GetQueueLength(ctx context.Context, eventID uuid.UUID) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.queues[eventID]), nil
+}
+
+// GetQueueHead retrieves up to count entries from the front of the queue in order
+func (r *QueueRepository) GetQueueHead(ctx context.Context, eventID uuid.UUID, count int) ([]*domain.QueueEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var entries []*domain.QueueEntry
+	for i, userID := range r.queues[eventID] {
+		if i >= count {
+			break
+		}
+
+		stored, ok := r.entries[queueEntryKey{eventID: eventID, userID: userID}]
+		if !ok {
+			continue
+		}
+
+		entry := *stored
+		entries = append(entries, &entry)
+	}
+
+	return entries, nil
+}
+
+// Update persists changes to an existing queue entry
+func (r *QueueRepository) Update(ctx context.Context, entry *domain.QueueEntry) error {
+	if !domain.QueueStatus(entry.Status).IsValid() {
+		return fmt.Errorf("%w: %q", repository.ErrInvalidQueueStatus, entry.Status)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := queueEntryKey{eventID: entry.EventID, userID: entry.UserID}
+	if _, ok := r.entries[key]; !ok {
+		return repository.ErrQueueEntryNotFound
+	}
+
+	entry.UpdatedAt = time.Now()
+
+	stored := *entry
+	r.entries[key] = &stored
+
+	return nil
+}
+
+// UpdateExpiry moves the expiry of the queue entry behind a session. As the Redis keys would on reaching
+// their TTL, an entry whose expiry is moved into the past is dropped at once
+func (r *QueueRepository) UpdateExpiry(ctx context.Context, sessionID string, expiresAt, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.sessions[sessionID]
+	if !ok {
+		return repository.ErrQueueEntryNotFound
+	}
+
+	entry, ok := r.entries[key]
+	if !ok {
+		return repository.ErrQueueEntryNotFound
+	}
+
+	if !expiresAt.After(time.Now()) {
+		r.deleteEntryLocked(key, entry)
+		return nil
+	}
+
+	entry.ExpiresAt = &expiresAt
+	entry.UpdatedAt = now
+
+	return nil
+}
+
+// GetByUserID retrieves every queue entry of a user across events
+func (r *QueueRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.QueueEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var entries []*domain.QueueEntry
+	for key, stored := range r.entries {
+		if key.userID != userID {
+			continue
+		}
+
+		entry := *stored
+		entries = append(entries, &entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].EnteredAt.Before(entries[j].EnteredAt)
+	})
+
+	return entries, nil
+}
+
+// RemoveUser removes a user's entry, queue slot and session from an event queue
+func (r *QueueRepository) RemoveUser(ctx context.Context, eventID, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := queueEntryKey{eventID: eventID, userID: userID}
+	entry, ok := r.entries[key]
+	if !ok {
+		return repository.ErrQueueEntryNotFound
+	}
+
+	r.deleteEntryLocked(key, entry)
+
+	return nil
+}
+
+// UpdateStatus moves a queue entry to a new status, rejecting unknown statuses and disallowed transitions
+func (r *QueueRepository) UpdateStatus(ctx context.Context, entryID uuid.UUID, status string) error {
+	next := domain.QueueStatus(status)
+	if !next.IsValid() {
+		return fmt.Errorf("%w: %q", repository.ErrInvalidQueueStatus, status)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.byID[entryID]
+	if !ok {
+		return repository.ErrQueueEntryNotFound
+	}
+
+	entry, ok := r.entries[key]
+	if !ok {
+		return repository.ErrQueueEntryNotFound
+	}
+
+	if !domain.QueueStatus(entry.Status).CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s to %s", repository.ErrQueueStatusTransition, entry.Status, status)
+	}
+
+	entry.Status = status
+	entry.UpdatedAt = time.Now()
+	return nil
+}
+
+// ActivateNext activates the next user in queue
+func (r *QueueRepository) ActivateNext(ctx context.Context, eventID uuid.UUID) (*domain.QueueEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Pop the active user at the head, and any others no longer waiting, up to the first waiting user
+	queue := r.queues[eventID]
+	var entry *domain.QueueEntry
+	for len(queue) > 0 {
+		next, ok := r.entries[queueEntryKey{eventID: eventID, userID: queue[0]}]
+		if ok && next.IsWaiting() {
+			entry = next
+			break
+		}
+		queue = queue[1:]
+	}
+	r.queues[eventID] = queue
+
+	if entry == nil {
+		return nil, fmt.Errorf("failed to get next user: queue is empty")
+	}
+
+	entry.Status = string(domain.QueueStatusActive)
+	now := time.Now()
+	expiry := now.Add(15 * time.Minute)
+	entry.ExpiresAt = &expiry
+	entry.ActivatedAt = &now
+	entry.UpdatedAt = now
+
+	result := *entry
+	return &result, nil
+}
+
+// ActivateAll activates the waiting users of an event in queue order
+func (r *QueueRepository) ActivateAll(ctx context.Context, eventID uuid.UUID, expiresAt time.Time, limit int) ([]*domain.QueueEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	queue := r.queues[eventID]
+	now := time.Now()
+
+	activated := []*domain.QueueEntry{}
+	last := -1
+	stopped := false
+	for i, userID := range queue {
+		if limit > 0 && len(activated) >= limit {
+			stopped = true
+			break
+		}
+
+		entry, ok := r.entries[queueEntryKey{eventID: eventID, userID: userID}]
+		if !ok || !entry.IsWaiting() {
+			continue
+		}
+
+		activatedAt := now
+		expiry := expiresAt
+		entry.Status = string(domain.QueueStatusActive)
+		entry.ActivatedAt = &activatedAt
+		entry.ExpiresAt = &expiry
+		entry.UpdatedAt = now
+
+		result := *entry
+		activated = append(activated, &result)
+		last = i
+	}
+
+	if !stopped {
+		r.queues[eventID] = nil
+	} else if last >= 0 {
+		r.queues[eventID] = queue[last:]
+	}
+
+	return activated, nil
+}
+
+// RemoveFromQueue removes a user from the queue
+func (r *QueueRepository) RemoveFromQueue(ctx context.Context, entryID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.byID[entryID]
+	if !ok {
+		return repository.ErrQueueEntryNotFound
+	}
+
+	entry, ok := r.entries[key]
+	if !ok {
+		return repository.ErrQueueEntryNotFound
+	}
+
+	r.deleteEntryLocked(key, entry)
+	return nil
+}
+
+// GetActiveCount counts users holding an active session for an event that is unexpired as of now
+func (r *QueueRepository) GetActiveCount(ctx context.Context, eventID uuid.UUID, now time.Time) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for key, stored := range r.entries {
+		if key.eventID == eventID && stored.IsActive() && !stored.IsExpiredAt(now) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// GetLastActivation retrieves when a user was last activated for an event; the zero time means never
+func (r *QueueRepository) GetLastActivation(ctx context.Context, eventID uuid.UUID) (time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.lastActivation[eventID], nil
+}
+
+// SetLastActivation records when a user was last activated for an event
+func (r *QueueRepository) SetLastActivation(ctx context.Context, eventID uuid.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastActivation[eventID] = at
+	return nil
+}
+
+// GetActiveEntries retrieves the entries holding an active session for an event that is unexpired as of now,
+// soonest to expire first
+func (r *QueueRepository) GetActiveEntries(ctx context.Context, eventID uuid.UUID, now time.Time) ([]*domain.QueueEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := []*domain.QueueEntry{}
+	for key, stored := range r.entries {
+		if key.eventID != eventID || !stored.IsActive() || stored.IsExpiredAt(now) {
+			continue
+		}
+
+		entry := *stored
+		entries = append(entries, &entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return expiresBefore(entries[i], entries[j])
+	})
+
+	return entries, nil
+}
+
+// expiresBefore orders entries by session expiry; entries without one sort last
+func expiresBefore(a, b *domain.QueueEntry) bool {
+	if a.ExpiresAt == nil || b.ExpiresAt == nil {
+		return a.ExpiresAt != nil
+	}
+	return a.ExpiresAt.Before(*b.ExpiresAt)
+}
+
+// GetExpiredEntries retrieves all expired queue entries
+func (r *QueueRepository) GetExpiredEntries(ctx context.Context) ([]*domain.QueueEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var entries []*domain.QueueEntry
+	for _, stored := range r.entries {
+		if !stored.IsExpired() {
+			continue
+		}
+
+		entry := *stored
+		entries = append(entries, &entry)
+	}
+
+	return entries, nil
+}
+
+// CleanupExpiredEntries removes expired entries from the queue
+func (r *QueueRepository) CleanupExpiredEntries(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, entry := range r.entries {
+		if !entry.IsExpired() {
+			continue
+		}
+
+		r.deleteEntryLocked(key, entry)
+	}
+
+	return nil
+}
+
+// newIDLocked returns a new queue entry ID; the caller must hold the write lock
+func (r *QueueRepository) newIDLocked() uuid.UUID {
+	if r.ids == nil {
+		return uuid.New()
+	}
+	return r.ids.NewID()
+}
+
+// deleteEntryLocked removes an entry with its queue slot, session and ID index; the caller must hold the write lock
+func (r *QueueRepository) deleteEntryLocked(key queueEntryKey, entry *domain.QueueEntry) {
+	r.removeFromListLocked(key)
+	delete(r.sessions, entry.SessionID)
+	delete(r.byID, entry.ID)
+	delete(r.entries, key)
+}
+
+// removeFromListLocked removes a user from an event queue list; the caller must hold the write lock
+func (r *QueueRepository) removeFromListLocked(key queueEntryKey) {
+	queue := r.queues[key.eventID]
+	for i, userID := range queue {
+		if userID == key.userID {
+			r.queues[key.eventID] = append(queue[:i:i], queue[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/snowmerak/ticketing/lib/repository"
+	"github.com/snowmerak/ticketing/pkg/repository/repotest"
+)
+
+func TestQueueRepository(t *testing.T) {
+	repotest.RunQueueRepositoryTests(t, func(t *testing.T) repository.QueueRepository {
+		return NewQueueRepository()
+	})
+}
@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// ResaleRepository implements repository.ResaleRepository using in-process maps
+type ResaleRepository struct {
+	mu       sync.RWMutex
+	listings map[uuid.UUID]*domain.ResaleListing
+	byTicket map[uuid.UUID]uuid.UUID // Open listing of each ticket
+}
+
+// NewResaleRepository creates a new in-memory ResaleRepository
+func NewResaleRepository() *ResaleRepository {
+	return &ResaleRepository{
+		listings: make(map[uuid.UUID]*domain.ResaleListing),
+		byTicket: make(map[uuid.UUID]uuid.UUID),
+	}
+}
+
+// Compile-time check to ensure ResaleRepository implements repository.ResaleRepository
+var _ repository.ResaleRepository = (*ResaleRepository)(nil)
+
+// Create stores a new open listing
+func (r *ResaleRepository) Create(ctx context.Context, listing *domain.ResaleListing) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, listed := r.byTicket[listing.TicketID]; listed {
+		return repository.ErrTicketAlreadyListed
+	}
+
+	listing.Status = string(domain.ResaleStatusListed)
+	listing.CreatedAt = time.Now()
+	listing.UpdatedAt = time.Now()
+
+	stored := *listing
+	r.listings[listing.ID] = &stored
+	r.byTicket[listing.TicketID] = listing.ID
+
+	return nil
+}
+
+// GetByID retrieves a listing by its ID
+func (r *ResaleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ResaleListing, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.listings[id]
+	if !ok {
+		return nil, repository.ErrResaleListingNotFound
+	}
+
+	listing := *stored
+	return &listing, nil
+}
+
+// ListOpenByEvent retrieves the open listings of an event, oldest first
+func (r *ResaleRepository) ListOpenByEvent(ctx context.Context, eventID uuid.UUID) ([]*domain.ResaleListing, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var listings []*domain.ResaleListing
+	for _, stored := range r.listings {
+		if stored.EventID != eventID || !stored.IsListed() {
+			continue
+		}
+
+		listing := *stored
+		listings = append(listings, &listing)
+	}
+
+	sort.Slice(listings, func(i, j int) bool {
+		return listings[i].CreatedAt.Before(listings[j].CreatedAt)
+	})
+
+	return listings, nil
+}
+
+// Claim atomically marks an open listing sold to a buyer and takes it off the market
+func (r *ResaleRepository) Claim(ctx context.Context, id, buyerID uuid.UUID) (*domain.ResaleListing, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.listings[id]
+	if !ok {
+		return nil, repository.ErrResaleListingNotFound
+	}
+
+	if !stored.IsListed() {
+		return nil, repository.ErrResaleListingNotAvailable
+	}
+
+	now := time.Now()
+	stored.Status = string(domain.ResaleStatusSold)
+	stored.BuyerID = &buyerID
+	stored.SoldAt = &now
+	stored.UpdatedAt = now
+	delete(r.byTicket, stored.TicketID)
+
+	listing := *stored
+	return &listing, nil
+}
+
+// Reopen puts a claimed listing back on the market
+func (r *ResaleRepository) Reopen(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.listings[id]
+	if !ok {
+		return repository.ErrResaleListingNotFound
+	}
+
+	if !stored.IsSold() {
+		return repository.ErrResaleListingNotAvailable
+	}
+
+	stored.Status = string(domain.ResaleStatusListed)
+	stored.BuyerID = nil
+	stored.SoldAt = nil
+	stored.UpdatedAt = time.Now()
+	r.byTicket[stored.TicketID] = stored.ID
+
+	return nil
+}
+
+// Cancel withdraws an open listing
+func (r *ResaleRepository) Cancel(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.listings[id]
+	if !ok {
+		return repository.ErrResaleListingNotFound
+	}
+
+	if !stored.IsListed() {
+		return repository.ErrResaleListingNotAvailable
+	}
+
+	stored.Status = string(domain.ResaleStatusCancelled)
+	stored.UpdatedAt = time.Now()
+	delete(r.byTicket, stored.TicketID)
+
+	return nil
+}
+
+// Update persists changes to an existing listing
+func (r *ResaleRepository) Update(ctx context.Context, listing *domain.ResaleListing) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.listings[listing.ID]; !ok {
+		return repository.ErrResaleListingNotFound
+	}
+
+	listing.UpdatedAt = time.Now()
+
+	stored := *listing
+	r.listings[listing.ID] = &stored
+
+	return nil
+}
@@ -0,0 +1,341 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// SeatRepository implements repository.SeatRepository using in-process maps
+type SeatRepository struct {
+	mu    sync.RWMutex
+	seats map[uuid.UUID]*domain.Seat
+
+	// holds maps seats reserved by ReserveSeats to when their reservation hold lapses
+	holds        map[uuid.UUID]time.Time
+	holdDuration time.Duration
+}
+
+// NewSeatRepository creates a new in-memory SeatRepository whose reservation holds last holdDuration, or
+// repository.DefaultReservationHold when holdDuration is not positive
+func NewSeatRepository(holdDuration time.Duration) *SeatRepository {
+	if holdDuration <= 0 {
+		holdDuration = repository.DefaultReservationHold
+	}
+
+	return &SeatRepository{
+		seats:        make(map[uuid.UUID]*domain.Seat),
+		holds:        make(map[uuid.UUID]time.Time),
+		holdDuration: holdDuration,
+	}
+}
+
+// Compile-time check to ensure SeatRepository implements repository.SeatRepository
+var _ repository.SeatRepository = (*SeatRepository)(nil)
+
+// Create creates a new seat
+func (r *SeatRepository) Create(ctx context.Context, seat *domain.Seat) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.createLocked(seat)
+	return nil
+}
+
+// CreateBatch creates multiple seats in a single transaction
+func (r *SeatRepository) CreateBatch(ctx context.Context, seats []*domain.Seat) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, seat := range seats {
+		r.createLocked(seat)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a seat by its ID
+func (r *SeatRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Seat, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.seats[id]
+	if !ok {
+		return nil, repository.ErrSeatNotFound
+	}
+
+	seat := *stored
+	return &seat, nil
+}
+
+// GetByEventID retrieves all seats for an event
+func (r *SeatRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain.Seat, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sortedSeats(func(seat *domain.Seat) bool {
+		return seat.EventID == eventID
+	}), nil
+}
+
+// GetAvailableByEventID retrieves available seats for an event
+func (r *SeatRepository) GetAvailableByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain.Seat, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sortedSeats(func(seat *domain.Seat) bool {
+		return seat.EventID == eventID && seat.IsAvailable()
+	}), nil
+}
+
+// CountAvailable counts an event's available seats
+func (r *SeatRepository) CountAvailable(ctx context.Context, eventID uuid.UUID) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, seat := range r.seats {
+		if seat.EventID == eventID && seat.IsAvailable() {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// GetBySection retrieves seats by section
+func (r *SeatRepository) GetBySection(ctx context.Context, eventID uuid.UUID, section string) ([]*domain.Seat, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sortedSeats(func(seat *domain.Seat) bool {
+		return seat.EventID == eventID && seat.Section == section
+	}), nil
+}
+
+// Update updates an existing seat
+func (r *SeatRepository) Update(ctx context.Context, seat *domain.Seat) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.seats[seat.ID]
+	if !ok {
+		return repository.ErrSeatNotFound
+	}
+	if current.Version != seat.Version {
+		return repository.ErrSeatVersionConflict
+	}
+
+	seat.Touch(time.Now())
+
+	stored := *seat
+	r.seats[seat.ID] = &stored
+
+	return nil
+}
+
+// UpdateStatus updates seat status
+func (r *SeatRepository) UpdateStatus(ctx context.Context, seatID uuid.UUID, status string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seat, ok := r.seats[seatID]
+	if !ok {
+		return fmt.Errorf("failed to get seat: seat not found")
+	}
+
+	seat.Status = status
+	seat.Touch(time.Now())
+
+	return nil
+}
+
+// UpdatePrices reprices unsold seats of an event matching the filter and returns how many changed
+func (r *SeatRepository) UpdatePrices(ctx context.Context, eventID uuid.UUID, filter repository.SeatPriceFilter, price int64) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	updated := 0
+	for _, seat := range r.seats {
+		if seat.EventID != eventID || seat.IsSold() {
+			continue
+		}
+		if filter.Section != "" && seat.Section != filter.Section {
+			continue
+		}
+		if filter.Price != nil && seat.Price != *filter.Price {
+			continue
+		}
+
+		seat.Price = price
+		seat.Touch(time.Now())
+		updated++
+	}
+
+	return updated, nil
+}
+
+// ReserveSeats reserves multiple seats atomically
+func (r *SeatRepository) ReserveSeats(ctx context.Context, seatIDs []uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, seatID := range seatIDs {
+		seat, ok := r.seats[seatID]
+		if !ok {
+			return fmt.Errorf("one or more seats not found")
+		}
+		if !seat.CanBeReserved() {
+			return fmt.Errorf("one or more seats not available: %w", repository.ErrSeatNotAvailable)
+		}
+	}
+
+	now := time.Now()
+	for _, seatID := range seatIDs {
+		r.seats[seatID].Status = string(domain.SeatStatusReserved)
+		r.seats[seatID].Touch(now)
+		r.holds[seatID] = now.Add(r.holdDuration)
+	}
+
+	return nil
+}
+
+// ReleaseSeats releases reserved seats atomically
+func (r *SeatRepository) ReleaseSeats(ctx context.Context, seatIDs []uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, seatID := range seatIDs {
+		seat, ok := r.seats[seatID]
+		if !ok {
+			return fmt.Errorf("one or more seats not found")
+		}
+		if !seat.CanBeReleased() {
+			return fmt.Errorf("one or more seats not reserved")
+		}
+	}
+
+	for _, seatID := range seatIDs {
+		r.seats[seatID].Status = string(domain.SeatStatusAvailable)
+		r.seats[seatID].Touch(time.Now())
+		delete(r.holds, seatID)
+	}
+
+	return nil
+}
+
+// ReleaseReservedSeats releases those of the seats that are still reserved and returns their IDs
+func (r *SeatRepository) ReleaseReservedSeats(ctx context.Context, seatIDs []uuid.UUID) ([]uuid.UUID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	released := []uuid.UUID{}
+	for _, seatID := range seatIDs {
+		seat, ok := r.seats[seatID]
+		if !ok || !seat.CanBeReleased() {
+			continue
+		}
+
+		seat.Status = string(domain.SeatStatusAvailable)
+		seat.Touch(time.Now())
+		delete(r.holds, seatID)
+		released = append(released, seatID)
+	}
+
+	return released, nil
+}
+
+// GetLapsedReservationHolds returns the seats still reserved whose reservation hold lapsed by now
+func (r *SeatRepository) GetLapsedReservationHolds(ctx context.Context, now time.Time) ([]*domain.Seat, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for seatID, lapsesAt := range r.holds {
+		if lapsesAt.After(now) {
+			continue
+		}
+		if seat, ok := r.seats[seatID]; !ok || !seat.IsReserved() {
+			delete(r.holds, seatID)
+		}
+	}
+
+	return r.sortedSeats(func(seat *domain.Seat) bool {
+		lapsesAt, ok := r.holds[seat.ID]
+		return ok && !lapsesAt.After(now)
+	}), nil
+}
+
+// ClearReservationHolds drops the reservation holds of seats
+func (r *SeatRepository) ClearReservationHolds(ctx context.Context, seatIDs []uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, seatID := range seatIDs {
+		delete(r.holds, seatID)
+	}
+
+	return nil
+}
+
+// Delete deletes a seat by its ID
+func (r *SeatRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.seats[id]; !ok {
+		return fmt.Errorf("failed to get seat: seat not found")
+	}
+
+	delete(r.seats, id)
+	delete(r.holds, id)
+	return nil
+}
+
+// DeleteByEventID deletes all seats for an event
+func (r *SeatRepository) DeleteByEventID(ctx context.Context, eventID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, seat := range r.seats {
+		if seat.EventID == eventID {
+			delete(r.seats, id)
+			delete(r.holds, id)
+		}
+	}
+
+	return nil
+}
+
+// createLocked stores a copy of a seat; the caller must hold the write lock
+func (r *SeatRepository) createLocked(seat *domain.Seat) {
+	seat.CreatedAt = time.Now()
+	seat.UpdatedAt = time.Now()
+
+	stored := *seat
+	r.seats[seat.ID] = &stored
+}
+
+// sortedSeats returns copies of the seats matching the predicate in a stable order
+func (r *SeatRepository) sortedSeats(match func(*domain.Seat) bool) []*domain.Seat {
+	seats := make([]*domain.Seat, 0)
+	for _, stored := range r.seats {
+		if !match(stored) {
+			continue
+		}
+
+		seat := *stored
+		seats = append(seats, &seat)
+	}
+
+	sort.Slice(seats, func(i, j int) bool {
+		return seats[i].ID.String() < seats[j].ID.String()
+	})
+
+	return seats
+}
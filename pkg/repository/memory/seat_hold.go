@@ -0,0 +1,180 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// seatHold is the user holding a seat and when the hold lapses
+type seatHold struct {
+	userID    uuid.UUID
+	expiresAt time.Time
+}
+
+// live reports whether the hold has not lapsed yet
+func (h seatHold) live(now time.Time) bool {
+	return now.Before(h.expiresAt)
+}
+
+// SeatHoldRepository implements repository.SeatHoldRepository on top of the in-memory seat and ticket
+// repositories, holding both of their locks while converting a hold so the change is atomic
+type SeatHoldRepository struct {
+	mu      sync.Mutex
+	seats   *SeatRepository
+	tickets *TicketRepository
+	holds   map[uuid.UUID]seatHold
+}
+
+// NewSeatHoldRepository creates a new in-memory SeatHoldRepository over the given seat and ticket repositories
+func NewSeatHoldRepository(seats *SeatRepository, tickets *TicketRepository) *SeatHoldRepository {
+	return &SeatHoldRepository{
+		seats:   seats,
+		tickets: tickets,
+		holds:   make(map[uuid.UUID]seatHold),
+	}
+}
+
+// Compile-time check to ensure SeatHoldRepository implements repository.SeatHoldRepository
+var _ repository.SeatHoldRepository = (*SeatHoldRepository)(nil)
+
+// Hold marks an available seat, or a held seat whose hold has lapsed, as held by a user for ttl
+func (r *SeatHoldRepository) Hold(ctx context.Context, seatID, userID uuid.UUID, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seats.mu.Lock()
+	defer r.seats.mu.Unlock()
+
+	seat, ok := r.seats.seats[seatID]
+	if !ok {
+		return repository.ErrSeatNotFound
+	}
+
+	now := time.Now()
+	if seat.IsHeld() {
+		if hold, ok := r.holds[seatID]; ok && hold.live(now) {
+			return repository.ErrSeatNotAvailable
+		}
+	} else if !seat.IsAvailable() {
+		return repository.ErrSeatNotAvailable
+	}
+
+	seat.Status = string(domain.SeatStatusHeld)
+	seat.Touch(now)
+	r.holds[seatID] = seatHold{userID: userID, expiresAt: now.Add(ttl)}
+
+	return nil
+}
+
+// Release drops a user's hold and returns the seat to the available pool
+func (r *SeatHoldRepository) Release(ctx context.Context, seatID, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seats.mu.Lock()
+	defer r.seats.mu.Unlock()
+
+	hold, ok := r.holds[seatID]
+	if !ok || !hold.live(time.Now()) || hold.userID != userID {
+		return repository.ErrSeatHoldNotFound
+	}
+
+	r.releaseLocked(seatID)
+	return nil
+}
+
+// ConvertToTicket atomically moves a held seat to reserved and creates the ticket reserving it
+func (r *SeatHoldRepository) ConvertToTicket(ctx context.Context, ticket *domain.Ticket) error {
+	if ticket.SeatID == nil {
+		return fmt.Errorf("ticket has no seat")
+	}
+	if ticket.Status != string(domain.TicketStatusReserved) || ticket.ExpiresAt == nil {
+		return fmt.Errorf("only reserved tickets with an expiry can be created from a hold")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seats.mu.Lock()
+	defer r.seats.mu.Unlock()
+
+	seatID := *ticket.SeatID
+	seat, ok := r.seats.seats[seatID]
+	if !ok {
+		return repository.ErrSeatNotFound
+	}
+
+	hold, ok := r.holds[seatID]
+	if !ok || !hold.live(time.Now()) {
+		r.releaseLocked(seatID)
+		return repository.ErrSeatHoldNotFound
+	}
+
+	if hold.userID != ticket.UserID || !seat.IsHeld() {
+		return repository.ErrSeatHoldNotFound
+	}
+
+	// The seat lock is still held, so nobody sees the seat between the ticket write and the status flip
+	if err := r.tickets.Create(ctx, ticket); err != nil {
+		return err
+	}
+
+	seat.Status = string(domain.SeatStatusReserved)
+	seat.Touch(time.Now())
+	delete(r.holds, seatID)
+
+	return nil
+}
+
+// GetHeldByEventID retrieves an event's seats under a live hold, whoever holds them
+func (r *SeatHoldRepository) GetHeldByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain.Seat, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seats.mu.RLock()
+	defer r.seats.mu.RUnlock()
+
+	now := time.Now()
+	return r.seats.sortedSeats(func(seat *domain.Seat) bool {
+		hold, ok := r.holds[seat.ID]
+		return ok && hold.live(now) && seat.EventID == eventID && seat.IsHeld()
+	}), nil
+}
+
+// ReleaseExpired returns seats whose holds lapsed to the available pool and reports how many were released
+func (r *SeatHoldRepository) ReleaseExpired(ctx context.Context) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seats.mu.Lock()
+	defer r.seats.mu.Unlock()
+
+	now := time.Now()
+	released := 0
+	for seatID, hold := range r.holds {
+		if hold.live(now) {
+			continue
+		}
+
+		if seat, ok := r.seats.seats[seatID]; ok && seat.IsHeld() {
+			released++
+		}
+		r.releaseLocked(seatID)
+	}
+
+	return released, nil
+}
+
+// releaseLocked drops a hold and makes a still-held seat available; the caller must hold both locks
+func (r *SeatHoldRepository) releaseLocked(seatID uuid.UUID) {
+	delete(r.holds, seatID)
+
+	seat, ok := r.seats.seats[seatID]
+	if !ok || !seat.IsHeld() {
+		return
+	}
+
+	seat.Status = string(domain.SeatStatusAvailable)
+	seat.Touch(time.Now())
+}
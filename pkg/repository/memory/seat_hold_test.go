@@ -0,0 +1,19 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/snowmerak/ticketing/pkg/repository/repotest"
+)
+
+func TestSeatHoldRepository(t *testing.T) {
+	repotest.RunSeatHoldRepositoryTests(t, func(t *testing.T) repotest.SeatHoldRepositories {
+		seats := NewSeatRepository(0)
+		tickets := NewTicketRepository()
+		return repotest.SeatHoldRepositories{
+			Holds:   NewSeatHoldRepository(seats, tickets),
+			Seats:   seats,
+			Tickets: tickets,
+		}
+	})
+}
@@ -0,0 +1,20 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/snowmerak/ticketing/lib/repository"
+	"github.com/snowmerak/ticketing/pkg/repository/repotest"
+)
+
+func TestSeatRepository(t *testing.T) {
+	repotest.RunSeatRepositoryTests(t, func(t *testing.T) repository.SeatRepository {
+		return NewSeatRepository(0)
+	})
+}
+
+func BenchmarkSeatCreate(b *testing.B) {
+	repotest.RunSeatCreateBenchmarks(b, func(b *testing.B) repository.SeatRepository {
+		return NewSeatRepository(0)
+	})
+}
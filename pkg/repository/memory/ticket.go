@@ -0,0 +1,505 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// TicketRepository implements repository.TicketRepository using in-process maps
+type TicketRepository struct {
+	mu         sync.RWMutex
+	tickets    map[uuid.UUID]*domain.Ticket
+	seatTicket map[uuid.UUID]uuid.UUID
+	gaCounters map[uuid.UUID]int64
+	userCounts map[userEventKey]int
+	tokens     map[string]confirmationToken
+	notices    map[uuid.UUID]time.Time
+}
+
+// userEventKey identifies the tickets a user holds for one event
+type userEventKey struct {
+	eventID uuid.UUID
+	userID  uuid.UUID
+}
+
+// confirmationToken is a stored confirmation token and when it lapses
+type confirmationToken struct {
+	ticketID  uuid.UUID
+	expiresAt time.Time
+}
+
+// NewTicketRepository creates a new in-memory TicketRepository
+func NewTicketRepository() *TicketRepository {
+	return &TicketRepository{
+		tickets:    make(map[uuid.UUID]*domain.Ticket),
+		seatTicket: make(map[uuid.UUID]uuid.UUID),
+		gaCounters: make(map[uuid.UUID]int64),
+		userCounts: make(map[userEventKey]int),
+		tokens:     make(map[string]confirmationToken),
+		notices:    make(map[uuid.UUID]time.Time),
+	}
+}
+
+// Compile-time check to ensure TicketRepository implements repository.TicketRepository
+var _ repository.TicketRepository = (*TicketRepository)(nil)
+
+// Create creates a new ticket
+func (r *TicketRepository) Create(ctx context.Context, ticket *domain.Ticket) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ticket.SeatID != nil {
+		if _, taken := r.seatTicket[*ticket.SeatID]; taken {
+			return repository.ErrSeatAlreadyTicketed
+		}
+	}
+
+	ticket.CreatedAt = time.Now()
+	ticket.UpdatedAt = time.Now()
+
+	stored := *ticket
+	r.tickets[ticket.ID] = &stored
+
+	if ticket.SeatID != nil {
+		r.seatTicket[*ticket.SeatID] = ticket.ID
+	}
+
+	return nil
+}
+
+// GetByID retrieves a ticket by its ID
+func (r *TicketRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ticket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.tickets[id]
+	if !ok {
+		return nil, fmt.Errorf("ticket not found")
+	}
+
+	ticket := *stored
+	return &ticket, nil
+}
+
+// GetByIDs retrieves the tickets with the given IDs in request order, skipping any that don't exist
+func (r *TicketRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Ticket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tickets := []*domain.Ticket{}
+	for _, id := range ids {
+		stored, ok := r.tickets[id]
+		if !ok {
+			continue
+		}
+
+		ticket := *stored
+		tickets = append(tickets, &ticket)
+	}
+
+	return tickets, nil
+}
+
+// GetByUserID retrieves all tickets for a user
+func (r *TicketRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Ticket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sortedTickets(func(ticket *domain.Ticket) bool {
+		return ticket.UserID == userID
+	}), nil
+}
+
+// GetByUserAndEvent retrieves a user's tickets for one event
+func (r *TicketRepository) GetByUserAndEvent(ctx context.Context, userID, eventID uuid.UUID) ([]*domain.Ticket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := r.sortedTickets(func(ticket *domain.Ticket) bool {
+		return ticket.UserID == userID && ticket.EventID == eventID
+	})
+
+	return append([]*domain.Ticket{}, matched...), nil
+}
+
+// GetByEventID retrieves all tickets for an event
+func (r *TicketRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain.Ticket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sortedTickets(func(ticket *domain.Ticket) bool {
+		return ticket.EventID == eventID
+	}), nil
+}
+
+// GetByEventIDPaginated retrieves a page of an event's tickets in creation order along with the event's total
+func (r *TicketRepository) GetByEventIDPaginated(ctx context.Context, eventID uuid.UUID, offset, limit int) ([]*domain.Ticket, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := r.sortedTickets(func(ticket *domain.Ticket) bool {
+		return ticket.EventID == eventID
+	})
+
+	tickets := []*domain.Ticket{}
+	if offset >= len(matched) || limit <= 0 {
+		return tickets, len(matched), nil
+	}
+
+	end := offset + limit
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	return append(tickets, matched[offset:end]...), len(matched), nil
+}
+
+// GetByEventIDAndStatus retrieves an event's tickets in one status
+func (r *TicketRepository) GetByEventIDAndStatus(ctx context.Context, eventID uuid.UUID, status string) ([]*domain.Ticket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := r.sortedTickets(func(ticket *domain.Ticket) bool {
+		return ticket.EventID == eventID && ticket.Status == status
+	})
+
+	return append([]*domain.Ticket{}, matched...), nil
+}
+
+// GetByEventIDAndStatusPaginated retrieves a page of an event's tickets in one status
+func (r *TicketRepository) GetByEventIDAndStatusPaginated(ctx context.Context, eventID uuid.UUID, status string, offset, limit int) ([]*domain.Ticket, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := r.sortedTickets(func(ticket *domain.Ticket) bool {
+		return ticket.EventID == eventID && ticket.Status == status
+	})
+
+	tickets := []*domain.Ticket{}
+	if offset >= len(matched) || limit <= 0 {
+		return tickets, len(matched), nil
+	}
+
+	end := offset + limit
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	return append(tickets, matched[offset:end]...), len(matched), nil
+}
+
+// GetBySeatID retrieves a ticket by seat ID
+func (r *TicketRepository) GetBySeatID(ctx context.Context, seatID uuid.UUID) (*domain.Ticket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ticketID, ok := r.seatTicket[seatID]
+	if !ok {
+		return nil, fmt.Errorf("seat ticket not found")
+	}
+
+	stored, ok := r.tickets[ticketID]
+	if !ok {
+		return nil, fmt.Errorf("ticket not found")
+	}
+
+	ticket := *stored
+	return &ticket, nil
+}
+
+// Update updates an existing ticket
+func (r *TicketRepository) Update(ctx context.Context, ticket *domain.Ticket) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ticket.UpdatedAt = time.Now()
+
+	stored := *ticket
+	r.tickets[ticket.ID] = &stored
+
+	return nil
+}
+
+// UpdateStatus updates ticket status
+func (r *TicketRepository) UpdateStatus(ctx context.Context, ticketID uuid.UUID, status string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ticket, ok := r.tickets[ticketID]
+	if !ok {
+		return fmt.Errorf("failed to get ticket: ticket not found")
+	}
+
+	now := time.Now()
+	ticket.SetStatus(status, now)
+	ticket.UpdatedAt = now
+
+	return nil
+}
+
+// Transfer moves a ticket from one holder to another and replaces its gate access token
+func (r *TicketRepository) Transfer(ctx context.Context, ticketID, fromUserID, toUserID uuid.UUID, accessToken string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ticket, ok := r.tickets[ticketID]
+	if !ok {
+		return fmt.Errorf("ticket not found")
+	}
+
+	if ticket.UserID != fromUserID {
+		return repository.ErrTicketOwnerMismatch
+	}
+
+	ticket.UserID = toUserID
+	ticket.GuestEmail = ""
+	ticket.AccessToken = accessToken
+	ticket.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// GetExpiredReservations retrieves the reservations lapsed as of now
+func (r *TicketRepository) GetExpiredReservations(ctx context.Context, now time.Time) ([]*domain.Ticket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sortedTickets(func(ticket *domain.Ticket) bool {
+		return ticket.IsReserved() && ticket.IsExpiredAt(now)
+	}), nil
+}
+
+// GetReservationsExpiringBy retrieves the reservations that lapse at or before by
+func (r *TicketRepository) GetReservationsExpiringBy(ctx context.Context, by time.Time) ([]*domain.Ticket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sortedTickets(func(ticket *domain.Ticket) bool {
+		return ticket.IsReserved() && ticket.ExpiresAt != nil && !ticket.ExpiresAt.After(by)
+	}), nil
+}
+
+// MarkExpiryNotice records that a reservation's holder was warned, reporting true the first time
+func (r *TicketRepository) MarkExpiryNotice(ctx context.Context, ticketID uuid.UUID, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if expiresAt, ok := r.notices[ticketID]; ok && time.Now().Before(expiresAt) {
+		return false, nil
+	}
+
+	r.notices[ticketID] = time.Now().Add(ttl)
+	return true, nil
+}
+
+// ConfirmTicket confirms a reserved ticket
+func (r *TicketRepository) ConfirmTicket(ctx context.Context, ticketID uuid.UUID) error {
+	return r.UpdateStatus(ctx, ticketID, string(domain.TicketStatusConfirmed))
+}
+
+// ConfirmReservation confirms a ticket that is still reserved and issues its gate access token
+func (r *TicketRepository) ConfirmReservation(ctx context.Context, ticketID uuid.UUID, accessToken string, at time.Time) (*domain.Ticket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ticket, ok := r.tickets[ticketID]
+	if !ok {
+		return nil, fmt.Errorf("failed to get ticket: ticket not found")
+	}
+	if !ticket.IsReserved() {
+		return nil, repository.ErrTicketNotReserved
+	}
+
+	ticket.SetStatus(string(domain.TicketStatusConfirmed), at)
+	ticket.AccessToken = accessToken
+	ticket.UpdatedAt = at
+
+	confirmed := *ticket
+	return &confirmed, nil
+}
+
+// CheckIn admits a confirmed ticket that has not been admitted yet
+func (r *TicketRepository) CheckIn(ctx context.Context, ticketID uuid.UUID, at time.Time) (*domain.Ticket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ticket, ok := r.tickets[ticketID]
+	if !ok {
+		return nil, fmt.Errorf("failed to get ticket: ticket not found")
+	}
+	if ticket.IsCheckedIn() {
+		return nil, repository.ErrTicketAlreadyCheckedIn
+	}
+	if !ticket.IsConfirmed() {
+		return nil, repository.ErrTicketNotConfirmed
+	}
+
+	ticket.CheckedInAt = &at
+	ticket.UpdatedAt = at
+
+	admitted := *ticket
+	return &admitted, nil
+}
+
+// CancelTicket cancels a ticket that is not already cancelled and frees its seat mapping for reuse
+func (r *TicketRepository) CancelTicket(ctx context.Context, ticketID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ticket, ok := r.tickets[ticketID]
+	if !ok {
+		return fmt.Errorf("failed to get ticket: ticket not found")
+	}
+	if ticket.IsCancelled() {
+		return repository.ErrTicketAlreadyCancelled
+	}
+
+	r.cancel(ticket)
+	return nil
+}
+
+// CancelReservation cancels a ticket that is still reserved and frees its seat mapping for reuse
+func (r *TicketRepository) CancelReservation(ctx context.Context, ticketID uuid.UUID) (*domain.Ticket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ticket, ok := r.tickets[ticketID]
+	if !ok {
+		return nil, fmt.Errorf("failed to get ticket: ticket not found")
+	}
+	if !ticket.IsReserved() {
+		return nil, repository.ErrTicketNotReserved
+	}
+
+	r.cancel(ticket)
+
+	cancelled := *ticket
+	return &cancelled, nil
+}
+
+// cancel marks a stored ticket cancelled and frees its seat mapping; the caller holds r.mu
+func (r *TicketRepository) cancel(ticket *domain.Ticket) {
+	now := time.Now()
+	ticket.SetStatus(string(domain.TicketStatusCancelled), now)
+	ticket.UpdatedAt = now
+
+	if ticket.SeatID != nil && r.seatTicket[*ticket.SeatID] == ticket.ID {
+		delete(r.seatTicket, *ticket.SeatID)
+	}
+}
+
+// NextGANumber atomically allocates the next general admission number for an event
+func (r *TicketRepository) NextGANumber(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.gaCounters[eventID]++
+	return r.gaCounters[eventID], nil
+}
+
+// IncrementUserEventCount adds count to a user's tickets for an event unless that would exceed limit
+func (r *TicketRepository) IncrementUserEventCount(ctx context.Context, eventID, userID uuid.UUID, count, limit int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := userEventKey{eventID: eventID, userID: userID}
+	if limit > 0 && r.userCounts[key]+count > limit {
+		return r.userCounts[key], repository.ErrUserTicketLimitReached
+	}
+
+	r.userCounts[key] += count
+	return r.userCounts[key], nil
+}
+
+// DecrementUserEventCount takes count off a user's tickets for an event, never going below zero
+func (r *TicketRepository) DecrementUserEventCount(ctx context.Context, eventID, userID uuid.UUID, count int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := userEventKey{eventID: eventID, userID: userID}
+	if r.userCounts[key] <= count {
+		delete(r.userCounts, key)
+		return nil
+	}
+
+	r.userCounts[key] -= count
+	return nil
+}
+
+// SaveConfirmationToken stores a single-use confirmation token hash for a ticket that lapses after ttl
+func (r *TicketRepository) SaveConfirmationToken(ctx context.Context, tokenHash string, ticketID uuid.UUID, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.tokens[tokenHash]; ok && time.Now().Before(existing.expiresAt) {
+		return nil
+	}
+
+	r.tokens[tokenHash] = confirmationToken{ticketID: ticketID, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+// ConsumeConfirmationToken atomically looks up and deletes a confirmation token hash
+func (r *TicketRepository) ConsumeConfirmationToken(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.tokens[tokenHash]
+	if !ok {
+		return uuid.Nil, repository.ErrConfirmationTokenNotFound
+	}
+
+	delete(r.tokens, tokenHash)
+	if !time.Now().Before(token.expiresAt) {
+		return uuid.Nil, repository.ErrConfirmationTokenNotFound
+	}
+
+	return token.ticketID, nil
+}
+
+// Delete deletes a ticket by its ID
+func (r *TicketRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ticket, ok := r.tickets[id]
+	if !ok {
+		return fmt.Errorf("failed to get ticket: ticket not found")
+	}
+
+	if ticket.SeatID != nil && r.seatTicket[*ticket.SeatID] == id {
+		delete(r.seatTicket, *ticket.SeatID)
+	}
+
+	delete(r.tickets, id)
+	return nil
+}
+
+// sortedTickets returns copies of the tickets matching the predicate ordered by creation time
+func (r *TicketRepository) sortedTickets(match func(*domain.Ticket) bool) []*domain.Ticket {
+	var tickets []*domain.Ticket
+	for _, stored := range r.tickets {
+		if !match(stored) {
+			continue
+		}
+
+		ticket := *stored
+		tickets = append(tickets, &ticket)
+	}
+
+	sort.Slice(tickets, func(i, j int) bool {
+		if tickets[i].CreatedAt.Equal(tickets[j].CreatedAt) {
+			return tickets[i].ID.String() < tickets[j].ID.String()
+		}
+		return tickets[i].CreatedAt.Before(tickets[j].CreatedAt)
+	})
+
+	return tickets
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/snowmerak/ticketing/lib/repository"
+	"github.com/snowmerak/ticketing/pkg/repository/repotest"
+)
+
+func TestTicketRepository(t *testing.T) {
+	repotest.RunTicketRepositoryTests(t, func(t *testing.T) repository.TicketRepository {
+		return NewTicketRepository()
+	})
+}
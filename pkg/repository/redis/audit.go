@@ -0,0 +1,214 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+	"github.com/snowmerak/ticketing/pkg/client/redis"
+)
+
+const (
+	// accessLogStreamKey is the Redis stream holding the access log
+	accessLogStreamKey = "audit:access"
+
+	// DefaultAccessLogMaxLen is roughly how many access log entries are kept
+	DefaultAccessLogMaxLen = 100000
+
+	// accessLogScanPage is how many stream entries are read per round trip while filtering
+	accessLogScanPage = 500
+
+	// PurchaseSignalsPerEvent is roughly how many purchase signals are kept for each event
+	PurchaseSignalsPerEvent = 1000
+)
+
+// AuditRepository implements repository.AuditRepository using a capped Redis stream
+type AuditRepository struct {
+	client *redis.Client
+	maxLen int64
+}
+
+// NewAuditRepository creates a new AuditRepository that keeps about maxLen entries; 0 uses DefaultAccessLogMaxLen
+func NewAuditRepository(client *redis.Client, maxLen int64) *AuditRepository {
+	if maxLen <= 0 {
+		maxLen = DefaultAccessLogMaxLen
+	}
+
+	return &AuditRepository{
+		client: client,
+		maxLen: maxLen,
+	}
+}
+
+// Compile-time check to ensure AuditRepository implements repository.AuditRepository
+var _ repository.AuditRepository = (*AuditRepository)(nil)
+
+// AppendAccess records an access; the stream is trimmed approximately to maxLen as it grows
+func (r *AuditRepository) AppendAccess(ctx context.Context, entry *domain.AccessLogEntry) error {
+	userID := ""
+	if entry.UserID != nil {
+		userID = entry.UserID.String()
+	}
+
+	cmd := r.client.GetRedisClient().B().Xadd().Key(accessLogStreamKey).
+		Maxlen().Almost().Threshold(strconv.FormatInt(r.maxLen, 10)).
+		Id("*").FieldValue().
+		FieldValue("endpoint", entry.Endpoint).
+		FieldValue("method", entry.Method).
+		FieldValue("user_id", userID).
+		FieldValue("session_id", entry.SessionID).
+		FieldValue("status", strconv.Itoa(entry.Status)).
+		FieldValue("remote_addr", entry.RemoteAddr).
+		FieldValue("at", entry.At.Format(time.RFC3339Nano)).
+		Build()
+
+	id, err := r.client.GetRedisClient().Do(ctx, cmd).ToString()
+	if err != nil {
+		return fmt.Errorf("failed to append access log entry: %w", err)
+	}
+
+	entry.ID = id
+	return nil
+}
+
+// ListAccess retrieves matching entries newest first, paging backwards through the stream
+func (r *AuditRepository) ListAccess(ctx context.Context, filter repository.AccessLogFilter) ([]*domain.AccessLogEntry, error) {
+	if filter.Limit <= 0 {
+		return nil, nil
+	}
+
+	var entries []*domain.AccessLogEntry
+	end := "+"
+	for len(entries) < filter.Limit {
+		cmd := r.client.GetRedisClient().B().Xrevrange().Key(accessLogStreamKey).End(end).Start("-").Count(accessLogScanPage).Build()
+		page, err := r.client.GetRedisClient().Do(ctx, cmd).AsXRange()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read access log: %w", err)
+		}
+
+		for _, raw := range page {
+			entry := decodeAccessLogEntry(raw.ID, raw.FieldValues)
+			if filter.UserID != nil && (entry.UserID == nil || *entry.UserID != *filter.UserID) {
+				continue
+			}
+
+			entries = append(entries, entry)
+			if len(entries) == filter.Limit {
+				break
+			}
+		}
+
+		if len(page) < accessLogScanPage {
+			break
+		}
+		end = "(" + page[len(page)-1].ID
+	}
+
+	return entries, nil
+}
+
+// decodeAccessLogEntry builds an entry from a stream record's fields
+func decodeAccessLogEntry(id string, fields map[string]string) *domain.AccessLogEntry {
+	entry := &domain.AccessLogEntry{
+		ID:         id,
+		Endpoint:   fields["endpoint"],
+		Method:     fields["method"],
+		SessionID:  fields["session_id"],
+		RemoteAddr: fields["remote_addr"],
+	}
+
+	if userID, err := uuid.Parse(fields["user_id"]); err == nil {
+		entry.UserID = &userID
+	}
+	if status, err := strconv.Atoi(fields["status"]); err == nil {
+		entry.Status = status
+	}
+	if at, err := time.Parse(time.RFC3339Nano, fields["at"]); err == nil {
+		entry.At = at
+	}
+
+	return entry
+}
+
+// purchaseSignalsKey returns the key of the stream holding an event's purchase signals
+func purchaseSignalsKey(eventID uuid.UUID) string {
+	return fmt.Sprintf("audit:purchase_signals:%s", eventID.String())
+}
+
+// AppendPurchaseSignal records a purchase's signals; the event's stream is trimmed approximately to
+// PurchaseSignalsPerEvent as it grows
+func (r *AuditRepository) AppendPurchaseSignal(ctx context.Context, signal *domain.PurchaseSignal) error {
+	ticketIDs := make([]string, len(signal.TicketIDs))
+	for i, ticketID := range signal.TicketIDs {
+		ticketIDs[i] = ticketID.String()
+	}
+
+	cmd := r.client.GetRedisClient().B().Xadd().Key(purchaseSignalsKey(signal.EventID)).
+		Maxlen().Almost().Threshold(strconv.Itoa(PurchaseSignalsPerEvent)).
+		Id("*").FieldValue().
+		FieldValue("user_id", signal.UserID.String()).
+		FieldValue("session_id", signal.SessionID).
+		FieldValue("ticket_ids", strings.Join(ticketIDs, ",")).
+		FieldValue("remote_addr", signal.RemoteAddr).
+		FieldValue("user_agent", signal.UserAgent).
+		FieldValue("at", signal.At.Format(time.RFC3339Nano)).
+		Build()
+
+	id, err := r.client.GetRedisClient().Do(ctx, cmd).ToString()
+	if err != nil {
+		return fmt.Errorf("failed to append purchase signal: %w", err)
+	}
+
+	signal.ID = id
+	return nil
+}
+
+// ListPurchaseSignals retrieves up to limit of an event's purchase signals newest first
+func (r *AuditRepository) ListPurchaseSignals(ctx context.Context, eventID uuid.UUID, limit int) ([]*domain.PurchaseSignal, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	cmd := r.client.GetRedisClient().B().Xrevrange().Key(purchaseSignalsKey(eventID)).End("+").Start("-").Count(int64(limit)).Build()
+	records, err := r.client.GetRedisClient().Do(ctx, cmd).AsXRange()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read purchase signals: %w", err)
+	}
+
+	signals := make([]*domain.PurchaseSignal, 0, len(records))
+	for _, raw := range records {
+		signals = append(signals, decodePurchaseSignal(eventID, raw.ID, raw.FieldValues))
+	}
+
+	return signals, nil
+}
+
+// decodePurchaseSignal builds a purchase signal from a stream record's fields
+func decodePurchaseSignal(eventID uuid.UUID, id string, fields map[string]string) *domain.PurchaseSignal {
+	signal := &domain.PurchaseSignal{
+		ID:         id,
+		EventID:    eventID,
+		SessionID:  fields["session_id"],
+		RemoteAddr: fields["remote_addr"],
+		UserAgent:  fields["user_agent"],
+	}
+
+	if userID, err := uuid.Parse(fields["user_id"]); err == nil {
+		signal.UserID = userID
+	}
+	for _, raw := range strings.Split(fields["ticket_ids"], ",") {
+		if ticketID, err := uuid.Parse(raw); err == nil {
+			signal.TicketIDs = append(signal.TicketIDs, ticketID)
+		}
+	}
+	if at, err := time.Parse(time.RFC3339Nano, fields["at"]); err == nil {
+		signal.At = at
+	}
+
+	return signal
+}
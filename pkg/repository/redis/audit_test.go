@@ -0,0 +1,14 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/snowmerak/ticketing/lib/repository"
+	"github.com/snowmerak/ticketing/pkg/repository/repotest"
+)
+
+func TestAuditRepository(t *testing.T) {
+	repotest.RunAuditRepositoryTests(t, func(t *testing.T) repository.AuditRepository {
+		return NewAuditRepository(newTestClient(t), 0)
+	})
+}
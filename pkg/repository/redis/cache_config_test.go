@@ -0,0 +1,42 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+func TestSetCacheConfig(t *testing.T) {
+	custom := adapter.CacheConfig{
+		EventTTL:      7 * time.Minute,
+		SeatListTTL:   -time.Second,
+		TicketTTL:     3 * time.Second,
+		QueueEntryTTL: 2 * time.Second,
+	}
+	want := adapter.DefaultCacheConfig()
+	want.EventTTL = custom.EventTTL
+	want.TicketTTL = custom.TicketTTL
+	want.QueueEntryTTL = custom.QueueEntryTTL
+
+	events := NewEventRepository(nil)
+	seats := NewSeatRepository(nil, 0)
+	tickets := NewTicketRepository(nil)
+	queue := NewQueueRepository(nil)
+	events.SetCacheConfig(custom)
+	seats.SetCacheConfig(custom)
+	tickets.SetCacheConfig(custom)
+	queue.SetCacheConfig(custom)
+
+	// The TTLs handed to DoCache come from cacheTTL, with unset and negative ones left at their defaults
+	for name, got := range map[string]adapter.CacheConfig{
+		"event":  events.cacheTTL,
+		"seat":   seats.cacheTTL,
+		"ticket": tickets.cacheTTL,
+		"queue":  queue.cacheTTL,
+	} {
+		if got != want {
+			t.Errorf("%s repository caches with %+v, want %+v", name, got, want)
+		}
+	}
+}
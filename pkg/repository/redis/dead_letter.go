@@ -0,0 +1,160 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/redis/rueidis"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+	"github.com/snowmerak/ticketing/pkg/client/redis"
+)
+
+const (
+	deadLetterActionsKey = "dead_letter:actions" // hash of action ID -> JSON
+	deadLetterIndexKey   = "dead_letter:index"   // sorted set of action IDs scored by creation time
+)
+
+// DeadLetterRepository implements repository.DeadLetterRepository using Redis
+type DeadLetterRepository struct {
+	client *redis.Client
+}
+
+// NewDeadLetterRepository creates a new DeadLetterRepository
+func NewDeadLetterRepository(client *redis.Client) *DeadLetterRepository {
+	return &DeadLetterRepository{
+		client: client,
+	}
+}
+
+// Compile-time check to ensure DeadLetterRepository implements repository.DeadLetterRepository
+var _ repository.DeadLetterRepository = (*DeadLetterRepository)(nil)
+
+// Add records a failed action
+func (r *DeadLetterRepository) Add(ctx context.Context, action *domain.FailedAction) error {
+	data, err := json.Marshal(action)
+	if err != nil {
+		return fmt.Errorf("failed to marshal failed action: %w", err)
+	}
+
+	id := action.ID.String()
+
+	setCmd := r.client.GetRedisClient().B().Hset().Key(deadLetterActionsKey).FieldValue().FieldValue(id, string(data)).Build()
+	if err := r.client.GetRedisClient().Do(ctx, setCmd).Error(); err != nil {
+		return fmt.Errorf("failed to add failed action: %w", err)
+	}
+
+	// Index by creation time so the oldest inconsistencies are listed and retried first
+	indexCmd := r.client.GetRedisClient().B().Zadd().Key(deadLetterIndexKey).ScoreMember().ScoreMember(float64(action.CreatedAt.UnixMilli()), id).Build()
+	if err := r.client.GetRedisClient().Do(ctx, indexCmd).Error(); err != nil {
+		return fmt.Errorf("failed to index failed action: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a failed action by its ID
+func (r *DeadLetterRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.FailedAction, error) {
+	cmd := r.client.GetRedisClient().B().Hget().Key(deadLetterActionsKey).Field(id.String()).Build()
+	result := r.client.GetRedisClient().Do(ctx, cmd)
+	if result.Error() != nil {
+		if rueidis.IsRedisNil(result.Error()) {
+			return nil, repository.ErrFailedActionNotFound
+		}
+		return nil, fmt.Errorf("failed to get failed action: %w", result.Error())
+	}
+
+	data, err := result.ToString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get failed action data: %w", err)
+	}
+
+	var action domain.FailedAction
+	if err := json.Unmarshal([]byte(data), &action); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal failed action: %w", err)
+	}
+
+	return &action, nil
+}
+
+// List retrieves failed actions oldest first, along with the total count
+func (r *DeadLetterRepository) List(ctx context.Context, offset, limit int) ([]*domain.FailedAction, int, error) {
+	countCmd := r.client.GetRedisClient().B().Zcard().Key(deadLetterIndexKey).Build()
+	total, err := r.client.GetRedisClient().Do(ctx, countCmd).ToInt64()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count failed actions: %w", err)
+	}
+
+	actions := []*domain.FailedAction{}
+	if limit <= 0 || int64(offset) >= total {
+		return actions, int(total), nil
+	}
+
+	rangeCmd := r.client.GetRedisClient().B().Zrange().Key(deadLetterIndexKey).Min(fmt.Sprint(offset)).Max(fmt.Sprint(offset + limit - 1)).Build()
+	members, err := r.client.GetRedisClient().Do(ctx, rangeCmd).AsStrSlice()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list failed actions: %w", err)
+	}
+
+	for _, member := range members {
+		id, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+
+		action, err := r.GetByID(ctx, id)
+		if err != nil {
+			continue
+		}
+
+		actions = append(actions, action)
+	}
+
+	return actions, int(total), nil
+}
+
+// Update persists a new attempt count and error for a failed action
+func (r *DeadLetterRepository) Update(ctx context.Context, action *domain.FailedAction) error {
+	existsCmd := r.client.GetRedisClient().B().Hexists().Key(deadLetterActionsKey).Field(action.ID.String()).Build()
+	exists, err := r.client.GetRedisClient().Do(ctx, existsCmd).AsBool()
+	if err != nil {
+		return fmt.Errorf("failed to check failed action: %w", err)
+	}
+	if !exists {
+		return repository.ErrFailedActionNotFound
+	}
+
+	data, err := json.Marshal(action)
+	if err != nil {
+		return fmt.Errorf("failed to marshal failed action: %w", err)
+	}
+
+	cmd := r.client.GetRedisClient().B().Hset().Key(deadLetterActionsKey).FieldValue().FieldValue(action.ID.String(), string(data)).Build()
+	if err := r.client.GetRedisClient().Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("failed to update failed action: %w", err)
+	}
+
+	return nil
+}
+
+// Remove deletes a failed action once it has been replayed
+func (r *DeadLetterRepository) Remove(ctx context.Context, id uuid.UUID) error {
+	delCmd := r.client.GetRedisClient().B().Hdel().Key(deadLetterActionsKey).Field(id.String()).Build()
+	removed, err := r.client.GetRedisClient().Do(ctx, delCmd).ToInt64()
+	if err != nil {
+		return fmt.Errorf("failed to remove failed action: %w", err)
+	}
+
+	zremCmd := r.client.GetRedisClient().B().Zrem().Key(deadLetterIndexKey).Member(id.String()).Build()
+	if err := r.client.GetRedisClient().Do(ctx, zremCmd).Error(); err != nil {
+		return fmt.Errorf("failed to remove failed action from index: %w", err)
+	}
+
+	if removed == 0 {
+		return repository.ErrFailedActionNotFound
+	}
+
+	return nil
+}
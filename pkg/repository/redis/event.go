@@ -4,9 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/redis/rueidis"
+	"github.com/snowmerak/ticketing/lib/adapter"
 	"github.com/snowmerak/ticketing/lib/domain"
 	"github.com/snowmerak/ticketing/lib/repository"
 	"github.com/snowmerak/ticketing/pkg/client/redis"
@@ -14,16 +18,23 @@ import (
 
 // EventRepository implements repository.EventRepository using Redis
 type EventRepository struct {
-	client *redis.Client
+	client   *redis.Client
+	cacheTTL adapter.CacheConfig
 }
 
 // NewEventRepository creates a new EventRepository
 func NewEventRepository(client *redis.Client) *EventRepository {
 	return &EventRepository{
-		client: client,
+		client:   client,
+		cacheTTL: adapter.DefaultCacheConfig(),
 	}
 }
 
+// SetCacheConfig sets how long client-side cached reads are served; unset TTLs keep their defaults
+func (r *EventRepository) SetCacheConfig(config adapter.CacheConfig) {
+	r.cacheTTL = config.WithDefaults()
+}
+
 // Compile-time check to ensure EventRepository implements repository.EventRepository
 var _ repository.EventRepository = (*EventRepository)(nil)
 
@@ -53,6 +64,18 @@ func (r *EventRepository) Create(ctx context.Context, event *domain.Event) error
 		}
 	}
 
+	// Add to status index
+	statusCmd := r.client.GetRedisClient().B().Sadd().Key(eventStatusKey(event.Status)).Member(event.ID.String()).Build()
+	if err := r.client.GetRedisClient().Do(ctx, statusCmd).Error(); err != nil {
+		return fmt.Errorf("failed to add to status index: %w", err)
+	}
+
+	// Add to duplicate detection index
+	fingerprintCmd := r.client.GetRedisClient().B().Sadd().Key(eventFingerprintKey(event.Fingerprint())).Member(event.ID.String()).Build()
+	if err := r.client.GetRedisClient().Do(ctx, fingerprintCmd).Error(); err != nil {
+		return fmt.Errorf("failed to add to fingerprint index: %w", err)
+	}
+
 	// Add to all events index
 	allCmd := r.client.GetRedisClient().B().Sadd().Key("events:all").Member(event.ID.String()).Build()
 	if err := r.client.GetRedisClient().Do(ctx, allCmd).Error(); err != nil {
@@ -66,9 +89,8 @@ func (r *EventRepository) Create(ctx context.Context, event *domain.Event) error
 func (r *EventRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Event, error) {
 	key := fmt.Sprintf("event:%s", id.String())
 
-	const clientSideCacheTTL = 1 * time.Hour
 	cmd := r.client.GetRedisClient().B().Get().Key(key).Cache()
-	result := r.client.GetRedisClient().DoCache(ctx, cmd, clientSideCacheTTL)
+	result := r.client.GetRedisClient().DoCache(ctx, cmd, r.cacheTTL.EventTTL)
 	if result.Error() != nil {
 		return nil, fmt.Errorf("failed to get event: %w", result.Error())
 	}
@@ -86,6 +108,42 @@ func (r *EventRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ev
 	return &event, nil
 }
 
+// GetByIDs retrieves the events with the given IDs in request order, skipping any that don't exist
+func (r *EventRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Event, error) {
+	events := []*domain.Event{}
+	if len(ids) == 0 {
+		return events, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = fmt.Sprintf("event:%s", id.String())
+	}
+
+	cmd := r.client.GetRedisClient().B().Mget().Key(keys...).Build()
+	values, err := r.client.GetRedisClient().Do(ctx, cmd).ToArray()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+
+	for _, value := range values {
+		data, err := value.ToString()
+		if err != nil {
+			// Missing events come back as nil
+			continue
+		}
+
+		var event domain.Event
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+		}
+
+		events = append(events, &event)
+	}
+
+	return events, nil
+}
+
 // Update updates an existing event
 func (r *EventRepository) Update(ctx context.Context, event *domain.Event) error {
 	event.UpdatedAt = time.Now()
@@ -97,6 +155,12 @@ func (r *EventRepository) Update(ctx context.Context, event *domain.Event) error
 
 	key := fmt.Sprintf("event:%s", event.ID.String())
 
+	// Read the fingerprint the event was indexed under before overwriting it
+	previous, err := r.storedFingerprint(ctx, event.ID)
+	if err != nil {
+		return err
+	}
+
 	// Update the event data
 	cmd := r.client.GetRedisClient().B().Set().Key(key).Value(string(data)).Build()
 	if err := r.client.GetRedisClient().Do(ctx, cmd).Error(); err != nil {
@@ -116,6 +180,16 @@ func (r *EventRepository) Update(ctx context.Context, event *domain.Event) error
 		}
 	}
 
+	// Move the event into the index of its current status
+	if err := r.updateStatusIndex(ctx, event.ID, event.Status); err != nil {
+		return err
+	}
+
+	// Move the event to the fingerprint of its current name, venue and start day
+	if err := r.updateFingerprintIndex(ctx, event.ID, previous, event.Fingerprint()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -123,6 +197,11 @@ func (r *EventRepository) Update(ctx context.Context, event *domain.Event) error
 func (r *EventRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	key := fmt.Sprintf("event:%s", id.String())
 
+	previous, err := r.storedFingerprint(ctx, id)
+	if err != nil {
+		return err
+	}
+
 	// Remove from Redis
 	delCmd := r.client.GetRedisClient().B().Del().Key(key).Build()
 	if err := r.client.GetRedisClient().Do(ctx, delCmd).Error(); err != nil {
@@ -141,14 +220,116 @@ func (r *EventRepository) Delete(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("failed to remove from active events: %w", err)
 	}
 
+	alertsCmd := r.client.GetRedisClient().B().Del().Key(capacityAlertsKey(id)).Build()
+	if err := r.client.GetRedisClient().Do(ctx, alertsCmd).Error(); err != nil {
+		return fmt.Errorf("failed to delete capacity alerts: %w", err)
+	}
+
+	if err := r.deleteZoneTickets(ctx, id); err != nil {
+		return err
+	}
+
+	if err := r.updateStatusIndex(ctx, id, ""); err != nil {
+		return err
+	}
+
+	if err := r.updateFingerprintIndex(ctx, id, previous, ""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// storedFingerprint returns the fingerprint of the event as currently stored, or "" when it isn't stored
+func (r *EventRepository) storedFingerprint(ctx context.Context, id uuid.UUID) (string, error) {
+	cmd := r.client.GetRedisClient().B().Get().Key(fmt.Sprintf("event:%s", id.String())).Build()
+	data, err := r.client.GetRedisClient().Do(ctx, cmd).ToString()
+	if rueidis.IsRedisNil(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get stored event: %w", err)
+	}
+
+	var event domain.Event
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		return "", fmt.Errorf("failed to unmarshal stored event: %w", err)
+	}
+
+	return event.Fingerprint(), nil
+}
+
+// updateFingerprintIndex moves an event from the index set of its previous fingerprint to that of its current
+// one; an empty fingerprint on either side is skipped
+func (r *EventRepository) updateFingerprintIndex(ctx context.Context, id uuid.UUID, previous, current string) error {
+	if previous == current {
+		return nil
+	}
+
+	if previous != "" {
+		remCmd := r.client.GetRedisClient().B().Srem().Key(eventFingerprintKey(previous)).Member(id.String()).Build()
+		if err := r.client.GetRedisClient().Do(ctx, remCmd).Error(); err != nil {
+			return fmt.Errorf("failed to remove from fingerprint index: %w", err)
+		}
+	}
+
+	if current != "" {
+		addCmd := r.client.GetRedisClient().B().Sadd().Key(eventFingerprintKey(current)).Member(id.String()).Build()
+		if err := r.client.GetRedisClient().Do(ctx, addCmd).Error(); err != nil {
+			return fmt.Errorf("failed to add to fingerprint index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetIDsByFingerprint retrieves the IDs of the events whose Fingerprint is the given one
+func (r *EventRepository) GetIDsByFingerprint(ctx context.Context, fingerprint string) ([]uuid.UUID, error) {
+	cmd := r.client.GetRedisClient().B().Smembers().Key(eventFingerprintKey(fingerprint)).Build()
+	members, err := r.client.GetRedisClient().Do(ctx, cmd).AsStrSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events by fingerprint: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		id, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// deleteZoneTickets drops the ticket counters of every standing zone an event has had
+func (r *EventRepository) deleteZoneTickets(ctx context.Context, eventID uuid.UUID) error {
+	zonesKey := standingZonesKey(eventID)
+	membersCmd := r.client.GetRedisClient().B().Smembers().Key(zonesKey).Build()
+	zones, err := r.client.GetRedisClient().Do(ctx, membersCmd).AsStrSlice()
+	if err != nil {
+		return fmt.Errorf("failed to get standing zones: %w", err)
+	}
+
+	keys := make([]string, 0, len(zones)+1)
+	for _, zone := range zones {
+		keys = append(keys, standingZoneKey(eventID, zone))
+	}
+	keys = append(keys, zonesKey)
+
+	delCmd := r.client.GetRedisClient().B().Del().Key(keys...).Build()
+	if err := r.client.GetRedisClient().Do(ctx, delCmd).Error(); err != nil {
+		return fmt.Errorf("failed to delete standing zone counters: %w", err)
+	}
+
 	return nil
 }
 
 // List retrieves all events with pagination
 func (r *EventRepository) List(ctx context.Context, offset, limit int) ([]*domain.Event, error) {
-	const clientSideCacheTTL = 2 * time.Minute // shorter TTL for events list
 	cmd := r.client.GetRedisClient().B().Smembers().Key("events:all").Cache()
-	result := r.client.GetRedisClient().DoCache(ctx, cmd, clientSideCacheTTL)
+	result := r.client.GetRedisClient().DoCache(ctx, cmd, r.cacheTTL.EventListTTL)
 	if result.Error() != nil {
 		return nil, fmt.Errorf("failed to get all events: %w", result.Error())
 	}
@@ -189,9 +370,8 @@ func (r *EventRepository) List(ctx context.Context, offset, limit int) ([]*domai
 
 // GetActiveEvents retrieves all active events
 func (r *EventRepository) GetActiveEvents(ctx context.Context) ([]*domain.Event, error) {
-	const clientSideCacheTTL = 5 * time.Minute // shorter TTL for active events list
 	cmd := r.client.GetRedisClient().B().Smembers().Key("events:active").Cache()
-	result := r.client.GetRedisClient().DoCache(ctx, cmd, clientSideCacheTTL)
+	result := r.client.GetRedisClient().DoCache(ctx, cmd, r.cacheTTL.ActiveEventsTTL)
 	if result.Error() != nil {
 		return nil, fmt.Errorf("failed to get active events: %w", result.Error())
 	}
@@ -219,112 +399,274 @@ func (r *EventRepository) GetActiveEvents(ctx context.Context) ([]*domain.Event,
 	return events, nil
 }
 
-// UpdateAvailableTickets updates the available ticket count
-func (r *EventRepository) UpdateAvailableTickets(ctx context.Context, eventID uuid.UUID, count int) error {
-	event, err := r.GetByID(ctx, eventID)
+// GetByStatus retrieves events with the given status with pagination, along with the total number of matches
+func (r *EventRepository) GetByStatus(ctx context.Context, status string, offset, limit int) ([]*domain.Event, int, error) {
+	cmd := r.client.GetRedisClient().B().Smembers().Key(eventStatusKey(status)).Cache()
+	result := r.client.GetRedisClient().DoCache(ctx, cmd, r.cacheTTL.EventsByStatusTTL)
+	if result.Error() != nil {
+		return nil, 0, fmt.Errorf("failed to get events by status: %w", result.Error())
+	}
+
+	members, err := result.AsStrSlice()
 	if err != nil {
-		return fmt.Errorf("failed to get event: %w", err)
+		return nil, 0, fmt.Errorf("failed to parse members: %w", err)
 	}
 
-	event.AvailableTickets = count
+	// Sets are unordered, so sort to keep pages stable between requests
+	sort.Strings(members)
 
-	return r.Update(ctx, event)
-}
+	var events []*domain.Event
+	start := offset
+	end := offset + limit
 
-// DecrementAvailableTickets decrements available tickets atomically
-func (r *EventRepository) DecrementAvailableTickets(ctx context.Context, eventID uuid.UUID, count int) error {
-	key := fmt.Sprintf("event:%s:available_tickets", eventID.String())
+	if start >= len(members) {
+		return events, len(members), nil
+	}
 
-	// Use Lua script for atomic decrement
-	script := `
-		local current = redis.call('GET', KEYS[1])
-		if current == false then
-			return -1
-		end
-		
-		local currentVal = tonumber(current)
-		local decrementBy = tonumber(ARGV[1])
-		
-		if currentVal < decrementBy then
-			return -2
-		end
-		
-		local newVal = currentVal - decrementBy
-		redis.call('SET', KEYS[1], newVal)
-		return newVal
-	`
-
-	cmd := r.client.GetRedisClient().B().Eval().Script(script).Numkeys(1).Key(key).Arg(fmt.Sprintf("%d", count)).Build()
-	result := r.client.GetRedisClient().Do(ctx, cmd)
-	if result.Error() != nil {
-		return fmt.Errorf("failed to decrement available tickets: %w", result.Error())
+	if end > len(members) {
+		end = len(members)
 	}
 
-	resultVal, err := result.ToInt64()
-	if err != nil {
-		return fmt.Errorf("failed to parse result: %w", err)
+	for i := start; i < end; i++ {
+		eventID, err := uuid.Parse(members[i])
+		if err != nil {
+			continue
+		}
+
+		event, err := r.GetByID(ctx, eventID)
+		if err != nil {
+			continue
+		}
+
+		events = append(events, event)
 	}
 
-	if resultVal == -1 {
-		return fmt.Errorf("event not found")
+	return events, len(members), nil
+}
+
+// updateStatusIndex adds the event to the index of the given status and removes it from every other status index.
+// An empty status removes the event from all status indexes.
+func (r *EventRepository) updateStatusIndex(ctx context.Context, id uuid.UUID, status string) error {
+	for _, known := range domain.EventStatuses {
+		if string(known) == status {
+			continue
+		}
+
+		remCmd := r.client.GetRedisClient().B().Srem().Key(eventStatusKey(string(known))).Member(id.String()).Build()
+		if err := r.client.GetRedisClient().Do(ctx, remCmd).Error(); err != nil {
+			return fmt.Errorf("failed to remove from status index: %w", err)
+		}
 	}
 
-	if resultVal == -2 {
-		return fmt.Errorf("insufficient tickets available")
+	if status == "" {
+		return nil
 	}
 
-	// Update the event object
-	event, err := r.GetByID(ctx, eventID)
+	addCmd := r.client.GetRedisClient().B().Sadd().Key(eventStatusKey(status)).Member(id.String()).Build()
+	if err := r.client.GetRedisClient().Do(ctx, addCmd).Error(); err != nil {
+		return fmt.Errorf("failed to add to status index: %w", err)
+	}
+
+	return nil
+}
+
+// eventFingerprintKey returns the key of the index set for events sharing a duplicate detection fingerprint
+func eventFingerprintKey(fingerprint string) string {
+	return fmt.Sprintf("events:fingerprint:%s", fingerprint)
+}
+
+// eventStatusKey returns the key of the index set for events with the given status
+func eventStatusKey(status string) string {
+	return fmt.Sprintf("events:status:%s", status)
+}
+
+// adjustAvailableTicketsScript changes the available ticket count inside the event record itself,
+// so concurrent purchases and releases can't overwrite each other's count.
+// ARGV[1] is the mode ("set", "decrement" or "increment"), ARGV[2] the amount and ARGV[3] the update time.
+var adjustAvailableTicketsScript = redis.RegisterScript("event_adjust_available_tickets", `
+	local data = redis.call('GET', KEYS[1])
+	if data == false then
+		return -1
+	end
+
+	local event = cjson.decode(data)
+	local amount = tonumber(ARGV[2])
+	local available = tonumber(event.available_tickets) or 0
+
+	if ARGV[1] == 'set' then
+		available = amount
+	elseif ARGV[1] == 'decrement' then
+		if available < amount then
+			return -2
+		end
+		available = available - amount
+	else
+		available = available + amount
+	end
+
+	event.available_tickets = available
+	event.updated_at = ARGV[3]
+	redis.call('SET', KEYS[1], cjson.encode(event))
+	return available
+`)
+
+// adjustAvailableTickets runs the available ticket script in the given mode
+func (r *EventRepository) adjustAvailableTickets(ctx context.Context, eventID uuid.UUID, mode string, count int) error {
+	key := fmt.Sprintf("event:%s", eventID.String())
+	now := time.Now().Format(time.RFC3339Nano)
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(adjustAvailableTicketsScript).Numkeys(1).Key(key).Arg(mode, strconv.Itoa(count), now).Build()
+	result, err := r.client.GetRedisClient().Do(ctx, cmd).ToInt64()
 	if err != nil {
-		return fmt.Errorf("failed to get event: %w", err)
+		return fmt.Errorf("failed to %s available tickets: %w", mode, err)
 	}
 
-	event.AvailableTickets = int(resultVal)
+	switch result {
+	case -1:
+		return fmt.Errorf("event not found")
+	case -2:
+		return fmt.Errorf("insufficient tickets available")
+	}
+
+	return nil
+}
 
-	return r.Update(ctx, event)
+// UpdateAvailableTickets updates the available ticket count
+func (r *EventRepository) UpdateAvailableTickets(ctx context.Context, eventID uuid.UUID, count int) error {
+	return r.adjustAvailableTickets(ctx, eventID, "set", count)
+}
+
+// DecrementAvailableTickets decrements available tickets atomically
+func (r *EventRepository) DecrementAvailableTickets(ctx context.Context, eventID uuid.UUID, count int) error {
+	return r.adjustAvailableTickets(ctx, eventID, "decrement", count)
 }
 
 // IncrementAvailableTickets increments available tickets atomically
 func (r *EventRepository) IncrementAvailableTickets(ctx context.Context, eventID uuid.UUID, count int) error {
-	key := fmt.Sprintf("event:%s:available_tickets", eventID.String())
+	return r.adjustAvailableTickets(ctx, eventID, "increment", count)
+}
+
+// MarkCapacityAlert records that a capacity threshold fired; SADD makes only the first caller see it as new
+func (r *EventRepository) MarkCapacityAlert(ctx context.Context, eventID uuid.UUID, threshold int) (bool, error) {
+	cmd := r.client.GetRedisClient().B().Sadd().Key(capacityAlertsKey(eventID)).Member(strconv.Itoa(threshold)).Build()
+	added, err := r.client.GetRedisClient().Do(ctx, cmd).AsInt64()
+	if err != nil {
+		return false, fmt.Errorf("failed to mark capacity alert: %w", err)
+	}
+
+	return added == 1, nil
+}
 
-	// Use Lua script for atomic increment
-	script := `
-		local current = redis.call('GET', KEYS[1])
-		if current == false then
-			return -1
+// initZoneTicketsScript seeds a standing zone's counter only if it has none and records the zone in the
+// event's set of zones, so Delete can find every counter.
+// KEYS[1] is the zone counter, KEYS[2] the event's zone set; ARGV[1] is the zone and ARGV[2] the count.
+var initZoneTicketsScript = redis.RegisterScript("event_init_zone_tickets", `
+	redis.call('SADD', KEYS[2], ARGV[1])
+	redis.call('SET', KEYS[1], ARGV[2], 'NX')
+	return 1
+`)
+
+// adjustZoneTicketsScript changes a standing zone's counter, refusing to take it below zero.
+// ARGV[1] is the mode ("decrement" or "increment") and ARGV[2] the amount.
+var adjustZoneTicketsScript = redis.RegisterScript("event_adjust_zone_tickets", `
+	local left = redis.call('GET', KEYS[1])
+	if left == false then
+		return -1
+	end
+
+	local amount = tonumber(ARGV[2])
+	if ARGV[1] == 'decrement' then
+		if tonumber(left) < amount then
+			return -2
 		end
-		
-		local currentVal = tonumber(current)
-		local incrementBy = tonumber(ARGV[1])
-		
-		local newVal = currentVal + incrementBy
-		redis.call('SET', KEYS[1], newVal)
-		return newVal
-	`
-
-	cmd := r.client.GetRedisClient().B().Eval().Script(script).Numkeys(1).Key(key).Arg(fmt.Sprintf("%d", count)).Build()
-	result := r.client.GetRedisClient().Do(ctx, cmd)
-	if result.Error() != nil {
-		return fmt.Errorf("failed to increment available tickets: %w", result.Error())
+		return redis.call('DECRBY', KEYS[1], amount)
+	end
+	return redis.call('INCRBY', KEYS[1], amount)
+`)
+
+// InitZoneTickets seeds a standing zone's ticket counter unless the zone already has one
+func (r *EventRepository) InitZoneTickets(ctx context.Context, eventID uuid.UUID, zone string, count int) error {
+	cmd := r.client.GetRedisClient().B().Eval().Script(initZoneTicketsScript).Numkeys(2).
+		Key(standingZoneKey(eventID, zone), standingZonesKey(eventID)).
+		Arg(zone, strconv.Itoa(count)).Build()
+	if err := r.client.GetRedisClient().Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("failed to init standing zone tickets: %w", err)
 	}
 
-	resultVal, err := result.ToInt64()
+	return nil
+}
+
+// adjustZoneTickets runs the zone counter script in the given mode
+func (r *EventRepository) adjustZoneTickets(ctx context.Context, eventID uuid.UUID, zone, mode string, count int) error {
+	cmd := r.client.GetRedisClient().B().Eval().Script(adjustZoneTicketsScript).Numkeys(1).
+		Key(standingZoneKey(eventID, zone)).Arg(mode, strconv.Itoa(count)).Build()
+	result, err := r.client.GetRedisClient().Do(ctx, cmd).ToInt64()
 	if err != nil {
-		return fmt.Errorf("failed to parse result: %w", err)
+		return fmt.Errorf("failed to %s standing zone tickets: %w", mode, err)
 	}
 
-	if resultVal == -1 {
-		return fmt.Errorf("event not found")
+	switch result {
+	case -1:
+		return repository.ErrStandingZoneNotFound
+	case -2:
+		return repository.ErrStandingZoneSoldOut
+	}
+
+	return nil
+}
+
+// DecrementZoneTickets takes tickets off a standing zone's counter atomically
+func (r *EventRepository) DecrementZoneTickets(ctx context.Context, eventID uuid.UUID, zone string, count int) error {
+	return r.adjustZoneTickets(ctx, eventID, zone, "decrement", count)
+}
+
+// IncrementZoneTickets returns tickets to a standing zone's counter atomically
+func (r *EventRepository) IncrementZoneTickets(ctx context.Context, eventID uuid.UUID, zone string, count int) error {
+	return r.adjustZoneTickets(ctx, eventID, zone, "increment", count)
+}
+
+// GetZoneTickets retrieves how many tickets are left in each of the given zones with one MGET; the counters
+// change with every sale, so they are never read from the client-side cache
+func (r *EventRepository) GetZoneTickets(ctx context.Context, eventID uuid.UUID, zones []string) (map[string]int, error) {
+	left := make(map[string]int, len(zones))
+	if len(zones) == 0 {
+		return left, nil
 	}
 
-	// Update the event object
-	event, err := r.GetByID(ctx, eventID)
+	keys := make([]string, len(zones))
+	for i, zone := range zones {
+		keys[i] = standingZoneKey(eventID, zone)
+	}
+
+	cmd := r.client.GetRedisClient().B().Mget().Key(keys...).Build()
+	values, err := r.client.GetRedisClient().Do(ctx, cmd).ToArray()
 	if err != nil {
-		return fmt.Errorf("failed to get event: %w", err)
+		return nil, fmt.Errorf("failed to get standing zone tickets: %w", err)
+	}
+
+	for i, value := range values {
+		count, err := value.AsInt64()
+		if err != nil {
+			// Zones without a counter come back as nil
+			continue
+		}
+		left[zones[i]] = int(count)
 	}
 
-	event.AvailableTickets = int(resultVal)
+	return left, nil
+}
+
+// standingZoneKey returns the key of a standing zone's ticket counter
+func standingZoneKey(eventID uuid.UUID, zone string) string {
+	return fmt.Sprintf("event:%s:zone:%s", eventID.String(), zone)
+}
+
+// standingZonesKey returns the key of the set of standing zones an event has counters for
+func standingZonesKey(eventID uuid.UUID) string {
+	return fmt.Sprintf("event:%s:zones", eventID.String())
+}
 
-	return r.Update(ctx, event)
+// capacityAlertsKey returns the key of the set of capacity thresholds that already fired for an event
+func capacityAlertsKey(eventID uuid.UUID) string {
+	return fmt.Sprintf("event:%s:capacity_alerts", eventID.String())
 }
@@ -0,0 +1,14 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/snowmerak/ticketing/lib/repository"
+	"github.com/snowmerak/ticketing/pkg/repository/repotest"
+)
+
+func TestEventRepository(t *testing.T) {
+	repotest.RunEventRepositoryTests(t, func(t *testing.T) repository.EventRepository {
+		return NewEventRepository(newTestClient(t))
+	})
+}
@@ -3,10 +3,15 @@ package redis
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/redis/rueidis"
+	"github.com/snowmerak/ticketing/lib/adapter"
 	"github.com/snowmerak/ticketing/lib/domain"
 	"github.com/snowmerak/ticketing/lib/repository"
 	"github.com/snowmerak/ticketing/pkg/client/redis"
@@ -14,47 +19,79 @@ import (
 
 // QueueRepository implements repository.QueueRepository using Redis
 type QueueRepository struct {
-	client *redis.Client
+	client   *redis.Client
+	ids      adapter.IDGenerator
+	cacheTTL adapter.CacheConfig
 }
 
 // NewQueueRepository creates a new QueueRepository
 func NewQueueRepository(client *redis.Client) *QueueRepository {
 	return &QueueRepository{
-		client: client,
+		client:   client,
+		cacheTTL: adapter.DefaultCacheConfig(),
 	}
 }
 
+// SetCacheConfig sets how long client-side cached reads are served; unset TTLs keep their defaults
+func (r *QueueRepository) SetCacheConfig(config adapter.CacheConfig) {
+	r.cacheTTL = config.WithDefaults()
+}
+
 // Compile-time check to ensure QueueRepository implements repository.QueueRepository
 var _ repository.QueueRepository = (*QueueRepository)(nil)
 
-// Join adds a user to the queue for an event
-func (r *QueueRepository) Join(ctx context.Context, eventID, userID uuid.UUID, sessionID string) (*domain.QueueEntry, error) {
-	// Check if user is already in queue
-	existing, err := r.GetPosition(ctx, eventID, userID)
-	if err == nil && existing != nil {
-		return existing, nil
+// SetIDGenerator sets the generator used for new queue entry IDs; random UUIDs are used when none is set
+func (r *QueueRepository) SetIDGenerator(ids adapter.IDGenerator) {
+	r.ids = ids
+}
+
+// newID returns a new queue entry ID from the configured generator
+func (r *QueueRepository) newID() uuid.UUID {
+	if r.ids == nil {
+		return uuid.New()
 	}
+	return r.ids.NewID()
+}
 
+// joinQueueScript appends a user to the queue and takes the resulting list length as their position. A user
+// rejoining after their entry lapsed may still hold a slot in the list; it is dropped so they are queued once.
+var joinQueueScript = redis.RegisterScript("queue_join", `
+	local existing = redis.call('GET', KEYS[2])
+	if existing ~= false then
+		return existing
+	end
+
+	redis.call('LREM', KEYS[1], 0, ARGV[1])
+	local position = redis.call('RPUSH', KEYS[1], ARGV[1])
+	local template = ARGV[2]
+	if position == 1 then
+		template = ARGV[3]
+		redis.call('ZADD', KEYS[5], ARGV[4], ARGV[1])
+	end
+
+	local entry = cjson.decode(template)
+	entry.position = position
+	local data = cjson.encode(entry)
+
+	redis.call('SET', KEYS[2], data)
+	redis.call('HSET', KEYS[3], 'queue_entry', KEYS[2])
+	redis.call('SADD', KEYS[4], KEYS[2])
+	redis.call('SET', KEYS[6], KEYS[2])
+	return data
+`)
+
+// Join adds a user to the queue for an event
+func (r *QueueRepository) Join(ctx context.Context, eventID, userID uuid.UUID, sessionID string) (*domain.QueueEntry, error) {
 	queueKey := fmt.Sprintf("queue:%s", eventID.String())
 	entryKey := fmt.Sprintf("queue_entry:%s:%s", eventID.String(), userID.String())
-
-	// Get current queue length to determine position
-	lenCmd := r.client.GetRedisClient().B().Llen().Key(queueKey).Build()
-	lenResult := r.client.GetRedisClient().Do(ctx, lenCmd)
-	if lenResult.Error() != nil {
-		return nil, fmt.Errorf("failed to get queue length: %w", lenResult.Error())
-	}
-
-	length, err := lenResult.ToInt64()
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse queue length: %w", err)
-	}
+	sessionKey := fmt.Sprintf("session:%s", sessionID)
+	userEntriesKey := fmt.Sprintf("user_queue_entries:%s", userID.String())
+	activeKey := queueActiveKey(eventID)
 
 	entry := &domain.QueueEntry{
-		ID:        uuid.New(),
+		ID:        r.newID(),
 		EventID:   eventID,
 		UserID:    userID,
-		Position:  int(length + 1),
 		Status:    string(domain.QueueStatusWaiting),
 		SessionID: sessionID,
 		EnteredAt: time.Now(),
@@ -62,46 +99,49 @@ func (r *QueueRepository) Join(ctx context.Context, eventID, userID uuid.UUID, s
 		UpdatedAt: time.Now(),
 	}
 
-	// If this is the first person in queue, activate them immediately
-	if length == 0 {
-		entry.Status = string(domain.QueueStatusActive)
-		// Set expiration for active session (15 minutes)
-		expiry := time.Now().Add(15 * time.Minute)
-		entry.ExpiresAt = &expiry
+	waiting, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal queue entry: %w", err)
 	}
 
-	// Serialize entry
-	data, err := json.Marshal(entry)
+	// If this is the first person in queue, activate them immediately with a 15 minute session
+	active := *entry
+	active.Status = string(domain.QueueStatusActive)
+	now := time.Now()
+	expiry := now.Add(15 * time.Minute)
+	active.ExpiresAt = &expiry
+	active.ActivatedAt = &now
+
+	activeData, err := json.Marshal(&active)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal queue entry: %w", err)
 	}
 
-	// Add to queue and store entry data
-	rpushCmd := r.client.GetRedisClient().B().Rpush().Key(queueKey).Element(userID.String()).Build()
-	if err := r.client.GetRedisClient().Do(ctx, rpushCmd).Error(); err != nil {
-		return nil, fmt.Errorf("failed to add to queue: %w", err)
+	cmd := r.client.GetRedisClient().B().Eval().Script(joinQueueScript).Numkeys(6).Key(queueKey, entryKey, sessionKey, userEntriesKey, activeKey, queueEntryByIDKey(entry.ID)).Arg(userID.String(), string(waiting), string(activeData), strconv.FormatInt(expiry.UnixMilli(), 10)).Build()
+	result := r.client.GetRedisClient().Do(ctx, cmd)
+	if result.Error() != nil {
+		return nil, fmt.Errorf("failed to add to queue: %w", result.Error())
 	}
 
-	setCmd := r.client.GetRedisClient().B().Set().Key(entryKey).Value(string(data)).Build()
-	if err := r.client.GetRedisClient().Do(ctx, setCmd).Error(); err != nil {
-		return nil, fmt.Errorf("failed to set entry data: %w", err)
+	data, err := result.ToString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entry data: %w", err)
 	}
 
-	hsetCmd := r.client.GetRedisClient().B().Hset().Key(fmt.Sprintf("session:%s", sessionID)).FieldValue().FieldValue("queue_entry", entryKey).Build()
-	if err := r.client.GetRedisClient().Do(ctx, hsetCmd).Error(); err != nil {
-		return nil, fmt.Errorf("failed to set session data: %w", err)
+	var joined domain.QueueEntry
+	if err := json.Unmarshal([]byte(data), &joined); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal queue entry: %w", err)
 	}
 
-	return entry, nil
+	return &joined, nil
 }
 
 // GetPosition retrieves a user's position in the queue
 func (r *QueueRepository) GetPosition(ctx context.Context, eventID, userID uuid.UUID) (*domain.QueueEntry, error) {
 	entryKey := fmt.Sprintf("queue_entry:%s:%s", eventID.String(), userID.String())
 
-	const clientSideCacheTTL = 1 * time.Minute // short TTL for queue position (frequently changing)
 	cmd := r.client.GetRedisClient().B().Get().Key(entryKey).Cache()
-	result := r.client.GetRedisClient().DoCache(ctx, cmd, clientSideCacheTTL)
+	result := r.client.GetRedisClient().DoCache(ctx, cmd, r.cacheTTL.QueueEntryTTL)
 	if result.Error() != nil {
 		return nil, fmt.Errorf("failed to get queue entry: %w", result.Error())
 	}
@@ -119,6 +159,50 @@ func (r *QueueRepository) GetPosition(ctx context.Context, eventID, userID uuid.
 	return &entry, nil
 }
 
+// GetPositionInList returns a user's live 1-based index in the event's queue list, or 0 when they are not in it.
+// The lookup is served from the client-side cache, which Redis invalidates as the list changes.
+func (r *QueueRepository) GetPositionInList(ctx context.Context, eventID, userID uuid.UUID) (int, error) {
+	queueKey := fmt.Sprintf("queue:%s", eventID.String())
+
+	cmd := r.client.GetRedisClient().B().Lpos().Key(queueKey).Element(userID.String()).Cache()
+	index, err := r.client.GetRedisClient().DoCache(ctx, cmd, r.cacheTTL.QueueEntryTTL).AsInt64()
+	if rueidis.IsRedisNil(err) {
+		return 0, nil
+	}
+	if isUnknownCommand(err) {
+		// LPOS arrived in Redis 6.0.6; older servers scan the list instead
+		return r.scanPositionInList(ctx, queueKey, userID)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get position in queue: %w", err)
+	}
+
+	return int(index) + 1, nil
+}
+
+// scanPositionInList finds a user's 1-based index by reading the whole queue list
+func (r *QueueRepository) scanPositionInList(ctx context.Context, queueKey string, userID uuid.UUID) (int, error) {
+	cmd := r.client.GetRedisClient().B().Lrange().Key(queueKey).Start(0).Stop(-1).Cache()
+	members, err := r.client.GetRedisClient().DoCache(ctx, cmd, r.cacheTTL.QueueEntryTTL).AsStrSlice()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read queue: %w", err)
+	}
+
+	member := userID.String()
+	for i, queued := range members {
+		if queued == member {
+			return i + 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// isUnknownCommand reports whether Redis rejected a command it does not implement
+func isUnknownCommand(err error) bool {
+	var redisErr *rueidis.RedisError
+	return errors.As(err, &redisErr) && strings.HasPrefix(redisErr.Error(), "ERR unknown command")
+}
+
 // GetBySessionID retrieves queue entry by session ID
 func (r *QueueRepository) GetBySessionID(ctx context.Context, sessionID string) (*domain.QueueEntry, error) {
 	hgetCmd := r.client.GetRedisClient().B().Hget().Key(fmt.Sprintf("session:%s", sessionID)).Field("queue_entry").Build()
@@ -132,9 +216,8 @@ func (r *QueueRepository) GetBySessionID(ctx context.Context, sessionID string)
 		return nil, fmt.Errorf("failed to get entry key: %w", err)
 	}
 
-	const clientSideCacheTTL = 1 * time.Minute // short TTL for queue entry
 	getCmd := r.client.GetRedisClient().B().Get().Key(entryKey).Cache()
-	getResult := r.client.GetRedisClient().DoCache(ctx, getCmd, clientSideCacheTTL)
+	getResult := r.client.GetRedisClient().DoCache(ctx, getCmd, r.cacheTTL.QueueEntryTTL)
 	if getResult.Error() != nil {
 		return nil, fmt.Errorf("failed to get queue entry: %w", getResult.Error())
 	}
@@ -194,78 +277,543 @@ func (r *QueueRepository) GetQueueLength(ctx context.Context, eventID uuid.UUID)
 	return int(length), nil
 }
 
-// UpdateStatus updates the status of a queue entry
-func (r *QueueRepository) UpdateStatus(ctx context.Context, entryID uuid.UUID, status string) error {
-	// We need to find the entry first
-	// This is a simplified approach - in a real implementation, you might want to maintain an index
-	return fmt.Errorf("not implemented - use specific methods like ActivateNext")
-}
+// GetQueueHead retrieves up to count entries from the front of the queue in order
+func (r *QueueRepository) GetQueueHead(ctx context.Context, eventID uuid.UUID, count int) ([]*domain.QueueEntry, error) {
+	if count <= 0 {
+		return nil, nil
+	}
 
-// ActivateNext activates the next user in queue
-func (r *QueueRepository) ActivateNext(ctx context.Context, eventID uuid.UUID) (*domain.QueueEntry, error) {
 	queueKey := fmt.Sprintf("queue:%s", eventID.String())
 
-	// Remove the current first user and get the next one
-	lpopCmd := r.client.GetRedisClient().B().Lpop().Key(queueKey).Build()
-	if err := r.client.GetRedisClient().Do(ctx, lpopCmd).Error(); err != nil {
-		return nil, fmt.Errorf("failed to remove current user from queue: %w", err)
+	cmd := r.client.GetRedisClient().B().Lrange().Key(queueKey).Start(0).Stop(int64(count - 1)).Build()
+	result := r.client.GetRedisClient().Do(ctx, cmd)
+	if result.Error() != nil {
+		return nil, fmt.Errorf("failed to get queue head: %w", result.Error())
 	}
 
-	// Get the new first user
-	lindexCmd := r.client.GetRedisClient().B().Lindex().Key(queueKey).Index(0).Build()
-	result := r.client.GetRedisClient().Do(ctx, lindexCmd)
+	members, err := result.AsStrSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse members: %w", err)
+	}
+
+	var entries []*domain.QueueEntry
+	for _, member := range members {
+		userID, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+
+		entry, err := r.GetPosition(ctx, eventID, userID)
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Update persists changes to an existing queue entry
+func (r *QueueRepository) Update(ctx context.Context, entry *domain.QueueEntry) error {
+	if !domain.QueueStatus(entry.Status).IsValid() {
+		return fmt.Errorf("%w: %q", repository.ErrInvalidQueueStatus, entry.Status)
+	}
+
+	entry.UpdatedAt = time.Now()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal queue entry: %w", err)
+	}
+
+	entryKey := fmt.Sprintf("queue_entry:%s:%s", entry.EventID.String(), entry.UserID.String())
+
+	// Keep the TTL a refreshed session was given so rewriting the entry never revives a reaped session
+	cmd := r.client.GetRedisClient().B().Set().Key(entryKey).Value(string(data)).Xx().Keepttl().Build()
+	result := r.client.GetRedisClient().Do(ctx, cmd)
 	if result.Error() != nil {
-		return nil, fmt.Errorf("failed to get next user: %w", result.Error())
+		if rueidis.IsRedisNil(result.Error()) {
+			return repository.ErrQueueEntryNotFound
+		}
+		return fmt.Errorf("failed to update queue entry: %w", result.Error())
 	}
 
-	userID, err := result.ToString()
+	return r.syncActiveIndex(ctx, entry)
+}
+
+// updateExpiryScript resolves a session's queue entry, rewrites its expiry and sets the entry, its session and its
+// ID index to expire then. The queue list slot and user index member left behind are dropped lazily, by Join,
+// ActivateNext and GetByUserID, once they find the entry gone.
+// KEYS[1] is the session key; ARGV[1] is the expiry, ARGV[2] the update time and ARGV[3] the expiry in unix ms.
+var updateExpiryScript = redis.RegisterScript("queue_update_expiry", `
+	local entryKey = redis.call('HGET', KEYS[1], 'queue_entry')
+	if not entryKey then
+		return false
+	end
+
+	local data = redis.call('GET', entryKey)
+	if not data then
+		return false
+	end
+
+	local entry = cjson.decode(data)
+	entry.expires_at = ARGV[1]
+	entry.updated_at = ARGV[2]
+	data = cjson.encode(entry)
+
+	redis.call('SET', entryKey, data)
+	redis.call('PEXPIREAT', entryKey, ARGV[3])
+	redis.call('PEXPIREAT', KEYS[1], ARGV[3])
+	redis.call('PEXPIREAT', 'queue_entry_by_id:' .. entry.id, ARGV[3])
+	return data
+`)
+
+// UpdateExpiry moves the expiry of the queue entry behind a session, setting a matching TTL on the entry and
+// session keys
+func (r *QueueRepository) UpdateExpiry(ctx context.Context, sessionID string, expiresAt, now time.Time) error {
+	sessionKey := fmt.Sprintf("session:%s", sessionID)
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(updateExpiryScript).Numkeys(1).Key(sessionKey).
+		Arg(expiresAt.Format(time.RFC3339Nano), now.Format(time.RFC3339Nano), strconv.FormatInt(expiresAt.UnixMilli(), 10)).Build()
+	result := r.client.GetRedisClient().Do(ctx, cmd)
+	if result.Error() != nil {
+		if rueidis.IsRedisNil(result.Error()) {
+			return repository.ErrQueueEntryNotFound
+		}
+		return fmt.Errorf("failed to update queue entry expiry: %w", result.Error())
+	}
+
+	data, err := result.ToString()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user ID: %w", err)
+		return fmt.Errorf("failed to get entry data: %w", err)
 	}
 
-	userUUID, err := uuid.Parse(userID)
+	var entry domain.QueueEntry
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		return fmt.Errorf("failed to unmarshal queue entry: %w", err)
+	}
+
+	return r.syncActiveIndex(ctx, &entry)
+}
+
+// GetByUserID retrieves every queue entry of a user across events
+func (r *QueueRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.QueueEntry, error) {
+	userEntriesKey := fmt.Sprintf("user_queue_entries:%s", userID.String())
+
+	cmd := r.client.GetRedisClient().B().Smembers().Key(userEntriesKey).Build()
+	result := r.client.GetRedisClient().Do(ctx, cmd)
+	if result.Error() != nil {
+		return nil, fmt.Errorf("failed to get user queue entries: %w", result.Error())
+	}
+
+	entryKeys, err := result.AsStrSlice()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse user ID: %w", err)
+		return nil, fmt.Errorf("failed to parse members: %w", err)
+	}
+
+	var entries []*domain.QueueEntry
+	var lapsed []string
+	for _, entryKey := range entryKeys {
+		getCmd := r.client.GetRedisClient().B().Get().Key(entryKey).Build()
+		data, err := r.client.GetRedisClient().Do(ctx, getCmd).ToString()
+		if rueidis.IsRedisNil(err) {
+			// The entry expired with its session
+			lapsed = append(lapsed, entryKey)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		var entry domain.QueueEntry
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			continue
+		}
+
+		entries = append(entries, &entry)
+	}
+
+	if len(lapsed) > 0 {
+		sremCmd := r.client.GetRedisClient().B().Srem().Key(userEntriesKey).Member(lapsed...).Build()
+		if err := r.client.GetRedisClient().Do(ctx, sremCmd).Error(); err != nil {
+			return nil, fmt.Errorf("failed to unindex lapsed queue entries: %w", err)
+		}
+	}
+
+	return entries, nil
+}
+
+// removeUserScript drops a user from the queue list and deletes their entry, session mapping, ID index and user index member
+var removeUserScript = redis.RegisterScript("queue_remove_user", `
+	local data = redis.call('GET', KEYS[2])
+	if data == false then
+		return 0
+	end
+
+	local entry = cjson.decode(data)
+	redis.call('LREM', KEYS[1], 0, ARGV[1])
+	redis.call('DEL', KEYS[2])
+	redis.call('DEL', 'session:' .. entry.session_id)
+	redis.call('DEL', 'queue_entry_by_id:' .. entry.id)
+	redis.call('SREM', KEYS[3], KEYS[2])
+	redis.call('ZREM', KEYS[4], ARGV[1])
+	return 1
+`)
+
+// RemoveUser removes a user's entry, queue slot and session from an event queue
+func (r *QueueRepository) RemoveUser(ctx context.Context, eventID, userID uuid.UUID) error {
+	queueKey := fmt.Sprintf("queue:%s", eventID.String())
+	entryKey := fmt.Sprintf("queue_entry:%s:%s", eventID.String(), userID.String())
+	userEntriesKey := fmt.Sprintf("user_queue_entries:%s", userID.String())
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(removeUserScript).Numkeys(4).Key(queueKey, entryKey, userEntriesKey, queueActiveKey(eventID)).Arg(userID.String()).Build()
+	result := r.client.GetRedisClient().Do(ctx, cmd)
+	if result.Error() != nil {
+		return fmt.Errorf("failed to remove user from queue: %w", result.Error())
+	}
+
+	removed, err := result.ToInt64()
+	if err != nil {
+		return fmt.Errorf("failed to parse result: %w", err)
+	}
+
+	if removed == 0 {
+		return repository.ErrQueueEntryNotFound
+	}
+
+	return nil
+}
+
+// UpdateStatus moves a queue entry to a new status, rejecting unknown statuses and disallowed transitions
+func (r *QueueRepository) UpdateStatus(ctx context.Context, entryID uuid.UUID, status string) error {
+	next := domain.QueueStatus(status)
+	if !next.IsValid() {
+		return fmt.Errorf("%w: %q", repository.ErrInvalidQueueStatus, status)
 	}
 
-	// Get the entry and update it
-	entry, err := r.GetPosition(ctx, eventID, userUUID)
+	entry, err := r.GetByID(ctx, entryID)
 	if err != nil {
+		return err
+	}
+
+	if !domain.QueueStatus(entry.Status).CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s to %s", repository.ErrQueueStatusTransition, entry.Status, status)
+	}
+
+	entry.Status = status
+	return r.Update(ctx, entry)
+}
+
+// GetByID retrieves a queue entry by its entry ID
+func (r *QueueRepository) GetByID(ctx context.Context, entryID uuid.UUID) (*domain.QueueEntry, error) {
+	indexCmd := r.client.GetRedisClient().B().Get().Key(queueEntryByIDKey(entryID)).Build()
+	entryKey, err := r.client.GetRedisClient().Do(ctx, indexCmd).ToString()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			return nil, repository.ErrQueueEntryNotFound
+		}
+		return nil, fmt.Errorf("failed to get queue entry key: %w", err)
+	}
+
+	getCmd := r.client.GetRedisClient().B().Get().Key(entryKey).Build()
+	data, err := r.client.GetRedisClient().Do(ctx, getCmd).ToString()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			// The entry is gone but the index outlived it, as indexes written before it expired with the entry do
+			delCmd := r.client.GetRedisClient().B().Del().Key(queueEntryByIDKey(entryID)).Build()
+			if err := r.client.GetRedisClient().Do(ctx, delCmd).Error(); err != nil {
+				return nil, fmt.Errorf("failed to drop lapsed queue entry index: %w", err)
+			}
+			return nil, repository.ErrQueueEntryNotFound
+		}
 		return nil, fmt.Errorf("failed to get queue entry: %w", err)
 	}
 
-	// Update status to active
-	entry.Status = string(domain.QueueStatusActive)
-	expiry := time.Now().Add(15 * time.Minute)
-	entry.ExpiresAt = &expiry
-	entry.UpdatedAt = time.Now()
+	var entry domain.QueueEntry
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal queue entry: %w", err)
+	}
 
-	// Save the updated entry
-	entryKey := fmt.Sprintf("queue_entry:%s:%s", eventID.String(), userUUID.String())
-	data, err := json.Marshal(entry)
+	return &entry, nil
+}
+
+// activateNextScript activates the first waiting user of an event's queue list, indexing them as active. Users
+// ahead of them are popped: the active user at the head, and any whose entry lapsed or left the waiting state, so
+// the list never hands out a slot nobody holds. It returns the activated entry, or false once no one is waiting.
+// KEYS[1] is the queue list and KEYS[2] the active index; ARGV[1] is the event ID, ARGV[2] the activation time,
+// ARGV[3] the expiry and ARGV[4] the expiry in unix ms.
+var activateNextScript = redis.RegisterScript("queue_activate_next", `
+	while true do
+		local user = redis.call('LINDEX', KEYS[1], 0)
+		if not user then
+			return false
+		end
+
+		local key = 'queue_entry:' .. ARGV[1] .. ':' .. user
+		local data = redis.call('GET', key)
+		local entry = data and cjson.decode(data)
+		if entry and entry.status == 'waiting' then
+			entry.status = 'active'
+			entry.activated_at = ARGV[2]
+			entry.expires_at = ARGV[3]
+			entry.updated_at = ARGV[2]
+			data = cjson.encode(entry)
+
+			redis.call('SET', key, data)
+			-- Entries written before the ID index existed pick it up on activation
+			redis.call('SET', 'queue_entry_by_id:' .. entry.id, key)
+			redis.call('ZADD', KEYS[2], ARGV[4], user)
+			return data
+		end
+
+		redis.call('LPOP', KEYS[1])
+	end
+`)
+
+// ActivateNext drops the active user at the head of the queue and activates the next waiting one, in a single
+// script
+func (r *QueueRepository) ActivateNext(ctx context.Context, eventID uuid.UUID) (*domain.QueueEntry, error) {
+	queueKey := fmt.Sprintf("queue:%s", eventID.String())
+	now := time.Now()
+	expiry := now.Add(15 * time.Minute)
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(activateNextScript).Numkeys(2).Key(queueKey, queueActiveKey(eventID)).
+		Arg(eventID.String(), now.Format(time.RFC3339Nano), expiry.Format(time.RFC3339Nano), strconv.FormatInt(expiry.UnixMilli(), 10)).Build()
+	data, err := r.client.GetRedisClient().Do(ctx, cmd).ToString()
+	if rueidis.IsRedisNil(err) {
+		return nil, fmt.Errorf("failed to get next user: queue is empty")
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal queue entry: %w", err)
+		return nil, fmt.Errorf("failed to activate next user: %w", err)
 	}
 
-	setCmd := r.client.GetRedisClient().B().Set().Key(entryKey).Value(string(data)).Build()
-	if err := r.client.GetRedisClient().Do(ctx, setCmd).Error(); err != nil {
-		return nil, fmt.Errorf("failed to update queue entry: %w", err)
+	var entry domain.QueueEntry
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal queue entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// activateAllScript activates the waiting entries of an event's queue list in order, indexing each as active.
+// Once no one is left waiting the list is deleted; when the limit stops it early, the list is trimmed so the
+// last activated user stays at its head, which ActivateNext pops before activating the next.
+// KEYS[1] is the queue list and KEYS[2] the active index; ARGV[1] is the event ID, ARGV[2] the limit (0 for
+// no limit), ARGV[3] the activation time, ARGV[4] the expiry and ARGV[5] the expiry in unix ms.
+var activateAllScript = redis.RegisterScript("queue_activate_all", `
+	local users = redis.call('LRANGE', KEYS[1], 0, -1)
+	local limit = tonumber(ARGV[2])
+	local activated = {}
+	local last = 0
+	local stopped = false
+
+	for i, user in ipairs(users) do
+		if limit > 0 and #activated >= limit then
+			stopped = true
+			break
+		end
+
+		local key = 'queue_entry:' .. ARGV[1] .. ':' .. user
+		local data = redis.call('GET', key)
+		if data then
+			local entry = cjson.decode(data)
+			if entry.status == 'waiting' then
+				entry.status = 'active'
+				entry.activated_at = ARGV[3]
+				entry.expires_at = ARGV[4]
+				entry.updated_at = ARGV[3]
+				data = cjson.encode(entry)
+				redis.call('SET', key, data)
+				redis.call('ZADD', KEYS[2], ARGV[5], user)
+				table.insert(activated, data)
+				last = i
+			end
+		end
+	end
+
+	if not stopped then
+		redis.call('DEL', KEYS[1])
+	elseif last > 0 then
+		redis.call('LTRIM', KEYS[1], last - 1, -1)
+	end
+	return activated
+`)
+
+// ActivateAll activates the waiting users of an event in queue order in a single script
+func (r *QueueRepository) ActivateAll(ctx context.Context, eventID uuid.UUID, expiresAt time.Time, limit int) ([]*domain.QueueEntry, error) {
+	queueKey := fmt.Sprintf("queue:%s", eventID.String())
+	now := time.Now().Format(time.RFC3339Nano)
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(activateAllScript).Numkeys(2).Key(queueKey, queueActiveKey(eventID)).
+		Arg(eventID.String(), strconv.Itoa(limit), now, expiresAt.Format(time.RFC3339Nano), strconv.FormatInt(expiresAt.UnixMilli(), 10)).Build()
+	values, err := r.client.GetRedisClient().Do(ctx, cmd).AsStrSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to activate queue: %w", err)
 	}
 
-	return entry, nil
+	entries := make([]*domain.QueueEntry, 0, len(values))
+	for _, data := range values {
+		var entry domain.QueueEntry
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal queue entry: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	return entries, nil
+}
+
+// GetActiveCount counts users holding an active session for an event that is unexpired as of now
+func (r *QueueRepository) GetActiveCount(ctx context.Context, eventID uuid.UUID, now time.Time) (int, error) {
+	activeKey := queueActiveKey(eventID)
+
+	// Sessions are scored by expiry, so drop the lapsed ones and count what is left
+	until := strconv.FormatInt(now.UnixMilli(), 10)
+	pruneCmd := r.client.GetRedisClient().B().Zremrangebyscore().Key(activeKey).Min("-inf").Max(until).Build()
+	if err := r.client.GetRedisClient().Do(ctx, pruneCmd).Error(); err != nil {
+		return 0, fmt.Errorf("failed to prune active sessions: %w", err)
+	}
+
+	countCmd := r.client.GetRedisClient().B().Zcard().Key(activeKey).Build()
+	count, err := r.client.GetRedisClient().Do(ctx, countCmd).ToInt64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count active sessions: %w", err)
+	}
+
+	return int(count), nil
+}
+
+// syncActiveIndex keeps the active session set in step with an entry's status and expiry
+func (r *QueueRepository) syncActiveIndex(ctx context.Context, entry *domain.QueueEntry) error {
+	activeKey := queueActiveKey(entry.EventID)
+
+	if entry.IsActive() && entry.ExpiresAt != nil {
+		cmd := r.client.GetRedisClient().B().Zadd().Key(activeKey).ScoreMember().ScoreMember(float64(entry.ExpiresAt.UnixMilli()), entry.UserID.String()).Build()
+		if err := r.client.GetRedisClient().Do(ctx, cmd).Error(); err != nil {
+			return fmt.Errorf("failed to index active session: %w", err)
+		}
+		return nil
+	}
+
+	cmd := r.client.GetRedisClient().B().Zrem().Key(activeKey).Member(entry.UserID.String()).Build()
+	if err := r.client.GetRedisClient().Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("failed to unindex active session: %w", err)
+	}
+	return nil
+}
+
+// queueActiveKey names the sorted set of an event's active users scored by session expiry
+func queueActiveKey(eventID uuid.UUID) string {
+	return fmt.Sprintf("queue_active:%s", eventID.String())
+}
+
+// queueLastActivationKey names the timestamp of an event's most recent queue activation
+func queueLastActivationKey(eventID uuid.UUID) string {
+	return fmt.Sprintf("queue_last_activation:%s", eventID.String())
+}
+
+// queueEntryByIDKey names the index from a queue entry ID to its entry key
+func queueEntryByIDKey(entryID uuid.UUID) string {
+	return fmt.Sprintf("queue_entry_by_id:%s", entryID.String())
 }
 
 // RemoveFromQueue removes a user from the queue
 func (r *QueueRepository) RemoveFromQueue(ctx context.Context, entryID uuid.UUID) error {
-	// This is a simplified implementation
-	// In a real scenario, you'd need to maintain better indexing
-	return fmt.Errorf("not implemented - use session-based removal")
+	entry, err := r.GetByID(ctx, entryID)
+	if err != nil {
+		return err
+	}
+
+	return r.RemoveUser(ctx, entry.EventID, entry.UserID)
 }
 
-// GetActiveEntries retrieves all active queue entries for an event
-func (r *QueueRepository) GetActiveEntries(ctx context.Context, eventID uuid.UUID) ([]*domain.QueueEntry, error) {
-	// This would require scanning all entries - simplified implementation
-	return nil, fmt.Errorf("not implemented")
+// GetLastActivation retrieves when a user was last activated for an event; the zero time means never
+func (r *QueueRepository) GetLastActivation(ctx context.Context, eventID uuid.UUID) (time.Time, error) {
+	cmd := r.client.GetRedisClient().B().Get().Key(queueLastActivationKey(eventID)).Build()
+	millis, err := r.client.GetRedisClient().Do(ctx, cmd).AsInt64()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("failed to get last activation: %w", err)
+	}
+
+	return time.UnixMilli(millis), nil
+}
+
+// SetLastActivation records when a user was last activated for an event
+func (r *QueueRepository) SetLastActivation(ctx context.Context, eventID uuid.UUID, at time.Time) error {
+	cmd := r.client.GetRedisClient().B().Set().Key(queueLastActivationKey(eventID)).Value(strconv.FormatInt(at.UnixMilli(), 10)).Build()
+	if err := r.client.GetRedisClient().Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("failed to set last activation: %w", err)
+	}
+	return nil
+}
+
+// GetActiveEntries retrieves the event's users holding an active session unexpired as of now, soonest to expire
+// first. It reads the active session set rather than scanning entries, and drops members whose session lapsed
+// or whose entry is gone or no longer active from the set as it goes.
+func (r *QueueRepository) GetActiveEntries(ctx context.Context, eventID uuid.UUID, now time.Time) ([]*domain.QueueEntry, error) {
+	activeKey := queueActiveKey(eventID)
+
+	until := strconv.FormatInt(now.UnixMilli(), 10)
+	pruneCmd := r.client.GetRedisClient().B().Zremrangebyscore().Key(activeKey).Min("-inf").Max(until).Build()
+	if err := r.client.GetRedisClient().Do(ctx, pruneCmd).Error(); err != nil {
+		return nil, fmt.Errorf("failed to prune active sessions: %w", err)
+	}
+
+	rangeCmd := r.client.GetRedisClient().B().Zrange().Key(activeKey).Min("0").Max("-1").Build()
+	users, err := r.client.GetRedisClient().Do(ctx, rangeCmd).AsStrSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active sessions: %w", err)
+	}
+
+	entries := []*domain.QueueEntry{}
+	if len(users) == 0 {
+		return entries, nil
+	}
+
+	keys := make([]string, len(users))
+	for i, user := range users {
+		keys[i] = fmt.Sprintf("queue_entry:%s:%s", eventID.String(), user)
+	}
+
+	mgetCmd := r.client.GetRedisClient().B().Mget().Key(keys...).Build()
+	values, err := r.client.GetRedisClient().Do(ctx, mgetCmd).ToArray()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active queue entries: %w", err)
+	}
+
+	var stale []string
+	for i, value := range values {
+		data, err := value.ToString()
+		if err != nil {
+			// The entry is gone, e.g. the user left without the set being updated
+			stale = append(stale, users[i])
+			continue
+		}
+
+		var entry domain.QueueEntry
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal queue entry: %w", err)
+		}
+		if !entry.IsActive() || entry.IsExpiredAt(now) {
+			stale = append(stale, users[i])
+			continue
+		}
+
+		entries = append(entries, &entry)
+	}
+
+	if len(stale) > 0 {
+		remCmd := r.client.GetRedisClient().B().Zrem().Key(activeKey).Member(stale...).Build()
+		if err := r.client.GetRedisClient().Do(ctx, remCmd).Error(); err != nil {
+			return nil, fmt.Errorf("failed to unindex stale active sessions: %w", err)
+		}
+	}
+
+	return entries, nil
 }
 
 // GetExpiredEntries retrieves all expired queue entries
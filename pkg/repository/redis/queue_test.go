@@ -0,0 +1,163 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/repository"
+	"github.com/snowmerak/ticketing/pkg/client/redis"
+	"github.com/snowmerak/ticketing/pkg/repository/repotest"
+)
+
+func TestQueueRepository(t *testing.T) {
+	repotest.RunQueueRepositoryTests(t, func(t *testing.T) repository.QueueRepository {
+		return NewQueueRepository(newTestClient(t))
+	})
+}
+
+// keyExists reports whether a key is present in Redis
+func keyExists(t *testing.T, ctx context.Context, client *redis.Client, key string) bool {
+	t.Helper()
+
+	cmd := client.GetRedisClient().B().Exists().Key(key).Build()
+	n, err := client.GetRedisClient().Do(ctx, cmd).AsInt64()
+	if err != nil {
+		t.Fatalf("exists %s: %v", key, err)
+	}
+	return n > 0
+}
+
+func TestQueueRepositoryLapsedEntries(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, ctx context.Context, client *redis.Client, repo *QueueRepository)
+	}{
+		{
+			name: "lapsed entry takes its ID index and user index member with it",
+			run: func(t *testing.T, ctx context.Context, client *redis.Client, repo *QueueRepository) {
+				eventID, userID := uuid.New(), uuid.New()
+				entry, err := repo.Join(ctx, eventID, userID, uuid.NewString())
+				if err != nil {
+					t.Fatalf("join: %v", err)
+				}
+
+				if err := repo.UpdateExpiry(ctx, entry.SessionID, time.Now().Add(-time.Second), time.Now()); err != nil {
+					t.Fatalf("lapse session: %v", err)
+				}
+
+				if keyExists(t, ctx, client, queueEntryByIDKey(entry.ID)) {
+					t.Fatal("ID index outlived its entry")
+				}
+				if _, err := repo.GetByID(ctx, entry.ID); !errors.Is(err, repository.ErrQueueEntryNotFound) {
+					t.Fatalf("expected ErrQueueEntryNotFound, got %v", err)
+				}
+
+				entries, err := repo.GetByUserID(ctx, userID)
+				if err != nil {
+					t.Fatalf("get by user: %v", err)
+				}
+				if len(entries) != 0 {
+					t.Fatalf("expected no entries for the user, got %d", len(entries))
+				}
+				if keyExists(t, ctx, client, "user_queue_entries:"+userID.String()) {
+					t.Fatal("user index kept the lapsed entry")
+				}
+			},
+		},
+		{
+			name: "rejoining after a lapse takes one fresh slot",
+			run: func(t *testing.T, ctx context.Context, client *redis.Client, repo *QueueRepository) {
+				eventID, userID := uuid.New(), uuid.New()
+				first, err := repo.Join(ctx, eventID, userID, uuid.NewString())
+				if err != nil {
+					t.Fatalf("join: %v", err)
+				}
+				second, err := repo.Join(ctx, eventID, uuid.New(), uuid.NewString())
+				if err != nil {
+					t.Fatalf("second join: %v", err)
+				}
+
+				if err := repo.UpdateExpiry(ctx, first.SessionID, time.Now().Add(-time.Second), time.Now()); err != nil {
+					t.Fatalf("lapse session: %v", err)
+				}
+
+				rejoined, err := repo.Join(ctx, eventID, userID, uuid.NewString())
+				if err != nil {
+					t.Fatalf("rejoin: %v", err)
+				}
+				if rejoined.ID == first.ID || !rejoined.IsWaiting() {
+					t.Fatalf("expected a fresh waiting entry, got %s (%s)", rejoined.ID, rejoined.Status)
+				}
+
+				length, err := repo.GetQueueLength(ctx, eventID)
+				if err != nil {
+					t.Fatalf("queue length: %v", err)
+				}
+				if length != 2 {
+					t.Fatalf("expected two queued users, got %d", length)
+				}
+				position, err := repo.GetPositionInList(ctx, eventID, userID)
+				if err != nil {
+					t.Fatalf("position in list: %v", err)
+				}
+				if position != 2 {
+					t.Fatalf("expected the rejoined user behind the one waiting, got position %d", position)
+				}
+
+				activated, err := repo.ActivateNext(ctx, eventID)
+				if err != nil {
+					t.Fatalf("activate next: %v", err)
+				}
+				if activated.ID != second.ID {
+					t.Fatalf("expected %s activated, got %s", second.ID, activated.ID)
+				}
+			},
+		},
+		{
+			name: "activation skips users whose entry lapsed",
+			run: func(t *testing.T, ctx context.Context, client *redis.Client, repo *QueueRepository) {
+				eventID := uuid.New()
+				var joined []uuid.UUID
+				var sessions []string
+				for i := 0; i < 3; i++ {
+					entry, err := repo.Join(ctx, eventID, uuid.New(), uuid.NewString())
+					if err != nil {
+						t.Fatalf("join: %v", err)
+					}
+					joined = append(joined, entry.ID)
+					sessions = append(sessions, entry.SessionID)
+				}
+
+				if err := repo.UpdateExpiry(ctx, sessions[1], time.Now().Add(-time.Second), time.Now()); err != nil {
+					t.Fatalf("lapse second session: %v", err)
+				}
+
+				activated, err := repo.ActivateNext(ctx, eventID)
+				if err != nil {
+					t.Fatalf("activate next: %v", err)
+				}
+				if activated.ID != joined[2] {
+					t.Fatalf("expected the third user activated past the lapsed second, got %s", activated.ID)
+				}
+
+				length, err := repo.GetQueueLength(ctx, eventID)
+				if err != nil {
+					t.Fatalf("queue length: %v", err)
+				}
+				if length != 1 {
+					t.Fatalf("expected only the activated user left queued, got %d", length)
+				}
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newTestClient(t)
+			tc.run(t, context.Background(), client, NewQueueRepository(client))
+		})
+	}
+}
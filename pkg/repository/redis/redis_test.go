@@ -0,0 +1,42 @@
+package redis
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/snowmerak/ticketing/pkg/client/redis"
+)
+
+// newTestClient connects to the Redis server named by REDIS_ADDR, skipping the test when it is unset.
+// The server must be a scratch one: the database selected by REDIS_DB is flushed before each case, so the
+// suites start from an empty store.
+func newTestClient(t testing.TB) *redis.Client {
+	t.Helper()
+
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		t.Skip("REDIS_ADDR is not set")
+	}
+
+	db := 0
+	if value := os.Getenv("REDIS_DB"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			t.Fatalf("invalid REDIS_DB %q: %v", value, err)
+		}
+		db = parsed
+	}
+
+	client := redis.NewClient(addr, os.Getenv("REDIS_PASSWORD"), db, zerolog.Nop())
+	t.Cleanup(func() { client.Close() })
+
+	rdb := client.GetRedisClient()
+	if err := rdb.Do(context.Background(), rdb.B().Flushdb().Build()).Error(); err != nil {
+		t.Fatalf("flush test database: %v", err)
+	}
+
+	return client
+}
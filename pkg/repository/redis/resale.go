@@ -0,0 +1,274 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/rueidis"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+	"github.com/snowmerak/ticketing/pkg/client/redis"
+)
+
+// ResaleRepository implements repository.ResaleRepository using Redis.
+// Listings live in resale_listing:{id}; resale_ticket:{ticketID} points at a ticket's open listing and
+// resale_event:{eventID} is a sorted set of an event's open listings scored by creation time.
+type ResaleRepository struct {
+	client *redis.Client
+}
+
+// NewResaleRepository creates a new ResaleRepository
+func NewResaleRepository(client *redis.Client) *ResaleRepository {
+	return &ResaleRepository{
+		client: client,
+	}
+}
+
+// Compile-time check to ensure ResaleRepository implements repository.ResaleRepository
+var _ repository.ResaleRepository = (*ResaleRepository)(nil)
+
+// resaleListingKey names the key holding a listing
+func resaleListingKey(id uuid.UUID) string {
+	return fmt.Sprintf("resale_listing:%s", id.String())
+}
+
+// createListingScript stores a listing unless its ticket already has an open one
+var createListingScript = redis.RegisterScript("resale_create", `
+	if redis.call('SET', KEYS[2], ARGV[1], 'NX') == false then
+		return 'already_listed'
+	end
+
+	redis.call('SET', KEYS[1], ARGV[2])
+	redis.call('ZADD', KEYS[3], ARGV[3], ARGV[1])
+	return 'success'
+`)
+
+// Create stores a new open listing
+func (r *ResaleRepository) Create(ctx context.Context, listing *domain.ResaleListing) error {
+	listing.Status = string(domain.ResaleStatusListed)
+	listing.CreatedAt = time.Now()
+	listing.UpdatedAt = time.Now()
+
+	data, err := json.Marshal(listing)
+	if err != nil {
+		return fmt.Errorf("failed to marshal resale listing: %w", err)
+	}
+
+	ticketKey := fmt.Sprintf("resale_ticket:%s", listing.TicketID.String())
+	eventKey := fmt.Sprintf("resale_event:%s", listing.EventID.String())
+	score := strconv.FormatInt(listing.CreatedAt.UnixMilli(), 10)
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(createListingScript).Numkeys(3).Key(resaleListingKey(listing.ID), ticketKey, eventKey).Arg(listing.ID.String(), string(data), score).Build()
+	result, err := r.client.GetRedisClient().Do(ctx, cmd).ToString()
+	if err != nil {
+		return fmt.Errorf("failed to create resale listing: %w", err)
+	}
+
+	if result == "already_listed" {
+		return repository.ErrTicketAlreadyListed
+	}
+
+	return nil
+}
+
+// GetByID retrieves a listing by its ID
+func (r *ResaleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ResaleListing, error) {
+	cmd := r.client.GetRedisClient().B().Get().Key(resaleListingKey(id)).Build()
+	data, err := r.client.GetRedisClient().Do(ctx, cmd).ToString()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			return nil, repository.ErrResaleListingNotFound
+		}
+		return nil, fmt.Errorf("failed to get resale listing: %w", err)
+	}
+
+	var listing domain.ResaleListing
+	if err := json.Unmarshal([]byte(data), &listing); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resale listing: %w", err)
+	}
+
+	return &listing, nil
+}
+
+// ListOpenByEvent retrieves the open listings of an event, oldest first
+func (r *ResaleRepository) ListOpenByEvent(ctx context.Context, eventID uuid.UUID) ([]*domain.ResaleListing, error) {
+	eventKey := fmt.Sprintf("resale_event:%s", eventID.String())
+
+	cmd := r.client.GetRedisClient().B().Zrange().Key(eventKey).Min("0").Max("-1").Build()
+	members, err := r.client.GetRedisClient().Do(ctx, cmd).AsStrSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resale listings: %w", err)
+	}
+
+	var listings []*domain.ResaleListing
+	for _, member := range members {
+		id, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+
+		listing, err := r.GetByID(ctx, id)
+		if err != nil || !listing.IsListed() {
+			continue
+		}
+
+		listings = append(listings, listing)
+	}
+
+	return listings, nil
+}
+
+// claimListingScript marks an open listing sold and takes it off the market
+var claimListingScript = redis.RegisterScript("resale_claim", `
+	local data = redis.call('GET', KEYS[1])
+	if data == false then
+		return 'listing_not_found'
+	end
+
+	local listing = cjson.decode(data)
+	if listing.status ~= 'listed' then
+		return 'listing_not_available'
+	end
+
+	listing.status = 'sold'
+	listing.buyer_id = ARGV[1]
+	listing.sold_at = ARGV[2]
+	listing.updated_at = ARGV[2]
+	data = cjson.encode(listing)
+	redis.call('SET', KEYS[1], data)
+	redis.call('ZREM', 'resale_event:' .. listing.event_id, listing.id)
+	redis.call('DEL', 'resale_ticket:' .. listing.ticket_id)
+	return data
+`)
+
+// Claim atomically marks an open listing sold to a buyer and takes it off the market
+func (r *ResaleRepository) Claim(ctx context.Context, id, buyerID uuid.UUID) (*domain.ResaleListing, error) {
+	now := time.Now().Format(time.RFC3339Nano)
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(claimListingScript).Numkeys(1).Key(resaleListingKey(id)).Arg(buyerID.String(), now).Build()
+	data, err := r.client.GetRedisClient().Do(ctx, cmd).ToString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim resale listing: %w", err)
+	}
+
+	switch data {
+	case "listing_not_found":
+		return nil, repository.ErrResaleListingNotFound
+	case "listing_not_available":
+		return nil, repository.ErrResaleListingNotAvailable
+	}
+
+	var listing domain.ResaleListing
+	if err := json.Unmarshal([]byte(data), &listing); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resale listing: %w", err)
+	}
+
+	return &listing, nil
+}
+
+// reopenListingScript returns a sold listing to the market
+var reopenListingScript = redis.RegisterScript("resale_reopen", `
+	local data = redis.call('GET', KEYS[1])
+	if data == false then
+		return 'listing_not_found'
+	end
+
+	local listing = cjson.decode(data)
+	if listing.status ~= 'sold' then
+		return 'listing_not_available'
+	end
+
+	listing.status = 'listed'
+	listing.buyer_id = nil
+	listing.sold_at = nil
+	listing.updated_at = ARGV[2]
+	redis.call('SET', KEYS[1], cjson.encode(listing))
+	redis.call('ZADD', 'resale_event:' .. listing.event_id, ARGV[1], listing.id)
+	redis.call('SET', 'resale_ticket:' .. listing.ticket_id, listing.id)
+	return 'success'
+`)
+
+// Reopen puts a claimed listing back on the market
+func (r *ResaleRepository) Reopen(ctx context.Context, id uuid.UUID) error {
+	listing, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Keep the listing's original place in the event's oldest-first order
+	score := strconv.FormatInt(listing.CreatedAt.UnixMilli(), 10)
+	now := time.Now().Format(time.RFC3339Nano)
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(reopenListingScript).Numkeys(1).Key(resaleListingKey(id)).Arg(score, now).Build()
+	return r.runListingScript(ctx, cmd, "reopen")
+}
+
+// cancelListingScript withdraws an open listing
+var cancelListingScript = redis.RegisterScript("resale_cancel", `
+	local data = redis.call('GET', KEYS[1])
+	if data == false then
+		return 'listing_not_found'
+	end
+
+	local listing = cjson.decode(data)
+	if listing.status ~= 'listed' then
+		return 'listing_not_available'
+	end
+
+	listing.status = 'cancelled'
+	listing.updated_at = ARGV[1]
+	redis.call('SET', KEYS[1], cjson.encode(listing))
+	redis.call('ZREM', 'resale_event:' .. listing.event_id, listing.id)
+	redis.call('DEL', 'resale_ticket:' .. listing.ticket_id)
+	return 'success'
+`)
+
+// Cancel withdraws an open listing
+func (r *ResaleRepository) Cancel(ctx context.Context, id uuid.UUID) error {
+	now := time.Now().Format(time.RFC3339Nano)
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(cancelListingScript).Numkeys(1).Key(resaleListingKey(id)).Arg(now).Build()
+	return r.runListingScript(ctx, cmd, "cancel")
+}
+
+// runListingScript runs a listing status script and maps its result to repository errors
+func (r *ResaleRepository) runListingScript(ctx context.Context, cmd rueidis.Completed, action string) error {
+	result, err := r.client.GetRedisClient().Do(ctx, cmd).ToString()
+	if err != nil {
+		return fmt.Errorf("failed to %s resale listing: %w", action, err)
+	}
+
+	switch result {
+	case "listing_not_found":
+		return repository.ErrResaleListingNotFound
+	case "listing_not_available":
+		return repository.ErrResaleListingNotAvailable
+	}
+
+	return nil
+}
+
+// Update persists changes to an existing listing
+func (r *ResaleRepository) Update(ctx context.Context, listing *domain.ResaleListing) error {
+	listing.UpdatedAt = time.Now()
+
+	data, err := json.Marshal(listing)
+	if err != nil {
+		return fmt.Errorf("failed to marshal resale listing: %w", err)
+	}
+
+	cmd := r.client.GetRedisClient().B().Set().Key(resaleListingKey(listing.ID)).Value(string(data)).Xx().Build()
+	result := r.client.GetRedisClient().Do(ctx, cmd)
+	if result.Error() != nil {
+		if rueidis.IsRedisNil(result.Error()) {
+			return repository.ErrResaleListingNotFound
+		}
+		return fmt.Errorf("failed to update resale listing: %w", result.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/snowmerak/ticketing/lib/repository"
+	"github.com/snowmerak/ticketing/pkg/repository/repotest"
+)
+
+func TestResaleRepository(t *testing.T) {
+	repotest.RunResaleRepositoryTests(t, func(t *testing.T) repository.ResaleRepository {
+		return NewResaleRepository(newTestClient(t))
+	})
+}
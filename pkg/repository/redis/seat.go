@@ -3,10 +3,14 @@ package redis
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/redis/rueidis"
+	"github.com/snowmerak/ticketing/lib/adapter"
 	"github.com/snowmerak/ticketing/lib/domain"
 	"github.com/snowmerak/ticketing/lib/repository"
 	"github.com/snowmerak/ticketing/pkg/client/redis"
@@ -14,16 +18,39 @@ import (
 
 // SeatRepository implements repository.SeatRepository using Redis
 type SeatRepository struct {
-	client *redis.Client
+	client       *redis.Client
+	cacheTTL     adapter.CacheConfig
+	holdDuration time.Duration
 }
 
-// NewSeatRepository creates a new SeatRepository
-func NewSeatRepository(client *redis.Client) *SeatRepository {
+// NewSeatRepository creates a new SeatRepository whose reservation holds last holdDuration, or
+// repository.DefaultReservationHold when holdDuration is not positive
+func NewSeatRepository(client *redis.Client, holdDuration time.Duration) *SeatRepository {
+	if holdDuration <= 0 {
+		holdDuration = repository.DefaultReservationHold
+	}
+
 	return &SeatRepository{
-		client: client,
+		client:       client,
+		cacheTTL:     adapter.DefaultCacheConfig(),
+		holdDuration: holdDuration,
 	}
 }
 
+// seatReservationHoldsKey is a sorted set of seat IDs reserved by ReserveSeats, scored by when their reservation
+// hold lapses in milliseconds. The reserve and release scripts build it and seatReservationHoldKey by name.
+const seatReservationHoldsKey = "seat_reservation_holds"
+
+// seatReservationHoldKey names the key that marks a seat's reservation hold until it lapses
+func seatReservationHoldKey(seatID string) string {
+	return "seat_hold:" + seatID
+}
+
+// SetCacheConfig sets how long client-side cached reads are served; unset TTLs keep their defaults
+func (r *SeatRepository) SetCacheConfig(config adapter.CacheConfig) {
+	r.cacheTTL = config.WithDefaults()
+}
+
 // Compile-time check to ensure SeatRepository implements repository.SeatRepository
 var _ repository.SeatRepository = (*SeatRepository)(nil)
 
@@ -71,30 +98,97 @@ func (r *SeatRepository) Create(ctx context.Context, seat *domain.Seat) error {
 	return nil
 }
 
-// CreateBatch creates multiple seats in a single transaction
+// seatBatchSize caps how many seats CreateBatch sends in one pipeline, so a large venue doesn't build a single
+// unbounded round trip
+const seatBatchSize = 1000
+
+// CreateBatch creates multiple seats, pipelining them in chunks of seatBatchSize. Each chunk is one round trip
+// holding a SET per seat followed by one SADD per index it touches, rather than the four round trips per seat that
+// Create takes. Seats whose SET fails are reported individually; the rest of the batch is still written.
 func (r *SeatRepository) CreateBatch(ctx context.Context, seats []*domain.Seat) error {
-	if len(seats) == 0 {
-		return nil
+	var errs []error
+	for start := 0; start < len(seats); start += seatBatchSize {
+		end := min(start+seatBatchSize, len(seats))
+		if err := r.createChunk(ctx, seats[start:end]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// createChunk writes one pipeline of seats and their index memberships
+func (r *SeatRepository) createChunk(ctx context.Context, seats []*domain.Seat) error {
+	client := r.client.GetRedisClient()
+	now := time.Now()
+
+	cmds := make(rueidis.Commands, 0, len(seats)+4)
+	indexes := make(map[string][]string)
+	var indexKeys []string
+	index := func(key, member string) {
+		if _, ok := indexes[key]; !ok {
+			indexKeys = append(indexKeys, key)
+		}
+		indexes[key] = append(indexes[key], member)
 	}
 
-	// Create all seats individually for simplicity
 	for _, seat := range seats {
-		if err := r.Create(ctx, seat); err != nil {
-			return fmt.Errorf("failed to create seat %s: %w", seat.ID.String(), err)
+		seat.CreatedAt = now
+		seat.UpdatedAt = now
+
+		data, err := json.Marshal(seat)
+		if err != nil {
+			return fmt.Errorf("failed to marshal seat %s: %w", seat.ID.String(), err)
+		}
+
+		id := seat.ID.String()
+		cmds = append(cmds, client.B().Set().Key(fmt.Sprintf("seat:%s", id)).Value(string(data)).Build())
+
+		index(fmt.Sprintf("event_seats:%s", seat.EventID.String()), id)
+		index(fmt.Sprintf("section:%s:%s", seat.EventID.String(), seat.Section), id)
+		if seat.Status == string(domain.SeatStatusAvailable) {
+			index(fmt.Sprintf("available_seats:%s", seat.EventID.String()), id)
 		}
 	}
 
-	return nil
+	for _, key := range indexKeys {
+		cmds = append(cmds, client.B().Sadd().Key(key).Member(indexes[key]...).Build())
+	}
+
+	var errs []error
+	for i, result := range client.DoMulti(ctx, cmds...) {
+		if err := result.Error(); err != nil {
+			if i < len(seats) {
+				errs = append(errs, fmt.Errorf("failed to create seat %s: %w", seats[i].ID.String(), err))
+			} else {
+				errs = append(errs, fmt.Errorf("failed to index seats in %s: %w", indexKeys[i-len(seats)], err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 // GetByID retrieves a seat by its ID
 func (r *SeatRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Seat, error) {
+	return r.getByID(ctx, id, true)
+}
+
+// getByID reads a seat, from the client-side cache unless cached is false; a retry after a version conflict
+// reads past the cache, which may not have seen the invalidation yet
+func (r *SeatRepository) getByID(ctx context.Context, id uuid.UUID, cached bool) (*domain.Seat, error) {
 	key := fmt.Sprintf("seat:%s", id.String())
 
-	const clientSideCacheTTL = 30 * time.Minute // moderate TTL for seat data
-	cmd := r.client.GetRedisClient().B().Get().Key(key).Cache()
-	result := r.client.GetRedisClient().DoCache(ctx, cmd, clientSideCacheTTL)
+	var result rueidis.RedisResult
+	if cached {
+		result = r.client.GetRedisClient().DoCache(ctx, r.client.GetRedisClient().B().Get().Key(key).Cache(), r.cacheTTL.SeatTTL)
+	} else {
+		result = r.client.GetRedisClient().Do(ctx, r.client.GetRedisClient().B().Get().Key(key).Build())
+	}
 	if result.Error() != nil {
+		if rueidis.IsRedisNil(result.Error()) {
+			return nil, repository.ErrSeatNotFound
+		}
 		return nil, fmt.Errorf("failed to get seat: %w", result.Error())
 	}
 
@@ -111,48 +205,64 @@ func (r *SeatRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Sea
 	return &seat, nil
 }
 
-// GetByEventID retrieves all seats for an event
-func (r *SeatRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain.Seat, error) {
-	eventSeatsKey := fmt.Sprintf("event_seats:%s", eventID.String())
+// parseSeatIDs parses the members of a seat index, skipping any that are not seat IDs
+func parseSeatIDs(members []string) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		id, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
 
-	const clientSideCacheTTL = 10 * time.Minute // shorter TTL for seat lists
-	cmd := r.client.GetRedisClient().B().Smembers().Key(eventSeatsKey).Cache()
-	result := r.client.GetRedisClient().DoCache(ctx, cmd, clientSideCacheTTL)
-	if result.Error() != nil {
-		return nil, fmt.Errorf("failed to get event seats: %w", result.Error())
+// getSeatsByIDs reads the seats with the given IDs in a single MGET, in request order. Seats that no longer
+// exist, because an index outlived them, and seats that fail to decode are skipped.
+func (r *SeatRepository) getSeatsByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Seat, error) {
+	seats := make([]*domain.Seat, 0, len(ids))
+	if len(ids) == 0 {
+		return seats, nil
 	}
 
-	members, err := result.AsStrSlice()
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = fmt.Sprintf("seat:%s", id.String())
+	}
+
+	cmd := r.client.GetRedisClient().B().Mget().Key(keys...).Build()
+	values, err := r.client.GetRedisClient().Do(ctx, cmd).ToArray()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse members: %w", err)
+		return nil, fmt.Errorf("failed to get seats: %w", err)
 	}
 
-	var seats []*domain.Seat
-	for _, member := range members {
-		seatID, err := uuid.Parse(member)
+	for _, value := range values {
+		data, err := value.ToString()
 		if err != nil {
+			// Missing seats come back as nil
 			continue
 		}
 
-		seat, err := r.GetByID(ctx, seatID)
-		if err != nil {
+		var seat domain.Seat
+		if err := json.Unmarshal([]byte(data), &seat); err != nil {
 			continue
 		}
 
-		seats = append(seats, seat)
+		seats = append(seats, &seat)
 	}
 
 	return seats, nil
 }
 
-// GetAvailableByEventID retrieves available seats for an event
-func (r *SeatRepository) GetAvailableByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain.Seat, error) {
-	availableKey := fmt.Sprintf("available_seats:%s", eventID.String())
+// GetByEventID retrieves all seats for an event
+func (r *SeatRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain.Seat, error) {
+	eventSeatsKey := fmt.Sprintf("event_seats:%s", eventID.String())
 
-	cmd := r.client.GetRedisClient().B().Smembers().Key(availableKey).Build()
-	result := r.client.GetRedisClient().Do(ctx, cmd)
+	cmd := r.client.GetRedisClient().B().Smembers().Key(eventSeatsKey).Cache()
+	result := r.client.GetRedisClient().DoCache(ctx, cmd, r.cacheTTL.SeatListTTL)
 	if result.Error() != nil {
-		return nil, fmt.Errorf("failed to get available seats: %w", result.Error())
+		return nil, fmt.Errorf("failed to get event seats: %w", result.Error())
 	}
 
 	members, err := result.AsStrSlice()
@@ -160,22 +270,39 @@ func (r *SeatRepository) GetAvailableByEventID(ctx context.Context, eventID uuid
 		return nil, fmt.Errorf("failed to parse members: %w", err)
 	}
 
-	var seats []*domain.Seat
-	for _, member := range members {
-		seatID, err := uuid.Parse(member)
-		if err != nil {
-			continue
-		}
+	seats, err := r.getSeatsByIDs(ctx, parseSeatIDs(members))
+	if err != nil {
+		return nil, err
+	}
 
-		seat, err := r.GetByID(ctx, seatID)
-		if err != nil {
-			continue
-		}
+	return seats, nil
+}
+
+// GetAvailableByEventID retrieves available seats for an event
+func (r *SeatRepository) GetAvailableByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain.Seat, error) {
+	availableKey := fmt.Sprintf("available_seats:%s", eventID.String())
 
-		seats = append(seats, seat)
+	// An event that never had an available seat has no index at all; that is an empty result, not a failure
+	cmd := r.client.GetRedisClient().B().Smembers().Key(availableKey).Build()
+	members, err := r.client.GetRedisClient().Do(ctx, cmd).AsStrSlice()
+	if err != nil && !rueidis.IsRedisNil(err) {
+		return nil, fmt.Errorf("failed to get available seats: %w", err)
 	}
 
-	return seats, nil
+	return r.getSeatsByIDs(ctx, parseSeatIDs(members))
+}
+
+// CountAvailable counts an event's available seats from the size of its available index, without reading any seat
+func (r *SeatRepository) CountAvailable(ctx context.Context, eventID uuid.UUID) (int, error) {
+	availableKey := fmt.Sprintf("available_seats:%s", eventID.String())
+
+	cmd := r.client.GetRedisClient().B().Scard().Key(availableKey).Build()
+	count, err := r.client.GetRedisClient().Do(ctx, cmd).AsInt64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count available seats: %w", err)
+	}
+
+	return int(count), nil
 }
 
 // GetBySection retrieves seats by section
@@ -193,110 +320,205 @@ func (r *SeatRepository) GetBySection(ctx context.Context, eventID uuid.UUID, se
 		return nil, fmt.Errorf("failed to parse members: %w", err)
 	}
 
-	var seats []*domain.Seat
-	for _, member := range members {
-		seatID, err := uuid.Parse(member)
-		if err != nil {
-			continue
-		}
-
-		seat, err := r.GetByID(ctx, seatID)
-		if err != nil {
-			continue
-		}
-
-		seats = append(seats, seat)
+	seats, err := r.getSeatsByIDs(ctx, parseSeatIDs(members))
+	if err != nil {
+		return nil, err
 	}
 
 	return seats, nil
 }
 
-// Update updates an existing seat
+// maxSeatStatusAttempts bounds how many times UpdateStatus re-reads a seat after losing a version race
+const maxSeatStatusAttempts = 3
+
+// updateSeatScript writes a seat only if the stored copy still has the version the caller read, keeping the
+// available index in step with a status change
+var updateSeatScript = redis.RegisterScript("seat_update", `
+	local data = redis.call('GET', KEYS[1])
+	if data == false then
+		return 'seat_not_found'
+	end
+
+	local stored = cjson.decode(data)
+	if (stored.version or 0) ~= tonumber(ARGV[2]) then
+		return 'version_conflict'
+	end
+
+	redis.call('SET', KEYS[1], ARGV[1])
+	if stored.status == 'available' and ARGV[3] ~= 'available' then
+		redis.call('SREM', KEYS[2], ARGV[4])
+	elseif stored.status ~= 'available' and ARGV[3] == 'available' then
+		redis.call('SADD', KEYS[2], ARGV[4])
+	end
+	return 'success'
+`)
+
+// Update updates an existing seat if it still has the stored version, bumping the version
 func (r *SeatRepository) Update(ctx context.Context, seat *domain.Seat) error {
-	seat.UpdatedAt = time.Now()
+	expected := seat.Version
+	updatedAt := seat.UpdatedAt
+	seat.Touch(time.Now())
 
 	data, err := json.Marshal(seat)
 	if err != nil {
+		seat.Version, seat.UpdatedAt = expected, updatedAt
 		return fmt.Errorf("failed to marshal seat: %w", err)
 	}
 
 	key := fmt.Sprintf("seat:%s", seat.ID.String())
+	availableKey := fmt.Sprintf("available_seats:%s", seat.EventID.String())
 
-	// Update the seat data
-	cmd := r.client.GetRedisClient().B().Set().Key(key).Value(string(data)).Build()
-	if err := r.client.GetRedisClient().Do(ctx, cmd).Error(); err != nil {
+	cmd := r.client.GetRedisClient().B().Eval().Script(updateSeatScript).Numkeys(2).Key(key, availableKey).Arg(string(data), strconv.FormatInt(expected, 10), seat.Status, seat.ID.String()).Build()
+	result, err := r.client.GetRedisClient().Do(ctx, cmd).ToString()
+	if err == nil && result != "success" {
+		switch result {
+		case "seat_not_found":
+			err = repository.ErrSeatNotFound
+		case "version_conflict":
+			err = repository.ErrSeatVersionConflict
+		default:
+			err = fmt.Errorf("unexpected result %q", result)
+		}
+	}
+	if err != nil {
+		seat.Version, seat.UpdatedAt = expected, updatedAt
 		return fmt.Errorf("failed to update seat: %w", err)
 	}
 
 	return nil
 }
 
-// UpdateStatus updates seat status
+// UpdateStatus updates seat status, re-reading the seat when another write got in first
 func (r *SeatRepository) UpdateStatus(ctx context.Context, seatID uuid.UUID, status string) error {
-	seat, err := r.GetByID(ctx, seatID)
+	var err error
+	for attempt := 0; attempt < maxSeatStatusAttempts; attempt++ {
+		var seat *domain.Seat
+		seat, err = r.getByID(ctx, seatID, attempt == 0)
+		if err != nil {
+			return fmt.Errorf("failed to get seat: %w", err)
+		}
+
+		seat.Status = status
+		err = r.Update(ctx, seat)
+		if !errors.Is(err, repository.ErrSeatVersionConflict) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// updateSeatPricesScript reprices the unsold seats among KEYS, optionally only those at a current price.
+// Prices are compared as numbers, since cjson may decode a large price into a float whose string form differs
+// from the integer. ARGV[1] is the new price, ARGV[2] the price to match or empty for any and ARGV[3] the update time.
+var updateSeatPricesScript = redis.RegisterScript("seat_update_prices", `
+	local tier = tonumber(ARGV[2])
+	local updated = 0
+	for i, seatKey in ipairs(KEYS) do
+		local seatData = redis.call('GET', seatKey)
+		if seatData ~= false then
+			local seat = cjson.decode(seatData)
+			local matches = seat.status ~= 'sold'
+			if matches and tier ~= nil and tonumber(seat.price) ~= tier then
+				matches = false
+			end
+			if matches then
+				seat.price = tonumber(ARGV[1])
+				seat.updated_at = ARGV[3]
+				seat.version = (seat.version or 0) + 1
+				redis.call('SET', seatKey, cjson.encode(seat))
+				updated = updated + 1
+			end
+		end
+	end
+	return updated
+`)
+
+// UpdatePrices reprices unsold seats of an event matching the filter and returns how many changed. Seats are
+// repriced in chunks of seatBatchSize, one script per chunk, so a large venue never blocks Redis on a single
+// script; a failed chunk is reported and the rest are still repriced.
+func (r *SeatRepository) UpdatePrices(ctx context.Context, eventID uuid.UUID, filter repository.SeatPriceFilter, price int64) (int, error) {
+	indexKey := fmt.Sprintf("event_seats:%s", eventID.String())
+	if filter.Section != "" {
+		indexKey = fmt.Sprintf("section:%s:%s", eventID.String(), filter.Section)
+	}
+
+	membersCmd := r.client.GetRedisClient().B().Smembers().Key(indexKey).Build()
+	seatIDs, err := r.client.GetRedisClient().Do(ctx, membersCmd).AsStrSlice()
 	if err != nil {
-		return fmt.Errorf("failed to get seat: %w", err)
+		return 0, fmt.Errorf("failed to get seats to reprice: %w", err)
 	}
 
-	oldStatus := seat.Status
-	seat.Status = status
+	tier := ""
+	if filter.Price != nil {
+		tier = strconv.FormatInt(*filter.Price, 10)
+	}
+	now := time.Now().Format(time.RFC3339)
 
-	// Update available seats index
-	availableKey := fmt.Sprintf("available_seats:%s", seat.EventID.String())
+	updated := 0
+	var errs []error
+	for start := 0; start < len(seatIDs); start += seatBatchSize {
+		end := min(start+seatBatchSize, len(seatIDs))
 
-	if oldStatus == string(domain.SeatStatusAvailable) && status != string(domain.SeatStatusAvailable) {
-		// Remove from available seats
-		remCmd := r.client.GetRedisClient().B().Srem().Key(availableKey).Member(seatID.String()).Build()
-		if err := r.client.GetRedisClient().Do(ctx, remCmd).Error(); err != nil {
-			return fmt.Errorf("failed to remove from available seats: %w", err)
+		keys := make([]string, 0, end-start)
+		for _, id := range seatIDs[start:end] {
+			keys = append(keys, fmt.Sprintf("seat:%s", id))
 		}
-	} else if oldStatus != string(domain.SeatStatusAvailable) && status == string(domain.SeatStatusAvailable) {
-		// Add to available seats
-		addCmd := r.client.GetRedisClient().B().Sadd().Key(availableKey).Member(seatID.String()).Build()
-		if err := r.client.GetRedisClient().Do(ctx, addCmd).Error(); err != nil {
-			return fmt.Errorf("failed to add to available seats: %w", err)
+
+		cmd := r.client.GetRedisClient().B().Eval().Script(updateSeatPricesScript).Numkeys(int64(len(keys))).Key(keys...).
+			Arg(strconv.FormatInt(price, 10), tier, now).Build()
+		count, err := r.client.GetRedisClient().Do(ctx, cmd).ToInt64()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to update prices of seats %d to %d: %w", start, end, err))
+			continue
 		}
+		updated += int(count)
 	}
 
-	return r.Update(ctx, seat)
+	return updated, errors.Join(errs...)
 }
 
-// ReserveSeats reserves multiple seats atomically
-func (r *SeatRepository) ReserveSeats(ctx context.Context, seatIDs []uuid.UUID) error {
-	// Use Lua script for atomic operation
-	script := `
-		local seats = {}
-		for i, seatKey in ipairs(KEYS) do
-			local seatData = redis.call('GET', seatKey)
-			if seatData == false then
-				return 'seat_not_found'
-			end
-			
-			local seat = cjson.decode(seatData)
-			if seat.status ~= 'available' then
-				return 'seat_not_available'
-			end
-			
-			seat.status = 'reserved'
-			seat.updated_at = ARGV[1]
-			seats[i] = {key = seatKey, data = cjson.encode(seat), id = seat.id, event_id = seat.event_id}
+// reserveSeatsScript reserves a batch of seats all or nothing and gives each a reservation hold
+var reserveSeatsScript = redis.RegisterScript("seat_reserve", `
+	local seats = {}
+	for i, seatKey in ipairs(KEYS) do
+		local seatData = redis.call('GET', seatKey)
+		if seatData == false then
+			return 'seat_not_found'
 		end
 		
-		for i, seat in ipairs(seats) do
-			redis.call('SET', seat.key, seat.data)
-			redis.call('SREM', 'available_seats:' .. seat.event_id, seat.id)
+		local seat = cjson.decode(seatData)
+		if seat.status ~= 'available' then
+			return 'seat_not_available'
 		end
 		
-		return 'success'
-	`
+		seat.status = 'reserved'
+		seat.updated_at = ARGV[1]
+		seat.version = (seat.version or 0) + 1
+		seats[i] = {key = seatKey, data = cjson.encode(seat), id = seat.id, event_id = seat.event_id}
+	end
+	
+	for i, seat in ipairs(seats) do
+		redis.call('SET', seat.key, seat.data)
+		redis.call('SREM', 'available_seats:' .. seat.event_id, seat.id)
+		redis.call('SET', 'seat_hold:' .. seat.id, ARGV[1], 'PX', ARGV[2])
+		redis.call('ZADD', 'seat_reservation_holds', ARGV[3], seat.id)
+	end
+	
+	return 'success'
+`)
 
+// ReserveSeats reserves multiple seats atomically
+func (r *SeatRepository) ReserveSeats(ctx context.Context, seatIDs []uuid.UUID) error {
 	var keys []string
 	for _, seatID := range seatIDs {
 		keys = append(keys, fmt.Sprintf("seat:%s", seatID.String()))
 	}
 
-	now := time.Now().Format(time.RFC3339)
-	cmd := r.client.GetRedisClient().B().Eval().Script(script).Numkeys(int64(len(keys))).Key(keys...).Arg(now).Build()
+	now := time.Now()
+	holdMs := strconv.FormatInt(r.holdDuration.Milliseconds(), 10)
+	lapsesAt := strconv.FormatInt(now.Add(r.holdDuration).UnixMilli(), 10)
+	cmd := r.client.GetRedisClient().B().Eval().Script(reserveSeatsScript).Numkeys(int64(len(keys))).Key(keys...).Arg(now.Format(time.RFC3339), holdMs, lapsesAt).Build()
 	result := r.client.GetRedisClient().Do(ctx, cmd)
 	if result.Error() != nil {
 		return fmt.Errorf("failed to reserve seats: %w", result.Error())
@@ -311,48 +533,51 @@ func (r *SeatRepository) ReserveSeats(ctx context.Context, seatIDs []uuid.UUID)
 		return fmt.Errorf("one or more seats not found")
 	}
 	if resultStr == "seat_not_available" {
-		return fmt.Errorf("one or more seats not available")
+		return fmt.Errorf("one or more seats not available: %w", repository.ErrSeatNotAvailable)
 	}
 
 	return nil
 }
 
-// ReleaseSeats releases reserved seats atomically
-func (r *SeatRepository) ReleaseSeats(ctx context.Context, seatIDs []uuid.UUID) error {
-	// Use Lua script for atomic operation
-	script := `
-		local seats = {}
-		for i, seatKey in ipairs(KEYS) do
-			local seatData = redis.call('GET', seatKey)
-			if seatData == false then
-				return 'seat_not_found'
-			end
-			
-			local seat = cjson.decode(seatData)
-			if seat.status ~= 'reserved' then
-				return 'seat_not_reserved'
-			end
-			
-			seat.status = 'available'
-			seat.updated_at = ARGV[1]
-			seats[i] = {key = seatKey, data = cjson.encode(seat), id = seat.id, event_id = seat.event_id}
+// releaseSeatsScript releases a batch of reserved seats all or nothing and drops their reservation holds
+var releaseSeatsScript = redis.RegisterScript("seat_release", `
+	local seats = {}
+	for i, seatKey in ipairs(KEYS) do
+		local seatData = redis.call('GET', seatKey)
+		if seatData == false then
+			return 'seat_not_found'
 		end
 		
-		for i, seat in ipairs(seats) do
-			redis.call('SET', seat.key, seat.data)
-			redis.call('SADD', 'available_seats:' .. seat.event_id, seat.id)
+		local seat = cjson.decode(seatData)
+		if seat.status ~= 'reserved' then
+			return 'seat_not_reserved'
 		end
 		
-		return 'success'
-	`
+		seat.status = 'available'
+		seat.updated_at = ARGV[1]
+		seat.version = (seat.version or 0) + 1
+		seats[i] = {key = seatKey, data = cjson.encode(seat), id = seat.id, event_id = seat.event_id}
+	end
+	
+	for i, seat in ipairs(seats) do
+		redis.call('SET', seat.key, seat.data)
+		redis.call('SADD', 'available_seats:' .. seat.event_id, seat.id)
+		redis.call('DEL', 'seat_hold:' .. seat.id)
+		redis.call('ZREM', 'seat_reservation_holds', seat.id)
+	end
+	
+	return 'success'
+`)
 
+// ReleaseSeats releases reserved seats atomically
+func (r *SeatRepository) ReleaseSeats(ctx context.Context, seatIDs []uuid.UUID) error {
 	var keys []string
 	for _, seatID := range seatIDs {
 		keys = append(keys, fmt.Sprintf("seat:%s", seatID.String()))
 	}
 
 	now := time.Now().Format(time.RFC3339)
-	cmd := r.client.GetRedisClient().B().Eval().Script(script).Numkeys(int64(len(keys))).Key(keys...).Arg(now).Build()
+	cmd := r.client.GetRedisClient().B().Eval().Script(releaseSeatsScript).Numkeys(int64(len(keys))).Key(keys...).Arg(now).Build()
 	result := r.client.GetRedisClient().Do(ctx, cmd)
 	if result.Error() != nil {
 		return fmt.Errorf("failed to release seats: %w", result.Error())
@@ -373,6 +598,116 @@ func (r *SeatRepository) ReleaseSeats(ctx context.Context, seatIDs []uuid.UUID)
 	return nil
 }
 
+// releaseReservedSeatsScript releases the seats of a batch that are still reserved, skipping the rest, and
+// returns the IDs it released
+var releaseReservedSeatsScript = redis.RegisterScript("seat_release_reserved", `
+	local released = {}
+	for _, seatKey in ipairs(KEYS) do
+		local seatData = redis.call('GET', seatKey)
+		if seatData ~= false then
+			local seat = cjson.decode(seatData)
+			if seat.status == 'reserved' then
+				seat.status = 'available'
+				seat.updated_at = ARGV[1]
+				seat.version = (seat.version or 0) + 1
+				redis.call('SET', seatKey, cjson.encode(seat))
+				redis.call('SADD', 'available_seats:' .. seat.event_id, seat.id)
+				redis.call('DEL', 'seat_hold:' .. seat.id)
+				redis.call('ZREM', 'seat_reservation_holds', seat.id)
+				table.insert(released, seat.id)
+			end
+		end
+	end
+
+	return released
+`)
+
+// ReleaseReservedSeats releases those of the seats that are still reserved and returns their IDs
+func (r *SeatRepository) ReleaseReservedSeats(ctx context.Context, seatIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(seatIDs) == 0 {
+		return []uuid.UUID{}, nil
+	}
+
+	keys := make([]string, 0, len(seatIDs))
+	for _, seatID := range seatIDs {
+		keys = append(keys, fmt.Sprintf("seat:%s", seatID.String()))
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	cmd := r.client.GetRedisClient().B().Eval().Script(releaseReservedSeatsScript).Numkeys(int64(len(keys))).Key(keys...).Arg(now).Build()
+	members, err := r.client.GetRedisClient().Do(ctx, cmd).AsStrSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to release reserved seats: %w", err)
+	}
+
+	return parseSeatIDs(members), nil
+}
+
+// GetLapsedReservationHolds returns the seats still reserved whose reservation hold lapsed by now
+func (r *SeatRepository) GetLapsedReservationHolds(ctx context.Context, now time.Time) ([]*domain.Seat, error) {
+	cmd := r.client.GetRedisClient().B().Zrangebyscore().Key(seatReservationHoldsKey).Min("-inf").Max(strconv.FormatInt(now.UnixMilli(), 10)).Build()
+	members, err := r.client.GetRedisClient().Do(ctx, cmd).AsStrSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lapsed reservation holds: %w", err)
+	}
+
+	seats, err := r.getSeatsByIDs(ctx, parseSeatIDs(members))
+	if err != nil {
+		return nil, err
+	}
+
+	reserved := make([]*domain.Seat, 0, len(seats))
+	stillReserved := make(map[string]bool, len(seats))
+	for _, seat := range seats {
+		if seat.IsReserved() {
+			reserved = append(reserved, seat)
+			stillReserved[seat.ID.String()] = true
+		}
+	}
+
+	// Seats released or sold some other way, and seats that are gone, no longer need their hold
+	var stale []uuid.UUID
+	for _, member := range members {
+		if stillReserved[member] {
+			continue
+		}
+		if seatID, err := uuid.Parse(member); err == nil {
+			stale = append(stale, seatID)
+		}
+	}
+	if err := r.ClearReservationHolds(ctx, stale); err != nil {
+		return nil, err
+	}
+
+	return reserved, nil
+}
+
+// ClearReservationHolds drops the reservation holds of seats
+func (r *SeatRepository) ClearReservationHolds(ctx context.Context, seatIDs []uuid.UUID) error {
+	if len(seatIDs) == 0 {
+		return nil
+	}
+
+	members := make([]string, len(seatIDs))
+	keys := make([]string, len(seatIDs))
+	for i, seatID := range seatIDs {
+		members[i] = seatID.String()
+		keys[i] = seatReservationHoldKey(members[i])
+	}
+
+	zremCmd := r.client.GetRedisClient().B().Zrem().Key(seatReservationHoldsKey).Member(members...).Build()
+	if err := r.client.GetRedisClient().Do(ctx, zremCmd).Error(); err != nil {
+		return fmt.Errorf("failed to clear reservation holds: %w", err)
+	}
+
+	delCmd := r.client.GetRedisClient().B().Del().Key(keys...).Build()
+	if err := r.client.GetRedisClient().Do(ctx, delCmd).Error(); err != nil {
+		return fmt.Errorf("failed to delete reservation hold keys: %w", err)
+	}
+
+	return nil
+}
+
 // Delete deletes a seat by its ID
 func (r *SeatRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	seat, err := r.GetByID(ctx, id)
@@ -0,0 +1,324 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/rueidis"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+	"github.com/snowmerak/ticketing/pkg/client/redis"
+)
+
+// seatHoldIndexKey is a sorted set of held seat IDs scored by hold expiry in milliseconds
+const seatHoldIndexKey = "seat_holds"
+
+// eventSeatHoldsKey names the per-event sorted set of held seat IDs scored by hold expiry in milliseconds.
+// The scripts below keep it in step with seatHoldIndexKey by building the same name from the seat's event.
+func eventSeatHoldsKey(eventID uuid.UUID) string {
+	return fmt.Sprintf("held_seats:%s", eventID.String())
+}
+
+// SeatHoldRepository implements repository.SeatHoldRepository using Redis.
+// The holder of a seat lives in seat_user_hold:{seatID} with a TTL, so a hold lapses on its own;
+// the seat record keeps status "held" until the hold is released, converted or swept.
+type SeatHoldRepository struct {
+	client *redis.Client
+}
+
+// NewSeatHoldRepository creates a new SeatHoldRepository
+func NewSeatHoldRepository(client *redis.Client) *SeatHoldRepository {
+	return &SeatHoldRepository{
+		client: client,
+	}
+}
+
+// Compile-time check to ensure SeatHoldRepository implements repository.SeatHoldRepository
+var _ repository.SeatHoldRepository = (*SeatHoldRepository)(nil)
+
+// seatHoldKey names the key holding the user that holds a seat
+func seatHoldKey(seatID uuid.UUID) string {
+	return fmt.Sprintf("seat_user_hold:%s", seatID.String())
+}
+
+// holdSeatScript takes an available seat, or a held seat whose hold lapsed, out of the pool for a user
+var holdSeatScript = redis.RegisterScript("seat_hold", `
+	local seatData = redis.call('GET', KEYS[1])
+	if seatData == false then
+		return 'seat_not_found'
+	end
+
+	local seat = cjson.decode(seatData)
+	if seat.status == 'held' then
+		if redis.call('EXISTS', KEYS[2]) == 1 then
+			return 'seat_not_available'
+		end
+	elseif seat.status ~= 'available' then
+		return 'seat_not_available'
+	end
+
+	seat.status = 'held'
+	seat.updated_at = ARGV[4]
+	seat.version = (seat.version or 0) + 1
+	redis.call('SET', KEYS[1], cjson.encode(seat))
+	redis.call('SREM', 'available_seats:' .. seat.event_id, seat.id)
+	redis.call('SET', KEYS[2], ARGV[1], 'PX', ARGV[2])
+	redis.call('ZADD', KEYS[3], ARGV[3], seat.id)
+	redis.call('ZADD', 'held_seats:' .. seat.event_id, ARGV[3], seat.id)
+	return 'success'
+`)
+
+// Hold marks an available seat, or a held seat whose hold has lapsed, as held by a user for ttl
+func (r *SeatHoldRepository) Hold(ctx context.Context, seatID, userID uuid.UUID, ttl time.Duration) error {
+	seatKey := fmt.Sprintf("seat:%s", seatID.String())
+	ttlMs := strconv.FormatInt(ttl.Milliseconds(), 10)
+	expiry := strconv.FormatInt(time.Now().Add(ttl).UnixMilli(), 10)
+	now := time.Now().Format(time.RFC3339)
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(holdSeatScript).Numkeys(3).Key(seatKey, seatHoldKey(seatID), seatHoldIndexKey).Arg(userID.String(), ttlMs, expiry, now).Build()
+	result, err := r.client.GetRedisClient().Do(ctx, cmd).ToString()
+	if err != nil {
+		return fmt.Errorf("failed to hold seat: %w", err)
+	}
+
+	switch result {
+	case "seat_not_found":
+		return repository.ErrSeatNotFound
+	case "seat_not_available":
+		return repository.ErrSeatNotAvailable
+	}
+
+	return nil
+}
+
+// releaseHoldScript drops a user's hold and puts the seat back in the available pool
+var releaseHoldScript = redis.RegisterScript("seat_hold_release", `
+	if redis.call('GET', KEYS[2]) ~= ARGV[1] then
+		return 'hold_not_found'
+	end
+
+	redis.call('DEL', KEYS[2])
+	redis.call('ZREM', KEYS[3], ARGV[2])
+
+	local seatData = redis.call('GET', KEYS[1])
+	if seatData == false then
+		return 'success'
+	end
+
+	local seat = cjson.decode(seatData)
+	redis.call('ZREM', 'held_seats:' .. seat.event_id, seat.id)
+	if seat.status == 'held' then
+		seat.status = 'available'
+		seat.updated_at = ARGV[3]
+		seat.version = (seat.version or 0) + 1
+		redis.call('SET', KEYS[1], cjson.encode(seat))
+		redis.call('SADD', 'available_seats:' .. seat.event_id, seat.id)
+	end
+	return 'success'
+`)
+
+// Release drops a user's hold and returns the seat to the available pool
+func (r *SeatHoldRepository) Release(ctx context.Context, seatID, userID uuid.UUID) error {
+	seatKey := fmt.Sprintf("seat:%s", seatID.String())
+	now := time.Now().Format(time.RFC3339)
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(releaseHoldScript).Numkeys(3).Key(seatKey, seatHoldKey(seatID), seatHoldIndexKey).Arg(userID.String(), seatID.String(), now).Build()
+	result, err := r.client.GetRedisClient().Do(ctx, cmd).ToString()
+	if err != nil {
+		return fmt.Errorf("failed to release seat hold: %w", err)
+	}
+
+	if result == "hold_not_found" {
+		return repository.ErrSeatHoldNotFound
+	}
+
+	return nil
+}
+
+// convertHoldScript turns a live hold into a reserved ticket, writing the seat, the seat ticket mapping,
+// the ticket and its indexes in one step. A lapsed hold is released instead.
+var convertHoldScript = redis.RegisterScript("seat_hold_convert", `
+	local seatData = redis.call('GET', KEYS[1])
+	if seatData == false then
+		return 'seat_not_found'
+	end
+
+	local seat = cjson.decode(seatData)
+	local holder = redis.call('GET', KEYS[2])
+	if holder == false then
+		if seat.status == 'held' then
+			seat.status = 'available'
+			seat.updated_at = ARGV[5]
+			seat.version = (seat.version or 0) + 1
+			redis.call('SET', KEYS[1], cjson.encode(seat))
+			redis.call('SADD', 'available_seats:' .. seat.event_id, seat.id)
+		end
+		redis.call('ZREM', KEYS[3], ARGV[2])
+		redis.call('ZREM', 'held_seats:' .. seat.event_id, seat.id)
+		return 'hold_expired'
+	end
+
+	if holder ~= ARGV[1] or seat.status ~= 'held' then
+		return 'hold_not_owned'
+	end
+
+	if redis.call('SET', KEYS[4], ARGV[3], 'NX') == false then
+		return 'seat_already_ticketed'
+	end
+
+	seat.status = 'reserved'
+	seat.updated_at = ARGV[5]
+	seat.version = (seat.version or 0) + 1
+	redis.call('SET', KEYS[1], cjson.encode(seat))
+	redis.call('DEL', KEYS[2])
+	redis.call('ZREM', KEYS[3], ARGV[2])
+	redis.call('ZREM', 'held_seats:' .. seat.event_id, seat.id)
+
+	redis.call('SET', KEYS[5], ARGV[4])
+	redis.call('SADD', KEYS[6], ARGV[3])
+	redis.call('SADD', KEYS[7], ARGV[3])
+	redis.call('ZADD', KEYS[8], ARGV[6], ARGV[3])
+	redis.call('SADD', KEYS[9], ARGV[3])
+	redis.call('SADD', KEYS[10], ARGV[3])
+	return 'success'
+`)
+
+// ConvertToTicket atomically moves a held seat to reserved and creates the ticket reserving it
+func (r *SeatHoldRepository) ConvertToTicket(ctx context.Context, ticket *domain.Ticket) error {
+	if ticket.SeatID == nil {
+		return fmt.Errorf("ticket has no seat")
+	}
+	if ticket.Status != string(domain.TicketStatusReserved) || ticket.ExpiresAt == nil {
+		return fmt.Errorf("only reserved tickets with an expiry can be created from a hold")
+	}
+
+	ticket.CreatedAt = time.Now()
+	ticket.UpdatedAt = time.Now()
+
+	data, err := json.Marshal(ticket)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ticket: %w", err)
+	}
+
+	seatID := *ticket.SeatID
+	keys := []string{
+		fmt.Sprintf("seat:%s", seatID.String()),
+		seatHoldKey(seatID),
+		seatHoldIndexKey,
+		fmt.Sprintf("seat_ticket:%s", seatID.String()),
+		fmt.Sprintf("ticket:%s", ticket.ID.String()),
+		fmt.Sprintf("user_tickets:%s", ticket.UserID.String()),
+		fmt.Sprintf("event_tickets:%s", ticket.EventID.String()),
+		reservedTicketsKey,
+		eventTicketsStatusKey(ticket.EventID, ticket.Status),
+		userEventTicketsKey(ticket.UserID, ticket.EventID),
+	}
+	now := time.Now().Format(time.RFC3339)
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(convertHoldScript).Numkeys(int64(len(keys))).Key(keys...).Arg(ticket.UserID.String(), seatID.String(), ticket.ID.String(), string(data), now, strconv.FormatInt(ticket.ExpiresAt.Unix(), 10)).Build()
+	result, err := r.client.GetRedisClient().Do(ctx, cmd).ToString()
+	if err != nil {
+		return fmt.Errorf("failed to convert seat hold: %w", err)
+	}
+
+	switch result {
+	case "seat_not_found":
+		return repository.ErrSeatNotFound
+	case "hold_expired", "hold_not_owned":
+		return repository.ErrSeatHoldNotFound
+	case "seat_already_ticketed":
+		return repository.ErrSeatAlreadyTicketed
+	}
+
+	return nil
+}
+
+// GetHeldByEventID retrieves an event's seats under a live hold, whoever holds them.
+// Holds that lapsed but weren't swept yet score below now and are left out.
+func (r *SeatHoldRepository) GetHeldByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain.Seat, error) {
+	nowMs := strconv.FormatInt(time.Now().UnixMilli(), 10)
+
+	rangeCmd := r.client.GetRedisClient().B().Zrangebyscore().Key(eventSeatHoldsKey(eventID)).Min("(" + nowMs).Max("+inf").Build()
+	members, err := r.client.GetRedisClient().Do(ctx, rangeCmd).AsStrSlice()
+	if err != nil && !rueidis.IsRedisNil(err) {
+		return nil, fmt.Errorf("failed to get held seats: %w", err)
+	}
+
+	seats := make([]*domain.Seat, 0, len(members))
+	if len(members) == 0 {
+		return seats, nil
+	}
+
+	keys := make([]string, len(members))
+	for i, member := range members {
+		keys[i] = fmt.Sprintf("seat:%s", member)
+	}
+
+	cmd := r.client.GetRedisClient().B().Mget().Key(keys...).Build()
+	values, err := r.client.GetRedisClient().Do(ctx, cmd).ToArray()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get held seats: %w", err)
+	}
+
+	for _, value := range values {
+		data, err := value.ToString()
+		if err != nil {
+			// The index outlived the seat; skip it
+			continue
+		}
+
+		var seat domain.Seat
+		if err := json.Unmarshal([]byte(data), &seat); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal seat: %w", err)
+		}
+
+		if seat.IsHeld() {
+			seats = append(seats, &seat)
+		}
+	}
+
+	return seats, nil
+}
+
+// releaseExpiredHoldsScript returns seats whose hold key has expired to the available pool
+var releaseExpiredHoldsScript = redis.RegisterScript("seat_hold_release_expired", `
+	local expired = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+	local released = 0
+	for _, seatID in ipairs(expired) do
+		if redis.call('EXISTS', 'seat_user_hold:' .. seatID) == 0 then
+			redis.call('ZREM', KEYS[1], seatID)
+			local seatData = redis.call('GET', 'seat:' .. seatID)
+			if seatData then
+				local seat = cjson.decode(seatData)
+				redis.call('ZREM', 'held_seats:' .. seat.event_id, seatID)
+				if seat.status == 'held' then
+					seat.status = 'available'
+					seat.updated_at = ARGV[2]
+					seat.version = (seat.version or 0) + 1
+					redis.call('SET', 'seat:' .. seatID, cjson.encode(seat))
+					redis.call('SADD', 'available_seats:' .. seat.event_id, seat.id)
+					released = released + 1
+				end
+			end
+		end
+	end
+	return released
+`)
+
+// ReleaseExpired returns seats whose holds lapsed to the available pool and reports how many were released
+func (r *SeatHoldRepository) ReleaseExpired(ctx context.Context) (int, error) {
+	nowMs := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	now := time.Now().Format(time.RFC3339)
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(releaseExpiredHoldsScript).Numkeys(1).Key(seatHoldIndexKey).Arg(nowMs, now).Build()
+	released, err := r.client.GetRedisClient().Do(ctx, cmd).AsInt64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to release expired seat holds: %w", err)
+	}
+
+	return int(released), nil
+}
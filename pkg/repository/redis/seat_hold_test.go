@@ -0,0 +1,18 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/snowmerak/ticketing/pkg/repository/repotest"
+)
+
+func TestSeatHoldRepository(t *testing.T) {
+	repotest.RunSeatHoldRepositoryTests(t, func(t *testing.T) repotest.SeatHoldRepositories {
+		client := newTestClient(t)
+		return repotest.SeatHoldRepositories{
+			Holds:   NewSeatHoldRepository(client),
+			Seats:   NewSeatRepository(client, 0),
+			Tickets: NewTicketRepository(client),
+		}
+	})
+}
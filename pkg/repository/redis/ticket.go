@@ -4,9 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/redis/rueidis"
+	"github.com/snowmerak/ticketing/lib/adapter"
 	"github.com/snowmerak/ticketing/lib/domain"
 	"github.com/snowmerak/ticketing/lib/repository"
 	"github.com/snowmerak/ticketing/pkg/client/redis"
@@ -14,16 +18,23 @@ import (
 
 // TicketRepository implements repository.TicketRepository using Redis
 type TicketRepository struct {
-	client *redis.Client
+	client   *redis.Client
+	cacheTTL adapter.CacheConfig
 }
 
 // NewTicketRepository creates a new TicketRepository
 func NewTicketRepository(client *redis.Client) *TicketRepository {
 	return &TicketRepository{
-		client: client,
+		client:   client,
+		cacheTTL: adapter.DefaultCacheConfig(),
 	}
 }
 
+// SetCacheConfig sets how long client-side cached reads are served; unset TTLs keep their defaults
+func (r *TicketRepository) SetCacheConfig(config adapter.CacheConfig) {
+	r.cacheTTL = config.WithDefaults()
+}
+
 // Compile-time check to ensure TicketRepository implements repository.TicketRepository
 var _ repository.TicketRepository = (*TicketRepository)(nil)
 
@@ -39,9 +50,27 @@ func (r *TicketRepository) Create(ctx context.Context, ticket *domain.Ticket) er
 
 	key := fmt.Sprintf("ticket:%s", ticket.ID.String())
 
+	// Claim the seat first so a second ticket for the same seat is rejected instead of overwriting the mapping
+	if ticket.SeatID != nil {
+		seatTicketKey := fmt.Sprintf("seat_ticket:%s", ticket.SeatID.String())
+		seatCmd := r.client.GetRedisClient().B().Set().Key(seatTicketKey).Value(ticket.ID.String()).Nx().Build()
+		result := r.client.GetRedisClient().Do(ctx, seatCmd)
+		if result.Error() != nil {
+			if rueidis.IsRedisNil(result.Error()) {
+				return repository.ErrSeatAlreadyTicketed
+			}
+			return fmt.Errorf("failed to add seat ticket mapping: %w", result.Error())
+		}
+	}
+
 	// Set the ticket data
 	cmd := r.client.GetRedisClient().B().Set().Key(key).Value(string(data)).Build()
 	if err := r.client.GetRedisClient().Do(ctx, cmd).Error(); err != nil {
+		if ticket.SeatID != nil {
+			if relErr := r.releaseSeatTicket(ctx, *ticket.SeatID, ticket.ID); relErr != nil {
+				return fmt.Errorf("failed to create ticket: %w (and failed to release seat mapping: %v)", err, relErr)
+			}
+		}
 		return fmt.Errorf("failed to create ticket: %w", err)
 	}
 
@@ -52,6 +81,12 @@ func (r *TicketRepository) Create(ctx context.Context, ticket *domain.Ticket) er
 		return fmt.Errorf("failed to add to user tickets: %w", err)
 	}
 
+	// Add to the user's tickets for the event
+	userEventCmd := r.client.GetRedisClient().B().Sadd().Key(userEventTicketsKey(ticket.UserID, ticket.EventID)).Member(ticket.ID.String()).Build()
+	if err := r.client.GetRedisClient().Do(ctx, userEventCmd).Error(); err != nil {
+		return fmt.Errorf("failed to add to user event tickets: %w", err)
+	}
+
 	// Add to event tickets index
 	eventTicketsKey := fmt.Sprintf("event_tickets:%s", ticket.EventID.String())
 	eventCmd := r.client.GetRedisClient().B().Sadd().Key(eventTicketsKey).Member(ticket.ID.String()).Build()
@@ -59,19 +94,15 @@ func (r *TicketRepository) Create(ctx context.Context, ticket *domain.Ticket) er
 		return fmt.Errorf("failed to add to event tickets: %w", err)
 	}
 
-	// Add to seat ticket index if seat exists
-	if ticket.SeatID != nil {
-		seatTicketKey := fmt.Sprintf("seat_ticket:%s", ticket.SeatID.String())
-		seatCmd := r.client.GetRedisClient().B().Set().Key(seatTicketKey).Value(ticket.ID.String()).Build()
-		if err := r.client.GetRedisClient().Do(ctx, seatCmd).Error(); err != nil {
-			return fmt.Errorf("failed to add seat ticket mapping: %w", err)
-		}
+	// Add to event tickets by status index
+	statusCmd := r.client.GetRedisClient().B().Sadd().Key(eventTicketsStatusKey(ticket.EventID, ticket.Status)).Member(ticket.ID.String()).Build()
+	if err := r.client.GetRedisClient().Do(ctx, statusCmd).Error(); err != nil {
+		return fmt.Errorf("failed to add to event tickets by status: %w", err)
 	}
 
 	// Add to reserved tickets index if reserved
 	if ticket.Status == string(domain.TicketStatusReserved) && ticket.ExpiresAt != nil {
-		reservedKey := fmt.Sprintf("reserved_tickets:%d", ticket.ExpiresAt.Unix())
-		reservedCmd := r.client.GetRedisClient().B().Sadd().Key(reservedKey).Member(ticket.ID.String()).Build()
+		reservedCmd := r.client.GetRedisClient().B().Zadd().Key(reservedTicketsKey).ScoreMember().ScoreMember(float64(ticket.ExpiresAt.Unix()), ticket.ID.String()).Build()
 		if err := r.client.GetRedisClient().Do(ctx, reservedCmd).Error(); err != nil {
 			return fmt.Errorf("failed to add to reserved tickets: %w", err)
 		}
@@ -80,13 +111,23 @@ func (r *TicketRepository) Create(ctx context.Context, ticket *domain.Ticket) er
 	return nil
 }
 
+// reservedTicketsKey is the sorted set of reserved ticket IDs scored by when their reservation expires
+const reservedTicketsKey = "reserved_tickets_zset"
+
+// reservedTicketScore returns a ticket's score in the reserved tickets index, or "" when it does not belong there
+func reservedTicketScore(ticket *domain.Ticket) string {
+	if ticket.Status != string(domain.TicketStatusReserved) || ticket.ExpiresAt == nil {
+		return ""
+	}
+	return strconv.FormatInt(ticket.ExpiresAt.Unix(), 10)
+}
+
 // GetByID retrieves a ticket by its ID
 func (r *TicketRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ticket, error) {
 	key := fmt.Sprintf("ticket:%s", id.String())
 
-	const clientSideCacheTTL = 15 * time.Minute // moderate TTL for ticket data
 	cmd := r.client.GetRedisClient().B().Get().Key(key).Cache()
-	result := r.client.GetRedisClient().DoCache(ctx, cmd, clientSideCacheTTL)
+	result := r.client.GetRedisClient().DoCache(ctx, cmd, r.cacheTTL.TicketTTL)
 	if result.Error() != nil {
 		return nil, fmt.Errorf("failed to get ticket: %w", result.Error())
 	}
@@ -104,13 +145,49 @@ func (r *TicketRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.T
 	return &ticket, nil
 }
 
+// GetByIDs retrieves the tickets with the given IDs in request order, skipping any that don't exist.
+// It reads past the client-side cache so pollers see status changes as soon as they are written.
+func (r *TicketRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Ticket, error) {
+	tickets := []*domain.Ticket{}
+	if len(ids) == 0 {
+		return tickets, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = fmt.Sprintf("ticket:%s", id.String())
+	}
+
+	cmd := r.client.GetRedisClient().B().Mget().Key(keys...).Build()
+	values, err := r.client.GetRedisClient().Do(ctx, cmd).ToArray()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tickets: %w", err)
+	}
+
+	for _, value := range values {
+		data, err := value.ToString()
+		if err != nil {
+			// Missing tickets come back as nil
+			continue
+		}
+
+		var ticket domain.Ticket
+		if err := json.Unmarshal([]byte(data), &ticket); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ticket: %w", err)
+		}
+
+		tickets = append(tickets, &ticket)
+	}
+
+	return tickets, nil
+}
+
 // GetByUserID retrieves all tickets for a user
 func (r *TicketRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Ticket, error) {
 	userTicketsKey := fmt.Sprintf("user_tickets:%s", userID.String())
 
-	const clientSideCacheTTL = 5 * time.Minute // shorter TTL for user ticket lists
 	cmd := r.client.GetRedisClient().B().Smembers().Key(userTicketsKey).Cache()
-	result := r.client.GetRedisClient().DoCache(ctx, cmd, clientSideCacheTTL)
+	result := r.client.GetRedisClient().DoCache(ctx, cmd, r.cacheTTL.UserTicketsTTL)
 	if result.Error() != nil {
 		return nil, fmt.Errorf("failed to get user tickets: %w", result.Error())
 	}
@@ -138,6 +215,47 @@ func (r *TicketRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([
 	return tickets, nil
 }
 
+// userEventTicketsKey names the set of a user's ticket IDs for one event
+func userEventTicketsKey(userID, eventID uuid.UUID) string {
+	return fmt.Sprintf("user_event_tickets:%s:%s", userID.String(), eventID.String())
+}
+
+// GetByUserAndEvent retrieves a user's tickets for one event from the user's per-event index, in one MGET
+func (r *TicketRepository) GetByUserAndEvent(ctx context.Context, userID, eventID uuid.UUID) ([]*domain.Ticket, error) {
+	cmd := r.client.GetRedisClient().B().Smembers().Key(userEventTicketsKey(userID, eventID)).Build()
+	members, err := r.client.GetRedisClient().Do(ctx, cmd).AsStrSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user event tickets: %w", err)
+	}
+
+	// Sets are unordered; sort so repeated listings come back in the same order
+	sort.Strings(members)
+
+	ids := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		ticketID, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, ticketID)
+	}
+
+	tickets, err := r.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// Transfers move the ID in the same script that writes the ticket, so this only skips a ticket changing hands mid-read
+	matched := make([]*domain.Ticket, 0, len(tickets))
+	for _, ticket := range tickets {
+		if ticket.UserID == userID && ticket.EventID == eventID {
+			matched = append(matched, ticket)
+		}
+	}
+
+	return matched, nil
+}
+
 // GetByEventID retrieves all tickets for an event
 func (r *TicketRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain.Ticket, error) {
 	eventTicketsKey := fmt.Sprintf("event_tickets:%s", eventID.String())
@@ -171,6 +289,124 @@ func (r *TicketRepository) GetByEventID(ctx context.Context, eventID uuid.UUID)
 	return tickets, nil
 }
 
+// GetByEventIDPaginated retrieves a page of an event's tickets along with the event's total. Only the page's
+// tickets are read, in one MGET, so large events are never loaded whole.
+func (r *TicketRepository) GetByEventIDPaginated(ctx context.Context, eventID uuid.UUID, offset, limit int) ([]*domain.Ticket, int, error) {
+	eventTicketsKey := fmt.Sprintf("event_tickets:%s", eventID.String())
+
+	cmd := r.client.GetRedisClient().B().Smembers().Key(eventTicketsKey).Build()
+	members, err := r.client.GetRedisClient().Do(ctx, cmd).AsStrSlice()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get event tickets: %w", err)
+	}
+
+	// Sets are unordered, so sort to keep pages stable between requests
+	sort.Strings(members)
+
+	if offset >= len(members) || limit <= 0 {
+		return []*domain.Ticket{}, len(members), nil
+	}
+
+	end := offset + limit
+	if end > len(members) {
+		end = len(members)
+	}
+
+	ids := make([]uuid.UUID, 0, end-offset)
+	for _, member := range members[offset:end] {
+		ticketID, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, ticketID)
+	}
+
+	tickets, err := r.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return tickets, len(members), nil
+}
+
+// eventTicketsStatusKey names the set of an event's ticket IDs in one status
+func eventTicketsStatusKey(eventID uuid.UUID, status string) string {
+	return fmt.Sprintf("event_tickets_status:%s:%s", eventID.String(), status)
+}
+
+// GetByEventIDAndStatus retrieves an event's tickets in one status from the event's status index, in one MGET
+func (r *TicketRepository) GetByEventIDAndStatus(ctx context.Context, eventID uuid.UUID, status string) ([]*domain.Ticket, error) {
+	cmd := r.client.GetRedisClient().B().Smembers().Key(eventTicketsStatusKey(eventID, status)).Build()
+	members, err := r.client.GetRedisClient().Do(ctx, cmd).AsStrSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event tickets by status: %w", err)
+	}
+
+	// Sets are unordered; sort so repeated listings come back in the same order
+	sort.Strings(members)
+
+	ids := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		ticketID, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, ticketID)
+	}
+
+	tickets, err := r.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// The status is moved in the same script that writes the ticket, so this only skips a ticket deleted mid-read
+	matched := make([]*domain.Ticket, 0, len(tickets))
+	for _, ticket := range tickets {
+		if ticket.Status == status {
+			matched = append(matched, ticket)
+		}
+	}
+
+	return matched, nil
+}
+
+// GetByEventIDAndStatusPaginated retrieves a page of an event's tickets in one status from the status index
+func (r *TicketRepository) GetByEventIDAndStatusPaginated(ctx context.Context, eventID uuid.UUID, status string, offset, limit int) ([]*domain.Ticket, int, error) {
+	cmd := r.client.GetRedisClient().B().Smembers().Key(eventTicketsStatusKey(eventID, status)).Build()
+	members, err := r.client.GetRedisClient().Do(ctx, cmd).AsStrSlice()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get event tickets by status: %w", err)
+	}
+
+	// Sets are unordered, so sort to keep pages stable between requests
+	sort.Strings(members)
+
+	if offset >= len(members) || limit <= 0 {
+		return []*domain.Ticket{}, len(members), nil
+	}
+
+	end := offset + limit
+	if end > len(members) {
+		end = len(members)
+	}
+
+	ids := make([]uuid.UUID, 0, end-offset)
+	for _, member := range members[offset:end] {
+		ticketID, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, ticketID)
+	}
+
+	tickets, err := r.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return tickets, len(members), nil
+}
+
 // GetBySeatID retrieves a ticket by seat ID
 func (r *TicketRepository) GetBySeatID(ctx context.Context, seatID uuid.UUID) (*domain.Ticket, error) {
 	seatTicketKey := fmt.Sprintf("seat_ticket:%s", seatID.String())
@@ -194,7 +430,28 @@ func (r *TicketRepository) GetBySeatID(ctx context.Context, seatID uuid.UUID) (*
 	return r.GetByID(ctx, ticketUUID)
 }
 
-// Update updates an existing ticket
+// updateTicketScript writes a ticket and, when its status changed, moves its ID from the old status set
+// to the new one in the same step, so the status index never lists a ticket under two statuses. The ticket
+// stays in the reserved tickets index (KEYS[3]) only while ARGV[4] carries its expiry score.
+var updateTicketScript = redis.RegisterScript("ticket_update", `
+	local previous = redis.call('GET', KEYS[1])
+	redis.call('SET', KEYS[1], ARGV[1])
+	if previous ~= false then
+		local old = cjson.decode(previous)
+		if old.status ~= ARGV[3] then
+			redis.call('SREM', 'event_tickets_status:' .. old.event_id .. ':' .. old.status, ARGV[2])
+		end
+	end
+	redis.call('SADD', KEYS[2], ARGV[2])
+	if ARGV[4] ~= '' then
+		redis.call('ZADD', KEYS[3], ARGV[4], ARGV[2])
+	else
+		redis.call('ZREM', KEYS[3], ARGV[2])
+	end
+	return 'success'
+`)
+
+// Update updates an existing ticket, moving it between the event's status sets if its status changed
 func (r *TicketRepository) Update(ctx context.Context, ticket *domain.Ticket) error {
 	ticket.UpdatedAt = time.Now()
 
@@ -205,8 +462,7 @@ func (r *TicketRepository) Update(ctx context.Context, ticket *domain.Ticket) er
 
 	key := fmt.Sprintf("ticket:%s", ticket.ID.String())
 
-	// Update the ticket data
-	cmd := r.client.GetRedisClient().B().Set().Key(key).Value(string(data)).Build()
+	cmd := r.client.GetRedisClient().B().Eval().Script(updateTicketScript).Numkeys(3).Key(key, eventTicketsStatusKey(ticket.EventID, ticket.Status), reservedTicketsKey).Arg(string(data), ticket.ID.String(), ticket.Status, reservedTicketScore(ticket)).Build()
 	if err := r.client.GetRedisClient().Do(ctx, cmd).Error(); err != nil {
 		return fmt.Errorf("failed to update ticket: %w", err)
 	}
@@ -221,51 +477,142 @@ func (r *TicketRepository) UpdateStatus(ctx context.Context, ticketID uuid.UUID,
 		return fmt.Errorf("failed to get ticket: %w", err)
 	}
 
-	ticket.Status = status
+	ticket.SetStatus(status, time.Now())
 	return r.Update(ctx, ticket)
 }
 
-// GetExpiredReservations retrieves all expired reservations
-func (r *TicketRepository) GetExpiredReservations(ctx context.Context) ([]*domain.Ticket, error) {
-	now := time.Now().Unix()
+// transferTicketScript hands a ticket to a new holder if it is still held by the expected one,
+// dropping a guest holder's email and moving it between the holders' ticket indexes and per-event indexes
+var transferTicketScript = redis.RegisterScript("ticket_transfer", `
+	local data = redis.call('GET', KEYS[1])
+	if data == false then
+		return 'ticket_not_found'
+	end
+
+	local ticket = cjson.decode(data)
+	if ticket.user_id ~= ARGV[1] then
+		return 'owner_mismatch'
+	end
+
+	ticket.user_id = ARGV[2]
+	ticket.guest_email = nil
+	ticket.access_token = ARGV[3]
+	ticket.updated_at = ARGV[4]
+	redis.call('SET', KEYS[1], cjson.encode(ticket))
+	redis.call('SREM', KEYS[2], ticket.id)
+	redis.call('SADD', KEYS[3], ticket.id)
+	redis.call('SREM', 'user_event_tickets:' .. ARGV[1] .. ':' .. ticket.event_id, ticket.id)
+	redis.call('SADD', 'user_event_tickets:' .. ARGV[2] .. ':' .. ticket.event_id, ticket.id)
+	return 'success'
+`)
+
+// Transfer moves a ticket from one holder to another and replaces its gate access token
+func (r *TicketRepository) Transfer(ctx context.Context, ticketID, fromUserID, toUserID uuid.UUID, accessToken string) error {
+	key := fmt.Sprintf("ticket:%s", ticketID.String())
+	fromKey := fmt.Sprintf("user_tickets:%s", fromUserID.String())
+	toKey := fmt.Sprintf("user_tickets:%s", toUserID.String())
+	now := time.Now().Format(time.RFC3339Nano)
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(transferTicketScript).Numkeys(3).Key(key, fromKey, toKey).Arg(fromUserID.String(), toUserID.String(), accessToken, now).Build()
+	result, err := r.client.GetRedisClient().Do(ctx, cmd).ToString()
+	if err != nil {
+		return fmt.Errorf("failed to transfer ticket: %w", err)
+	}
 
-	// Get all reservation keys up to current time
-	var expiredTickets []*domain.Ticket
+	switch result {
+	case "ticket_not_found":
+		return fmt.Errorf("ticket not found")
+	case "owner_mismatch":
+		return repository.ErrTicketOwnerMismatch
+	}
 
-	// This is a simplified implementation - in production, you'd use a better approach
-	// to track expiration times, possibly with sorted sets
-	for i := now - 3600; i <= now; i++ { // Check last hour
-		reservedKey := fmt.Sprintf("reserved_tickets:%d", i)
+	return nil
+}
 
-		cmd := r.client.GetRedisClient().B().Smembers().Key(reservedKey).Build()
-		result := r.client.GetRedisClient().Do(ctx, cmd)
-		if result.Error() != nil {
+// GetExpiredReservations retrieves the reservations lapsed as of now, however long ago, by reading the reserved
+// tickets index up to now and hydrating only those tickets
+func (r *TicketRepository) GetExpiredReservations(ctx context.Context, now time.Time) ([]*domain.Ticket, error) {
+	until := strconv.FormatInt(now.Unix(), 10)
+
+	cmd := r.client.GetRedisClient().B().Zrangebyscore().Key(reservedTicketsKey).Min("-inf").Max(until).Build()
+	members, err := r.client.GetRedisClient().Do(ctx, cmd).AsStrSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reserved tickets: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		ticketID, err := uuid.Parse(member)
+		if err != nil {
 			continue
 		}
+		ids = append(ids, ticketID)
+	}
+
+	tickets, err := r.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	expiredTickets := make([]*domain.Ticket, 0, len(tickets))
+	for _, ticket := range tickets {
+		// The score is rounded down to the second, so a reservation can be listed just before it lapses
+		if ticket.IsReserved() && ticket.IsExpiredAt(now) {
+			expiredTickets = append(expiredTickets, ticket)
+		}
+	}
+
+	return expiredTickets, nil
+}
+
+// GetReservationsExpiringBy retrieves the reservations that lapse at or before by from the reserved tickets index
+func (r *TicketRepository) GetReservationsExpiringBy(ctx context.Context, by time.Time) ([]*domain.Ticket, error) {
+	// Scores are rounded down to the second, so read through the second by falls in and filter exactly below
+	until := strconv.FormatInt(by.Unix(), 10)
+
+	cmd := r.client.GetRedisClient().B().Zrangebyscore().Key(reservedTicketsKey).Min("-inf").Max(until).Build()
+	members, err := r.client.GetRedisClient().Do(ctx, cmd).AsStrSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reserved tickets: %w", err)
+	}
 
-		members, err := result.AsStrSlice()
+	ids := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		ticketID, err := uuid.Parse(member)
 		if err != nil {
 			continue
 		}
+		ids = append(ids, ticketID)
+	}
 
-		for _, member := range members {
-			ticketID, err := uuid.Parse(member)
-			if err != nil {
-				continue
-			}
+	tickets, err := r.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
 
-			ticket, err := r.GetByID(ctx, ticketID)
-			if err != nil {
-				continue
-			}
+	expiring := make([]*domain.Ticket, 0, len(tickets))
+	for _, ticket := range tickets {
+		if ticket.IsReserved() && ticket.ExpiresAt != nil && !ticket.ExpiresAt.After(by) {
+			expiring = append(expiring, ticket)
+		}
+	}
 
-			if ticket.IsExpired() {
-				expiredTickets = append(expiredTickets, ticket)
-			}
+	return expiring, nil
+}
+
+// MarkExpiryNotice records that a reservation's holder was warned; SET NX makes only the first caller see it as new
+func (r *TicketRepository) MarkExpiryNotice(ctx context.Context, ticketID uuid.UUID, ttl time.Duration) (bool, error) {
+	noticeKey := fmt.Sprintf("expiry_notice:%s", ticketID.String())
+
+	cmd := r.client.GetRedisClient().B().Set().Key(noticeKey).Value("1").Nx().Px(ttl).Build()
+	if err := r.client.GetRedisClient().Do(ctx, cmd).Error(); err != nil {
+		if rueidis.IsRedisNil(err) {
+			return false, nil
 		}
+		return false, fmt.Errorf("failed to mark expiry notice: %w", err)
 	}
 
-	return expiredTickets, nil
+	return true, nil
 }
 
 // ConfirmTicket confirms a reserved ticket
@@ -273,9 +620,340 @@ func (r *TicketRepository) ConfirmTicket(ctx context.Context, ticketID uuid.UUID
 	return r.UpdateStatus(ctx, ticketID, string(domain.TicketStatusConfirmed))
 }
 
-// CancelTicket cancels a ticket and updates its status
+// confirmReservationScript confirms a ticket only while it is still reserved, checking and writing in one step.
+// It stamps the confirmation time (ARGV[2]) and access token (ARGV[1]), moves the ticket to the confirmed status
+// set and drops it from the reserved tickets index (KEYS[2]), returning the confirmed ticket.
+var confirmReservationScript = redis.RegisterScript("ticket_confirm_reservation", `
+	local data = redis.call('GET', KEYS[1])
+	if data == false then
+		return {'ticket_not_found'}
+	end
+
+	local ticket = cjson.decode(data)
+	if ticket.status ~= 'reserved' then
+		return {'not_reserved'}
+	end
+
+	ticket.status = 'confirmed'
+	ticket.access_token = ARGV[1]
+	ticket.confirmed_at = ARGV[2]
+	ticket.updated_at = ARGV[2]
+	local confirmed = cjson.encode(ticket)
+
+	redis.call('SET', KEYS[1], confirmed)
+	redis.call('SREM', 'event_tickets_status:' .. ticket.event_id .. ':reserved', ticket.id)
+	redis.call('SADD', 'event_tickets_status:' .. ticket.event_id .. ':confirmed', ticket.id)
+	redis.call('ZREM', KEYS[2], ticket.id)
+	return {'success', confirmed}
+`)
+
+// ConfirmReservation confirms a ticket that is still reserved and issues its gate access token
+func (r *TicketRepository) ConfirmReservation(ctx context.Context, ticketID uuid.UUID, accessToken string, at time.Time) (*domain.Ticket, error) {
+	key := fmt.Sprintf("ticket:%s", ticketID.String())
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(confirmReservationScript).Numkeys(2).Key(key, reservedTicketsKey).Arg(accessToken, at.Format(time.RFC3339Nano)).Build()
+	values, err := r.client.GetRedisClient().Do(ctx, cmd).ToArray()
+	if err != nil {
+		return nil, fmt.Errorf("failed to confirm ticket: %w", err)
+	}
+
+	return decodeTicketTransition(values, map[string]error{
+		"ticket_not_found": fmt.Errorf("failed to get ticket: ticket not found"),
+		"not_reserved":     repository.ErrTicketNotReserved,
+	})
+}
+
+// checkInTicketScript records a confirmed ticket's admission (ARGV[1]) unless it was already admitted,
+// checking and writing in one step, and returns the admitted ticket
+var checkInTicketScript = redis.RegisterScript("ticket_check_in", `
+	local data = redis.call('GET', KEYS[1])
+	if data == false then
+		return {'ticket_not_found'}
+	end
+
+	local ticket = cjson.decode(data)
+	if ticket.checked_in_at then
+		return {'already_checked_in'}
+	end
+	if ticket.status ~= 'confirmed' then
+		return {'not_confirmed'}
+	end
+
+	ticket.checked_in_at = ARGV[1]
+	ticket.updated_at = ARGV[1]
+	local admitted = cjson.encode(ticket)
+
+	redis.call('SET', KEYS[1], admitted)
+	return {'success', admitted}
+`)
+
+// CheckIn admits a confirmed ticket that has not been admitted yet
+func (r *TicketRepository) CheckIn(ctx context.Context, ticketID uuid.UUID, at time.Time) (*domain.Ticket, error) {
+	key := fmt.Sprintf("ticket:%s", ticketID.String())
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(checkInTicketScript).Numkeys(1).Key(key).Arg(at.Format(time.RFC3339Nano)).Build()
+	values, err := r.client.GetRedisClient().Do(ctx, cmd).ToArray()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check in ticket: %w", err)
+	}
+
+	return decodeTicketTransition(values, map[string]error{
+		"ticket_not_found":   fmt.Errorf("failed to get ticket: ticket not found"),
+		"already_checked_in": repository.ErrTicketAlreadyCheckedIn,
+		"not_confirmed":      repository.ErrTicketNotConfirmed,
+	})
+}
+
+// decodeTicketTransition reads a {result, ticket} script reply, mapping a refusal result to its error
+func decodeTicketTransition(values []rueidis.RedisMessage, refusals map[string]error) (*domain.Ticket, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("empty script result")
+	}
+
+	result, err := values[0].ToString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script result: %w", err)
+	}
+	if refusal, ok := refusals[result]; ok {
+		return nil, refusal
+	}
+
+	if len(values) < 2 {
+		return nil, fmt.Errorf("script returned no ticket")
+	}
+	data, err := values[1].ToString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ticket: %w", err)
+	}
+
+	var ticket domain.Ticket
+	if err := json.Unmarshal([]byte(data), &ticket); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ticket: %w", err)
+	}
+
+	return &ticket, nil
+}
+
+// cancelTicketScript cancels a ticket unless it is already cancelled or, when ARGV[1] is set, is not in that
+// status, checking and writing in one step. It moves the ticket to the cancelled status set, drops it from the
+// reserved tickets index (KEYS[2]) and frees its seat mapping if the mapping still points to it, returning the
+// cancelled ticket.
+var cancelTicketScript = redis.RegisterScript("ticket_cancel", `
+	local data = redis.call('GET', KEYS[1])
+	if data == false then
+		return {'ticket_not_found'}
+	end
+
+	local ticket = cjson.decode(data)
+	if ticket.status == 'cancelled' then
+		return {'already_cancelled'}
+	end
+	if ARGV[1] ~= '' and ticket.status ~= ARGV[1] then
+		return {'status_mismatch'}
+	end
+
+	local previous = ticket.status
+	ticket.status = 'cancelled'
+	ticket.cancelled_at = ARGV[2]
+	ticket.updated_at = ARGV[2]
+	local cancelled = cjson.encode(ticket)
+
+	redis.call('SET', KEYS[1], cancelled)
+	redis.call('SREM', 'event_tickets_status:' .. ticket.event_id .. ':' .. previous, ticket.id)
+	redis.call('SADD', 'event_tickets_status:' .. ticket.event_id .. ':cancelled', ticket.id)
+	redis.call('ZREM', KEYS[2], ticket.id)
+	if ticket.seat_id then
+		local seatTicketKey = 'seat_ticket:' .. ticket.seat_id
+		if redis.call('GET', seatTicketKey) == ticket.id then
+			redis.call('DEL', seatTicketKey)
+		end
+	end
+	return {'success', cancelled}
+`)
+
+// CancelTicket cancels a ticket that is not already cancelled and frees its seat mapping for reuse
 func (r *TicketRepository) CancelTicket(ctx context.Context, ticketID uuid.UUID) error {
-	return r.UpdateStatus(ctx, ticketID, string(domain.TicketStatusCancelled))
+	_, err := r.cancel(ctx, ticketID, "")
+	return err
+}
+
+// CancelReservation cancels a ticket that is still reserved and frees its seat mapping for reuse
+func (r *TicketRepository) CancelReservation(ctx context.Context, ticketID uuid.UUID) (*domain.Ticket, error) {
+	return r.cancel(ctx, ticketID, string(domain.TicketStatusReserved))
+}
+
+// cancel runs cancelTicketScript for a ticket, requiring it to be in status unless status is empty
+func (r *TicketRepository) cancel(ctx context.Context, ticketID uuid.UUID, status string) (*domain.Ticket, error) {
+	key := fmt.Sprintf("ticket:%s", ticketID.String())
+	now := time.Now().Format(time.RFC3339Nano)
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(cancelTicketScript).Numkeys(2).Key(key, reservedTicketsKey).Arg(status, now).Build()
+	values, err := r.client.GetRedisClient().Do(ctx, cmd).ToArray()
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel ticket: %w", err)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("failed to cancel ticket: empty script result")
+	}
+
+	result, err := values[0].ToString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cancel result: %w", err)
+	}
+
+	switch result {
+	case "ticket_not_found":
+		return nil, fmt.Errorf("failed to get ticket: ticket not found")
+	case "already_cancelled":
+		if status == "" {
+			return nil, repository.ErrTicketAlreadyCancelled
+		}
+		return nil, repository.ErrTicketNotReserved
+	case "status_mismatch":
+		return nil, repository.ErrTicketNotReserved
+	}
+
+	if len(values) < 2 {
+		return nil, fmt.Errorf("failed to cancel ticket: script returned no ticket")
+	}
+	data, err := values[1].ToString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cancelled ticket: %w", err)
+	}
+
+	var ticket domain.Ticket
+	if err := json.Unmarshal([]byte(data), &ticket); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ticket: %w", err)
+	}
+
+	return &ticket, nil
+}
+
+// releaseSeatTicketScript deletes a seat ticket mapping only if it still points to the given ticket
+var releaseSeatTicketScript = redis.RegisterScript("ticket_release_seat", `
+	if redis.call('GET', KEYS[1]) == ARGV[1] then
+		return redis.call('DEL', KEYS[1])
+	end
+	return 0
+`)
+
+// releaseSeatTicket removes the seat ticket mapping if it belongs to the ticket
+func (r *TicketRepository) releaseSeatTicket(ctx context.Context, seatID, ticketID uuid.UUID) error {
+	seatTicketKey := fmt.Sprintf("seat_ticket:%s", seatID.String())
+
+	cmd := r.client.GetRedisClient().B().Eval().Script(releaseSeatTicketScript).Numkeys(1).Key(seatTicketKey).Arg(ticketID.String()).Build()
+	if err := r.client.GetRedisClient().Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("failed to remove seat ticket mapping: %w", err)
+	}
+
+	return nil
+}
+
+// NextGANumber atomically allocates the next general admission number for an event
+func (r *TicketRepository) NextGANumber(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	counterKey := fmt.Sprintf("ga_counter:%s", eventID.String())
+
+	cmd := r.client.GetRedisClient().B().Incr().Key(counterKey).Build()
+	result := r.client.GetRedisClient().Do(ctx, cmd)
+	if result.Error() != nil {
+		return 0, fmt.Errorf("failed to increment GA counter: %w", result.Error())
+	}
+
+	number, err := result.ToInt64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse GA number: %w", err)
+	}
+
+	return number, nil
+}
+
+// userEventCountKey names the counter of tickets a user holds for an event
+func userEventCountKey(eventID, userID uuid.UUID) string {
+	return fmt.Sprintf("user_event_count:%s:%s", eventID.String(), userID.String())
+}
+
+// incrementUserEventCountScript adds to a user's ticket count unless the result would pass the limit
+var incrementUserEventCountScript = redis.RegisterScript("user_event_count_incr", `
+	local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+	local limit = tonumber(ARGV[2])
+	if limit > 0 and current + tonumber(ARGV[1]) > limit then
+		return -1
+	end
+	return redis.call('INCRBY', KEYS[1], ARGV[1])
+`)
+
+// IncrementUserEventCount adds count to a user's tickets for an event unless that would exceed limit
+func (r *TicketRepository) IncrementUserEventCount(ctx context.Context, eventID, userID uuid.UUID, count, limit int) (int, error) {
+	cmd := r.client.GetRedisClient().B().Eval().Script(incrementUserEventCountScript).Numkeys(1).Key(userEventCountKey(eventID, userID)).Arg(strconv.Itoa(count), strconv.Itoa(limit)).Build()
+	total, err := r.client.GetRedisClient().Do(ctx, cmd).AsInt64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment user ticket count: %w", err)
+	}
+
+	if total < 0 {
+		return 0, repository.ErrUserTicketLimitReached
+	}
+
+	return int(total), nil
+}
+
+// decrementUserEventCountScript takes from a user's ticket count, deleting the counter once it reaches zero
+var decrementUserEventCountScript = redis.RegisterScript("user_event_count_decr", `
+	local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+	if current <= tonumber(ARGV[1]) then
+		redis.call('DEL', KEYS[1])
+		return 0
+	end
+	return redis.call('DECRBY', KEYS[1], ARGV[1])
+`)
+
+// DecrementUserEventCount takes count off a user's tickets for an event, never going below zero
+func (r *TicketRepository) DecrementUserEventCount(ctx context.Context, eventID, userID uuid.UUID, count int) error {
+	cmd := r.client.GetRedisClient().B().Eval().Script(decrementUserEventCountScript).Numkeys(1).Key(userEventCountKey(eventID, userID)).Arg(strconv.Itoa(count)).Build()
+	if err := r.client.GetRedisClient().Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("failed to decrement user ticket count: %w", err)
+	}
+
+	return nil
+}
+
+// SaveConfirmationToken stores a single-use confirmation token hash for a ticket that lapses after ttl
+func (r *TicketRepository) SaveConfirmationToken(ctx context.Context, tokenHash string, ticketID uuid.UUID, ttl time.Duration) error {
+	tokenKey := fmt.Sprintf("confirm_token:%s", tokenHash)
+
+	cmd := r.client.GetRedisClient().B().Set().Key(tokenKey).Value(ticketID.String()).Nx().Px(ttl).Build()
+	if err := r.client.GetRedisClient().Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("failed to save confirmation token: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeConfirmationToken atomically looks up and deletes a confirmation token hash
+func (r *TicketRepository) ConsumeConfirmationToken(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	tokenKey := fmt.Sprintf("confirm_token:%s", tokenHash)
+
+	// GETDEL makes the token single-use even when the link is opened twice at once
+	cmd := r.client.GetRedisClient().B().Getdel().Key(tokenKey).Build()
+	result := r.client.GetRedisClient().Do(ctx, cmd)
+	if result.Error() != nil {
+		if rueidis.IsRedisNil(result.Error()) {
+			return uuid.Nil, repository.ErrConfirmationTokenNotFound
+		}
+		return uuid.Nil, fmt.Errorf("failed to consume confirmation token: %w", result.Error())
+	}
+
+	value, err := result.ToString()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get confirmation token: %w", err)
+	}
+
+	ticketID, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to parse ticket ID: %w", err)
+	}
+
+	return ticketID, nil
 }
 
 // Delete deletes a ticket by its ID
@@ -303,6 +981,12 @@ func (r *TicketRepository) Delete(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("failed to remove from user tickets: %w", err)
 	}
 
+	// Remove from the user's tickets for the event
+	userEventRemCmd := r.client.GetRedisClient().B().Srem().Key(userEventTicketsKey(ticket.UserID, ticket.EventID)).Member(idStr).Build()
+	if err := r.client.GetRedisClient().Do(ctx, userEventRemCmd).Error(); err != nil {
+		return fmt.Errorf("failed to remove from user event tickets: %w", err)
+	}
+
 	// Remove from event tickets
 	eventTicketsKey := fmt.Sprintf("event_tickets:%s", ticket.EventID.String())
 	eventRemCmd := r.client.GetRedisClient().B().Srem().Key(eventTicketsKey).Member(idStr).Build()
@@ -310,21 +994,31 @@ func (r *TicketRepository) Delete(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("failed to remove from event tickets: %w", err)
 	}
 
-	// Remove seat ticket mapping if exists
+	// Remove from event tickets by status
+	statusRemCmd := r.client.GetRedisClient().B().Srem().Key(eventTicketsStatusKey(ticket.EventID, ticket.Status)).Member(idStr).Build()
+	if err := r.client.GetRedisClient().Do(ctx, statusRemCmd).Error(); err != nil {
+		return fmt.Errorf("failed to remove from event tickets by status: %w", err)
+	}
+
+	// Remove seat ticket mapping if it still belongs to this ticket
 	if ticket.SeatID != nil {
-		seatTicketKey := fmt.Sprintf("seat_ticket:%s", ticket.SeatID.String())
-		seatDelCmd := r.client.GetRedisClient().B().Del().Key(seatTicketKey).Build()
-		if err := r.client.GetRedisClient().Do(ctx, seatDelCmd).Error(); err != nil {
-			return fmt.Errorf("failed to remove seat ticket mapping: %w", err)
+		if err := r.releaseSeatTicket(ctx, *ticket.SeatID, id); err != nil {
+			return err
 		}
 	}
 
-	// Remove from reserved tickets if applicable
-	if ticket.Status == string(domain.TicketStatusReserved) && ticket.ExpiresAt != nil {
-		reservedKey := fmt.Sprintf("reserved_tickets:%d", ticket.ExpiresAt.Unix())
-		reservedRemCmd := r.client.GetRedisClient().B().Srem().Key(reservedKey).Member(idStr).Build()
-		if err := r.client.GetRedisClient().Do(ctx, reservedRemCmd).Error(); err != nil {
-			return fmt.Errorf("failed to remove from reserved tickets: %w", err)
+	// Remove from reserved tickets
+	reservedRemCmd := r.client.GetRedisClient().B().Zrem().Key(reservedTicketsKey).Member(idStr).Build()
+	if err := r.client.GetRedisClient().Do(ctx, reservedRemCmd).Error(); err != nil {
+		return fmt.Errorf("failed to remove from reserved tickets: %w", err)
+	}
+
+	// Reservations made before the sorted set was introduced are still listed in per-second sets
+	if ticket.ExpiresAt != nil {
+		legacyKey := fmt.Sprintf("reserved_tickets:%d", ticket.ExpiresAt.Unix())
+		legacyRemCmd := r.client.GetRedisClient().B().Srem().Key(legacyKey).Member(idStr).Build()
+		if err := r.client.GetRedisClient().Do(ctx, legacyRemCmd).Error(); err != nil {
+			return fmt.Errorf("failed to remove from legacy reserved tickets: %w", err)
 		}
 	}
 
@@ -0,0 +1,128 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// AuditRepositoryFactory returns a fresh, empty AuditRepository for a single test case
+type AuditRepositoryFactory func(t *testing.T) repository.AuditRepository
+
+// RunAuditRepositoryTests runs the AuditRepository conformance suite
+func RunAuditRepositoryTests(t *testing.T, newRepo AuditRepositoryFactory) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, ctx context.Context, repo repository.AuditRepository)
+	}{
+		{
+			name: "append then list returns entries newest first",
+			run: func(t *testing.T, ctx context.Context, repo repository.AuditRepository) {
+				userID := uuid.New()
+				first := newTestAccessLogEntry("/events/{id}/seats/available", &userID, 200)
+				second := newTestAccessLogEntry("/tickets/purchase", &userID, 409)
+				mustNoError(t, repo.AppendAccess(ctx, first), "append first entry")
+				mustNoError(t, repo.AppendAccess(ctx, second), "append second entry")
+
+				if first.ID == "" || second.ID == "" {
+					t.Fatal("expected appended entries to be assigned IDs")
+				}
+
+				entries, err := repo.ListAccess(ctx, repository.AccessLogFilter{Limit: 10})
+				mustNoError(t, err, "list entries")
+				if len(entries) != 2 {
+					t.Fatalf("expected 2 entries, got %d", len(entries))
+				}
+
+				got := entries[0]
+				if got.ID != second.ID || got.Endpoint != second.Endpoint || got.Status != 409 || got.SessionID != second.SessionID {
+					t.Fatalf("newest entry mismatch: got %+v", got)
+				}
+				if got.UserID == nil || *got.UserID != userID {
+					t.Fatalf("expected user %s on entry, got %v", userID, got.UserID)
+				}
+			},
+		},
+		{
+			name: "list filters by user and honours the limit",
+			run: func(t *testing.T, ctx context.Context, repo repository.AuditRepository) {
+				userID := uuid.New()
+				other := uuid.New()
+				for i := 0; i < 3; i++ {
+					mustNoError(t, repo.AppendAccess(ctx, newTestAccessLogEntry("/tickets/purchase", &userID, 201)), "append user entry")
+					mustNoError(t, repo.AppendAccess(ctx, newTestAccessLogEntry("/tickets/purchase", &other, 201)), "append other entry")
+				}
+				mustNoError(t, repo.AppendAccess(ctx, newTestAccessLogEntry("/events/{id}/seats/available", nil, 200)), "append anonymous entry")
+
+				entries, err := repo.ListAccess(ctx, repository.AccessLogFilter{UserID: &userID, Limit: 10})
+				mustNoError(t, err, "list user entries")
+				if len(entries) != 3 {
+					t.Fatalf("expected 3 entries for the user, got %d", len(entries))
+				}
+				for _, entry := range entries {
+					if entry.UserID == nil || *entry.UserID != userID {
+						t.Fatalf("entry of another user returned: %+v", entry)
+					}
+				}
+
+				limited, err := repo.ListAccess(ctx, repository.AccessLogFilter{Limit: 2})
+				mustNoError(t, err, "list limited entries")
+				if len(limited) != 2 {
+					t.Fatalf("expected limit of 2 entries, got %d", len(limited))
+				}
+			},
+		},
+		{
+			name: "purchase signals are listed per event newest first",
+			run: func(t *testing.T, ctx context.Context, repo repository.AuditRepository) {
+				eventID := uuid.New()
+				first := newTestPurchaseSignal(eventID, "198.51.100.7:5000")
+				second := newTestPurchaseSignal(eventID, "203.0.113.9:6000")
+				mustNoError(t, repo.AppendPurchaseSignal(ctx, first), "append first signal")
+				mustNoError(t, repo.AppendPurchaseSignal(ctx, second), "append second signal")
+				mustNoError(t, repo.AppendPurchaseSignal(ctx, newTestPurchaseSignal(uuid.New(), "192.0.2.1:4321")), "append signal of another event")
+
+				if first.ID == "" || second.ID == "" {
+					t.Fatal("expected appended signals to be assigned IDs")
+				}
+
+				signals, err := repo.ListPurchaseSignals(ctx, eventID, 10)
+				mustNoError(t, err, "list signals")
+				if len(signals) != 2 {
+					t.Fatalf("expected 2 signals for the event, got %d", len(signals))
+				}
+
+				got := signals[0]
+				if got.ID != second.ID || got.EventID != eventID || got.UserID != second.UserID || got.SessionID != second.SessionID {
+					t.Fatalf("newest signal mismatch: got %+v", got)
+				}
+				if got.RemoteAddr != second.RemoteAddr || got.UserAgent != second.UserAgent {
+					t.Fatalf("expected address %q and agent %q, got %q and %q", second.RemoteAddr, second.UserAgent, got.RemoteAddr, got.UserAgent)
+				}
+				if len(got.TicketIDs) != 2 || got.TicketIDs[0] != second.TicketIDs[0] || got.TicketIDs[1] != second.TicketIDs[1] {
+					t.Fatalf("expected tickets %v, got %v", second.TicketIDs, got.TicketIDs)
+				}
+
+				limited, err := repo.ListPurchaseSignals(ctx, eventID, 1)
+				mustNoError(t, err, "list limited signals")
+				if len(limited) != 1 || limited[0].ID != second.ID {
+					t.Fatalf("expected only the newest signal, got %+v", limited)
+				}
+
+				none, err := repo.ListPurchaseSignals(ctx, uuid.New(), 10)
+				mustNoError(t, err, "list signals of an event without purchases")
+				if len(none) != 0 {
+					t.Fatalf("expected no signals, got %d", len(none))
+				}
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, testContext(t), newRepo(t))
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// DeadLetterRepositoryFactory returns a fresh, empty DeadLetterRepository for a single test case
+type DeadLetterRepositoryFactory func(t *testing.T) repository.DeadLetterRepository
+
+// RunDeadLetterRepositoryTests runs the DeadLetterRepository conformance suite
+func RunDeadLetterRepositoryTests(t *testing.T, newRepo DeadLetterRepositoryFactory) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, ctx context.Context, repo repository.DeadLetterRepository)
+	}{
+		{
+			name: "add then get round trips the action",
+			run: func(t *testing.T, ctx context.Context, repo repository.DeadLetterRepository) {
+				seatID := uuid.New()
+				action := newTestFailedAction(domain.FailedActionReleaseSeat, time.Now())
+				action.SeatID = &seatID
+				mustNoError(t, repo.Add(ctx, action), "add action")
+
+				got, err := repo.GetByID(ctx, action.ID)
+				mustNoError(t, err, "get action")
+				if got.Kind != action.Kind || got.EventID != action.EventID || got.SeatID == nil || *got.SeatID != seatID {
+					t.Fatalf("round trip mismatch: got %+v", got)
+				}
+			},
+		},
+		{
+			name: "list pages oldest first with total",
+			run: func(t *testing.T, ctx context.Context, repo repository.DeadLetterRepository) {
+				base := time.Now().Add(-time.Hour)
+				var ids []uuid.UUID
+				for i := 0; i < 3; i++ {
+					action := newTestFailedAction(domain.FailedActionIncrementAvailable, base.Add(time.Duration(i)*time.Minute))
+					mustNoError(t, repo.Add(ctx, action), "add action")
+					ids = append(ids, action.ID)
+				}
+
+				page, total, err := repo.List(ctx, 1, 5)
+				mustNoError(t, err, "list actions")
+				if total != 3 {
+					t.Fatalf("expected total 3, got %d", total)
+				}
+				if len(page) != 2 || page[0].ID != ids[1] || page[1].ID != ids[2] {
+					t.Fatalf("expected the two newest actions in order, got %d", len(page))
+				}
+			},
+		},
+		{
+			name: "update records a retry attempt",
+			run: func(t *testing.T, ctx context.Context, repo repository.DeadLetterRepository) {
+				action := newTestFailedAction(domain.FailedActionDecrementAvailable, time.Now())
+				mustNoError(t, repo.Add(ctx, action), "add action")
+
+				action.Attempts = 2
+				action.LastError = "still unreachable"
+				mustNoError(t, repo.Update(ctx, action), "update action")
+
+				got, err := repo.GetByID(ctx, action.ID)
+				mustNoError(t, err, "get action")
+				if got.Attempts != 2 || got.LastError != "still unreachable" {
+					t.Fatalf("update not persisted: got %+v", got)
+				}
+			},
+		},
+		{
+			name: "remove drains the action and missing actions report not found",
+			run: func(t *testing.T, ctx context.Context, repo repository.DeadLetterRepository) {
+				action := newTestFailedAction(domain.FailedActionMarkSeatSold, time.Now())
+				mustNoError(t, repo.Add(ctx, action), "add action")
+				mustNoError(t, repo.Remove(ctx, action.ID), "remove action")
+
+				if _, err := repo.GetByID(ctx, action.ID); !errors.Is(err, repository.ErrFailedActionNotFound) {
+					t.Fatalf("expected ErrFailedActionNotFound after remove, got %v", err)
+				}
+				if err := repo.Remove(ctx, action.ID); !errors.Is(err, repository.ErrFailedActionNotFound) {
+					t.Fatalf("expected ErrFailedActionNotFound on second remove, got %v", err)
+				}
+				if _, total, err := repo.List(ctx, 0, 10); err != nil || total != 0 {
+					t.Fatalf("expected empty dead-letter, got total %d err %v", total, err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, testContext(t), newRepo(t))
+		})
+	}
+}
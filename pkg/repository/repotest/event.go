@@ -0,0 +1,410 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// EventRepositoryFactory returns a fresh, empty EventRepository for a single test case
+type EventRepositoryFactory func(t *testing.T) repository.EventRepository
+
+// RunEventRepositoryTests runs the EventRepository conformance suite
+func RunEventRepositoryTests(t *testing.T, newRepo EventRepositoryFactory) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, ctx context.Context, repo repository.EventRepository)
+	}{
+		{
+			name: "create then get round trips the event",
+			run: func(t *testing.T, ctx context.Context, repo repository.EventRepository) {
+				event := createTestEvent(t, ctx, repo, 100)
+
+				got, err := repo.GetByID(ctx, event.ID)
+				mustNoError(t, err, "get event")
+
+				if got.Name != event.Name || got.TotalTickets != event.TotalTickets || got.AvailableTickets != event.AvailableTickets {
+					t.Fatalf("round trip mismatch: got %+v, want %+v", got, event)
+				}
+				if got.CreatedAt.IsZero() || got.UpdatedAt.IsZero() {
+					t.Fatalf("expected timestamps to be set, got created=%v updated=%v", got.CreatedAt, got.UpdatedAt)
+				}
+			},
+		},
+		{
+			name: "media URLs round trip through create and update",
+			run: func(t *testing.T, ctx context.Context, repo repository.EventRepository) {
+				event := newTestEvent(10)
+				event.ImageURL = "https://cdn.example.com/events/banner.png"
+				event.ThumbnailURL = "https://cdn.example.com/events/thumb.png?w=200"
+				mustNoError(t, repo.Create(ctx, event), "create event")
+
+				got, err := repo.GetByID(ctx, event.ID)
+				mustNoError(t, err, "get event")
+				if got.ImageURL != event.ImageURL || got.ThumbnailURL != event.ThumbnailURL {
+					t.Fatalf("media mismatch after create: got %q/%q", got.ImageURL, got.ThumbnailURL)
+				}
+
+				got.ThumbnailURL = ""
+				mustNoError(t, repo.Update(ctx, got), "update event")
+
+				got, err = repo.GetByID(ctx, event.ID)
+				mustNoError(t, err, "get updated event")
+				if got.ImageURL != event.ImageURL || got.ThumbnailURL != "" {
+					t.Fatalf("media mismatch after update: got %q/%q", got.ImageURL, got.ThumbnailURL)
+				}
+			},
+		},
+		{
+			name: "get missing event fails",
+			run: func(t *testing.T, ctx context.Context, repo repository.EventRepository) {
+				if _, err := repo.GetByID(ctx, uuid.New()); err == nil {
+					t.Fatal("expected error for missing event")
+				}
+			},
+		},
+		{
+			name: "batch get returns existing events in order and skips missing ones",
+			run: func(t *testing.T, ctx context.Context, repo repository.EventRepository) {
+				first, second := newTestEvent(10), newTestEvent(20)
+				mustNoError(t, repo.Create(ctx, first), "create first event")
+				mustNoError(t, repo.Create(ctx, second), "create second event")
+
+				events, err := repo.GetByIDs(ctx, []uuid.UUID{second.ID, uuid.New(), first.ID})
+				mustNoError(t, err, "batch get events")
+				if len(events) != 2 || events[0].ID != second.ID || events[1].ID != first.ID {
+					t.Fatalf("expected [second, first], got %d events", len(events))
+				}
+
+				empty, err := repo.GetByIDs(ctx, nil)
+				mustNoError(t, err, "batch get no events")
+				if len(empty) != 0 {
+					t.Fatalf("expected no events, got %d", len(empty))
+				}
+			},
+		},
+		{
+			name: "active index follows status transitions",
+			run: func(t *testing.T, ctx context.Context, repo repository.EventRepository) {
+				event := createTestEvent(t, ctx, repo, 10)
+
+				active, err := repo.GetActiveEvents(ctx)
+				mustNoError(t, err, "get active events")
+				if !containsID(active, event.ID, eventID) {
+					t.Fatal("active event missing from active index")
+				}
+
+				event.Status = string(domain.EventStatusInactive)
+				mustNoError(t, repo.Update(ctx, event), "deactivate event")
+
+				active, err = repo.GetActiveEvents(ctx)
+				mustNoError(t, err, "get active events")
+				if containsID(active, event.ID, eventID) {
+					t.Fatal("inactive event still present in active index")
+				}
+			},
+		},
+		{
+			name: "status index follows status transitions",
+			run: func(t *testing.T, ctx context.Context, repo repository.EventRepository) {
+				event := createTestEvent(t, ctx, repo, 10)
+
+				for _, status := range domain.EventStatuses {
+					event.Status = string(status)
+					mustNoError(t, repo.Update(ctx, event), "update status")
+
+					for _, other := range domain.EventStatuses {
+						events, total, err := repo.GetByStatus(ctx, string(other), 0, 100)
+						mustNoError(t, err, "get by status")
+						listed := containsID(events, event.ID, eventID)
+						if listed != (other == status) || (listed && total < 1) {
+							t.Fatalf("status %s: listed under %s = %v (total %d)", status, other, listed, total)
+						}
+					}
+				}
+
+				mustNoError(t, repo.Delete(ctx, event.ID), "delete event")
+				events, _, err := repo.GetByStatus(ctx, event.Status, 0, 100)
+				mustNoError(t, err, "get by status")
+				if containsID(events, event.ID, eventID) {
+					t.Fatal("deleted event still present in status index")
+				}
+			},
+		},
+		{
+			name: "delete removes event from every index",
+			run: func(t *testing.T, ctx context.Context, repo repository.EventRepository) {
+				event := createTestEvent(t, ctx, repo, 10)
+				mustNoError(t, repo.Delete(ctx, event.ID), "delete event")
+
+				if _, err := repo.GetByID(ctx, event.ID); err == nil {
+					t.Fatal("expected deleted event to be missing")
+				}
+
+				active, err := repo.GetActiveEvents(ctx)
+				mustNoError(t, err, "get active events")
+				if containsID(active, event.ID, eventID) {
+					t.Fatal("deleted event still present in active index")
+				}
+
+				all, err := repo.List(ctx, 0, 1000)
+				mustNoError(t, err, "list events")
+				if containsID(all, event.ID, eventID) {
+					t.Fatal("deleted event still present in list")
+				}
+			},
+		},
+		{
+			name: "fingerprint index follows name, venue and start day",
+			run: func(t *testing.T, ctx context.Context, repo repository.EventRepository) {
+				original := newTestEvent(10)
+				original.Name = "Fingerprint " + uuid.NewString()
+				mustNoError(t, repo.Create(ctx, original), "create event")
+
+				// The same event keyed in again with other case and spacing
+				copied := newTestEvent(10)
+				copied.Name = "  " + strings.ToUpper(original.Name) + " "
+				copied.Venue = strings.ToLower(original.Venue)
+				copied.StartTime = original.StartTime
+				mustNoError(t, repo.Create(ctx, copied), "create copy")
+				if original.Fingerprint() != copied.Fingerprint() {
+					t.Fatal("expected events differing in case and spacing to share a fingerprint")
+				}
+
+				ids, err := repo.GetIDsByFingerprint(ctx, original.Fingerprint())
+				mustNoError(t, err, "get by fingerprint")
+				if len(ids) != 2 || !containsID(ids, original.ID, uuidID) || !containsID(ids, copied.ID, uuidID) {
+					t.Fatalf("expected both events under the fingerprint, got %v", ids)
+				}
+
+				fingerprint := copied.Fingerprint()
+				copied.StartTime = copied.StartTime.Add(48 * time.Hour)
+				mustNoError(t, repo.Update(ctx, copied), "move copy to another day")
+				ids, err = repo.GetIDsByFingerprint(ctx, fingerprint)
+				mustNoError(t, err, "get by fingerprint")
+				if len(ids) != 1 || ids[0] != original.ID {
+					t.Fatalf("expected only the original under the old fingerprint, got %v", ids)
+				}
+				ids, err = repo.GetIDsByFingerprint(ctx, copied.Fingerprint())
+				mustNoError(t, err, "get by fingerprint")
+				if len(ids) != 1 || ids[0] != copied.ID {
+					t.Fatalf("expected the moved event under its new fingerprint, got %v", ids)
+				}
+
+				mustNoError(t, repo.Delete(ctx, original.ID), "delete event")
+				ids, err = repo.GetIDsByFingerprint(ctx, fingerprint)
+				mustNoError(t, err, "get by fingerprint")
+				if len(ids) != 0 {
+					t.Fatalf("expected a deleted event to leave the fingerprint index, got %v", ids)
+				}
+			},
+		},
+		{
+			name: "list paginates without overlap",
+			run: func(t *testing.T, ctx context.Context, repo repository.EventRepository) {
+				for i := 0; i < 5; i++ {
+					mustNoError(t, repo.Create(ctx, newTestEvent(10)), "create event")
+				}
+
+				first, err := repo.List(ctx, 0, 3)
+				mustNoError(t, err, "list first page")
+				second, err := repo.List(ctx, 3, 3)
+				mustNoError(t, err, "list second page")
+				beyond, err := repo.List(ctx, 10, 3)
+				mustNoError(t, err, "list beyond end")
+
+				if len(first) != 3 || len(second) != 2 || len(beyond) != 0 {
+					t.Fatalf("unexpected page sizes: %d, %d, %d", len(first), len(second), len(beyond))
+				}
+				for _, event := range second {
+					if containsID(first, event.ID, eventID) {
+						t.Fatalf("event %s returned on two pages", event.ID)
+					}
+				}
+			},
+		},
+		{
+			name: "decrement and increment adjust available tickets",
+			run: func(t *testing.T, ctx context.Context, repo repository.EventRepository) {
+				event := createTestEvent(t, ctx, repo, 10)
+
+				mustNoError(t, repo.DecrementAvailableTickets(ctx, event.ID, 3), "decrement")
+				mustNoError(t, repo.IncrementAvailableTickets(ctx, event.ID, 1), "increment")
+
+				got, err := repo.GetByID(ctx, event.ID)
+				mustNoError(t, err, "get event")
+				if got.AvailableTickets != 8 {
+					t.Fatalf("expected 8 available tickets, got %d", got.AvailableTickets)
+				}
+			},
+		},
+		{
+			name: "decrement beyond availability fails without side effects",
+			run: func(t *testing.T, ctx context.Context, repo repository.EventRepository) {
+				event := createTestEvent(t, ctx, repo, 2)
+
+				if err := repo.DecrementAvailableTickets(ctx, event.ID, 3); err == nil {
+					t.Fatal("expected error when decrementing beyond availability")
+				}
+
+				got, err := repo.GetByID(ctx, event.ID)
+				mustNoError(t, err, "get event")
+				if got.AvailableTickets != 2 {
+					t.Fatalf("expected availability to stay at 2, got %d", got.AvailableTickets)
+				}
+			},
+		},
+		{
+			name: "interleaved increments and decrements keep an exact count",
+			run: func(t *testing.T, ctx context.Context, repo repository.EventRepository) {
+				const workers = 50
+				event := createTestEvent(t, ctx, repo, 100)
+
+				// Each worker takes two tickets and gives one back, racing every other worker
+				var wg sync.WaitGroup
+				for i := 0; i < workers; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						if err := repo.DecrementAvailableTickets(ctx, event.ID, 2); err != nil {
+							t.Errorf("decrement: %v", err)
+							return
+						}
+						if err := repo.IncrementAvailableTickets(ctx, event.ID, 1); err != nil {
+							t.Errorf("increment: %v", err)
+						}
+					}()
+				}
+				wg.Wait()
+
+				got, err := repo.GetByID(ctx, event.ID)
+				mustNoError(t, err, "get event")
+				if got.AvailableTickets != 100-workers {
+					t.Fatalf("expected %d available tickets, got %d", 100-workers, got.AvailableTickets)
+				}
+			},
+		},
+		{
+			name: "update available tickets sets the count",
+			run: func(t *testing.T, ctx context.Context, repo repository.EventRepository) {
+				event := createTestEvent(t, ctx, repo, 10)
+
+				mustNoError(t, repo.UpdateAvailableTickets(ctx, event.ID, 4), "update available tickets")
+				mustNoError(t, repo.DecrementAvailableTickets(ctx, event.ID, 1), "decrement")
+
+				got, err := repo.GetByID(ctx, event.ID)
+				mustNoError(t, err, "get event")
+				if got.AvailableTickets != 3 {
+					t.Fatalf("expected 3 available tickets, got %d", got.AvailableTickets)
+				}
+			},
+		},
+		{
+			name: "capacity alert is marked once per threshold",
+			run: func(t *testing.T, ctx context.Context, repo repository.EventRepository) {
+				event := createTestEvent(t, ctx, repo, 10)
+
+				first, err := repo.MarkCapacityAlert(ctx, event.ID, 90)
+				mustNoError(t, err, "mark 90")
+				again, err := repo.MarkCapacityAlert(ctx, event.ID, 90)
+				mustNoError(t, err, "mark 90 again")
+				other, err := repo.MarkCapacityAlert(ctx, event.ID, 95)
+				mustNoError(t, err, "mark 95")
+
+				if !first || again || !other {
+					t.Fatalf("expected first=true again=false other=true, got %v %v %v", first, again, other)
+				}
+			},
+		},
+		{
+			name: "standing zones sell out independently",
+			run: func(t *testing.T, ctx context.Context, repo repository.EventRepository) {
+				event := createTestEvent(t, ctx, repo, 5)
+				mustNoError(t, repo.InitZoneTickets(ctx, event.ID, "floor", 2), "init floor")
+				mustNoError(t, repo.InitZoneTickets(ctx, event.ID, "balcony", 3), "init balcony")
+
+				mustNoError(t, repo.DecrementZoneTickets(ctx, event.ID, "floor", 2), "sell out floor")
+				if err := repo.DecrementZoneTickets(ctx, event.ID, "floor", 1); !errors.Is(err, repository.ErrStandingZoneSoldOut) {
+					t.Fatalf("expected ErrStandingZoneSoldOut, got %v", err)
+				}
+				mustNoError(t, repo.DecrementZoneTickets(ctx, event.ID, "balcony", 1), "buy balcony")
+				if err := repo.DecrementZoneTickets(ctx, event.ID, "pit", 1); !errors.Is(err, repository.ErrStandingZoneNotFound) {
+					t.Fatalf("expected ErrStandingZoneNotFound, got %v", err)
+				}
+
+				// Seeding again must not restock what was sold
+				mustNoError(t, repo.InitZoneTickets(ctx, event.ID, "floor", 2), "re-init floor")
+				mustNoError(t, repo.IncrementZoneTickets(ctx, event.ID, "balcony", 1), "return balcony")
+
+				left, err := repo.GetZoneTickets(ctx, event.ID, []string{"floor", "balcony", "pit"})
+				mustNoError(t, err, "get zone tickets")
+				if len(left) != 2 || left["floor"] != 0 || left["balcony"] != 3 {
+					t.Fatalf("expected floor=0 balcony=3 and no pit, got %v", left)
+				}
+			},
+		},
+		{
+			name: "concurrent zone decrements never oversell",
+			run: func(t *testing.T, ctx context.Context, repo repository.EventRepository) {
+				event := createTestEvent(t, ctx, repo, 10)
+				mustNoError(t, repo.InitZoneTickets(ctx, event.ID, "floor", 10), "init floor")
+
+				const workers = 25
+				var wg sync.WaitGroup
+				var mu sync.Mutex
+				sold := 0
+				for i := 0; i < workers; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						if err := repo.DecrementZoneTickets(ctx, event.ID, "floor", 1); err == nil {
+							mu.Lock()
+							sold++
+							mu.Unlock()
+						} else if !errors.Is(err, repository.ErrStandingZoneSoldOut) {
+							t.Errorf("decrement: %v", err)
+						}
+					}()
+				}
+				wg.Wait()
+
+				left, err := repo.GetZoneTickets(ctx, event.ID, []string{"floor"})
+				mustNoError(t, err, "get zone tickets")
+				if sold != 10 || left["floor"] != 0 {
+					t.Fatalf("expected 10 sold and none left, got %d sold and %d left", sold, left["floor"])
+				}
+			},
+		},
+		{
+			name: "decrement on missing event fails",
+			run: func(t *testing.T, ctx context.Context, repo repository.EventRepository) {
+				if err := repo.DecrementAvailableTickets(ctx, uuid.New(), 1); err == nil {
+					t.Fatal("expected error for missing event")
+				}
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, testContext(t), newRepo(t))
+		})
+	}
+}
+
+// eventID extracts the ID of an event
+func eventID(event *domain.Event) uuid.UUID {
+	return event.ID
+}
+
+// uuidID is the identity, so containsID can search plain ID lists
+func uuidID(id uuid.UUID) uuid.UUID {
+	return id
+}
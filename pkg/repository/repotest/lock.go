@@ -0,0 +1,137 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+// LockFactory returns a Lock for a single test case; cases use their own keys, so the store may be shared
+type LockFactory func(t *testing.T) adapter.Lock
+
+// lockLapse is the expiration of locks the cases let run out
+const lockLapse = 100 * time.Millisecond
+
+// RunLockTests runs the Lock conformance suite
+func RunLockTests(t *testing.T, newLock LockFactory) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, ctx context.Context, lock adapter.Lock)
+	}{
+		{
+			name: "acquire excludes a second holder until released",
+			run: func(t *testing.T, ctx context.Context, lock adapter.Lock) {
+				key := "repotest:" + uuid.NewString()
+
+				token, acquired, err := lock.Acquire(ctx, key, time.Minute)
+				mustNoError(t, err, "acquire")
+				if !acquired || token == "" {
+					t.Fatalf("expected the free lock to be acquired with a token, got %v %q", acquired, token)
+				}
+
+				_, acquired, err = lock.Acquire(ctx, key, time.Minute)
+				mustNoError(t, err, "acquire held lock")
+				if acquired {
+					t.Fatal("expected a held lock to refuse a second holder")
+				}
+
+				mustNoError(t, lock.Release(ctx, key, token), "release")
+				locked, err := lock.IsLocked(ctx, key)
+				mustNoError(t, err, "is locked")
+				if locked {
+					t.Fatal("expected the lock to be free after release")
+				}
+			},
+		},
+		{
+			name: "stale holder cannot release a lock now held by someone else",
+			run: func(t *testing.T, ctx context.Context, lock adapter.Lock) {
+				key := "repotest:" + uuid.NewString()
+
+				stale, acquired, err := lock.Acquire(ctx, key, lockLapse)
+				mustNoError(t, err, "acquire")
+				if !acquired {
+					t.Fatal("expected the free lock to be acquired")
+				}
+				time.Sleep(2 * lockLapse)
+
+				current, acquired, err := lock.Acquire(ctx, key, time.Minute)
+				mustNoError(t, err, "reacquire lapsed lock")
+				if !acquired || current == stale {
+					t.Fatalf("expected the lapsed lock to be reacquired under a new token, got %v", acquired)
+				}
+
+				mustNoError(t, lock.Release(ctx, key, stale), "stale release")
+				locked, err := lock.IsLocked(ctx, key)
+				mustNoError(t, err, "is locked")
+				if !locked {
+					t.Fatal("expected a stale release to leave the current holder's lock in place")
+				}
+
+				mustNoError(t, lock.Release(ctx, key, current), "release")
+				locked, err = lock.IsLocked(ctx, key)
+				mustNoError(t, err, "is locked")
+				if locked {
+					t.Fatal("expected the current holder to release the lock")
+				}
+			},
+		},
+		{
+			name: "stale holder cannot extend a lock now held by someone else",
+			run: func(t *testing.T, ctx context.Context, lock adapter.Lock) {
+				key := "repotest:" + uuid.NewString()
+
+				stale, _, err := lock.Acquire(ctx, key, lockLapse)
+				mustNoError(t, err, "acquire")
+				time.Sleep(2 * lockLapse)
+
+				current, acquired, err := lock.Acquire(ctx, key, time.Minute)
+				mustNoError(t, err, "reacquire lapsed lock")
+				if !acquired {
+					t.Fatal("expected the lapsed lock to be reacquired")
+				}
+
+				if err := lock.Extend(ctx, key, stale, time.Hour); !errors.Is(err, adapter.ErrLockNotHeld) {
+					t.Fatalf("expected ErrLockNotHeld for a stale extend, got %v", err)
+				}
+				ttl, err := lock.TTL(ctx, key)
+				mustNoError(t, err, "ttl")
+				if ttl <= 0 || ttl > time.Minute {
+					t.Fatalf("expected the current holder's expiry to stand, got %v", ttl)
+				}
+
+				mustNoError(t, lock.Extend(ctx, key, current, time.Hour), "extend")
+				ttl, err = lock.TTL(ctx, key)
+				mustNoError(t, err, "ttl")
+				if ttl <= time.Minute {
+					t.Fatalf("expected the current holder to extend the lock, got %v", ttl)
+				}
+				mustNoError(t, lock.Release(ctx, key, current), "release")
+			},
+		},
+		{
+			name: "extend fails once the lock is released",
+			run: func(t *testing.T, ctx context.Context, lock adapter.Lock) {
+				key := "repotest:" + uuid.NewString()
+
+				token, _, err := lock.Acquire(ctx, key, time.Minute)
+				mustNoError(t, err, "acquire")
+				mustNoError(t, lock.Release(ctx, key, token), "release")
+
+				if err := lock.Extend(ctx, key, token, time.Minute); !errors.Is(err, adapter.ErrLockNotHeld) {
+					t.Fatalf("expected ErrLockNotHeld after release, got %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, testContext(t), newLock(t))
+		})
+	}
+}
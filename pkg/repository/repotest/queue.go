@@ -0,0 +1,866 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// QueueRepositoryFactory returns a fresh, empty QueueRepository for a single test case
+type QueueRepositoryFactory func(t *testing.T) repository.QueueRepository
+
+// RunQueueRepositoryTests runs the QueueRepository conformance suite
+func RunQueueRepositoryTests(t *testing.T, newRepo QueueRepositoryFactory) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, ctx context.Context, repo repository.QueueRepository)
+	}{
+		{
+			name: "first joiner is active and later joiners wait in order",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+
+				first, err := repo.Join(ctx, eventID, uuid.New(), "session-1")
+				mustNoError(t, err, "first join")
+				second, err := repo.Join(ctx, eventID, uuid.New(), "session-2")
+				mustNoError(t, err, "second join")
+
+				if first.Position != 1 || !first.IsActive() || first.ExpiresAt == nil || first.ActivatedAt == nil {
+					t.Fatalf("expected first joiner active at position 1, got %+v", first)
+				}
+				if second.Position != 2 || !second.IsWaiting() {
+					t.Fatalf("expected second joiner waiting at position 2, got %+v", second)
+				}
+
+				length, err := repo.GetQueueLength(ctx, eventID)
+				mustNoError(t, err, "get queue length")
+				if length != 2 {
+					t.Fatalf("expected queue length 2, got %d", length)
+				}
+			},
+		},
+		{
+			name: "joining twice returns the existing entry",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID, userID := uuid.New(), uuid.New()
+
+				first, err := repo.Join(ctx, eventID, userID, "session-1")
+				mustNoError(t, err, "first join")
+				again, err := repo.Join(ctx, eventID, userID, "session-1")
+				mustNoError(t, err, "second join")
+
+				if again.ID != first.ID {
+					t.Fatalf("expected the same entry, got %s and %s", first.ID, again.ID)
+				}
+
+				length, err := repo.GetQueueLength(ctx, eventID)
+				mustNoError(t, err, "get queue length")
+				if length != 1 {
+					t.Fatalf("expected queue length 1, got %d", length)
+				}
+			},
+		},
+		{
+			name: "concurrent joins get unique contiguous positions",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				const workers = 50
+				eventID := uuid.New()
+
+				var wg sync.WaitGroup
+				positions := make(chan int, workers)
+				for i := 0; i < workers; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						entry, err := repo.Join(ctx, eventID, uuid.New(), uuid.NewString())
+						if err != nil {
+							t.Errorf("join: %v", err)
+							return
+						}
+						positions <- entry.Position
+					}()
+				}
+				wg.Wait()
+				close(positions)
+
+				seen := make(map[int]bool)
+				for position := range positions {
+					if seen[position] {
+						t.Fatalf("position %d assigned twice", position)
+					}
+					seen[position] = true
+				}
+				for i := 1; i <= workers; i++ {
+					if !seen[i] {
+						t.Fatalf("position %d was skipped", i)
+					}
+				}
+			},
+		},
+		{
+			name: "session and position lookups resolve the same entry",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID, userID := uuid.New(), uuid.New()
+
+				entry, err := repo.Join(ctx, eventID, userID, "session-lookup")
+				mustNoError(t, err, "join")
+
+				bySession, err := repo.GetBySessionID(ctx, "session-lookup")
+				mustNoError(t, err, "get by session")
+				byPosition, err := repo.GetPosition(ctx, eventID, userID)
+				mustNoError(t, err, "get position")
+
+				if bySession.ID != entry.ID || byPosition.ID != entry.ID {
+					t.Fatalf("lookups resolved different entries: %s, %s, want %s", bySession.ID, byPosition.ID, entry.ID)
+				}
+			},
+		},
+		{
+			name: "lookups of unknown entries fail",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				if _, err := repo.GetBySessionID(ctx, "missing-session"); err == nil {
+					t.Fatal("expected error for missing session")
+				}
+				if _, err := repo.GetPosition(ctx, uuid.New(), uuid.New()); err == nil {
+					t.Fatal("expected error for missing entry")
+				}
+			},
+		},
+		{
+			name: "activate next promotes the following user",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+				_, err := repo.Join(ctx, eventID, uuid.New(), "session-1")
+				mustNoError(t, err, "first join")
+				second, err := repo.Join(ctx, eventID, uuid.New(), "session-2")
+				mustNoError(t, err, "second join")
+
+				activated, err := repo.ActivateNext(ctx, eventID)
+				mustNoError(t, err, "activate next")
+				if activated.ID != second.ID || !activated.IsActive() || activated.ExpiresAt == nil || activated.ActivatedAt == nil {
+					t.Fatalf("expected second entry to be active, got %+v", activated)
+				}
+
+				next, err := repo.GetNextInQueue(ctx, eventID)
+				mustNoError(t, err, "get next in queue")
+				if next.ID != second.ID {
+					t.Fatalf("expected head of queue %s, got %s", second.ID, next.ID)
+				}
+			},
+		},
+		{
+			name: "activate next skips a waiting head left behind by a departed active user",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+				first, err := repo.Join(ctx, eventID, uuid.New(), uuid.NewString())
+				mustNoError(t, err, "first join")
+				second, err := repo.Join(ctx, eventID, uuid.New(), uuid.NewString())
+				mustNoError(t, err, "second join")
+				third, err := repo.Join(ctx, eventID, uuid.New(), uuid.NewString())
+				mustNoError(t, err, "third join")
+
+				// The active user leaves, so a waiting user is now at the head
+				mustNoError(t, repo.RemoveUser(ctx, eventID, first.UserID), "remove active user")
+
+				activated, err := repo.ActivateNext(ctx, eventID)
+				mustNoError(t, err, "activate next")
+				if activated.ID != second.ID || !activated.IsActive() {
+					t.Fatalf("expected the waiting head %s to be activated, got %s (%s)", second.ID, activated.ID, activated.Status)
+				}
+
+				activated, err = repo.ActivateNext(ctx, eventID)
+				mustNoError(t, err, "activate next again")
+				if activated.ID != third.ID {
+					t.Fatalf("expected %s to be activated next, got %s", third.ID, activated.ID)
+				}
+
+				if _, err := repo.ActivateNext(ctx, eventID); err == nil {
+					t.Fatal("expected an error once no one is waiting")
+				}
+			},
+		},
+		{
+			name: "concurrent activations activate each waiting user once",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				const users = 20
+				eventID := uuid.New()
+				waiting := make(map[uuid.UUID]bool)
+				for i := 0; i < users; i++ {
+					entry, err := repo.Join(ctx, eventID, uuid.New(), uuid.NewString())
+					mustNoError(t, err, "join")
+					if entry.IsWaiting() {
+						waiting[entry.ID] = true
+					}
+				}
+
+				var wg sync.WaitGroup
+				activated := make(chan uuid.UUID, len(waiting))
+				for range waiting {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						entry, err := repo.ActivateNext(ctx, eventID)
+						if err != nil {
+							t.Errorf("activate next: %v", err)
+							return
+						}
+						activated <- entry.ID
+					}()
+				}
+				wg.Wait()
+				close(activated)
+
+				seen := make(map[uuid.UUID]bool)
+				for id := range activated {
+					if seen[id] {
+						t.Fatalf("entry %s activated twice", id)
+					}
+					if !waiting[id] {
+						t.Fatalf("activated entry %s was not waiting", id)
+					}
+					seen[id] = true
+				}
+				if len(seen) != len(waiting) {
+					t.Fatalf("expected %d activations, got %d", len(waiting), len(seen))
+				}
+			},
+		},
+		{
+			name: "active count tracks activations, expiry and removal",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+				first, second := uuid.New(), uuid.New()
+				_, err := repo.Join(ctx, eventID, first, "session-1")
+				mustNoError(t, err, "first join")
+				_, err = repo.Join(ctx, eventID, second, "session-2")
+				mustNoError(t, err, "second join")
+				_, err = repo.Join(ctx, eventID, uuid.New(), "session-3")
+				mustNoError(t, err, "third join")
+
+				assertActiveCount(t, ctx, repo, eventID, 1)
+
+				activated, err := repo.ActivateNext(ctx, eventID)
+				mustNoError(t, err, "activate next")
+				assertActiveCount(t, ctx, repo, eventID, 2)
+
+				lapsed := time.Now().Add(-time.Second)
+				activated.ExpiresAt = &lapsed
+				mustNoError(t, repo.Update(ctx, activated), "expire session")
+				assertActiveCount(t, ctx, repo, eventID, 1)
+
+				mustNoError(t, repo.RemoveUser(ctx, eventID, first), "remove first user")
+				assertActiveCount(t, ctx, repo, eventID, 0)
+			},
+		},
+		{
+			name: "active index stays consistent through activation, removal and expiry",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+				entries := make([]*domain.QueueEntry, 5)
+				for i := range entries {
+					entry, err := repo.Join(ctx, eventID, uuid.New(), uuid.NewString())
+					mustNoError(t, err, "join")
+					entries[i] = entry
+				}
+
+				// assertActive checks the count and the listing both report exactly the given entries
+				assertActive := func(step string, want ...*domain.QueueEntry) {
+					t.Helper()
+					assertActiveCount(t, ctx, repo, eventID, len(want))
+					active, err := repo.GetActiveEntries(ctx, eventID, time.Now())
+					mustNoError(t, err, "get active entries "+step)
+					if len(active) != len(want) {
+						t.Fatalf("%s: expected %d active entries, got %d", step, len(want), len(active))
+					}
+					for _, entry := range want {
+						if !containsID(active, entry.ID, queueEntryID) {
+							t.Fatalf("%s: expected %s among the active entries", step, entry.ID)
+						}
+					}
+				}
+
+				assertActive("after joining", entries[0])
+
+				_, err := repo.ActivateNext(ctx, eventID)
+				mustNoError(t, err, "activate next")
+				assertActive("after activating the next user", entries[0], entries[1])
+
+				_, err = repo.ActivateAll(ctx, eventID, time.Now().Add(15*time.Minute), 1)
+				mustNoError(t, err, "activate all")
+				assertActive("after a limited batch activation", entries[0], entries[1], entries[2])
+
+				mustNoError(t, repo.UpdateStatus(ctx, entries[1].ID, string(domain.QueueStatusCompleted)), "complete second")
+				assertActive("after completing a session", entries[0], entries[2])
+
+				mustNoError(t, repo.RemoveFromQueue(ctx, entries[2].ID), "remove third")
+				assertActive("after removing a session", entries[0])
+
+				mustNoError(t, repo.UpdateExpiry(ctx, entries[0].SessionID, time.Now().Add(-time.Second), time.Now()), "expire first")
+				assertActive("after a session lapses")
+
+				_, err = repo.ActivateNext(ctx, eventID)
+				mustNoError(t, err, "activate next after the lapse")
+				assertActive("after activating past the lapsed sessions", entries[3])
+			},
+		},
+		{
+			name: "active entries list only live sessions, soonest to expire first",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+				first, err := repo.Join(ctx, eventID, uuid.New(), "session-live-1")
+				mustNoError(t, err, "first join")
+				for i := 0; i < 3; i++ {
+					_, err := repo.Join(ctx, eventID, uuid.New(), uuid.NewString())
+					mustNoError(t, err, "join")
+				}
+				second, err := repo.ActivateNext(ctx, eventID)
+				mustNoError(t, err, "activate second")
+				lapsed, err := repo.ActivateNext(ctx, eventID)
+				mustNoError(t, err, "activate third")
+
+				mustNoError(t, repo.UpdateExpiry(ctx, lapsed.SessionID, time.Now().Add(-time.Second), time.Now()), "expire third")
+				mustNoError(t, repo.UpdateExpiry(ctx, first.SessionID, time.Now().Add(time.Hour), time.Now()), "extend first")
+				mustNoError(t, repo.UpdateExpiry(ctx, second.SessionID, time.Now().Add(time.Minute), time.Now()), "shorten second")
+
+				entries, err := repo.GetActiveEntries(ctx, eventID, time.Now())
+				mustNoError(t, err, "get active entries")
+				if len(entries) != 2 || entries[0].ID != second.ID || entries[1].ID != first.ID {
+					t.Fatalf("expected the two live sessions soonest to expire first, got %d entries", len(entries))
+				}
+
+				entries, err = repo.GetActiveEntries(ctx, uuid.New(), time.Now())
+				mustNoError(t, err, "get active entries of an empty event")
+				if len(entries) != 0 {
+					t.Fatalf("expected no active entries for an empty event, got %d", len(entries))
+				}
+			},
+		},
+		{
+			name: "queue head returns entries in order up to count",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+				var joined []uuid.UUID
+				for i := 0; i < 3; i++ {
+					entry, err := repo.Join(ctx, eventID, uuid.New(), uuid.NewString())
+					mustNoError(t, err, "join")
+					joined = append(joined, entry.ID)
+				}
+
+				head, err := repo.GetQueueHead(ctx, eventID, 2)
+				mustNoError(t, err, "get queue head")
+				if len(head) != 2 || head[0].ID != joined[0] || head[1].ID != joined[1] {
+					t.Fatalf("expected the first two joiners in order, got %d entries", len(head))
+				}
+			},
+		},
+		{
+			name: "update persists entry changes",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID, userID := uuid.New(), uuid.New()
+				entry, err := repo.Join(ctx, eventID, userID, "session-update")
+				mustNoError(t, err, "join")
+
+				entry.LastNotifiedThreshold = 3
+				entry.RefreshCount = 2
+				mustNoError(t, repo.Update(ctx, entry), "update entry")
+
+				got, err := repo.GetPosition(ctx, eventID, userID)
+				mustNoError(t, err, "get position")
+				if got.LastNotifiedThreshold != 3 || got.RefreshCount != 2 {
+					t.Fatalf("expected last notified threshold 3 and 2 refreshes, got %d and %d", got.LastNotifiedThreshold, got.RefreshCount)
+				}
+
+				if err := repo.Update(ctx, &domain.QueueEntry{EventID: eventID, UserID: uuid.New()}); err == nil {
+					t.Fatal("expected error updating a missing entry")
+				}
+			},
+		},
+		{
+			name: "update expiry extends a session near expiry",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+				entry, err := repo.Join(ctx, eventID, uuid.New(), "session-refresh")
+				mustNoError(t, err, "join")
+
+				// Refresh with a second of the session left
+				nearExpiry := time.Now().Add(time.Second).Truncate(time.Millisecond)
+				mustNoError(t, repo.UpdateExpiry(ctx, entry.SessionID, nearExpiry, time.Now()), "shorten expiry")
+				extended := nearExpiry.Add(15 * time.Minute)
+				refreshedAt := time.Now().Add(time.Minute).Truncate(time.Millisecond)
+				mustNoError(t, repo.UpdateExpiry(ctx, entry.SessionID, extended, refreshedAt), "extend expiry")
+
+				got, err := repo.GetBySessionID(ctx, entry.SessionID)
+				mustNoError(t, err, "get by session")
+				if got.ExpiresAt == nil || !got.ExpiresAt.Equal(extended) {
+					t.Fatalf("expected expiry %v, got %v", extended, got.ExpiresAt)
+				}
+				if !got.UpdatedAt.Equal(refreshedAt) {
+					t.Fatalf("expected the entry stamped as updated at %v, got %v", refreshedAt, got.UpdatedAt)
+				}
+
+				count, err := repo.GetActiveCount(ctx, eventID, time.Now())
+				mustNoError(t, err, "active count")
+				if count != 1 {
+					t.Fatalf("expected the refreshed session to stay active, got %d active", count)
+				}
+
+				if err := repo.UpdateExpiry(ctx, "session-unknown", extended, time.Now()); !errors.Is(err, repository.ErrQueueEntryNotFound) {
+					t.Fatalf("expected ErrQueueEntryNotFound, got %v", err)
+				}
+
+				// Expiry is judged against the time passed in, not the wall clock
+				later := extended.Add(time.Minute)
+				count, err = repo.GetActiveCount(ctx, eventID, later)
+				mustNoError(t, err, "active count after the session lapses")
+				if count != 0 {
+					t.Fatalf("expected no active sessions once the refreshed session lapses, got %d", count)
+				}
+				entries, err := repo.GetActiveEntries(ctx, eventID, later)
+				mustNoError(t, err, "active entries after the session lapses")
+				if len(entries) != 0 {
+					t.Fatalf("expected no active entries once the refreshed session lapses, got %d", len(entries))
+				}
+			},
+		},
+		{
+			name: "update expiry moves the entry's lifetime with its expiry",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+				entry, err := repo.Join(ctx, eventID, uuid.New(), uuid.NewString())
+				mustNoError(t, err, "join")
+
+				// Shorten the session, then extend it again: the entry has to outlive the shorter expiry
+				short := time.Now().Add(200 * time.Millisecond)
+				mustNoError(t, repo.UpdateExpiry(ctx, entry.SessionID, short, time.Now()), "shorten expiry")
+				extended := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+				mustNoError(t, repo.UpdateExpiry(ctx, entry.SessionID, extended, time.Now()), "extend expiry")
+				time.Sleep(time.Until(short) + 100*time.Millisecond)
+
+				got, err := repo.GetBySessionID(ctx, entry.SessionID)
+				mustNoError(t, err, "get by session past the shorter expiry")
+				if got.ExpiresAt == nil || !got.ExpiresAt.Equal(extended) {
+					t.Fatalf("expected expiry %v, got %v", extended, got.ExpiresAt)
+				}
+				_, err = repo.GetByID(ctx, entry.ID)
+				mustNoError(t, err, "get by ID past the shorter expiry")
+
+				// Moving the expiry into the past ends the entry's lifetime with it
+				mustNoError(t, repo.UpdateExpiry(ctx, entry.SessionID, time.Now().Add(-time.Second), time.Now()), "lapse session")
+
+				if _, err := repo.GetBySessionID(ctx, entry.SessionID); err == nil {
+					t.Fatal("expected the session lookup to fail after the lapse")
+				}
+				if _, err := repo.GetByID(ctx, entry.ID); !errors.Is(err, repository.ErrQueueEntryNotFound) {
+					t.Fatalf("expected ErrQueueEntryNotFound by ID after the lapse, got %v", err)
+				}
+				if _, err := repo.GetPosition(ctx, eventID, entry.UserID); !errors.Is(err, repository.ErrQueueEntryNotFound) {
+					t.Fatalf("expected ErrQueueEntryNotFound by position after the lapse, got %v", err)
+				}
+			},
+		},
+		{
+			name: "refreshed session reads back its later expiry",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+				entry, err := repo.Join(ctx, eventID, uuid.New(), uuid.NewString())
+				mustNoError(t, err, "join")
+
+				// Read the entry first, so a cached copy would be what goes stale
+				before, err := repo.GetBySessionID(ctx, entry.SessionID)
+				mustNoError(t, err, "get by session before refresh")
+				_, err = repo.GetPosition(ctx, eventID, entry.UserID)
+				mustNoError(t, err, "get position before refresh")
+
+				refreshed := before.ExpiresAt.Add(10 * time.Minute).Truncate(time.Millisecond)
+				mustNoError(t, repo.UpdateExpiry(ctx, entry.SessionID, refreshed, time.Now()), "refresh session")
+
+				got, err := repo.GetBySessionID(ctx, entry.SessionID)
+				mustNoError(t, err, "get by session after refresh")
+				if got.ExpiresAt == nil || !got.ExpiresAt.Equal(refreshed) || !got.ExpiresAt.After(*before.ExpiresAt) {
+					t.Fatalf("expected the session to expire later, at %v, got %v", refreshed, got.ExpiresAt)
+				}
+
+				byPosition, err := repo.GetPosition(ctx, eventID, entry.UserID)
+				mustNoError(t, err, "get position after refresh")
+				if byPosition.ExpiresAt == nil || !byPosition.ExpiresAt.Equal(refreshed) {
+					t.Fatalf("expected the position lookup to see expiry %v, got %v", refreshed, byPosition.ExpiresAt)
+				}
+
+				byID, err := repo.GetByID(ctx, entry.ID)
+				mustNoError(t, err, "get by ID after refresh")
+				if byID.ExpiresAt == nil || !byID.ExpiresAt.Equal(refreshed) {
+					t.Fatalf("expected the ID lookup to see expiry %v, got %v", refreshed, byID.ExpiresAt)
+				}
+			},
+		},
+		{
+			name: "activate all activates every waiting user and clears the queue",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+				var waiting []*domain.QueueEntry
+				for i := 0; i < 5; i++ {
+					entry, err := repo.Join(ctx, eventID, uuid.New(), uuid.NewString())
+					mustNoError(t, err, "join")
+					if entry.IsWaiting() {
+						waiting = append(waiting, entry)
+					}
+				}
+
+				expiresAt := time.Now().Add(15 * time.Minute).Truncate(time.Millisecond)
+				activated, err := repo.ActivateAll(ctx, eventID, expiresAt, 0)
+				mustNoError(t, err, "activate all")
+				if len(activated) != len(waiting) {
+					t.Fatalf("expected %d activated, got %d", len(waiting), len(activated))
+				}
+				for i, entry := range activated {
+					if entry.UserID != waiting[i].UserID || !entry.IsActive() || entry.ExpiresAt == nil || !entry.ExpiresAt.Equal(expiresAt) {
+						t.Fatalf("expected %s active until %v in queue order, got %+v", waiting[i].UserID, expiresAt, entry)
+					}
+
+					stored, err := repo.GetPosition(ctx, eventID, entry.UserID)
+					mustNoError(t, err, "get position")
+					if !stored.IsActive() {
+						t.Fatalf("expected stored entry of %s to be active, got %s", entry.UserID, stored.Status)
+					}
+				}
+
+				length, err := repo.GetQueueLength(ctx, eventID)
+				mustNoError(t, err, "queue length")
+				if length != 0 {
+					t.Fatalf("expected an empty waiting list, got %d", length)
+				}
+				count, err := repo.GetActiveCount(ctx, eventID, time.Now())
+				mustNoError(t, err, "active count")
+				if count != len(waiting)+1 {
+					t.Fatalf("expected %d active sessions, got %d", len(waiting)+1, count)
+				}
+			},
+		},
+		{
+			name: "activate all with a limit leaves the rest waiting behind the last activated",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+				var users []uuid.UUID
+				for i := 0; i < 5; i++ {
+					userID := uuid.New()
+					_, err := repo.Join(ctx, eventID, userID, uuid.NewString())
+					mustNoError(t, err, "join")
+					users = append(users, userID)
+				}
+
+				activated, err := repo.ActivateAll(ctx, eventID, time.Now().Add(15*time.Minute), 2)
+				mustNoError(t, err, "activate all")
+				if len(activated) != 2 || activated[0].UserID != users[1] || activated[1].UserID != users[2] {
+					t.Fatalf("expected users 2 and 3 activated, got %d entries", len(activated))
+				}
+
+				next, err := repo.ActivateNext(ctx, eventID)
+				mustNoError(t, err, "activate next")
+				if next.UserID != users[3] {
+					t.Fatalf("expected user 4 next, got %s", next.UserID)
+				}
+			},
+		},
+		{
+			name: "live positions move up as users are activated and leave",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+				var users []uuid.UUID
+				for i := 0; i < 4; i++ {
+					userID := uuid.New()
+					_, err := repo.Join(ctx, eventID, userID, uuid.NewString())
+					mustNoError(t, err, "join")
+					users = append(users, userID)
+				}
+
+				_, err := repo.ActivateNext(ctx, eventID)
+				mustNoError(t, err, "activate next")
+
+				for i, want := range []int{0, 1, 2, 3} {
+					position, err := repo.GetPositionInList(ctx, eventID, users[i])
+					mustNoError(t, err, "position in list")
+					if position != want {
+						t.Fatalf("expected user %d at live position %d, got %d", i+1, want, position)
+					}
+				}
+
+				third, err := repo.GetPosition(ctx, eventID, users[2])
+				mustNoError(t, err, "get position")
+				mustNoError(t, repo.RemoveFromQueue(ctx, third.ID), "remove from queue")
+
+				position, err := repo.GetPositionInList(ctx, eventID, users[3])
+				mustNoError(t, err, "position in list")
+				if position != 2 {
+					t.Fatalf("expected the last user to move up to live position 2, got %d", position)
+				}
+			},
+		},
+		{
+			name: "entry ID resolves joined and activated entries",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+				first, err := repo.Join(ctx, eventID, uuid.New(), "session-id-1")
+				mustNoError(t, err, "first join")
+				second, err := repo.Join(ctx, eventID, uuid.New(), "session-id-2")
+				mustNoError(t, err, "second join")
+
+				got, err := repo.GetByID(ctx, first.ID)
+				mustNoError(t, err, "get first by ID")
+				if got.SessionID != "session-id-1" || got.EventID != eventID {
+					t.Fatalf("expected the first entry, got %+v", got)
+				}
+
+				_, err = repo.ActivateNext(ctx, eventID)
+				mustNoError(t, err, "activate next")
+
+				got, err = repo.GetByID(ctx, second.ID)
+				mustNoError(t, err, "get second by ID")
+				if !got.IsActive() {
+					t.Fatalf("expected the activated entry, got status %q", got.Status)
+				}
+
+				got.LastNotifiedThreshold = 5
+				mustNoError(t, repo.Update(ctx, got), "update entry")
+				got, err = repo.GetByID(ctx, second.ID)
+				mustNoError(t, err, "get second by ID after update")
+				if got.LastNotifiedThreshold != 5 {
+					t.Fatalf("expected last notified threshold 5, got %d", got.LastNotifiedThreshold)
+				}
+
+				if _, err := repo.GetByID(ctx, uuid.New()); err == nil {
+					t.Fatal("expected error for unknown entry ID")
+				}
+			},
+		},
+		{
+			name: "remove from queue by entry ID drops the entry and its index",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+				entry, err := repo.Join(ctx, eventID, uuid.New(), "session-remove")
+				mustNoError(t, err, "join")
+
+				mustNoError(t, repo.RemoveFromQueue(ctx, entry.ID), "remove from queue")
+
+				if _, err := repo.GetByID(ctx, entry.ID); err == nil {
+					t.Fatal("expected removed entry to be unresolvable by ID")
+				}
+				if _, err := repo.GetBySessionID(ctx, "session-remove"); err == nil {
+					t.Fatal("expected removed entry's session to be gone")
+				}
+				length, err := repo.GetQueueLength(ctx, eventID)
+				mustNoError(t, err, "get queue length")
+				if length != 0 {
+					t.Fatalf("expected empty queue after removal, got %d", length)
+				}
+				if err := repo.RemoveFromQueue(ctx, entry.ID); err == nil {
+					t.Fatal("expected error removing an entry twice")
+				}
+			},
+		},
+		{
+			name: "remove from queue shortens the queue by one and leaves the rest queued",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+				_, err := repo.Join(ctx, eventID, uuid.New(), "session-leave-1")
+				mustNoError(t, err, "first join")
+				leaving, err := repo.Join(ctx, eventID, uuid.New(), "session-leave-2")
+				mustNoError(t, err, "second join")
+				staying, err := repo.Join(ctx, eventID, uuid.New(), "session-leave-3")
+				mustNoError(t, err, "third join")
+
+				before, err := repo.GetQueueLength(ctx, eventID)
+				mustNoError(t, err, "get queue length before")
+
+				mustNoError(t, repo.RemoveFromQueue(ctx, leaving.ID), "remove from queue")
+
+				after, err := repo.GetQueueLength(ctx, eventID)
+				mustNoError(t, err, "get queue length after")
+				if after != before-1 {
+					t.Fatalf("expected queue length %d after removal, got %d", before-1, after)
+				}
+				if _, err := repo.GetPosition(ctx, eventID, leaving.UserID); err == nil {
+					t.Fatal("expected removed user to be out of the queue")
+				}
+				if _, err := repo.GetPosition(ctx, eventID, staying.UserID); err != nil {
+					t.Fatalf("expected remaining user to stay queued: %v", err)
+				}
+			},
+		},
+		{
+			name: "update status round trips through the entry ID",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+				_, err := repo.Join(ctx, eventID, uuid.New(), "session-status-1")
+				mustNoError(t, err, "first join")
+				waiting, err := repo.Join(ctx, eventID, uuid.New(), "session-status-2")
+				mustNoError(t, err, "second join")
+
+				mustNoError(t, repo.UpdateStatus(ctx, waiting.ID, string(domain.QueueStatusCompleted)), "complete entry")
+
+				got, err := repo.GetBySessionID(ctx, "session-status-2")
+				mustNoError(t, err, "get by session")
+				if !got.IsCompleted() {
+					t.Fatalf("expected completed entry, got status %q", got.Status)
+				}
+
+				if err := repo.UpdateStatus(ctx, uuid.New(), string(domain.QueueStatusActive)); !errors.Is(err, repository.ErrQueueEntryNotFound) {
+					t.Fatalf("expected ErrQueueEntryNotFound updating the status of a missing entry, got %v", err)
+				}
+			},
+		},
+		{
+			name: "update status moves an entry from waiting to active to completed",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+				_, err := repo.Join(ctx, eventID, uuid.New(), "session-lifecycle-1")
+				mustNoError(t, err, "first join")
+				entry, err := repo.Join(ctx, eventID, uuid.New(), "session-lifecycle-2")
+				mustNoError(t, err, "second join")
+				if !entry.IsWaiting() {
+					t.Fatalf("expected waiting entry, got status %q", entry.Status)
+				}
+
+				for _, status := range []domain.QueueStatus{domain.QueueStatusActive, domain.QueueStatusCompleted} {
+					mustNoError(t, repo.UpdateStatus(ctx, entry.ID, string(status)), "move entry to "+string(status))
+
+					got, err := repo.GetByID(ctx, entry.ID)
+					mustNoError(t, err, "get by ID")
+					if got.Status != string(status) {
+						t.Fatalf("expected status %q, got %q", status, got.Status)
+					}
+					if got.UpdatedAt.Before(entry.UpdatedAt) {
+						t.Fatalf("expected updated_at to move forward, got %v before %v", got.UpdatedAt, entry.UpdatedAt)
+					}
+				}
+
+				if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, repository.ErrQueueEntryNotFound) {
+					t.Fatalf("expected ErrQueueEntryNotFound for a missing entry, got %v", err)
+				}
+			},
+		},
+		{
+			name: "invalid statuses and transitions are rejected",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID, userID := uuid.New(), uuid.New()
+				entry, err := repo.Join(ctx, eventID, userID, "session-invalid")
+				mustNoError(t, err, "join")
+
+				if err := repo.UpdateStatus(ctx, entry.ID, "paused"); !errors.Is(err, repository.ErrInvalidQueueStatus) {
+					t.Fatalf("expected ErrInvalidQueueStatus from update status, got %v", err)
+				}
+
+				entry.Status = "paused"
+				if err := repo.Update(ctx, entry); !errors.Is(err, repository.ErrInvalidQueueStatus) {
+					t.Fatalf("expected ErrInvalidQueueStatus from update, got %v", err)
+				}
+
+				mustNoError(t, repo.UpdateStatus(ctx, entry.ID, string(domain.QueueStatusExpired)), "expire entry")
+				if err := repo.UpdateStatus(ctx, entry.ID, string(domain.QueueStatusActive)); !errors.Is(err, repository.ErrQueueStatusTransition) {
+					t.Fatalf("expected ErrQueueStatusTransition reactivating an expired entry, got %v", err)
+				}
+
+				got, err := repo.GetPosition(ctx, eventID, userID)
+				mustNoError(t, err, "get position")
+				if got.Status != string(domain.QueueStatusExpired) {
+					t.Fatalf("expected rejected writes to leave status expired, got %q", got.Status)
+				}
+			},
+		},
+		{
+			name: "user index lists entries across events until removed",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				userID := uuid.New()
+				firstEvent, secondEvent := uuid.New(), uuid.New()
+
+				first, err := repo.Join(ctx, firstEvent, userID, "session-user-1")
+				mustNoError(t, err, "join first event")
+				second, err := repo.Join(ctx, secondEvent, userID, "session-user-2")
+				mustNoError(t, err, "join second event")
+
+				entries, err := repo.GetByUserID(ctx, userID)
+				mustNoError(t, err, "get by user")
+				if !containsID(entries, first.ID, queueEntryID) || !containsID(entries, second.ID, queueEntryID) {
+					t.Fatalf("expected both entries for the user, got %d", len(entries))
+				}
+
+				mustNoError(t, repo.RemoveUser(ctx, firstEvent, userID), "remove user")
+
+				entries, err = repo.GetByUserID(ctx, userID)
+				mustNoError(t, err, "get by user")
+				if containsID(entries, first.ID, queueEntryID) || !containsID(entries, second.ID, queueEntryID) {
+					t.Fatal("expected only the second entry after removal")
+				}
+				if _, err := repo.GetBySessionID(ctx, "session-user-1"); err == nil {
+					t.Fatal("expected removed entry's session to be gone")
+				}
+				length, err := repo.GetQueueLength(ctx, firstEvent)
+				mustNoError(t, err, "get queue length")
+				if length != 0 {
+					t.Fatalf("expected empty queue after removal, got %d", length)
+				}
+			},
+		},
+		{
+			name: "last activation round-trips per event",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				eventID := uuid.New()
+
+				last, err := repo.GetLastActivation(ctx, eventID)
+				mustNoError(t, err, "get last activation")
+				if !last.IsZero() {
+					t.Fatalf("expected no last activation, got %v", last)
+				}
+
+				at := time.UnixMilli(time.Now().UnixMilli())
+				mustNoError(t, repo.SetLastActivation(ctx, eventID, at), "set last activation")
+
+				last, err = repo.GetLastActivation(ctx, eventID)
+				mustNoError(t, err, "get last activation")
+				if !last.Equal(at) {
+					t.Fatalf("expected last activation %v, got %v", at, last)
+				}
+
+				other, err := repo.GetLastActivation(ctx, uuid.New())
+				mustNoError(t, err, "get other event's last activation")
+				if !other.IsZero() {
+					t.Fatal("expected last activation to be tracked per event")
+				}
+			},
+		},
+		{
+			name: "activate next on empty queue fails",
+			run: func(t *testing.T, ctx context.Context, repo repository.QueueRepository) {
+				if _, err := repo.ActivateNext(ctx, uuid.New()); err == nil {
+					t.Fatal("expected error for empty queue")
+				}
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, testContext(t), newRepo(t))
+		})
+	}
+}
+
+// queueEntryID extracts the ID of a queue entry
+func queueEntryID(entry *domain.QueueEntry) uuid.UUID {
+	return entry.ID
+}
+
+// assertActiveCount fails the test unless the event has exactly want active sessions
+func assertActiveCount(t *testing.T, ctx context.Context, repo repository.QueueRepository, eventID uuid.UUID, want int) {
+	t.Helper()
+	got, err := repo.GetActiveCount(ctx, eventID, time.Now())
+	mustNoError(t, err, "get active count")
+	if got != want {
+		t.Fatalf("expected %d active sessions, got %d", want, got)
+	}
+}
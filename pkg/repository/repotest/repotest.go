@@ -0,0 +1,163 @@
+// Package repotest provides behavioral conformance suites for implementations
+// of the lib/repository interfaces, and of the lib/adapter Lock. Each backend
+// (Redis, memory, ...) runs the same suite from its own tests by passing a
+// factory that returns a fresh, empty repository. Benchmarks comparing access
+// paths of a backend are run the same way.
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// newTestEvent builds an active event that is open for purchase
+func newTestEvent(totalTickets int) *domain.Event {
+	now := time.Now()
+	return &domain.Event{
+		ID:               uuid.New(),
+		Name:             "Conformance Event",
+		Description:      "Event used by the repository conformance suite",
+		StartTime:        now.Add(1 * time.Hour),
+		EndTime:          now.Add(4 * time.Hour),
+		Venue:            "Test Hall",
+		Status:           string(domain.EventStatusActive),
+		TotalTickets:     totalTickets,
+		AvailableTickets: totalTickets,
+	}
+}
+
+// newTestSeat builds an available seat for an event
+func newTestSeat(eventID uuid.UUID, section, row, number string, price int64) *domain.Seat {
+	return &domain.Seat{
+		ID:      uuid.New(),
+		EventID: eventID,
+		Section: section,
+		Row:     row,
+		Number:  number,
+		Price:   price,
+		Status:  string(domain.SeatStatusAvailable),
+	}
+}
+
+// newTestTicket builds a reserved ticket expiring after the given duration
+func newTestTicket(eventID, userID uuid.UUID, seatID *uuid.UUID, expiresIn time.Duration) *domain.Ticket {
+	expiry := time.Now().Add(expiresIn)
+	return &domain.Ticket{
+		ID:        uuid.New(),
+		EventID:   eventID,
+		SeatID:    seatID,
+		UserID:    userID,
+		Price:     5000,
+		Status:    string(domain.TicketStatusReserved),
+		IssuedAt:  time.Now(),
+		ExpiresAt: &expiry,
+	}
+}
+
+// newTestAccessLogEntry builds an access log entry for an endpoint
+func newTestAccessLogEntry(endpoint string, userID *uuid.UUID, status int) *domain.AccessLogEntry {
+	return &domain.AccessLogEntry{
+		Endpoint:   endpoint,
+		Method:     "GET",
+		UserID:     userID,
+		SessionID:  uuid.NewString(),
+		Status:     status,
+		RemoteAddr: "192.0.2.1:4321",
+		At:         time.Now(),
+	}
+}
+
+// newTestPurchaseSignal builds a two-ticket purchase signal for an event from a remote address
+func newTestPurchaseSignal(eventID uuid.UUID, remoteAddr string) *domain.PurchaseSignal {
+	return &domain.PurchaseSignal{
+		EventID:    eventID,
+		UserID:     uuid.New(),
+		SessionID:  uuid.NewString(),
+		TicketIDs:  []uuid.UUID{uuid.New(), uuid.New()},
+		RemoteAddr: remoteAddr,
+		UserAgent:  "Mozilla/5.0 (X11; Linux x86_64)",
+		At:         time.Now(),
+	}
+}
+
+// newTestFailedAction builds a dead-lettered action created at the given time
+func newTestFailedAction(kind domain.FailedActionKind, createdAt time.Time) *domain.FailedAction {
+	return &domain.FailedAction{
+		ID:            uuid.New(),
+		Kind:          string(kind),
+		EventID:       uuid.New(),
+		Quantity:      1,
+		Reason:        "conformance",
+		LastError:     "backend unavailable",
+		Attempts:      1,
+		CreatedAt:     createdAt,
+		LastAttemptAt: createdAt,
+	}
+}
+
+// newTestResaleListing builds a listing of a fresh ticket for an event
+func newTestResaleListing(eventID uuid.UUID, price int64) *domain.ResaleListing {
+	return &domain.ResaleListing{
+		ID:       uuid.New(),
+		TicketID: uuid.New(),
+		EventID:  eventID,
+		SellerID: uuid.New(),
+		Price:    price,
+		Currency: "USD",
+	}
+}
+
+// createTestEvent stores a fresh event with totalTickets tickets
+func createTestEvent(t *testing.T, ctx context.Context, repo repository.EventRepository, totalTickets int) *domain.Event {
+	t.Helper()
+	event := newTestEvent(totalTickets)
+	mustNoError(t, repo.Create(ctx, event), "create event")
+	return event
+}
+
+// createTestSeat stores a fresh available seat for an event
+func createTestSeat(t *testing.T, ctx context.Context, repo repository.SeatRepository, eventID uuid.UUID, section, row, number string, price int64) *domain.Seat {
+	t.Helper()
+	seat := newTestSeat(eventID, section, row, number, price)
+	mustNoError(t, repo.Create(ctx, seat), "create seat")
+	return seat
+}
+
+// createTestTicket stores a fresh reservation expiring after the given duration
+func createTestTicket(t *testing.T, ctx context.Context, repo repository.TicketRepository, eventID, userID uuid.UUID, seatID *uuid.UUID, expiresIn time.Duration) *domain.Ticket {
+	t.Helper()
+	ticket := newTestTicket(eventID, userID, seatID, expiresIn)
+	mustNoError(t, repo.Create(ctx, ticket), "create ticket")
+	return ticket
+}
+
+// containsID reports whether the list holds an item with the given ID
+func containsID[T any](items []T, id uuid.UUID, idOf func(T) uuid.UUID) bool {
+	for _, item := range items {
+		if idOf(item) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// mustNoError fails the test immediately when err is not nil
+func mustNoError(t *testing.T, err error, action string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: unexpected error: %v", action, err)
+	}
+}
+
+// testContext returns a context bounded so a hung backend fails the test instead of blocking
+func testContext(t *testing.T) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
@@ -0,0 +1,174 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// ResaleRepositoryFactory returns a fresh, empty ResaleRepository for a single test case
+type ResaleRepositoryFactory func(t *testing.T) repository.ResaleRepository
+
+// RunResaleRepositoryTests runs the ResaleRepository conformance suite
+func RunResaleRepositoryTests(t *testing.T, newRepo ResaleRepositoryFactory) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, ctx context.Context, repo repository.ResaleRepository)
+	}{
+		{
+			name: "create then get round trips the listing",
+			run: func(t *testing.T, ctx context.Context, repo repository.ResaleRepository) {
+				listing := newTestResaleListing(uuid.New(), 6000)
+				mustNoError(t, repo.Create(ctx, listing), "create listing")
+
+				got, err := repo.GetByID(ctx, listing.ID)
+				mustNoError(t, err, "get listing")
+				if !got.IsListed() || got.TicketID != listing.TicketID || got.Price != 6000 {
+					t.Fatalf("round trip mismatch: got %+v", got)
+				}
+
+				if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, repository.ErrResaleListingNotFound) {
+					t.Fatalf("expected ErrResaleListingNotFound, got %v", err)
+				}
+			},
+		},
+		{
+			name: "a ticket has one open listing at a time",
+			run: func(t *testing.T, ctx context.Context, repo repository.ResaleRepository) {
+				first := newTestResaleListing(uuid.New(), 5000)
+				mustNoError(t, repo.Create(ctx, first), "create listing")
+
+				again := newTestResaleListing(first.EventID, 4000)
+				again.TicketID = first.TicketID
+				if err := repo.Create(ctx, again); !errors.Is(err, repository.ErrTicketAlreadyListed) {
+					t.Fatalf("expected ErrTicketAlreadyListed, got %v", err)
+				}
+
+				mustNoError(t, repo.Cancel(ctx, first.ID), "cancel listing")
+				mustNoError(t, repo.Create(ctx, again), "relist after cancel")
+			},
+		},
+		{
+			name: "open listings are listed per event oldest first",
+			run: func(t *testing.T, ctx context.Context, repo repository.ResaleRepository) {
+				eventID := uuid.New()
+				first := newTestResaleListing(eventID, 5000)
+				mustNoError(t, repo.Create(ctx, first), "create first listing")
+				second := newTestResaleListing(eventID, 5000)
+				mustNoError(t, repo.Create(ctx, second), "create second listing")
+				mustNoError(t, repo.Create(ctx, newTestResaleListing(uuid.New(), 5000)), "create other event listing")
+				cancelled := newTestResaleListing(eventID, 5000)
+				mustNoError(t, repo.Create(ctx, cancelled), "create cancelled listing")
+				mustNoError(t, repo.Cancel(ctx, cancelled.ID), "cancel listing")
+
+				listings, err := repo.ListOpenByEvent(ctx, eventID)
+				mustNoError(t, err, "list open listings")
+				if len(listings) != 2 || listings[0].ID != first.ID || listings[1].ID != second.ID {
+					t.Fatalf("expected the two open listings oldest first, got %d", len(listings))
+				}
+			},
+		},
+		{
+			name: "claim sells a listing once and reopen puts it back",
+			run: func(t *testing.T, ctx context.Context, repo repository.ResaleRepository) {
+				listing := newTestResaleListing(uuid.New(), 5000)
+				mustNoError(t, repo.Create(ctx, listing), "create listing")
+				buyer := uuid.New()
+
+				claimed, err := repo.Claim(ctx, listing.ID, buyer)
+				mustNoError(t, err, "claim listing")
+				if !claimed.IsSold() || claimed.BuyerID == nil || *claimed.BuyerID != buyer || claimed.SoldAt == nil {
+					t.Fatalf("expected listing sold to the buyer, got %+v", claimed)
+				}
+
+				if _, err := repo.Claim(ctx, listing.ID, uuid.New()); !errors.Is(err, repository.ErrResaleListingNotAvailable) {
+					t.Fatalf("expected ErrResaleListingNotAvailable claiming twice, got %v", err)
+				}
+				if err := repo.Cancel(ctx, listing.ID); !errors.Is(err, repository.ErrResaleListingNotAvailable) {
+					t.Fatalf("expected ErrResaleListingNotAvailable cancelling a sold listing, got %v", err)
+				}
+
+				listings, err := repo.ListOpenByEvent(ctx, listing.EventID)
+				mustNoError(t, err, "list open listings")
+				if len(listings) != 0 {
+					t.Fatalf("expected sold listing off the market, got %d open", len(listings))
+				}
+
+				mustNoError(t, repo.Reopen(ctx, listing.ID), "reopen listing")
+				got, err := repo.GetByID(ctx, listing.ID)
+				mustNoError(t, err, "get listing")
+				if !got.IsListed() || got.BuyerID != nil {
+					t.Fatalf("expected reopened listing without a buyer, got %+v", got)
+				}
+				listings, err = repo.ListOpenByEvent(ctx, listing.EventID)
+				mustNoError(t, err, "list open listings")
+				if len(listings) != 1 {
+					t.Fatalf("expected reopened listing back on the market, got %d open", len(listings))
+				}
+			},
+		},
+		{
+			name: "concurrent claims sell a listing to exactly one buyer",
+			run: func(t *testing.T, ctx context.Context, repo repository.ResaleRepository) {
+				const workers = 20
+				listing := newTestResaleListing(uuid.New(), 5000)
+				mustNoError(t, repo.Create(ctx, listing), "create listing")
+
+				var wg sync.WaitGroup
+				var mu sync.Mutex
+				sold := 0
+				for i := 0; i < workers; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						_, err := repo.Claim(ctx, listing.ID, uuid.New())
+						if err == nil {
+							mu.Lock()
+							sold++
+							mu.Unlock()
+						} else if !errors.Is(err, repository.ErrResaleListingNotAvailable) {
+							t.Errorf("claim: %v", err)
+						}
+					}()
+				}
+				wg.Wait()
+
+				if sold != 1 {
+					t.Fatalf("expected exactly one successful claim, got %d", sold)
+				}
+			},
+		},
+		{
+			name: "update persists the payment ID",
+			run: func(t *testing.T, ctx context.Context, repo repository.ResaleRepository) {
+				listing := newTestResaleListing(uuid.New(), 5000)
+				mustNoError(t, repo.Create(ctx, listing), "create listing")
+
+				claimed, err := repo.Claim(ctx, listing.ID, uuid.New())
+				mustNoError(t, err, "claim listing")
+				claimed.PaymentID = "payment-1"
+				mustNoError(t, repo.Update(ctx, claimed), "update listing")
+
+				got, err := repo.GetByID(ctx, listing.ID)
+				mustNoError(t, err, "get listing")
+				if got.PaymentID != "payment-1" || !got.IsSold() {
+					t.Fatalf("expected sold listing with payment ID, got %+v", got)
+				}
+
+				if err := repo.Update(ctx, newTestResaleListing(uuid.New(), 5000)); !errors.Is(err, repository.ErrResaleListingNotFound) {
+					t.Fatalf("expected ErrResaleListingNotFound updating a missing listing, got %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, testContext(t), newRepo(t))
+		})
+	}
+}
@@ -0,0 +1,577 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// SeatRepositoryFactory returns a fresh, empty SeatRepository for a single test case
+type SeatRepositoryFactory func(t *testing.T) repository.SeatRepository
+
+// RunSeatRepositoryTests runs the SeatRepository conformance suite
+func RunSeatRepositoryTests(t *testing.T, newRepo SeatRepositoryFactory) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, ctx context.Context, repo repository.SeatRepository)
+	}{
+		{
+			name: "create batch then get round trips seats",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				eventID := uuid.New()
+				seats := []*domain.Seat{
+					newTestSeat(eventID, "A", "1", "1", 10000),
+					newTestSeat(eventID, "A", "1", "2", 10000),
+				}
+				seats[1].X, seats[1].Y = 12, 34
+				mustNoError(t, repo.CreateBatch(ctx, seats), "create seats")
+
+				got, err := repo.GetByID(ctx, seats[1].ID)
+				mustNoError(t, err, "get seat")
+				if got.Section != "A" || got.Number != "2" || got.X != 12 || got.Y != 34 {
+					t.Fatalf("round trip mismatch: got %+v", got)
+				}
+
+				all, err := repo.GetByEventID(ctx, eventID)
+				mustNoError(t, err, "get event seats")
+				if len(all) != 2 {
+					t.Fatalf("expected 2 event seats, got %d", len(all))
+				}
+			},
+		},
+		{
+			name: "get missing seat fails",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, repository.ErrSeatNotFound) {
+					t.Fatalf("expected ErrSeatNotFound for missing seat, got %v", err)
+				}
+			},
+		},
+		{
+			name: "reserve fails on unavailable seat",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				seat := createTestSeat(t, ctx, repo, uuid.New(), "A", "1", "1", 10000)
+				mustNoError(t, repo.ReserveSeats(ctx, []uuid.UUID{seat.ID}), "first reserve")
+
+				if err := repo.ReserveSeats(ctx, []uuid.UUID{seat.ID}); err == nil {
+					t.Fatal("expected second reservation of the same seat to fail")
+				}
+			},
+		},
+		{
+			name: "reserve is all or nothing",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				eventID := uuid.New()
+				free := newTestSeat(eventID, "A", "1", "1", 10000)
+				taken := newTestSeat(eventID, "A", "1", "2", 10000)
+				mustNoError(t, repo.CreateBatch(ctx, []*domain.Seat{free, taken}), "create seats")
+				mustNoError(t, repo.ReserveSeats(ctx, []uuid.UUID{taken.ID}), "reserve taken seat")
+
+				if err := repo.ReserveSeats(ctx, []uuid.UUID{free.ID, taken.ID}); err == nil {
+					t.Fatal("expected reservation including an unavailable seat to fail")
+				}
+
+				got, err := repo.GetByID(ctx, free.ID)
+				mustNoError(t, err, "get free seat")
+				if !got.IsAvailable() {
+					t.Fatalf("expected free seat to remain available, got %s", got.Status)
+				}
+			},
+		},
+		{
+			name: "reserve fails on missing seat",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				if err := repo.ReserveSeats(ctx, []uuid.UUID{uuid.New()}); err == nil {
+					t.Fatal("expected error for missing seat")
+				}
+			},
+		},
+		{
+			name: "available index follows reserve and release",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				eventID := uuid.New()
+				seat := createTestSeat(t, ctx, repo, eventID, "A", "1", "1", 10000)
+
+				mustNoError(t, repo.ReserveSeats(ctx, []uuid.UUID{seat.ID}), "reserve seat")
+				available, err := repo.GetAvailableByEventID(ctx, eventID)
+				mustNoError(t, err, "get available seats")
+				if containsID(available, seat.ID, seatID) {
+					t.Fatal("reserved seat still listed as available")
+				}
+
+				mustNoError(t, repo.ReleaseSeats(ctx, []uuid.UUID{seat.ID}), "release seat")
+				available, err = repo.GetAvailableByEventID(ctx, eventID)
+				mustNoError(t, err, "get available seats")
+				if !containsID(available, seat.ID, seatID) {
+					t.Fatal("released seat missing from available seats")
+				}
+			},
+		},
+		{
+			name: "available seats of an event without seats is an empty slice",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				available, err := repo.GetAvailableByEventID(ctx, uuid.New())
+				mustNoError(t, err, "get available seats")
+				if available == nil || len(available) != 0 {
+					t.Fatalf("expected an empty non-nil slice, got %v", available)
+				}
+
+				eventID := uuid.New()
+				seat := createTestSeat(t, ctx, repo, eventID, "A", "1", "1", 10000)
+				mustNoError(t, repo.ReserveSeats(ctx, []uuid.UUID{seat.ID}), "reserve seat")
+
+				available, err = repo.GetAvailableByEventID(ctx, eventID)
+				mustNoError(t, err, "get available seats")
+				if available == nil || len(available) != 0 {
+					t.Fatalf("expected an empty non-nil slice once every seat is taken, got %v", available)
+				}
+			},
+		},
+		{
+			name: "release fails on seat that is not reserved",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				seat := createTestSeat(t, ctx, repo, uuid.New(), "A", "1", "1", 10000)
+
+				if err := repo.ReleaseSeats(ctx, []uuid.UUID{seat.ID}); err == nil {
+					t.Fatal("expected releasing an available seat to fail")
+				}
+			},
+		},
+		{
+			name: "release reserved seats skips seats that are not reserved",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				eventID := uuid.New()
+				first := createTestSeat(t, ctx, repo, eventID, "A", "1", "1", 10000)
+				second := createTestSeat(t, ctx, repo, eventID, "A", "1", "2", 10000)
+				third := createTestSeat(t, ctx, repo, eventID, "A", "1", "3", 10000)
+				mustNoError(t, repo.ReserveSeats(ctx, []uuid.UUID{first.ID, second.ID, third.ID}), "reserve seats")
+				mustNoError(t, repo.ReleaseSeats(ctx, []uuid.UUID{second.ID}), "release one seat")
+
+				released, err := repo.ReleaseReservedSeats(ctx, []uuid.UUID{first.ID, second.ID, third.ID, uuid.New()})
+				mustNoError(t, err, "release reserved seats")
+				if len(released) != 2 || !slices.Contains(released, first.ID) || !slices.Contains(released, third.ID) {
+					t.Fatalf("expected the two still-reserved seats released, got %v", released)
+				}
+
+				available, err := repo.GetAvailableByEventID(ctx, eventID)
+				mustNoError(t, err, "get available seats")
+				if len(available) != 3 {
+					t.Fatalf("expected all 3 seats available, got %d", len(available))
+				}
+			},
+		},
+		{
+			name: "update status keeps available index consistent",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				eventID := uuid.New()
+				seat := createTestSeat(t, ctx, repo, eventID, "A", "1", "1", 10000)
+				mustNoError(t, repo.UpdateStatus(ctx, seat.ID, string(domain.SeatStatusSold)), "mark sold")
+
+				available, err := repo.GetAvailableByEventID(ctx, eventID)
+				mustNoError(t, err, "get available seats")
+				if containsID(available, seat.ID, seatID) {
+					t.Fatal("sold seat still listed as available")
+				}
+			},
+		},
+		{
+			name: "racing updates from the same version let one through and the other retries",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				eventID := uuid.New()
+				seat := createTestSeat(t, ctx, repo, eventID, "A", "1", "1", 10000)
+
+				first, err := repo.GetByID(ctx, seat.ID)
+				mustNoError(t, err, "get first copy")
+				second, err := repo.GetByID(ctx, seat.ID)
+				mustNoError(t, err, "get second copy")
+
+				first.Price = 12000
+				mustNoError(t, repo.Update(ctx, first), "first update")
+				if first.Version != second.Version+1 {
+					t.Fatalf("expected the update to bump the version from %d, got %d", second.Version, first.Version)
+				}
+
+				second.Status = string(domain.SeatStatusSold)
+				if err := repo.Update(ctx, second); !errors.Is(err, repository.ErrSeatVersionConflict) {
+					t.Fatalf("expected ErrSeatVersionConflict from the stale copy, got %v", err)
+				}
+
+				retry, err := repo.GetByID(ctx, seat.ID)
+				mustNoError(t, err, "re-read seat")
+				retry.Status = string(domain.SeatStatusSold)
+				mustNoError(t, repo.Update(ctx, retry), "retried update")
+
+				got, err := repo.GetByID(ctx, seat.ID)
+				mustNoError(t, err, "get seat")
+				if got.Price != 12000 || !got.IsSold() || got.Version != first.Version+1 {
+					t.Fatalf("expected both updates to land, got price %d, status %s, version %d", got.Price, got.Status, got.Version)
+				}
+
+				available, err := repo.GetAvailableByEventID(ctx, eventID)
+				mustNoError(t, err, "get available seats")
+				if containsID(available, seat.ID, seatID) {
+					t.Fatal("expected the sold seat to leave the available index")
+				}
+			},
+		},
+		{
+			name: "reserve and release invalidate copies read before them",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				seat := createTestSeat(t, ctx, repo, uuid.New(), "A", "1", "1", 10000)
+
+				stale, err := repo.GetByID(ctx, seat.ID)
+				mustNoError(t, err, "get seat")
+				mustNoError(t, repo.ReserveSeats(ctx, []uuid.UUID{seat.ID}), "reserve seat")
+
+				stale.Price = 1
+				if err := repo.Update(ctx, stale); !errors.Is(err, repository.ErrSeatVersionConflict) {
+					t.Fatalf("expected a copy read before the reservation to conflict, got %v", err)
+				}
+
+				mustNoError(t, repo.UpdateStatus(ctx, seat.ID, string(domain.SeatStatusSold)), "update status after reserve")
+				got, err := repo.GetByID(ctx, seat.ID)
+				mustNoError(t, err, "get seat")
+				if !got.IsSold() || got.Price != 10000 {
+					t.Fatalf("expected a sold seat at its original price, got %s at %d", got.Status, got.Price)
+				}
+			},
+		},
+		{
+			name: "concurrent status updates all land",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				seat := createTestSeat(t, ctx, repo, uuid.New(), "A", "1", "1", 10000)
+				before, err := repo.GetByID(ctx, seat.ID)
+				mustNoError(t, err, "get seat")
+
+				statuses := []string{string(domain.SeatStatusReserved), string(domain.SeatStatusSold)}
+				errs := make(chan error, len(statuses))
+				for _, status := range statuses {
+					go func(status string) {
+						errs <- repo.UpdateStatus(ctx, seat.ID, status)
+					}(status)
+				}
+				for range statuses {
+					mustNoError(t, <-errs, "racing status update")
+				}
+
+				got, err := repo.GetByID(ctx, seat.ID)
+				mustNoError(t, err, "get seat")
+				if got.Version != before.Version+int64(len(statuses)) {
+					t.Fatalf("expected %d writes on top of version %d, got version %d", len(statuses), before.Version, got.Version)
+				}
+			},
+		},
+		{
+			name: "section index only returns seats of that section",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				eventID := uuid.New()
+				a := newTestSeat(eventID, "A", "1", "1", 10000)
+				b := newTestSeat(eventID, "B", "1", "1", 10000)
+				mustNoError(t, repo.CreateBatch(ctx, []*domain.Seat{a, b}), "create seats")
+
+				section, err := repo.GetBySection(ctx, eventID, "A")
+				mustNoError(t, err, "get section seats")
+				if len(section) != 1 || section[0].ID != a.ID {
+					t.Fatalf("expected only seat %s in section A, got %d seats", a.ID, len(section))
+				}
+			},
+		},
+		{
+			name: "update prices skips sold seats and other sections",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				eventID := uuid.New()
+				open := newTestSeat(eventID, "A", "1", "1", 10000)
+				sold := newTestSeat(eventID, "A", "1", "2", 10000)
+				other := newTestSeat(eventID, "B", "1", "1", 10000)
+				mustNoError(t, repo.CreateBatch(ctx, []*domain.Seat{open, sold, other}), "create seats")
+				mustNoError(t, repo.UpdateStatus(ctx, sold.ID, string(domain.SeatStatusSold)), "mark sold")
+
+				updated, err := repo.UpdatePrices(ctx, eventID, repository.SeatPriceFilter{Section: "A"}, 7500)
+				mustNoError(t, err, "update prices")
+				if updated != 1 {
+					t.Fatalf("expected 1 repriced seat, got %d", updated)
+				}
+
+				for _, tc := range []struct {
+					id    uuid.UUID
+					price int64
+				}{{open.ID, 7500}, {sold.ID, 10000}, {other.ID, 10000}} {
+					got, err := repo.GetByID(ctx, tc.id)
+					mustNoError(t, err, "get seat")
+					if got.Price != tc.price {
+						t.Fatalf("seat %s: expected price %d, got %d", tc.id, tc.price, got.Price)
+					}
+				}
+			},
+		},
+		{
+			name: "update prices reprices a tier across a venue larger than one chunk",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				const seats = 2500
+				eventID := uuid.New()
+				// Prices beyond what a float prints exactly in the shortest form still match their tier
+				const tier, other = int64(123456789012), int64(10000)
+
+				batch := make([]*domain.Seat, 0, seats)
+				for i := 0; i < seats; i++ {
+					price := tier
+					if i%5 == 0 {
+						price = other
+					}
+					batch = append(batch, newTestSeat(eventID, "A", strconv.Itoa(i/100), strconv.Itoa(i), price))
+				}
+				batch[1].Status = string(domain.SeatStatusSold)
+				mustNoError(t, repo.CreateBatch(ctx, batch), "create seats")
+
+				filterPrice := tier
+				updated, err := repo.UpdatePrices(ctx, eventID, repository.SeatPriceFilter{Price: &filterPrice}, 9000)
+				mustNoError(t, err, "update prices")
+				// Every fifth seat is at the other price and one at the tier is sold
+				if want := seats - seats/5 - 1; updated != want {
+					t.Fatalf("expected %d repriced seats, got %d", want, updated)
+				}
+
+				for _, i := range []int{0, 1, 2, 1999, seats - 1} {
+					got, err := repo.GetByID(ctx, batch[i].ID)
+					mustNoError(t, err, "get seat")
+					want := int64(9000)
+					if i%5 == 0 || i == 1 {
+						want = batch[i].Price
+					}
+					if got.Price != want {
+						t.Fatalf("seat %d: expected price %d, got %d", i, want, got.Price)
+					}
+				}
+			},
+		},
+		{
+			name: "available count follows reservations and releases",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				eventID := uuid.New()
+				var seats []*domain.Seat
+				for i := 0; i < 5; i++ {
+					seats = append(seats, newTestSeat(eventID, "A", "1", strconv.Itoa(i+1), 10000))
+				}
+				seats[4].Status = string(domain.SeatStatusSold)
+				mustNoError(t, repo.CreateBatch(ctx, seats), "create seats")
+				mustNoError(t, repo.Create(ctx, newTestSeat(uuid.New(), "A", "1", "1", 10000)), "create other event's seat")
+
+				assertAvailableCount(t, ctx, repo, eventID, 4)
+
+				mustNoError(t, repo.ReserveSeats(ctx, []uuid.UUID{seats[0].ID, seats[1].ID}), "reserve seats")
+				assertAvailableCount(t, ctx, repo, eventID, 2)
+
+				mustNoError(t, repo.ReleaseSeats(ctx, []uuid.UUID{seats[0].ID}), "release seat")
+				assertAvailableCount(t, ctx, repo, eventID, 3)
+
+				available, err := repo.GetAvailableByEventID(ctx, eventID)
+				mustNoError(t, err, "get available")
+				if len(available) != 3 {
+					t.Fatalf("expected the count to match the 3 listed available seats, got %d listed", len(available))
+				}
+
+				assertAvailableCount(t, ctx, repo, uuid.New(), 0)
+			},
+		},
+		{
+			name: "create batch spanning several events indexes every seat",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				// Large enough to cross a pipelined backend's chunk boundaries, with seats of two events interleaved
+				first, second := uuid.New(), uuid.New()
+				var seats []*domain.Seat
+				for i := 0; i < 2500; i++ {
+					eventID := first
+					if i%2 == 1 {
+						eventID = second
+					}
+					seat := newTestSeat(eventID, "S"+strconv.Itoa(i%4), "1", strconv.Itoa(i), 10000)
+					if i%5 == 0 {
+						seat.Status = string(domain.SeatStatusSold)
+					}
+					seats = append(seats, seat)
+				}
+				mustNoError(t, repo.CreateBatch(ctx, seats), "create batch")
+
+				for _, eventID := range []uuid.UUID{first, second} {
+					all, err := repo.GetByEventID(ctx, eventID)
+					mustNoError(t, err, "get by event")
+					if len(all) != 1250 {
+						t.Fatalf("expected 1250 seats for event %s, got %d", eventID, len(all))
+					}
+
+					available, err := repo.GetAvailableByEventID(ctx, eventID)
+					mustNoError(t, err, "get available")
+					if len(available) != 1000 {
+						t.Fatalf("expected 1000 available seats for event %s, got %d", eventID, len(available))
+					}
+				}
+
+				section, err := repo.GetBySection(ctx, first, "S2")
+				mustNoError(t, err, "get by section")
+				if len(section) != 625 {
+					t.Fatalf("expected 625 seats in section S2, got %d", len(section))
+				}
+
+				last := seats[len(seats)-1]
+				got, err := repo.GetByID(ctx, last.ID)
+				mustNoError(t, err, "get last seat")
+				if got.EventID != second || got.CreatedAt.IsZero() {
+					t.Fatalf("expected the last seat stored under its event with a creation time, got %+v", got)
+				}
+			},
+		},
+		{
+			name: "listings of a large event return every seat",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				eventID := uuid.New()
+				sections := []string{"A", "B", "C", "D", "E"}
+				var seats []*domain.Seat
+				for i := 0; i < 500; i++ {
+					seats = append(seats, newTestSeat(eventID, sections[i%len(sections)], "1", strconv.Itoa(i/len(sections)+1), 10000))
+				}
+				mustNoError(t, repo.CreateBatch(ctx, seats), "create batch")
+
+				var reserved []uuid.UUID
+				for _, seat := range seats[:100] {
+					reserved = append(reserved, seat.ID)
+				}
+				mustNoError(t, repo.ReserveSeats(ctx, reserved), "reserve seats")
+
+				all, err := repo.GetByEventID(ctx, eventID)
+				mustNoError(t, err, "get by event")
+				if len(all) != 500 {
+					t.Fatalf("expected 500 seats, got %d", len(all))
+				}
+				for _, seat := range seats {
+					if !containsID(all, seat.ID, seatID) {
+						t.Fatalf("expected seat %s in the event listing", seat.ID)
+					}
+				}
+
+				available, err := repo.GetAvailableByEventID(ctx, eventID)
+				mustNoError(t, err, "get available")
+				if len(available) != 400 {
+					t.Fatalf("expected 400 available seats, got %d", len(available))
+				}
+
+				section, err := repo.GetBySection(ctx, eventID, "C")
+				mustNoError(t, err, "get by section")
+				if len(section) != 100 {
+					t.Fatalf("expected 100 seats in section C, got %d", len(section))
+				}
+				for _, seat := range section {
+					if seat.Section != "C" {
+						t.Fatalf("expected only section C seats, got %s", seat.Section)
+					}
+				}
+			},
+		},
+		{
+			name: "reserved seats surface once their reservation hold lapses",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				eventID := uuid.New()
+				seats := []*domain.Seat{
+					newTestSeat(eventID, "A", "1", "1", 10000),
+					newTestSeat(eventID, "A", "1", "2", 10000),
+					newTestSeat(eventID, "A", "1", "3", 10000),
+				}
+				mustNoError(t, repo.CreateBatch(ctx, seats), "create batch")
+				mustNoError(t, repo.ReserveSeats(ctx, []uuid.UUID{seats[0].ID, seats[1].ID}), "reserve seats")
+
+				lapsed, err := repo.GetLapsedReservationHolds(ctx, time.Now())
+				mustNoError(t, err, "get lapsed holds")
+				if len(lapsed) != 0 {
+					t.Fatalf("expected no lapsed holds right after reserving, got %d", len(lapsed))
+				}
+
+				// A day on, any hold has lapsed
+				later := time.Now().Add(24 * time.Hour)
+				lapsed, err = repo.GetLapsedReservationHolds(ctx, later)
+				mustNoError(t, err, "get lapsed holds")
+				if len(lapsed) != 2 || !containsID(lapsed, seats[0].ID, seatID) || !containsID(lapsed, seats[1].ID, seatID) {
+					t.Fatalf("expected both reserved seats to have lapsed holds, got %d", len(lapsed))
+				}
+
+				mustNoError(t, repo.ReleaseSeats(ctx, []uuid.UUID{seats[0].ID}), "release seat")
+				lapsed, err = repo.GetLapsedReservationHolds(ctx, later)
+				mustNoError(t, err, "get lapsed holds")
+				if len(lapsed) != 1 || lapsed[0].ID != seats[1].ID {
+					t.Fatalf("expected only the seat still reserved, got %d", len(lapsed))
+				}
+
+				mustNoError(t, repo.ClearReservationHolds(ctx, []uuid.UUID{seats[1].ID}), "clear holds")
+				lapsed, err = repo.GetLapsedReservationHolds(ctx, later)
+				mustNoError(t, err, "get lapsed holds")
+				if len(lapsed) != 0 {
+					t.Fatalf("expected cleared holds to stay cleared, got %d", len(lapsed))
+				}
+			},
+		},
+		{
+			name: "lapsed holds of seats sold since are dropped",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				seat := createTestSeat(t, ctx, repo, uuid.New(), "A", "1", "1", 10000)
+				mustNoError(t, repo.ReserveSeats(ctx, []uuid.UUID{seat.ID}), "reserve seat")
+				mustNoError(t, repo.UpdateStatus(ctx, seat.ID, string(domain.SeatStatusSold)), "sell seat")
+
+				lapsed, err := repo.GetLapsedReservationHolds(ctx, time.Now().Add(24*time.Hour))
+				mustNoError(t, err, "get lapsed holds")
+				if len(lapsed) != 0 {
+					t.Fatalf("expected a sold seat's hold to be dropped, got %d", len(lapsed))
+				}
+			},
+		},
+		{
+			name: "delete by event removes every seat and index entry",
+			run: func(t *testing.T, ctx context.Context, repo repository.SeatRepository) {
+				eventID := uuid.New()
+				seats := []*domain.Seat{
+					newTestSeat(eventID, "A", "1", "1", 10000),
+					newTestSeat(eventID, "B", "1", "1", 10000),
+				}
+				mustNoError(t, repo.CreateBatch(ctx, seats), "create seats")
+				mustNoError(t, repo.DeleteByEventID(ctx, eventID), "delete event seats")
+
+				for _, seat := range seats {
+					if _, err := repo.GetByID(ctx, seat.ID); err == nil {
+						t.Fatalf("expected seat %s to be deleted", seat.ID)
+					}
+				}
+
+				available, err := repo.GetAvailableByEventID(ctx, eventID)
+				mustNoError(t, err, "get available seats")
+				if len(available) != 0 {
+					t.Fatalf("expected no available seats after delete, got %d", len(available))
+				}
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, testContext(t), newRepo(t))
+		})
+	}
+}
+
+// seatID extracts the ID of a seat
+func seatID(seat *domain.Seat) uuid.UUID {
+	return seat.ID
+}
+
+// assertAvailableCount fails the test unless the event has exactly want available seats
+func assertAvailableCount(t *testing.T, ctx context.Context, repo repository.SeatRepository, eventID uuid.UUID, want int) {
+	t.Helper()
+	count, err := repo.CountAvailable(ctx, eventID)
+	mustNoError(t, err, "count available")
+	if count != want {
+		t.Fatalf("expected %d available seats, got %d", want, count)
+	}
+}
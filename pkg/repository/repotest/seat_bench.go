@@ -0,0 +1,63 @@
+package repotest
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// SeatBenchmarkFactory returns a SeatRepository for a benchmark; each iteration writes a fresh event, so the store
+// may be shared
+type SeatBenchmarkFactory func(b *testing.B) repository.SeatRepository
+
+// benchmarkVenueSizes are the seat counts the creation benchmarks lay out per iteration
+var benchmarkVenueSizes = []int{100, 1000, 10000}
+
+// RunSeatCreateBenchmarks compares laying out a venue seat by seat through Create with a single CreateBatch call
+func RunSeatCreateBenchmarks(b *testing.B, newRepo SeatBenchmarkFactory) {
+	for _, size := range benchmarkVenueSizes {
+		b.Run("loop/"+strconv.Itoa(size), func(b *testing.B) {
+			repo := newRepo(b)
+			ctx := context.Background()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				seats := benchmarkVenue(size)
+				b.StartTimer()
+
+				for _, seat := range seats {
+					if err := repo.Create(ctx, seat); err != nil {
+						b.Fatalf("create seat: %v", err)
+					}
+				}
+			}
+		})
+
+		b.Run("batch/"+strconv.Itoa(size), func(b *testing.B) {
+			repo := newRepo(b)
+			ctx := context.Background()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				seats := benchmarkVenue(size)
+				b.StartTimer()
+
+				if err := repo.CreateBatch(ctx, seats); err != nil {
+					b.Fatalf("create batch: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// benchmarkVenue builds the available seats of a new event spread over ten sections
+func benchmarkVenue(size int) []*domain.Seat {
+	eventID := uuid.New()
+	seats := make([]*domain.Seat, 0, size)
+	for i := 0; i < size; i++ {
+		seats = append(seats, newTestSeat(eventID, "S"+strconv.Itoa(i%10), strconv.Itoa(i/100+1), strconv.Itoa(i%100+1), 10000))
+	}
+	return seats
+}
@@ -0,0 +1,218 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// SeatHoldRepositories are the repositories a seat hold conformance case works with.
+// Holds share their storage with Seats and Tickets, so all three must be backed by the same store.
+type SeatHoldRepositories struct {
+	Holds   repository.SeatHoldRepository
+	Seats   repository.SeatRepository
+	Tickets repository.TicketRepository
+}
+
+// SeatHoldRepositoryFactory returns fresh, empty repositories sharing one store for a single test case
+type SeatHoldRepositoryFactory func(t *testing.T) SeatHoldRepositories
+
+// seatHoldExpiry is long enough to outlast a hold created with a short TTL in the expiry cases
+const seatHoldExpiry = 150 * time.Millisecond
+
+// RunSeatHoldRepositoryTests runs the SeatHoldRepository conformance suite
+func RunSeatHoldRepositoryTests(t *testing.T, newRepos SeatHoldRepositoryFactory) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, ctx context.Context, repos SeatHoldRepositories)
+	}{
+		{
+			name: "hold takes the seat out of the available pool",
+			run: func(t *testing.T, ctx context.Context, repos SeatHoldRepositories) {
+				seat := createTestSeat(t, ctx, repos.Seats, uuid.New(), "A", "1", "1", 10000)
+				mustNoError(t, repos.Holds.Hold(ctx, seat.ID, uuid.New(), time.Minute), "hold seat")
+
+				assertSeatStatus(t, ctx, repos.Seats, seat.ID, domain.SeatStatusHeld)
+
+				available, err := repos.Seats.GetAvailableByEventID(ctx, seat.EventID)
+				mustNoError(t, err, "get available seats")
+				if containsID(available, seat.ID, seatID) {
+					t.Fatal("held seat is still listed as available")
+				}
+
+				if err := repos.Holds.Hold(ctx, seat.ID, uuid.New(), time.Minute); !errors.Is(err, repository.ErrSeatNotAvailable) {
+					t.Fatalf("expected ErrSeatNotAvailable holding a held seat, got %v", err)
+				}
+			},
+		},
+		{
+			name: "converting a live hold creates the ticket and reserves the seat",
+			run: func(t *testing.T, ctx context.Context, repos SeatHoldRepositories) {
+				seat := newTestSeat(uuid.New(), "A", "1", "1", 10000)
+				userID := uuid.New()
+				mustNoError(t, repos.Seats.Create(ctx, seat), "create seat")
+				mustNoError(t, repos.Holds.Hold(ctx, seat.ID, userID, time.Minute), "hold seat")
+
+				ticket := newTestTicket(seat.EventID, userID, &seat.ID, 15*time.Minute)
+				mustNoError(t, repos.Holds.ConvertToTicket(ctx, ticket), "convert hold")
+
+				got, err := repos.Tickets.GetByID(ctx, ticket.ID)
+				mustNoError(t, err, "get ticket")
+				if got.UserID != userID || !got.IsReserved() {
+					t.Fatalf("unexpected ticket after conversion: %+v", got)
+				}
+
+				bySeat, err := repos.Tickets.GetBySeatID(ctx, seat.ID)
+				mustNoError(t, err, "get ticket by seat")
+				if bySeat.ID != ticket.ID {
+					t.Fatalf("seat maps to ticket %s, want %s", bySeat.ID, ticket.ID)
+				}
+
+				assertSeatStatus(t, ctx, repos.Seats, seat.ID, domain.SeatStatusReserved)
+			},
+		},
+		{
+			name: "converting another user's hold fails and keeps the hold",
+			run: func(t *testing.T, ctx context.Context, repos SeatHoldRepositories) {
+				seat := createTestSeat(t, ctx, repos.Seats, uuid.New(), "A", "1", "1", 10000)
+				mustNoError(t, repos.Holds.Hold(ctx, seat.ID, uuid.New(), time.Minute), "hold seat")
+
+				ticket := newTestTicket(seat.EventID, uuid.New(), &seat.ID, 15*time.Minute)
+				if err := repos.Holds.ConvertToTicket(ctx, ticket); !errors.Is(err, repository.ErrSeatHoldNotFound) {
+					t.Fatalf("expected ErrSeatHoldNotFound converting another user's hold, got %v", err)
+				}
+
+				if _, err := repos.Tickets.GetByID(ctx, ticket.ID); err == nil {
+					t.Fatal("ticket was created from another user's hold")
+				}
+
+				assertSeatStatus(t, ctx, repos.Seats, seat.ID, domain.SeatStatusHeld)
+			},
+		},
+		{
+			name: "converting an expired hold fails and frees the seat for others",
+			run: func(t *testing.T, ctx context.Context, repos SeatHoldRepositories) {
+				seat := newTestSeat(uuid.New(), "A", "1", "1", 10000)
+				userID := uuid.New()
+				mustNoError(t, repos.Seats.Create(ctx, seat), "create seat")
+				mustNoError(t, repos.Holds.Hold(ctx, seat.ID, userID, 50*time.Millisecond), "hold seat")
+				time.Sleep(seatHoldExpiry)
+
+				ticket := newTestTicket(seat.EventID, userID, &seat.ID, 15*time.Minute)
+				if err := repos.Holds.ConvertToTicket(ctx, ticket); !errors.Is(err, repository.ErrSeatHoldNotFound) {
+					t.Fatalf("expected ErrSeatHoldNotFound converting an expired hold, got %v", err)
+				}
+
+				if _, err := repos.Tickets.GetByID(ctx, ticket.ID); err == nil {
+					t.Fatal("ticket was created from an expired hold")
+				}
+
+				assertSeatStatus(t, ctx, repos.Seats, seat.ID, domain.SeatStatusAvailable)
+				mustNoError(t, repos.Holds.Hold(ctx, seat.ID, uuid.New(), time.Minute), "hold freed seat as another user")
+			},
+		},
+		{
+			name: "release returns the seat to the available pool",
+			run: func(t *testing.T, ctx context.Context, repos SeatHoldRepositories) {
+				seat := newTestSeat(uuid.New(), "A", "1", "1", 10000)
+				userID := uuid.New()
+				mustNoError(t, repos.Seats.Create(ctx, seat), "create seat")
+				mustNoError(t, repos.Holds.Hold(ctx, seat.ID, userID, time.Minute), "hold seat")
+
+				if err := repos.Holds.Release(ctx, seat.ID, uuid.New()); !errors.Is(err, repository.ErrSeatHoldNotFound) {
+					t.Fatalf("expected ErrSeatHoldNotFound releasing another user's hold, got %v", err)
+				}
+
+				mustNoError(t, repos.Holds.Release(ctx, seat.ID, userID), "release hold")
+				assertSeatStatus(t, ctx, repos.Seats, seat.ID, domain.SeatStatusAvailable)
+
+				available, err := repos.Seats.GetAvailableByEventID(ctx, seat.EventID)
+				mustNoError(t, err, "get available seats")
+				if !containsID(available, seat.ID, seatID) {
+					t.Fatal("released seat is not listed as available")
+				}
+			},
+		},
+		{
+			name: "held seats are listed apart from the available ones until released or lapsed",
+			run: func(t *testing.T, ctx context.Context, repos SeatHoldRepositories) {
+				eventID := uuid.New()
+				held := newTestSeat(eventID, "A", "1", "1", 10000)
+				lapsing := newTestSeat(eventID, "A", "1", "2", 10000)
+				open := newTestSeat(eventID, "A", "1", "3", 10000)
+				mustNoError(t, repos.Seats.CreateBatch(ctx, []*domain.Seat{held, lapsing, open}), "create seats")
+				userID := uuid.New()
+				mustNoError(t, repos.Holds.Hold(ctx, held.ID, userID, time.Minute), "hold seat")
+				mustNoError(t, repos.Holds.Hold(ctx, lapsing.ID, uuid.New(), 50*time.Millisecond), "hold lapsing seat")
+				time.Sleep(seatHoldExpiry)
+
+				available, err := repos.Seats.GetAvailableByEventID(ctx, eventID)
+				mustNoError(t, err, "get available seats")
+				if containsID(available, held.ID, seatID) || !containsID(available, open.ID, seatID) {
+					t.Fatal("available seats should list the open seat and not the held one")
+				}
+
+				heldSeats, err := repos.Holds.GetHeldByEventID(ctx, eventID)
+				mustNoError(t, err, "get held seats")
+				if len(heldSeats) != 1 || heldSeats[0].ID != held.ID || !heldSeats[0].IsHeld() {
+					t.Fatalf("expected only the live held seat, got %+v", heldSeats)
+				}
+
+				mustNoError(t, repos.Holds.Release(ctx, held.ID, userID), "release hold")
+				heldSeats, err = repos.Holds.GetHeldByEventID(ctx, eventID)
+				mustNoError(t, err, "get held seats after release")
+				if len(heldSeats) != 0 {
+					t.Fatalf("expected no held seats after release, got %d", len(heldSeats))
+				}
+
+				other, err := repos.Holds.GetHeldByEventID(ctx, uuid.New())
+				mustNoError(t, err, "get held seats of an event without holds")
+				if other == nil || len(other) != 0 {
+					t.Fatalf("expected an empty slice for an event without holds, got %v", other)
+				}
+			},
+		},
+		{
+			name: "release expired frees lapsed holds only",
+			run: func(t *testing.T, ctx context.Context, repos SeatHoldRepositories) {
+				eventID := uuid.New()
+				lapsed := newTestSeat(eventID, "A", "1", "1", 10000)
+				live := newTestSeat(eventID, "A", "1", "2", 10000)
+				mustNoError(t, repos.Seats.CreateBatch(ctx, []*domain.Seat{lapsed, live}), "create seats")
+				mustNoError(t, repos.Holds.Hold(ctx, lapsed.ID, uuid.New(), 50*time.Millisecond), "hold lapsing seat")
+				mustNoError(t, repos.Holds.Hold(ctx, live.ID, uuid.New(), time.Minute), "hold live seat")
+				time.Sleep(seatHoldExpiry)
+
+				released, err := repos.Holds.ReleaseExpired(ctx)
+				mustNoError(t, err, "release expired holds")
+				if released != 1 {
+					t.Fatalf("expected 1 released hold, got %d", released)
+				}
+
+				assertSeatStatus(t, ctx, repos.Seats, lapsed.ID, domain.SeatStatusAvailable)
+				assertSeatStatus(t, ctx, repos.Seats, live.ID, domain.SeatStatusHeld)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, testContext(t), newRepos(t))
+		})
+	}
+}
+
+// assertSeatStatus fails the test when a seat is not in the expected status
+func assertSeatStatus(t *testing.T, ctx context.Context, seats repository.SeatRepository, id uuid.UUID, want domain.SeatStatus) {
+	t.Helper()
+	seat, err := seats.GetByID(ctx, id)
+	mustNoError(t, err, "get seat")
+	if seat.Status != string(want) {
+		t.Fatalf("seat %s status = %q, want %q", id, seat.Status, want)
+	}
+}
@@ -0,0 +1,857 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/snowmerak/ticketing/lib/domain"
+	"github.com/snowmerak/ticketing/lib/repository"
+)
+
+// TicketRepositoryFactory returns a fresh, empty TicketRepository for a single test case
+type TicketRepositoryFactory func(t *testing.T) repository.TicketRepository
+
+// RunTicketRepositoryTests runs the TicketRepository conformance suite
+func RunTicketRepositoryTests(t *testing.T, newRepo TicketRepositoryFactory) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, ctx context.Context, repo repository.TicketRepository)
+	}{
+		{
+			name: "create then get round trips the ticket",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				seat := uuid.New()
+				ticket := createTestTicket(t, ctx, repo, uuid.New(), uuid.New(), &seat, 15*time.Minute)
+
+				got, err := repo.GetByID(ctx, ticket.ID)
+				mustNoError(t, err, "get ticket")
+				if got.UserID != ticket.UserID || got.Price != ticket.Price || got.SeatID == nil || *got.SeatID != seat {
+					t.Fatalf("round trip mismatch: got %+v", got)
+				}
+			},
+		},
+		{
+			name: "get missing ticket fails",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				if _, err := repo.GetByID(ctx, uuid.New()); err == nil {
+					t.Fatal("expected error for missing ticket")
+				}
+			},
+		},
+		{
+			name: "batch get returns existing tickets in order and skips missing ones",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				eventID, userID := uuid.New(), uuid.New()
+				first := newTestTicket(eventID, userID, nil, 15*time.Minute)
+				second := newTestTicket(eventID, userID, nil, 15*time.Minute)
+				mustNoError(t, repo.Create(ctx, first), "create first ticket")
+				mustNoError(t, repo.Create(ctx, second), "create second ticket")
+				mustNoError(t, repo.ConfirmTicket(ctx, second.ID), "confirm second ticket")
+
+				tickets, err := repo.GetByIDs(ctx, []uuid.UUID{second.ID, uuid.New(), first.ID})
+				mustNoError(t, err, "batch get tickets")
+				if len(tickets) != 2 || tickets[0].ID != second.ID || tickets[1].ID != first.ID {
+					t.Fatalf("expected [second, first], got %+v", tickets)
+				}
+				if !tickets[0].IsConfirmed() || !tickets[1].IsReserved() {
+					t.Fatalf("expected current statuses, got %s and %s", tickets[0].Status, tickets[1].Status)
+				}
+
+				empty, err := repo.GetByIDs(ctx, nil)
+				mustNoError(t, err, "batch get no tickets")
+				if len(empty) != 0 {
+					t.Fatalf("expected no tickets, got %d", len(empty))
+				}
+			},
+		},
+		{
+			name: "user, event and seat indexes resolve the ticket",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				eventID, userID, seat := uuid.New(), uuid.New(), uuid.New()
+				ticket := createTestTicket(t, ctx, repo, eventID, userID, &seat, 15*time.Minute)
+
+				byUser, err := repo.GetByUserID(ctx, userID)
+				mustNoError(t, err, "get by user")
+				if !containsID(byUser, ticket.ID, ticketID) {
+					t.Fatal("ticket missing from user index")
+				}
+
+				byEvent, err := repo.GetByEventID(ctx, eventID)
+				mustNoError(t, err, "get by event")
+				if !containsID(byEvent, ticket.ID, ticketID) {
+					t.Fatal("ticket missing from event index")
+				}
+
+				bySeat, err := repo.GetBySeatID(ctx, seat)
+				mustNoError(t, err, "get by seat")
+				if bySeat.ID != ticket.ID {
+					t.Fatalf("seat index resolved %s, want %s", bySeat.ID, ticket.ID)
+				}
+			},
+		},
+		{
+			name: "event tickets page in a stable order with the event total",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				eventID := uuid.New()
+				for i := 0; i < 5; i++ {
+					mustNoError(t, repo.Create(ctx, newTestTicket(eventID, uuid.New(), nil, 15*time.Minute)), "create ticket")
+				}
+				mustNoError(t, repo.Create(ctx, newTestTicket(uuid.New(), uuid.New(), nil, 15*time.Minute)), "create ticket of another event")
+
+				seen := make(map[uuid.UUID]bool)
+				for offset := 0; offset < 5; offset += 2 {
+					page, total, err := repo.GetByEventIDPaginated(ctx, eventID, offset, 2)
+					mustNoError(t, err, "get event tickets page")
+					if total != 5 {
+						t.Fatalf("expected total of 5, got %d", total)
+					}
+					if want := min(2, 5-offset); len(page) != want {
+						t.Fatalf("expected %d tickets at offset %d, got %d", want, offset, len(page))
+					}
+					for _, ticket := range page {
+						if ticket.EventID != eventID {
+							t.Fatalf("ticket of another event %s returned", ticket.EventID)
+						}
+						if seen[ticket.ID] {
+							t.Fatalf("ticket %s returned on two pages", ticket.ID)
+						}
+						seen[ticket.ID] = true
+					}
+				}
+				if len(seen) != 5 {
+					t.Fatalf("expected pages to cover 5 tickets, got %d", len(seen))
+				}
+
+				for _, offset := range []int{5, 6, 100} {
+					page, total, err := repo.GetByEventIDPaginated(ctx, eventID, offset, 2)
+					mustNoError(t, err, "get page past the end")
+					if page == nil || len(page) != 0 || total != 5 {
+						t.Fatalf("expected an empty page and total of 5 at offset %d, got %d tickets and total %d", offset, len(page), total)
+					}
+				}
+
+				page, total, err := repo.GetByEventIDPaginated(ctx, uuid.New(), 0, 10)
+				mustNoError(t, err, "get page of an event without tickets")
+				if page == nil || len(page) != 0 || total != 0 {
+					t.Fatalf("expected an empty page and total of 0, got %d tickets and total %d", len(page), total)
+				}
+			},
+		},
+		{
+			name: "status index moves a ticket from reserved to confirmed",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				eventID := uuid.New()
+				ticket := newTestTicket(eventID, uuid.New(), nil, 15*time.Minute)
+				other := newTestTicket(eventID, uuid.New(), nil, 15*time.Minute)
+				mustNoError(t, repo.Create(ctx, ticket), "create ticket")
+				mustNoError(t, repo.Create(ctx, other), "create other ticket")
+
+				reserved, err := repo.GetByEventIDAndStatus(ctx, eventID, string(domain.TicketStatusReserved))
+				mustNoError(t, err, "get reserved tickets")
+				if len(reserved) != 2 || !containsID(reserved, ticket.ID, ticketID) {
+					t.Fatalf("expected both tickets reserved, got %d", len(reserved))
+				}
+
+				mustNoError(t, repo.UpdateStatus(ctx, ticket.ID, string(domain.TicketStatusConfirmed)), "confirm ticket")
+
+				reserved, err = repo.GetByEventIDAndStatus(ctx, eventID, string(domain.TicketStatusReserved))
+				mustNoError(t, err, "get reserved tickets after confirming")
+				if len(reserved) != 1 || containsID(reserved, ticket.ID, ticketID) {
+					t.Fatal("confirmed ticket is still in the reserved set")
+				}
+
+				confirmed, err := repo.GetByEventIDAndStatus(ctx, eventID, string(domain.TicketStatusConfirmed))
+				mustNoError(t, err, "get confirmed tickets")
+				if len(confirmed) != 1 || confirmed[0].ID != ticket.ID || !confirmed[0].IsConfirmed() {
+					t.Fatalf("expected only the confirmed ticket in the confirmed set, got %d", len(confirmed))
+				}
+
+				mustNoError(t, repo.Delete(ctx, ticket.ID), "delete ticket")
+				confirmed, err = repo.GetByEventIDAndStatus(ctx, eventID, string(domain.TicketStatusConfirmed))
+				mustNoError(t, err, "get confirmed tickets after delete")
+				if len(confirmed) != 0 {
+					t.Fatal("deleted ticket is still in the confirmed set")
+				}
+
+				none, err := repo.GetByEventIDAndStatus(ctx, uuid.New(), string(domain.TicketStatusConfirmed))
+				mustNoError(t, err, "get tickets of an event without tickets")
+				if none == nil || len(none) != 0 {
+					t.Fatalf("expected an empty slice for an event without tickets, got %v", none)
+				}
+			},
+		},
+		{
+			name: "tickets in one status page in a stable order with the status total",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				eventID := uuid.New()
+				var confirmedID uuid.UUID
+				for i := 0; i < 6; i++ {
+					ticket := newTestTicket(eventID, uuid.New(), nil, 15*time.Minute)
+					mustNoError(t, repo.Create(ctx, ticket), "create ticket")
+					confirmedID = ticket.ID
+				}
+				mustNoError(t, repo.UpdateStatus(ctx, confirmedID, string(domain.TicketStatusConfirmed)), "confirm ticket")
+
+				reserved := string(domain.TicketStatusReserved)
+				seen := make(map[uuid.UUID]bool)
+				for offset := 0; offset < 5; offset += 2 {
+					page, total, err := repo.GetByEventIDAndStatusPaginated(ctx, eventID, reserved, offset, 2)
+					mustNoError(t, err, "get reserved tickets page")
+					if total != 5 {
+						t.Fatalf("expected total of 5, got %d", total)
+					}
+					if want := min(2, 5-offset); len(page) != want {
+						t.Fatalf("expected %d tickets at offset %d, got %d", want, offset, len(page))
+					}
+					for _, ticket := range page {
+						if !ticket.IsReserved() || ticket.EventID != eventID {
+							t.Fatalf("ticket %s of event %s in status %s returned", ticket.ID, ticket.EventID, ticket.Status)
+						}
+						if seen[ticket.ID] {
+							t.Fatalf("ticket %s returned on two pages", ticket.ID)
+						}
+						seen[ticket.ID] = true
+					}
+				}
+				if len(seen) != 5 {
+					t.Fatalf("expected pages to cover 5 tickets, got %d", len(seen))
+				}
+
+				page, total, err := repo.GetByEventIDAndStatusPaginated(ctx, eventID, reserved, 5, 2)
+				mustNoError(t, err, "get page past the end")
+				if page == nil || len(page) != 0 || total != 5 {
+					t.Fatalf("expected an empty page and total of 5, got %d tickets and total %d", len(page), total)
+				}
+			},
+		},
+		{
+			name: "user event count stops at the limit and frees slots on decrement",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				eventID, userID := uuid.New(), uuid.New()
+
+				count, err := repo.IncrementUserEventCount(ctx, eventID, userID, 2, 3)
+				mustNoError(t, err, "count two tickets")
+				if count != 2 {
+					t.Fatalf("expected count 2, got %d", count)
+				}
+
+				if _, err := repo.IncrementUserEventCount(ctx, eventID, userID, 2, 3); !errors.Is(err, repository.ErrUserTicketLimitReached) {
+					t.Fatalf("expected ErrUserTicketLimitReached past the limit, got %v", err)
+				}
+
+				count, err = repo.IncrementUserEventCount(ctx, eventID, userID, 1, 3)
+				mustNoError(t, err, "count up to the limit")
+				if count != 3 {
+					t.Fatalf("expected a rejected increment to count nothing, got %d", count)
+				}
+
+				mustNoError(t, repo.DecrementUserEventCount(ctx, eventID, userID, 1), "free one slot")
+				count, err = repo.IncrementUserEventCount(ctx, eventID, userID, 1, 3)
+				mustNoError(t, err, "reuse the freed slot")
+				if count != 3 {
+					t.Fatalf("expected count 3 after reusing the slot, got %d", count)
+				}
+
+				mustNoError(t, repo.DecrementUserEventCount(ctx, eventID, userID, 10), "decrement past zero")
+				count, err = repo.IncrementUserEventCount(ctx, eventID, userID, 1, 0)
+				mustNoError(t, err, "count without a limit")
+				if count != 1 {
+					t.Fatalf("expected the count to floor at zero, got %d", count-1)
+				}
+
+				other, err := repo.IncrementUserEventCount(ctx, uuid.New(), userID, 1, 1)
+				mustNoError(t, err, "count for another event")
+				if other != 1 {
+					t.Fatalf("expected counts to be kept per event, got %d", other)
+				}
+			},
+		},
+		{
+			name: "delete removes ticket from every index",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				eventID, userID, seat := uuid.New(), uuid.New(), uuid.New()
+				ticket := createTestTicket(t, ctx, repo, eventID, userID, &seat, 15*time.Minute)
+				mustNoError(t, repo.Delete(ctx, ticket.ID), "delete ticket")
+
+				if _, err := repo.GetByID(ctx, ticket.ID); err == nil {
+					t.Fatal("expected deleted ticket to be missing")
+				}
+
+				byUser, err := repo.GetByUserID(ctx, userID)
+				mustNoError(t, err, "get by user")
+				if containsID(byUser, ticket.ID, ticketID) {
+					t.Fatal("deleted ticket still present in user index")
+				}
+
+				byEvent, err := repo.GetByEventID(ctx, eventID)
+				mustNoError(t, err, "get by event")
+				if containsID(byEvent, ticket.ID, ticketID) {
+					t.Fatal("deleted ticket still present in event index")
+				}
+
+				if _, err := repo.GetBySeatID(ctx, seat); err == nil {
+					t.Fatal("deleted ticket still resolvable by seat")
+				}
+			},
+		},
+		{
+			name: "confirm and cancel transition status",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				confirmed := newTestTicket(uuid.New(), uuid.New(), nil, 15*time.Minute)
+				cancelled := newTestTicket(uuid.New(), uuid.New(), nil, 15*time.Minute)
+				mustNoError(t, repo.Create(ctx, confirmed), "create ticket")
+				mustNoError(t, repo.Create(ctx, cancelled), "create ticket")
+
+				mustNoError(t, repo.ConfirmTicket(ctx, confirmed.ID), "confirm ticket")
+				mustNoError(t, repo.CancelTicket(ctx, cancelled.ID), "cancel ticket")
+
+				got, err := repo.GetByID(ctx, confirmed.ID)
+				mustNoError(t, err, "get confirmed ticket")
+				if !got.IsConfirmed() {
+					t.Fatalf("expected confirmed status, got %s", got.Status)
+				}
+				if got.ConfirmedAt == nil || got.CancelledAt != nil {
+					t.Fatalf("expected only confirmed_at to be set, got confirmed=%v cancelled=%v", got.ConfirmedAt, got.CancelledAt)
+				}
+
+				got, err = repo.GetByID(ctx, cancelled.ID)
+				mustNoError(t, err, "get cancelled ticket")
+				if !got.IsCancelled() {
+					t.Fatalf("expected cancelled status, got %s", got.Status)
+				}
+				if got.CancelledAt == nil || got.ConfirmedAt != nil {
+					t.Fatalf("expected only cancelled_at to be set, got confirmed=%v cancelled=%v", got.ConfirmedAt, got.CancelledAt)
+				}
+			},
+		},
+		{
+			name: "cancel reservation only cancels a ticket that is still reserved",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				eventID, seat := uuid.New(), uuid.New()
+				reserved := createTestTicket(t, ctx, repo, eventID, uuid.New(), &seat, 15*time.Minute)
+				confirmed := createTestTicket(t, ctx, repo, eventID, uuid.New(), nil, 15*time.Minute)
+				mustNoError(t, repo.ConfirmTicket(ctx, confirmed.ID), "confirm ticket")
+
+				cancelled, err := repo.CancelReservation(ctx, reserved.ID)
+				mustNoError(t, err, "cancel reservation")
+				if !cancelled.IsCancelled() || cancelled.CancelledAt == nil || cancelled.SeatID == nil || *cancelled.SeatID != seat {
+					t.Fatalf("expected the cancelled ticket with its seat back, got status %s seat %v", cancelled.Status, cancelled.SeatID)
+				}
+				if _, err := repo.GetBySeatID(ctx, seat); err == nil {
+					t.Fatal("cancelled reservation still holds its seat mapping")
+				}
+
+				if _, err := repo.CancelReservation(ctx, reserved.ID); !errors.Is(err, repository.ErrTicketNotReserved) {
+					t.Fatalf("expected ErrTicketNotReserved cancelling a cancelled reservation, got %v", err)
+				}
+				if _, err := repo.CancelReservation(ctx, confirmed.ID); !errors.Is(err, repository.ErrTicketNotReserved) {
+					t.Fatalf("expected ErrTicketNotReserved cancelling a confirmed ticket, got %v", err)
+				}
+
+				got, err := repo.GetByID(ctx, confirmed.ID)
+				mustNoError(t, err, "get confirmed ticket")
+				if !got.IsConfirmed() {
+					t.Fatalf("confirmed ticket changed to %s", got.Status)
+				}
+
+				cancelledIDs, err := repo.GetByEventIDAndStatus(ctx, eventID, string(domain.TicketStatusCancelled))
+				mustNoError(t, err, "get cancelled tickets")
+				reservedIDs, err := repo.GetByEventIDAndStatus(ctx, eventID, string(domain.TicketStatusReserved))
+				mustNoError(t, err, "get reserved tickets")
+				if !containsID(cancelledIDs, reserved.ID, ticketID) || containsID(reservedIDs, reserved.ID, ticketID) {
+					t.Fatal("status index did not move the cancelled reservation")
+				}
+			},
+		},
+		{
+			name: "confirm reservation only confirms a ticket that is still reserved",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				eventID := uuid.New()
+				reserved := createTestTicket(t, ctx, repo, eventID, uuid.New(), nil, 15*time.Minute)
+				cancelled := createTestTicket(t, ctx, repo, eventID, uuid.New(), nil, 15*time.Minute)
+				mustNoError(t, repo.CancelTicket(ctx, cancelled.ID), "cancel ticket")
+				at := time.Now().Add(time.Minute).Truncate(time.Millisecond)
+
+				confirmed, err := repo.ConfirmReservation(ctx, reserved.ID, "token-1", at)
+				mustNoError(t, err, "confirm reservation")
+				if !confirmed.IsConfirmed() || confirmed.AccessToken != "token-1" || confirmed.ConfirmedAt == nil || !confirmed.ConfirmedAt.Equal(at) {
+					t.Fatalf("expected a confirmed ticket with token-1 at %v, got status %s token %q at %v", at, confirmed.Status, confirmed.AccessToken, confirmed.ConfirmedAt)
+				}
+
+				got, err := repo.GetByID(ctx, reserved.ID)
+				mustNoError(t, err, "get confirmed ticket")
+				if !got.IsConfirmed() || got.AccessToken != "token-1" {
+					t.Fatalf("confirmation was not stored, got status %s token %q", got.Status, got.AccessToken)
+				}
+
+				if _, err := repo.ConfirmReservation(ctx, reserved.ID, "token-2", at); !errors.Is(err, repository.ErrTicketNotReserved) {
+					t.Fatalf("expected ErrTicketNotReserved confirming a confirmed ticket, got %v", err)
+				}
+				if _, err := repo.ConfirmReservation(ctx, cancelled.ID, "token-3", at); !errors.Is(err, repository.ErrTicketNotReserved) {
+					t.Fatalf("expected ErrTicketNotReserved confirming a cancelled ticket, got %v", err)
+				}
+
+				got, err = repo.GetByID(ctx, reserved.ID)
+				mustNoError(t, err, "get confirmed ticket")
+				if got.AccessToken != "token-1" {
+					t.Fatalf("a refused confirmation replaced the access token with %q", got.AccessToken)
+				}
+
+				confirmedIDs, err := repo.GetByEventIDAndStatus(ctx, eventID, string(domain.TicketStatusConfirmed))
+				mustNoError(t, err, "get confirmed tickets")
+				reservedIDs, err := repo.GetByEventIDAndStatus(ctx, eventID, string(domain.TicketStatusReserved))
+				mustNoError(t, err, "get reserved tickets")
+				if !containsID(confirmedIDs, reserved.ID, ticketID) || containsID(reservedIDs, reserved.ID, ticketID) {
+					t.Fatal("status index did not move the confirmed reservation")
+				}
+
+				expiring, err := repo.GetReservationsExpiringBy(ctx, time.Now().Add(time.Hour))
+				mustNoError(t, err, "get expiring reservations")
+				if containsID(expiring, reserved.ID, ticketID) {
+					t.Fatal("confirmed ticket is still listed as an expiring reservation")
+				}
+			},
+		},
+		{
+			name: "a confirmation racing a reservation cancel has exactly one winner",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				const rounds = 20
+				for i := 0; i < rounds; i++ {
+					ticket := createTestTicket(t, ctx, repo, uuid.New(), uuid.New(), nil, 15*time.Minute)
+
+					var wg sync.WaitGroup
+					var confirmErr, cancelErr error
+					wg.Add(2)
+					go func() {
+						defer wg.Done()
+						_, confirmErr = repo.ConfirmReservation(ctx, ticket.ID, "token", time.Now())
+					}()
+					go func() {
+						defer wg.Done()
+						_, cancelErr = repo.CancelReservation(ctx, ticket.ID)
+					}()
+					wg.Wait()
+
+					if (confirmErr == nil) == (cancelErr == nil) {
+						t.Fatalf("expected exactly one of confirm and cancel to win, got confirm=%v cancel=%v", confirmErr, cancelErr)
+					}
+					for _, err := range []error{confirmErr, cancelErr} {
+						if err != nil && !errors.Is(err, repository.ErrTicketNotReserved) {
+							t.Fatalf("expected the loser to see ErrTicketNotReserved, got %v", err)
+						}
+					}
+
+					got, err := repo.GetByID(ctx, ticket.ID)
+					mustNoError(t, err, "get ticket")
+					if confirmErr == nil && !got.IsConfirmed() || cancelErr == nil && !got.IsCancelled() {
+						t.Fatalf("stored status %s does not match the winner", got.Status)
+					}
+				}
+			},
+		},
+		{
+			name: "check in admits a confirmed ticket once",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				reserved := createTestTicket(t, ctx, repo, uuid.New(), uuid.New(), nil, 15*time.Minute)
+				if _, err := repo.CheckIn(ctx, reserved.ID, time.Now()); !errors.Is(err, repository.ErrTicketNotConfirmed) {
+					t.Fatalf("expected ErrTicketNotConfirmed checking in a reservation, got %v", err)
+				}
+
+				ticket := createTestTicket(t, ctx, repo, uuid.New(), uuid.New(), nil, 15*time.Minute)
+				_, err := repo.ConfirmReservation(ctx, ticket.ID, "token", time.Now())
+				mustNoError(t, err, "confirm reservation")
+
+				const gates = 10
+				at := time.Now().Truncate(time.Millisecond)
+				var wg sync.WaitGroup
+				var mu sync.Mutex
+				admitted := 0
+				for i := 0; i < gates; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						_, err := repo.CheckIn(ctx, ticket.ID, at)
+						if err == nil {
+							mu.Lock()
+							admitted++
+							mu.Unlock()
+						} else if !errors.Is(err, repository.ErrTicketAlreadyCheckedIn) {
+							t.Errorf("check in: %v", err)
+						}
+					}()
+				}
+				wg.Wait()
+
+				if admitted != 1 {
+					t.Fatalf("expected exactly one gate to admit the ticket, got %d", admitted)
+				}
+
+				got, err := repo.GetByID(ctx, ticket.ID)
+				mustNoError(t, err, "get checked in ticket")
+				if got.CheckedInAt == nil || !got.CheckedInAt.Equal(at) {
+					t.Fatalf("expected checked_in_at %v, got %v", at, got.CheckedInAt)
+				}
+			},
+		},
+		{
+			name: "cancel refuses a ticket that is already cancelled",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				ticket := createTestTicket(t, ctx, repo, uuid.New(), uuid.New(), nil, 15*time.Minute)
+				mustNoError(t, repo.ConfirmTicket(ctx, ticket.ID), "confirm ticket")
+				mustNoError(t, repo.CancelTicket(ctx, ticket.ID), "cancel ticket")
+
+				if err := repo.CancelTicket(ctx, ticket.ID); !errors.Is(err, repository.ErrTicketAlreadyCancelled) {
+					t.Fatalf("expected ErrTicketAlreadyCancelled, got %v", err)
+				}
+			},
+		},
+		{
+			name: "concurrent reservation cancels have exactly one winner",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				const workers = 20
+				ticket := createTestTicket(t, ctx, repo, uuid.New(), uuid.New(), nil, 15*time.Minute)
+
+				var wg sync.WaitGroup
+				var mu sync.Mutex
+				won := 0
+				for i := 0; i < workers; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						_, err := repo.CancelReservation(ctx, ticket.ID)
+						if err == nil {
+							mu.Lock()
+							won++
+							mu.Unlock()
+						} else if !errors.Is(err, repository.ErrTicketNotReserved) {
+							t.Errorf("cancel reservation: %v", err)
+						}
+					}()
+				}
+				wg.Wait()
+
+				if won != 1 {
+					t.Fatalf("expected exactly one cancel to win, got %d", won)
+				}
+			},
+		},
+		{
+			name: "a seat maps to one ticket until that ticket is cancelled",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				eventID, seat := uuid.New(), uuid.New()
+				first := newTestTicket(eventID, uuid.New(), &seat, 15*time.Minute)
+				second := newTestTicket(eventID, uuid.New(), &seat, 15*time.Minute)
+				mustNoError(t, repo.Create(ctx, first), "create first ticket")
+
+				if err := repo.Create(ctx, second); !errors.Is(err, repository.ErrSeatAlreadyTicketed) {
+					t.Fatalf("expected ErrSeatAlreadyTicketed for second ticket, got %v", err)
+				}
+
+				bySeat, err := repo.GetBySeatID(ctx, seat)
+				mustNoError(t, err, "get by seat")
+				if bySeat.ID != first.ID {
+					t.Fatalf("seat mapping was overwritten: got %s, want %s", bySeat.ID, first.ID)
+				}
+
+				mustNoError(t, repo.CancelTicket(ctx, first.ID), "cancel first ticket")
+				mustNoError(t, repo.Create(ctx, second), "create second ticket after cancel")
+
+				bySeat, err = repo.GetBySeatID(ctx, seat)
+				mustNoError(t, err, "get by seat")
+				if bySeat.ID != second.ID {
+					t.Fatalf("expected seat to map to %s after reuse, got %s", second.ID, bySeat.ID)
+				}
+			},
+		},
+		{
+			name: "transfer moves the ticket to its new holder",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				seller, buyer := uuid.New(), uuid.New()
+				ticket := newTestTicket(uuid.New(), seller, nil, 15*time.Minute)
+				ticket.AccessToken = "seller-token"
+				mustNoError(t, repo.Create(ctx, ticket), "create ticket")
+
+				if err := repo.Transfer(ctx, ticket.ID, buyer, seller, "stolen-token"); !errors.Is(err, repository.ErrTicketOwnerMismatch) {
+					t.Fatalf("expected ErrTicketOwnerMismatch from a non-holder, got %v", err)
+				}
+
+				mustNoError(t, repo.Transfer(ctx, ticket.ID, seller, buyer, "buyer-token"), "transfer ticket")
+
+				got, err := repo.GetByID(ctx, ticket.ID)
+				mustNoError(t, err, "get ticket")
+				if got.UserID != buyer || got.AccessToken != "buyer-token" {
+					t.Fatalf("expected ticket held by the buyer with a new access token, got %+v", got)
+				}
+
+				sellerTickets, err := repo.GetByUserID(ctx, seller)
+				mustNoError(t, err, "get seller tickets")
+				buyerTickets, err := repo.GetByUserID(ctx, buyer)
+				mustNoError(t, err, "get buyer tickets")
+				if containsID(sellerTickets, ticket.ID, ticketID) || !containsID(buyerTickets, ticket.ID, ticketID) {
+					t.Fatal("expected the ticket to move from the seller's index to the buyer's")
+				}
+			},
+		},
+		{
+			name: "user and event index returns only matching tickets and follows transfers and deletes",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				userID, otherUser := uuid.New(), uuid.New()
+				eventID, otherEvent := uuid.New(), uuid.New()
+
+				first := createTestTicket(t, ctx, repo, eventID, userID, nil, 15*time.Minute)
+				second := createTestTicket(t, ctx, repo, eventID, userID, nil, 15*time.Minute)
+				mustNoError(t, repo.Create(ctx, newTestTicket(otherEvent, userID, nil, 15*time.Minute)), "create other event's ticket")
+				mustNoError(t, repo.Create(ctx, newTestTicket(eventID, otherUser, nil, 15*time.Minute)), "create other user's ticket")
+
+				tickets, err := repo.GetByUserAndEvent(ctx, userID, eventID)
+				mustNoError(t, err, "get by user and event")
+				if len(tickets) != 2 || !containsID(tickets, first.ID, ticketID) || !containsID(tickets, second.ID, ticketID) {
+					t.Fatalf("expected only the user's two tickets for the event, got %d", len(tickets))
+				}
+
+				mustNoError(t, repo.Delete(ctx, first.ID), "delete first ticket")
+				tickets, err = repo.GetByUserAndEvent(ctx, userID, eventID)
+				mustNoError(t, err, "get by user and event after delete")
+				if len(tickets) != 1 || !containsID(tickets, second.ID, ticketID) {
+					t.Fatalf("expected the deleted ticket to leave the index, got %d tickets", len(tickets))
+				}
+
+				mustNoError(t, repo.Transfer(ctx, second.ID, userID, otherUser, "new-token"), "transfer second ticket")
+				tickets, err = repo.GetByUserAndEvent(ctx, userID, eventID)
+				mustNoError(t, err, "get sender's tickets after transfer")
+				if tickets == nil || len(tickets) != 0 {
+					t.Fatalf("expected an empty, non-nil slice once the user holds nothing, got %v", tickets)
+				}
+				tickets, err = repo.GetByUserAndEvent(ctx, otherUser, eventID)
+				mustNoError(t, err, "get recipient's tickets after transfer")
+				if len(tickets) != 2 || !containsID(tickets, second.ID, ticketID) {
+					t.Fatalf("expected the transferred ticket in the recipient's index, got %d tickets", len(tickets))
+				}
+			},
+		},
+		{
+			name: "a guest's email round trips and is dropped on transfer",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				guest := domain.GuestUserID("guest@example.com")
+				ticket := newTestTicket(uuid.New(), guest, nil, 15*time.Minute)
+				ticket.GuestEmail = "guest@example.com"
+				mustNoError(t, repo.Create(ctx, ticket), "create guest ticket")
+
+				got, err := repo.GetByID(ctx, ticket.ID)
+				mustNoError(t, err, "get guest ticket")
+				if got.UserID != guest || got.GuestEmail != "guest@example.com" {
+					t.Fatalf("expected ticket held by guest %s with its email, got %+v", guest, got)
+				}
+
+				buyer := uuid.New()
+				mustNoError(t, repo.Transfer(ctx, ticket.ID, guest, buyer, "buyer-token"), "transfer guest ticket")
+
+				got, err = repo.GetByID(ctx, ticket.ID)
+				mustNoError(t, err, "get transferred ticket")
+				if got.UserID != buyer || got.GuestEmail != "" {
+					t.Fatalf("expected ticket held by the buyer without the guest's email, got %+v", got)
+				}
+			},
+		},
+		{
+			name: "update status on missing ticket fails",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				if err := repo.UpdateStatus(ctx, uuid.New(), string(domain.TicketStatusConfirmed)); err == nil {
+					t.Fatal("expected error for missing ticket")
+				}
+			},
+		},
+		{
+			name: "expired reservations only include lapsed reserved tickets",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				expired := newTestTicket(uuid.New(), uuid.New(), nil, -1*time.Second)
+				live := newTestTicket(uuid.New(), uuid.New(), nil, 15*time.Minute)
+				mustNoError(t, repo.Create(ctx, expired), "create expired ticket")
+				mustNoError(t, repo.Create(ctx, live), "create live ticket")
+
+				got, err := repo.GetExpiredReservations(ctx, time.Now())
+				mustNoError(t, err, "get expired reservations")
+				if !containsID(got, expired.ID, ticketID) {
+					t.Fatal("expired reservation missing")
+				}
+				if containsID(got, live.ID, ticketID) {
+					t.Fatal("live reservation reported as expired")
+				}
+			},
+		},
+		{
+			name: "expired reservations are judged as of the given time",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				eventID := uuid.New()
+				ticket := createTestTicket(t, ctx, repo, eventID, uuid.New(), nil, 15*time.Minute)
+
+				got, err := repo.GetExpiredReservations(ctx, time.Now())
+				mustNoError(t, err, "get expired reservations now")
+				if containsID(got, ticket.ID, ticketID) {
+					t.Fatal("live reservation reported as expired")
+				}
+
+				got, err = repo.GetExpiredReservations(ctx, time.Now().Add(time.Hour))
+				mustNoError(t, err, "get expired reservations an hour on")
+				if !containsID(got, ticket.ID, ticketID) {
+					t.Fatal("reservation not reported as expired an hour after it lapses")
+				}
+			},
+		},
+		{
+			name: "expired reservations are found across multiple hours",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				eventID := uuid.New()
+				var lapsed []*domain.Ticket
+				for _, age := range []time.Duration{3 * time.Hour, 90 * time.Minute, 59 * time.Minute, time.Second} {
+					ticket := createTestTicket(t, ctx, repo, eventID, uuid.New(), nil, -age)
+					lapsed = append(lapsed, ticket)
+				}
+
+				live := createTestTicket(t, ctx, repo, eventID, uuid.New(), nil, 2*time.Hour)
+				confirmed := createTestTicket(t, ctx, repo, eventID, uuid.New(), nil, -2*time.Hour)
+				mustNoError(t, repo.ConfirmTicket(ctx, confirmed.ID), "confirm ticket")
+				cancelled := createTestTicket(t, ctx, repo, eventID, uuid.New(), nil, -2*time.Hour)
+				mustNoError(t, repo.CancelTicket(ctx, cancelled.ID), "cancel ticket")
+				deleted := createTestTicket(t, ctx, repo, eventID, uuid.New(), nil, -2*time.Hour)
+				mustNoError(t, repo.Delete(ctx, deleted.ID), "delete ticket")
+
+				got, err := repo.GetExpiredReservations(ctx, time.Now())
+				mustNoError(t, err, "get expired reservations")
+				for _, ticket := range lapsed {
+					if !containsID(got, ticket.ID, ticketID) {
+						t.Fatalf("reservation lapsed %s ago missing", time.Since(*ticket.ExpiresAt).Round(time.Minute))
+					}
+				}
+				for _, ticket := range []*domain.Ticket{live, confirmed, cancelled, deleted} {
+					if containsID(got, ticket.ID, ticketID) {
+						t.Fatalf("ticket %s in status %s reported as an expired reservation", ticket.ID, ticket.Status)
+					}
+				}
+			},
+		},
+		{
+			name: "reservations expiring by a time include only reserved tickets lapsing by then",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				eventID := uuid.New()
+				lapsed := createTestTicket(t, ctx, repo, eventID, uuid.New(), nil, -time.Minute)
+				soon := createTestTicket(t, ctx, repo, eventID, uuid.New(), nil, 2*time.Minute)
+				later := createTestTicket(t, ctx, repo, eventID, uuid.New(), nil, time.Hour)
+				confirmed := createTestTicket(t, ctx, repo, eventID, uuid.New(), nil, 2*time.Minute)
+				mustNoError(t, repo.ConfirmTicket(ctx, confirmed.ID), "confirm ticket")
+
+				got, err := repo.GetReservationsExpiringBy(ctx, time.Now().Add(5*time.Minute))
+				mustNoError(t, err, "get reservations expiring soon")
+				for _, ticket := range []*domain.Ticket{lapsed, soon} {
+					if !containsID(got, ticket.ID, ticketID) {
+						t.Fatalf("reservation expiring at %s missing", ticket.ExpiresAt)
+					}
+				}
+				for _, ticket := range []*domain.Ticket{later, confirmed} {
+					if containsID(got, ticket.ID, ticketID) {
+						t.Fatalf("ticket %s in status %s expiring at %s listed", ticket.ID, ticket.Status, ticket.ExpiresAt)
+					}
+				}
+			},
+		},
+		{
+			name: "expiry notice is marked once per reservation",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				ticketID := uuid.New()
+
+				first, err := repo.MarkExpiryNotice(ctx, ticketID, time.Minute)
+				mustNoError(t, err, "mark expiry notice")
+				if !first {
+					t.Fatal("expected the first mark to be new")
+				}
+
+				again, err := repo.MarkExpiryNotice(ctx, ticketID, time.Minute)
+				mustNoError(t, err, "mark expiry notice again")
+				if again {
+					t.Fatal("expected a second mark of the same reservation to be refused")
+				}
+
+				other, err := repo.MarkExpiryNotice(ctx, uuid.New(), time.Minute)
+				mustNoError(t, err, "mark expiry notice of another reservation")
+				if !other {
+					t.Fatal("expected another reservation's mark to be new")
+				}
+			},
+		},
+		{
+			name: "confirmation tokens are single use and expire",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				ticketID := uuid.New()
+				mustNoError(t, repo.SaveConfirmationToken(ctx, "valid-hash", ticketID, time.Minute), "save token")
+
+				got, err := repo.ConsumeConfirmationToken(ctx, "valid-hash")
+				mustNoError(t, err, "consume token")
+				if got != ticketID {
+					t.Fatalf("expected ticket %s, got %s", ticketID, got)
+				}
+
+				if _, err := repo.ConsumeConfirmationToken(ctx, "valid-hash"); !errors.Is(err, repository.ErrConfirmationTokenNotFound) {
+					t.Fatalf("expected ErrConfirmationTokenNotFound on reuse, got %v", err)
+				}
+
+				mustNoError(t, repo.SaveConfirmationToken(ctx, "short-hash", ticketID, 50*time.Millisecond), "save short token")
+				time.Sleep(100 * time.Millisecond)
+				if _, err := repo.ConsumeConfirmationToken(ctx, "short-hash"); !errors.Is(err, repository.ErrConfirmationTokenNotFound) {
+					t.Fatalf("expected ErrConfirmationTokenNotFound after expiry, got %v", err)
+				}
+			},
+		},
+		{
+			name: "GA numbers are sequential and gapless under concurrency",
+			run: func(t *testing.T, ctx context.Context, repo repository.TicketRepository) {
+				const workers = 50
+				eventID := uuid.New()
+
+				var wg sync.WaitGroup
+				numbers := make(chan int64, workers)
+				for i := 0; i < workers; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						number, err := repo.NextGANumber(ctx, eventID)
+						if err != nil {
+							t.Errorf("next GA number: %v", err)
+							return
+						}
+						numbers <- number
+					}()
+				}
+				wg.Wait()
+				close(numbers)
+
+				seen := make(map[int64]bool)
+				for number := range numbers {
+					if seen[number] {
+						t.Fatalf("GA number %d issued twice", number)
+					}
+					seen[number] = true
+				}
+				for i := int64(1); i <= workers; i++ {
+					if !seen[i] {
+						t.Fatalf("GA number %d was skipped", i)
+					}
+				}
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, testContext(t), newRepo(t))
+		})
+	}
+}
+
+// ticketID extracts the ID of a ticket
+func ticketID(ticket *domain.Ticket) uuid.UUID {
+	return ticket.ID
+}
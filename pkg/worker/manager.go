@@ -0,0 +1,105 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+// ErrManagerStarted is returned when workers are registered or started on a manager that is already running
+var ErrManagerStarted = errors.New("worker manager is already started")
+
+// Manager runs background workers together and stops them together: StopAll cancels every worker's context
+// and waits for all of them to return, so graceful shutdown never cuts a cycle short.
+type Manager struct {
+	logger adapter.Logger
+
+	mu      sync.Mutex
+	workers []adapter.Worker
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewManager creates a new Manager
+func NewManager(logger adapter.Logger) *Manager {
+	return &Manager{
+		logger: logger,
+	}
+}
+
+// Register adds a worker to be run by StartAll
+func (m *Manager) Register(worker adapter.Worker) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cancel != nil {
+		return ErrManagerStarted
+	}
+
+	m.workers = append(m.workers, worker)
+	return nil
+}
+
+// StartAll runs every registered worker in its own goroutine until StopAll is called or ctx is cancelled.
+// A manager runs its workers once; it can't be started again after StopAll.
+func (m *Manager) StartAll(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cancel != nil {
+		return ErrManagerStarted
+	}
+
+	ctx, m.cancel = context.WithCancel(ctx)
+
+	for _, worker := range m.workers {
+		m.wg.Add(1)
+		go m.run(ctx, worker)
+	}
+
+	m.logger.Info(ctx, "Workers started", "workers", len(m.workers))
+	return nil
+}
+
+// run runs one worker, logging how it ended
+func (m *Manager) run(ctx context.Context, worker adapter.Worker) {
+	defer m.wg.Done()
+
+	err := worker.Run(ctx)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		m.logger.Error(ctx, "Worker stopped with error", "worker", worker.Name(), "error", err)
+		return
+	}
+
+	m.logger.Info(ctx, "Worker stopped", "worker", worker.Name())
+}
+
+// StopAll cancels every worker and waits until all of them have returned. If ctx ends first it returns
+// without waiting any longer; the workers still see their cancellation.
+func (m *Manager) StopAll(ctx context.Context) error {
+	m.mu.Lock()
+	cancel := m.cancel
+	m.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		m.logger.Info(ctx, "All workers stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("workers did not stop in time: %w", ctx.Err())
+	}
+}
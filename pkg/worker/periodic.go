@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/snowmerak/ticketing/lib/adapter"
+)
+
+// Periodic is a Worker that calls a function on a fixed interval, such as sweeping expired seat holds
+type Periodic struct {
+	name     string
+	interval time.Duration
+	fn       func(ctx context.Context) error
+	logger   adapter.Logger
+}
+
+// NewPeriodic creates a new Periodic worker that calls fn every interval; interval must be positive
+func NewPeriodic(name string, interval time.Duration, fn func(ctx context.Context) error, logger adapter.Logger) *Periodic {
+	return &Periodic{
+		name:     name,
+		interval: interval,
+		fn:       fn,
+		logger:   logger,
+	}
+}
+
+// Compile-time check to ensure Periodic implements adapter.Worker
+var _ adapter.Worker = (*Periodic)(nil)
+
+// Name returns the worker's name
+func (p *Periodic) Name() string {
+	return p.name
+}
+
+// Run calls the function every interval until ctx is cancelled. A cycle that is running when ctx is cancelled
+// runs to completion, detached from the cancellation, so its writes are not abandoned halfway; a failed cycle
+// is logged and the next one runs on schedule.
+func (p *Periodic) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		// A tick may be waiting alongside the cancellation; don't start another cycle
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err := p.fn(context.WithoutCancel(ctx)); err != nil {
+			p.logger.Warn(ctx, "Worker cycle failed", "worker", p.name, "error", err)
+		}
+	}
+}